@@ -1,14 +1,19 @@
 package main
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
+	"github.com/JMURv/effectiveMobile/internal/auth"
 	ctrl "github.com/JMURv/effectiveMobile/internal/ctrl"
 	"github.com/JMURv/effectiveMobile/internal/ctrl/external"
 	hdl "github.com/JMURv/effectiveMobile/internal/hdl/http"
+	"github.com/JMURv/effectiveMobile/internal/metrics"
 	db "github.com/JMURv/effectiveMobile/internal/repo/db"
+	"github.com/JMURv/effectiveMobile/internal/tracing"
 	cfg "github.com/JMURv/effectiveMobile/pkg/config"
 	"github.com/JMURv/effectiveMobile/pkg/model"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
 	"go.uber.org/zap"
 	"net/http"
 	"os"
@@ -26,6 +31,46 @@ func mustRegisterLogger(mode string) {
 	}
 }
 
+func mustBuildProviders(conf *cfg.Config) []external.Provider {
+	res := make([]external.Provider, 0, len(conf.Providers.Order))
+	for _, name := range conf.Providers.Order {
+		var api ctrl.APIRepo
+		switch name {
+		case "info":
+			api = external.NewResilient(
+				name,
+				external.NewInstrumented(name, external.New(conf.ExternalAPI, nil)),
+				conf.ExternalAPI.Resilience,
+			)
+		case "fixtures":
+			fixtures, err := external.NewFixtures(conf.Providers.FixturesDir)
+			if err != nil {
+				zap.L().Fatal("Failed to load fixtures", zap.Error(err))
+			}
+			api = external.NewInstrumented(name, fixtures)
+		case "http":
+			api = external.NewResilient(
+				name,
+				external.NewInstrumented(name, external.NewHTTPProvider(conf.Providers.HTTP, nil)),
+				conf.ExternalAPI.Resilience,
+			)
+		default:
+			zap.L().Fatal("Unknown metadata provider", zap.String("provider", name))
+		}
+		res = append(res, external.Provider{Name: name, API: api})
+	}
+	return res
+}
+
+// @securityDefinitions.apikey ApiKeyAuth
+// @in header
+// @name X-API-Key
+// @description Статический API-ключ из AUTH_API_KEYS
+
+// @securityDefinitions.apikey BearerAuth
+// @in header
+// @name Authorization
+// @description JWT в формате "Bearer <token>", роль в claim AUTH_JWT_ROLE_CLAIM
 func main() {
 	defer func() {
 		if err := recover(); err != nil {
@@ -38,12 +83,49 @@ func main() {
 	mustRegisterLogger(conf.Server.Mode)
 
 	// Setting up main app
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	shutdownTracing, err := tracing.Setup(ctx, conf.Tracing)
+	if err != nil {
+		zap.L().Fatal("Failed to set up tracing", zap.Error(err))
+	}
+
 	repo := db.New(conf.DB)
-	svc := ctrl.New(repo, external.New(conf.ExternalAPIPort))
+	api := external.NewMulti(mustBuildProviders(conf)...)
+
+	var detailCache external.DetailCacheRepo
+	if conf.ExternalAPI.Cache.Persistent {
+		detailCache = repo
+	}
+	cached := external.NewCached(api, detailCache, conf.ExternalAPI.Cache)
+
+	svc := ctrl.New(repo, cached)
 	h := hdl.New(svc)
+	if conf.Auth.Enabled {
+		authn, err := auth.New(conf.Auth)
+		if err != nil {
+			zap.L().Fatal("Failed to set up authentication", zap.Error(err))
+		}
+		h.UseAuth(authn)
+	}
+
+	// Expose metrics either on the API server or on a separate port
+	var metricsSrv *http.Server
+	if conf.Metrics.Enabled {
+		metrics.RegisterDBStats(repo.Stats)
+		if conf.Metrics.Port == 0 {
+			h.Mount("GET "+conf.Metrics.Path, promhttp.Handler())
+		} else {
+			metricsSrv = startMetrics(conf.Metrics)
+		}
+	}
+
+	// Start background enrichment of new songs
+	svc.StartEnrichment(ctx, conf.Enrichment)
 
 	// Start external API
-	go startExternalAPI(conf.ExternalAPIPort)
+	go startExternalAPI(conf.ExternalAPI.Port)
 
 	// Graceful shutdown
 	go func() {
@@ -52,6 +134,9 @@ func main() {
 		<-c
 
 		zap.L().Info("Shutting down gracefully...")
+		cancel()
+		// Let the workers finish with the database before it is closed
+		svc.WaitEnrichment()
 
 		if err := repo.Close(); err != nil {
 			zap.L().Debug("Error closing repository", zap.Error(err))
@@ -59,6 +144,14 @@ func main() {
 		if err := h.Close(); err != nil {
 			zap.L().Debug("Error closing handler", zap.Error(err))
 		}
+		if err := shutdownTracing(context.Background()); err != nil {
+			zap.L().Debug("Error flushing traces", zap.Error(err))
+		}
+		if metricsSrv != nil {
+			if err := metricsSrv.Shutdown(context.Background()); err != nil {
+				zap.L().Debug("Error closing metrics server", zap.Error(err))
+			}
+		}
 
 		os.Exit(0)
 	}()
@@ -70,6 +163,26 @@ func main() {
 
 	h.Start(conf.Server.Port)
 }
+
+func startMetrics(conf *cfg.MetricsConfig) *http.Server {
+	mux := http.NewServeMux()
+	mux.Handle("GET "+conf.Path, promhttp.Handler())
+
+	srv := &http.Server{
+		Handler:     mux,
+		Addr:        fmt.Sprintf(":%v", conf.Port),
+		ReadTimeout: 15 * time.Second,
+	}
+
+	go func() {
+		zap.L().Info(fmt.Sprintf("Starting metrics server on :%v%v", conf.Port, conf.Path))
+		if err := srv.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+			zap.L().Debug("Metrics server error", zap.Error(err))
+		}
+	}()
+	return srv
+}
+
 func startExternalAPI(port int) {
 	mux := http.NewServeMux()
 	mux.HandleFunc("/info", func(w http.ResponseWriter, r *http.Request) {
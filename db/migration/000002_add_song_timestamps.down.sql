@@ -0,0 +1,6 @@
+DROP TRIGGER IF EXISTS songs_set_updated_at ON songs;
+DROP FUNCTION IF EXISTS set_updated_at();
+
+ALTER TABLE songs
+    DROP COLUMN IF EXISTS updated_at,
+    DROP COLUMN IF EXISTS created_at;
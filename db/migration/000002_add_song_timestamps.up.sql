@@ -0,0 +1,16 @@
+ALTER TABLE songs
+    ADD COLUMN IF NOT EXISTS created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    ADD COLUMN IF NOT EXISTS updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW();
+
+CREATE OR REPLACE FUNCTION set_updated_at() RETURNS TRIGGER AS $$
+BEGIN
+    NEW.updated_at = NOW();
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+DROP TRIGGER IF EXISTS songs_set_updated_at ON songs;
+CREATE TRIGGER songs_set_updated_at
+    BEFORE UPDATE ON songs
+    FOR EACH ROW
+    EXECUTE FUNCTION set_updated_at();
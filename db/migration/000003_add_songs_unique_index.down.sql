@@ -0,0 +1 @@
+DROP INDEX IF EXISTS songs_group_song_uniq_idx;
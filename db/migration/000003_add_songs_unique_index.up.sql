@@ -0,0 +1,23 @@
+-- Duplicates could slip in while uniqueness was checked only by the application.
+-- Which of them to keep is up to the operator, so the migration stops instead of deleting rows
+DO $$
+DECLARE
+    duplicates BIGINT;
+BEGIN
+    SELECT count(*) INTO duplicates
+    FROM (
+        SELECT 1
+        FROM songs
+        GROUP BY lower(btrim(group_name)), lower(btrim(song_name))
+        HAVING count(*) > 1
+    ) AS d;
+
+    IF duplicates > 0 THEN
+        RAISE EXCEPTION '% group/song pairs have duplicate songs, merge or delete them before creating the unique index', duplicates
+            USING HINT = 'SELECT lower(btrim(group_name)), lower(btrim(song_name)), array_agg(id ORDER BY id) FROM songs GROUP BY 1, 2 HAVING count(*) > 1';
+    END IF;
+END
+$$;
+
+CREATE UNIQUE INDEX IF NOT EXISTS songs_group_song_uniq_idx
+    ON songs (lower(btrim(group_name)), lower(btrim(song_name)));
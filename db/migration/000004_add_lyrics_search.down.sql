@@ -0,0 +1,5 @@
+DROP INDEX IF EXISTS songs_lyrics_tsv_idx;
+
+ALTER TABLE songs DROP COLUMN IF EXISTS lyrics_tsv;
+
+DROP FUNCTION IF EXISTS lyrics_to_tsvector(TEXT[]);
@@ -0,0 +1,10 @@
+-- array_to_string is only STABLE, so generated columns can't call it directly
+CREATE OR REPLACE FUNCTION lyrics_to_tsvector(lyrics TEXT[]) RETURNS tsvector AS $$
+    SELECT to_tsvector('simple', array_to_string(lyrics, E'\n'));
+$$ LANGUAGE sql IMMUTABLE;
+
+ALTER TABLE songs
+    ADD COLUMN IF NOT EXISTS lyrics_tsv tsvector
+        GENERATED ALWAYS AS (lyrics_to_tsvector(lyrics)) STORED;
+
+CREATE INDEX IF NOT EXISTS songs_lyrics_tsv_idx ON songs USING GIN (lyrics_tsv);
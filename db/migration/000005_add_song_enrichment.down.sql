@@ -0,0 +1,11 @@
+DROP INDEX IF EXISTS songs_enrichment_pending_idx;
+
+UPDATE songs SET release_date = '0001-01-01' WHERE release_date IS NULL;
+
+ALTER TABLE songs
+    DROP COLUMN IF EXISTS enrichment_attempts,
+    DROP COLUMN IF EXISTS enrichment_error,
+    DROP COLUMN IF EXISTS enrichment_status,
+    ALTER COLUMN link DROP DEFAULT,
+    ALTER COLUMN lyrics DROP DEFAULT,
+    ALTER COLUMN release_date SET NOT NULL;
@@ -0,0 +1,11 @@
+ALTER TABLE songs
+    ALTER COLUMN release_date DROP NOT NULL,
+    ALTER COLUMN lyrics SET DEFAULT '{}',
+    ALTER COLUMN link SET DEFAULT '',
+    ADD COLUMN IF NOT EXISTS enrichment_status TEXT NOT NULL DEFAULT 'done'
+        CHECK (enrichment_status IN ('pending', 'processing', 'done', 'failed')),
+    ADD COLUMN IF NOT EXISTS enrichment_error TEXT NOT NULL DEFAULT '',
+    ADD COLUMN IF NOT EXISTS enrichment_attempts INT NOT NULL DEFAULT 0;
+
+CREATE INDEX IF NOT EXISTS songs_enrichment_pending_idx
+    ON songs (id) WHERE enrichment_status IN ('pending', 'processing');
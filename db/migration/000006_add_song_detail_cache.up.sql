@@ -0,0 +1,12 @@
+CREATE TABLE IF NOT EXISTS song_detail_cache (
+    group_key TEXT NOT NULL,
+    song_key TEXT NOT NULL,
+    release_date TEXT NOT NULL,
+    text TEXT NOT NULL,
+    link TEXT NOT NULL,
+    expires_at TIMESTAMPTZ NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (group_key, song_key)
+);
+
+CREATE INDEX IF NOT EXISTS song_detail_cache_expires_at_idx ON song_detail_cache (expires_at);
@@ -0,0 +1,5 @@
+ALTER TABLE song_detail_cache
+    DROP COLUMN IF EXISTS sources;
+
+ALTER TABLE songs
+    DROP COLUMN IF EXISTS metadata_sources;
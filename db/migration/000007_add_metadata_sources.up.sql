@@ -0,0 +1,5 @@
+ALTER TABLE songs
+    ADD COLUMN IF NOT EXISTS metadata_sources JSONB NOT NULL DEFAULT '{}';
+
+ALTER TABLE song_detail_cache
+    ADD COLUMN IF NOT EXISTS sources JSONB NOT NULL DEFAULT '{}';
@@ -0,0 +1,4 @@
+ALTER TABLE songs
+    DROP COLUMN IF EXISTS artist_id;
+
+DROP TABLE IF EXISTS artists;
@@ -0,0 +1,39 @@
+CREATE TABLE IF NOT EXISTS artists (
+    id SERIAL PRIMARY KEY,
+    name TEXT NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS artists_name_uniq_idx
+    ON artists (lower(btrim(name)));
+
+DROP TRIGGER IF EXISTS artists_set_updated_at ON artists;
+CREATE TRIGGER artists_set_updated_at
+    BEFORE UPDATE ON artists
+    FOR EACH ROW
+    EXECUTE FUNCTION set_updated_at();
+
+-- One artist per normalized group name, spelled as in its oldest song
+INSERT INTO artists (name)
+SELECT DISTINCT ON (lower(btrim(group_name))) btrim(group_name)
+FROM songs
+ORDER BY lower(btrim(group_name)), id
+ON CONFLICT DO NOTHING;
+
+ALTER TABLE songs
+    ADD COLUMN IF NOT EXISTS artist_id INTEGER REFERENCES artists (id) ON DELETE RESTRICT;
+
+-- The backfill is not an edit of the songs, keep their updated_at
+ALTER TABLE songs DISABLE TRIGGER songs_set_updated_at;
+UPDATE songs s
+SET artist_id = a.id
+FROM artists a
+WHERE lower(btrim(a.name)) = lower(btrim(s.group_name));
+ALTER TABLE songs ENABLE TRIGGER songs_set_updated_at;
+
+ALTER TABLE songs
+    ALTER COLUMN artist_id SET NOT NULL;
+
+CREATE INDEX IF NOT EXISTS songs_artist_id_idx
+    ON songs (artist_id);
@@ -0,0 +1,31 @@
+CREATE TABLE IF NOT EXISTS albums (
+    id SERIAL PRIMARY KEY,
+    artist_id INTEGER NOT NULL REFERENCES artists (id) ON DELETE RESTRICT,
+    title TEXT NOT NULL,
+    release_date DATE,
+    cover_link TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS albums_artist_title_uniq_idx
+    ON albums (artist_id, lower(btrim(title)));
+
+DROP TRIGGER IF EXISTS albums_set_updated_at ON albums;
+CREATE TRIGGER albums_set_updated_at
+    BEFORE UPDATE ON albums
+    FOR EACH ROW
+    EXECUTE FUNCTION set_updated_at();
+
+-- A song may appear on several albums, e.g. the studio album and a compilation
+CREATE TABLE IF NOT EXISTS album_tracks (
+    album_id INTEGER NOT NULL REFERENCES albums (id) ON DELETE CASCADE,
+    song_id INTEGER NOT NULL REFERENCES songs (id) ON DELETE CASCADE,
+    disc SMALLINT NOT NULL DEFAULT 1 CHECK (disc > 0),
+    track_number SMALLINT NOT NULL CHECK (track_number > 0),
+    PRIMARY KEY (album_id, song_id),
+    UNIQUE (album_id, disc, track_number)
+);
+
+CREATE INDEX IF NOT EXISTS album_tracks_song_id_idx
+    ON album_tracks (song_id);
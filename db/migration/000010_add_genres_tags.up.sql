@@ -0,0 +1,36 @@
+-- Genres are curated, tags are created on the fly by the editors
+CREATE TABLE IF NOT EXISTS genres (
+    id SERIAL PRIMARY KEY,
+    name TEXT NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS genres_name_uniq_idx
+    ON genres (lower(btrim(name)));
+
+CREATE TABLE IF NOT EXISTS tags (
+    id SERIAL PRIMARY KEY,
+    name TEXT NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS tags_name_uniq_idx
+    ON tags (lower(btrim(name)));
+
+CREATE TABLE IF NOT EXISTS song_genres (
+    song_id INTEGER NOT NULL REFERENCES songs (id) ON DELETE CASCADE,
+    genre_id INTEGER NOT NULL REFERENCES genres (id) ON DELETE CASCADE,
+    PRIMARY KEY (song_id, genre_id)
+);
+
+CREATE INDEX IF NOT EXISTS song_genres_genre_id_idx
+    ON song_genres (genre_id);
+
+CREATE TABLE IF NOT EXISTS song_tags (
+    song_id INTEGER NOT NULL REFERENCES songs (id) ON DELETE CASCADE,
+    tag_id INTEGER NOT NULL REFERENCES tags (id) ON DELETE CASCADE,
+    PRIMARY KEY (song_id, tag_id)
+);
+
+CREATE INDEX IF NOT EXISTS song_tags_tag_id_idx
+    ON song_tags (tag_id);
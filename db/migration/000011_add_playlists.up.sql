@@ -0,0 +1,27 @@
+CREATE TABLE IF NOT EXISTS playlists (
+    id SERIAL PRIMARY KEY,
+    name TEXT NOT NULL,
+    description TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+DROP TRIGGER IF EXISTS playlists_set_updated_at ON playlists;
+CREATE TRIGGER playlists_set_updated_at
+    BEFORE UPDATE ON playlists
+    FOR EACH ROW
+    EXECUTE FUNCTION set_updated_at();
+
+-- Positions run from 1 without gaps. The uniqueness is checked at commit,
+-- so that a whole range of entries can be shifted by one in a single statement
+CREATE TABLE IF NOT EXISTS playlist_songs (
+    playlist_id INTEGER NOT NULL REFERENCES playlists (id) ON DELETE CASCADE,
+    song_id INTEGER NOT NULL REFERENCES songs (id) ON DELETE CASCADE,
+    position INTEGER NOT NULL CHECK (position > 0),
+    added_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (playlist_id, song_id),
+    CONSTRAINT playlist_songs_position_uniq UNIQUE (playlist_id, position) DEFERRABLE INITIALLY DEFERRED
+);
+
+CREATE INDEX IF NOT EXISTS playlist_songs_song_id_idx
+    ON playlist_songs (song_id);
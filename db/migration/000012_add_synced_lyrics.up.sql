@@ -0,0 +1,9 @@
+-- Time-synced lyrics are kept apart from the verses, a line starts at time_ms
+-- from the beginning of the song. Positions keep the order of lines sharing a time
+CREATE TABLE IF NOT EXISTS synced_lyrics (
+    song_id INTEGER NOT NULL REFERENCES songs (id) ON DELETE CASCADE,
+    position INTEGER NOT NULL CHECK (position > 0),
+    time_ms BIGINT NOT NULL CHECK (time_ms >= 0),
+    text TEXT NOT NULL DEFAULT '',
+    PRIMARY KEY (song_id, position)
+);
@@ -0,0 +1,15 @@
+-- Translated lyrics have a verse for every verse of the original, in the same order
+CREATE TABLE IF NOT EXISTS song_translations (
+    song_id INTEGER NOT NULL REFERENCES songs (id) ON DELETE CASCADE,
+    lang TEXT NOT NULL,
+    lyrics TEXT[] NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (song_id, lang)
+);
+
+DROP TRIGGER IF EXISTS song_translations_set_updated_at ON song_translations;
+CREATE TRIGGER song_translations_set_updated_at
+    BEFORE UPDATE ON song_translations
+    FOR EACH ROW
+    EXECUTE FUNCTION set_updated_at();
@@ -0,0 +1,4 @@
+-- Claims expire after the enrichment lease, so that songs left in processing by a stopped
+-- instance are requeued without taking over the ones other instances are still working on
+ALTER TABLE songs
+    ADD COLUMN IF NOT EXISTS enrichment_claimed_at TIMESTAMPTZ;
@@ -15,13 +15,21 @@ const docTemplate = `{
     "host": "{{.Host}}",
     "basePath": "{{.BasePath}}",
     "paths": {
-        "/api/songs": {
+        "/api/albums": {
             "get": {
-                "description": "Получить список песен с возможностью фильтрации и пагинации",
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Получить список альбомов, новые выше",
                 "tags": [
-                    "songs"
+                    "albums"
                 ],
-                "summary": "Список песен",
+                "summary": "Список альбомов",
                 "parameters": [
                     {
                         "type": "integer",
@@ -39,185 +47,211 @@ const docTemplate = `{
                     },
                     {
                         "type": "string",
-                        "description": "Фильтр по имени группы",
-                        "name": "group",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "Фильтр по названию песни",
-                        "name": "song",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "Фильтр по дате релиза",
-                        "name": "release_date",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "Фильтр по дате релиза (минимальная)",
-                        "name": "min_release_date",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "Фильтр по дате релиза (максимальная)",
-                        "name": "release_date",
+                        "description": "Фильтр по названию альбома",
+                        "name": "title",
                         "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Список песен с пагинацией",
+                        "description": "Список альбомов с пагинацией",
                         "schema": {
-                            "$ref": "#/definitions/model.PaginatedSongs"
+                            "$ref": "#/definitions/model.PaginatedAlbums"
+                        }
+                    },
+                    "400": {
+                        "description": "Некорректный номер или размер страницы",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Problem"
+                        }
+                    },
+                    "401": {
+                        "description": "Требуется аутентификация",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Problem"
+                        }
+                    },
+                    "403": {
+                        "description": "Недостаточно прав, требуется роль reader",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Problem"
                         }
                     },
                     "500": {
                         "description": "Внутренняя ошибка сервера",
                         "schema": {
-                            "$ref": "#/definitions/utils.ErrorResponse"
+                            "$ref": "#/definitions/utils.Problem"
                         }
                     }
                 }
             },
             "post": {
-                "description": "Добавить новую песню",
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Исполнитель указывается по имени и создаётся, если его ещё нет",
                 "consumes": [
                     "application/json"
                 ],
                 "tags": [
-                    "songs"
+                    "albums"
                 ],
-                "summary": "Добавить новую песню",
+                "summary": "Добавить альбом",
                 "parameters": [
                     {
-                        "description": "Данные новой песни",
-                        "name": "song",
+                        "description": "Данные альбома",
+                        "name": "album",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/http.CreateSongRequest"
+                            "$ref": "#/definitions/http.AlbumRequest"
                         }
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "ID добавленной песни",
+                    "201": {
+                        "description": "Добавленный альбом",
                         "schema": {
-                            "type": "integer"
+                            "$ref": "#/definitions/model.Album"
                         }
                     },
                     "400": {
                         "description": "Ошибка валидации или декодирования запроса",
                         "schema": {
-                            "$ref": "#/definitions/utils.ErrorResponse"
+                            "$ref": "#/definitions/utils.Problem"
+                        }
+                    },
+                    "401": {
+                        "description": "Требуется аутентификация",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Problem"
+                        }
+                    },
+                    "403": {
+                        "description": "Недостаточно прав, требуется роль editor",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Problem"
                         }
                     },
                     "409": {
-                        "description": "Песня уже существует",
+                        "description": "У исполнителя уже есть альбом с таким названием",
                         "schema": {
-                            "$ref": "#/definitions/utils.ErrorResponse"
+                            "$ref": "#/definitions/utils.Problem"
                         }
                     },
                     "500": {
                         "description": "Внутренняя ошибка сервера",
                         "schema": {
-                            "$ref": "#/definitions/utils.ErrorResponse"
+                            "$ref": "#/definitions/utils.Problem"
                         }
                     }
                 }
             }
         },
-        "/api/songs/{id}": {
+        "/api/albums/{id}": {
             "get": {
-                "description": "Получить информацию о песне и её тексте(пагинация)",
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
                 "tags": [
-                    "songs"
+                    "albums"
                 ],
-                "summary": "Получить песню по ID",
+                "summary": "Получить альбом по ID",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "ID песни",
+                        "description": "ID альбома",
                         "name": "id",
                         "in": "path",
                         "required": true
-                    },
-                    {
-                        "type": "integer",
-                        "default": 1,
-                        "description": "Номер куплета для пагинации текста песни",
-                        "name": "page",
-                        "in": "query"
-                    },
-                    {
-                        "type": "integer",
-                        "default": 40,
-                        "description": "Размер куплета для пагинации текста песни",
-                        "name": "size",
-                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Детали песни с пагинированным текстом",
+                        "description": "Альбом",
                         "schema": {
-                            "$ref": "#/definitions/model.PaginatedSongs"
+                            "$ref": "#/definitions/model.Album"
                         }
                     },
                     "400": {
                         "description": "Некорректный запрос",
                         "schema": {
-                            "$ref": "#/definitions/utils.ErrorResponse"
+                            "$ref": "#/definitions/utils.Problem"
+                        }
+                    },
+                    "401": {
+                        "description": "Требуется аутентификация",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Problem"
+                        }
+                    },
+                    "403": {
+                        "description": "Недостаточно прав, требуется роль reader",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Problem"
                         }
                     },
                     "404": {
-                        "description": "Песня не найдена",
+                        "description": "Альбом не найден",
                         "schema": {
-                            "$ref": "#/definitions/utils.ErrorResponse"
+                            "$ref": "#/definitions/utils.Problem"
                         }
                     },
                     "500": {
                         "description": "Внутренняя ошибка сервера",
                         "schema": {
-                            "$ref": "#/definitions/utils.ErrorResponse"
+                            "$ref": "#/definitions/utils.Problem"
                         }
                     }
                 }
             },
             "put": {
-                "description": "Обновить существующую песню по ID",
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
                 "consumes": [
                     "application/json"
                 ],
                 "tags": [
-                    "songs"
+                    "albums"
                 ],
-                "summary": "Обновить информацию о песне",
+                "summary": "Обновить альбом",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "ID песни",
+                        "description": "ID альбома",
                         "name": "id",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "description": "Обновленные данные песни",
-                        "name": "song",
+                        "description": "Новые данные альбома",
+                        "name": "album",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/model.Song"
+                            "$ref": "#/definitions/http.AlbumRequest"
                         }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "OK",
+                        "description": "Альбом успешно обновлён",
                         "schema": {
                             "type": "string"
                         }
@@ -225,33 +259,59 @@ const docTemplate = `{
                     "400": {
                         "description": "Ошибка валидации или декодирования запроса",
                         "schema": {
-                            "$ref": "#/definitions/utils.ErrorResponse"
+                            "$ref": "#/definitions/utils.Problem"
+                        }
+                    },
+                    "401": {
+                        "description": "Требуется аутентификация",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Problem"
+                        }
+                    },
+                    "403": {
+                        "description": "Недостаточно прав, требуется роль editor",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Problem"
                         }
                     },
                     "404": {
-                        "description": "Песня не найдена",
+                        "description": "Альбом не найден",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Problem"
+                        }
+                    },
+                    "409": {
+                        "description": "У исполнителя уже есть альбом с таким названием",
                         "schema": {
-                            "$ref": "#/definitions/utils.ErrorResponse"
+                            "$ref": "#/definitions/utils.Problem"
                         }
                     },
                     "500": {
                         "description": "Внутренняя ошибка сервера",
                         "schema": {
-                            "$ref": "#/definitions/utils.ErrorResponse"
+                            "$ref": "#/definitions/utils.Problem"
                         }
                     }
                 }
             },
             "delete": {
-                "description": "Удалить существующую песню по ID",
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Удаляется альбом и его треклист, сами песни остаются",
                 "tags": [
-                    "songs"
+                    "albums"
                 ],
-                "summary": "Удалить песню",
+                "summary": "Удалить альбом",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "ID песни",
+                        "description": "ID альбома",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -259,7 +319,7 @@ const docTemplate = `{
                 ],
                 "responses": {
                     "204": {
-                        "description": "Песня успешно удалена",
+                        "description": "Альбом успешно удалён",
                         "schema": {
                             "type": "string"
                         }
@@ -267,124 +327,3935 @@ const docTemplate = `{
                     "400": {
                         "description": "Некорректный запрос",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/utils.Problem"
+                        }
+                    },
+                    "401": {
+                        "description": "Требуется аутентификация",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Problem"
+                        }
+                    },
+                    "403": {
+                        "description": "Недостаточно прав, требуется роль admin",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Problem"
                         }
                     },
                     "404": {
-                        "description": "Песня не найдена",
+                        "description": "Альбом не найден",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/utils.Problem"
                         }
                     },
                     "500": {
                         "description": "Внутренняя ошибка сервера",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/utils.Problem"
                         }
                     }
                 }
             }
-        }
-    },
-    "definitions": {
-        "http.CreateSongRequest": {
-            "type": "object",
-            "properties": {
-                "group": {
-                    "type": "string"
-                },
-                "song": {
-                    "type": "string"
-                }
-            }
-        },
-        "model.PaginatedSongs": {
-            "type": "object",
-            "properties": {
-                "count": {
-                    "type": "integer"
-                },
-                "current_page": {
-                    "type": "integer"
-                },
-                "data": {
-                    "$ref": "#/definitions/model.Song"
-                },
-                "has_next_page": {
-                    "type": "boolean"
-                },
-                "total_pages": {
-                    "type": "integer"
-                }
-            }
         },
-        "model.PaginatedSongs": {
-            "type": "object",
-            "properties": {
-                "count": {
-                    "type": "integer"
-                },
-                "current_page": {
-                    "type": "integer"
-                },
-                "data": {},
-                "has_next_page": {
-                    "type": "boolean"
-                },
-                "total_pages": {
-                    "type": "integer"
+        "/api/albums/{id}/tracks": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Получить песни альбома в порядке дисков и номеров треков",
+                "tags": [
+                    "albums"
+                ],
+                "summary": "Треклист альбома",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID альбома",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Треки альбома",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/model.Track"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Некорректный запрос",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Problem"
+                        }
+                    },
+                    "401": {
+                        "description": "Требуется аутентификация",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Problem"
+                        }
+                    },
+                    "403": {
+                        "description": "Недостаточно прав, требуется роль reader",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Альбом не найден",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Внутренняя ошибка сервера",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Problem"
+                        }
+                    }
                 }
             }
         },
-        "model.Song": {
-            "type": "object",
-            "properties": {
-                "created_at": {
-                    "type": "string"
-                },
-                "group": {
-                    "type": "string"
-                },
-                "id": {
-                    "type": "integer"
-                },
-                "link": {
-                    "type": "string"
-                },
-                "lyrics": {
-                    "type": "array",
-                    "items": {
-                        "type": "string"
+        "/api/albums/{id}/tracks/{song_id}": {
+            "put": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
                     }
-                },
-                "release_date": {
+                ],
+                "description": "Добавляет песню в альбом или переносит её на другой диск и номер трека. Без диска песня попадает на первый",
+                "consumes": [
+                    "application/json"
+                ],
+                "tags": [
+                    "albums"
+                ],
+                "summary": "Поставить песню в треклист альбома",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID альбома",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "ID песни",
+                        "name": "song_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Диск и номер трека",
+                        "name": "track",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/http.TrackRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Трек успешно сохранён",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Ошибка валидации, декодирования запроса или песня не найдена",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Problem"
+                        }
+                    },
+                    "401": {
+                        "description": "Требуется аутентификация",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Problem"
+                        }
+                    },
+                    "403": {
+                        "description": "Недостаточно прав, требуется роль editor",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Альбом не найден",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Problem"
+                        }
+                    },
+                    "409": {
+                        "description": "Позиция в треклисте уже занята",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Внутренняя ошибка сервера",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Problem"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Сама песня остаётся, номера остальных треков не меняются",
+                "tags": [
+                    "albums"
+                ],
+                "summary": "Убрать песню из треклиста альбома",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID альбома",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "ID песни",
+                        "name": "song_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "Песня убрана из альбома",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Некорректный запрос",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Problem"
+                        }
+                    },
+                    "401": {
+                        "description": "Требуется аутентификация",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Problem"
+                        }
+                    },
+                    "403": {
+                        "description": "Недостаточно прав, требуется роль editor",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Альбом не найден или песни в нём нет",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Внутренняя ошибка сервера",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/artists": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Получить список исполнителей, отсортированный по имени",
+                "tags": [
+                    "artists"
+                ],
+                "summary": "Список исполнителей",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "default": 1,
+                        "description": "Номер страницы",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 40,
+                        "description": "Размер страницы",
+                        "name": "size",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Фильтр по имени исполнителя",
+                        "name": "name",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Список исполнителей с пагинацией",
+                        "schema": {
+                            "$ref": "#/definitions/model.PaginatedArtists"
+                        }
+                    },
+                    "400": {
+                        "description": "Некорректный номер или размер страницы",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Problem"
+                        }
+                    },
+                    "401": {
+                        "description": "Требуется аутентификация",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Problem"
+                        }
+                    },
+                    "403": {
+                        "description": "Недостаточно прав, требуется роль reader",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Внутренняя ошибка сервера",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Problem"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "tags": [
+                    "artists"
+                ],
+                "summary": "Добавить исполнителя",
+                "parameters": [
+                    {
+                        "description": "Данные исполнителя",
+                        "name": "artist",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/http.ArtistRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Добавленный исполнитель",
+                        "schema": {
+                            "$ref": "#/definitions/model.Artist"
+                        }
+                    },
+                    "400": {
+                        "description": "Ошибка валидации или декодирования запроса",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Problem"
+                        }
+                    },
+                    "401": {
+                        "description": "Требуется аутентификация",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Problem"
+                        }
+                    },
+                    "403": {
+                        "description": "Недостаточно прав, требуется роль editor",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Problem"
+                        }
+                    },
+                    "409": {
+                        "description": "Исполнитель уже существует",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Внутренняя ошибка сервера",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/artists/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "tags": [
+                    "artists"
+                ],
+                "summary": "Получить исполнителя по ID",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID исполнителя",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Исполнитель",
+                        "schema": {
+                            "$ref": "#/definitions/model.Artist"
+                        }
+                    },
+                    "400": {
+                        "description": "Некорректный запрос",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Problem"
+                        }
+                    },
+                    "401": {
+                        "description": "Требуется аутентификация",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Problem"
+                        }
+                    },
+                    "403": {
+                        "description": "Недостаточно прав, требуется роль reader",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Исполнитель не найден",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Внутренняя ошибка сервера",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Problem"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Новое имя применяется и к группе всех песен исполнителя",
+                "consumes": [
+                    "application/json"
+                ],
+                "tags": [
+                    "artists"
+                ],
+                "summary": "Переименовать исполнителя",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID исполнителя",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Новые данные исполнителя",
+                        "name": "artist",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/http.ArtistRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Исполнитель успешно обновлён",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Ошибка валидации или декодирования запроса",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Problem"
+                        }
+                    },
+                    "401": {
+                        "description": "Требуется аутентификация",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Problem"
+                        }
+                    },
+                    "403": {
+                        "description": "Недостаточно прав, требуется роль editor",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Исполнитель не найден",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Problem"
+                        }
+                    },
+                    "409": {
+                        "description": "Исполнитель с таким именем уже существует",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Внутренняя ошибка сервера",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Problem"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Удалить можно только исполнителя без песен и альбомов",
+                "tags": [
+                    "artists"
+                ],
+                "summary": "Удалить исполнителя",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID исполнителя",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "Исполнитель успешно удалён",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Некорректный запрос",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Problem"
+                        }
+                    },
+                    "401": {
+                        "description": "Требуется аутентификация",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Problem"
+                        }
+                    },
+                    "403": {
+                        "description": "Недостаточно прав, требуется роль admin",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Исполнитель не найден",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Problem"
+                        }
+                    },
+                    "409": {
+                        "description": "У исполнителя есть песни или альбомы",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Внутренняя ошибка сервера",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/artists/{id}/songs": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Получить песни исполнителя с теми же фильтрами и сортировкой, что и в списке песен",
+                "tags": [
+                    "artists"
+                ],
+                "summary": "Песни исполнителя",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID исполнителя",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "default": 1,
+                        "description": "Номер страницы",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 40,
+                        "description": "Размер страницы",
+                        "name": "size",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Фильтр по названию песни",
+                        "name": "song",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "example": "-release_date",
+                        "description": "Сортировка через запятую, '-' перед полем - по убыванию",
+                        "name": "sort",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Список песен с пагинацией",
+                        "schema": {
+                            "$ref": "#/definitions/model.PaginatedSongs"
+                        }
+                    },
+                    "400": {
+                        "description": "Некорректный запрос",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Problem"
+                        }
+                    },
+                    "401": {
+                        "description": "Требуется аутентификация",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Problem"
+                        }
+                    },
+                    "403": {
+                        "description": "Недостаточно прав, требуется роль reader",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Исполнитель не найден",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Внутренняя ошибка сервера",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/genres": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Получить все жанры, отсортированные по названию",
+                "tags": [
+                    "genres"
+                ],
+                "summary": "Список жанров",
+                "responses": {
+                    "200": {
+                        "description": "Список жанров",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/model.Genre"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Требуется аутентификация",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Problem"
+                        }
+                    },
+                    "403": {
+                        "description": "Недостаточно прав, требуется роль reader",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Внутренняя ошибка сервера",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Problem"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Жанры ведутся кураторами, песням можно назначить только существующие",
+                "consumes": [
+                    "application/json"
+                ],
+                "tags": [
+                    "genres"
+                ],
+                "summary": "Добавить жанр",
+                "parameters": [
+                    {
+                        "description": "Данные жанра",
+                        "name": "genre",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/http.GenreRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Добавленный жанр",
+                        "schema": {
+                            "$ref": "#/definitions/model.Genre"
+                        }
+                    },
+                    "400": {
+                        "description": "Ошибка валидации или декодирования запроса",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Problem"
+                        }
+                    },
+                    "401": {
+                        "description": "Требуется аутентификация",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Problem"
+                        }
+                    },
+                    "403": {
+                        "description": "Недостаточно прав, требуется роль editor",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Problem"
+                        }
+                    },
+                    "409": {
+                        "description": "Жанр уже существует",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Внутренняя ошибка сервера",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/genres/{id}": {
+            "delete": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Жанр снимается со всех песен",
+                "tags": [
+                    "genres"
+                ],
+                "summary": "Удалить жанр",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID жанра",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "Жанр успешно удалён",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Некорректный запрос",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Problem"
+                        }
+                    },
+                    "401": {
+                        "description": "Требуется аутентификация",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Problem"
+                        }
+                    },
+                    "403": {
+                        "description": "Недостаточно прав, требуется роль admin",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Жанр не найден",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Внутренняя ошибка сервера",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/playlists": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Получить список плейлистов, недавно изменённые первыми",
+                "tags": [
+                    "playlists"
+                ],
+                "summary": "Список плейлистов",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "default": 1,
+                        "description": "Номер страницы",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 40,
+                        "description": "Размер страницы",
+                        "name": "size",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Фильтр по названию плейлиста",
+                        "name": "name",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Список плейлистов с пагинацией",
+                        "schema": {
+                            "$ref": "#/definitions/model.PaginatedPlaylists"
+                        }
+                    },
+                    "400": {
+                        "description": "Некорректный номер или размер страницы",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Problem"
+                        }
+                    },
+                    "401": {
+                        "description": "Требуется аутентификация",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Problem"
+                        }
+                    },
+                    "403": {
+                        "description": "Недостаточно прав, требуется роль reader",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Внутренняя ошибка сервера",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Problem"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "tags": [
+                    "playlists"
+                ],
+                "summary": "Создать плейлист",
+                "parameters": [
+                    {
+                        "description": "Данные плейлиста",
+                        "name": "playlist",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/http.PlaylistRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Созданный плейлист",
+                        "schema": {
+                            "$ref": "#/definitions/model.Playlist"
+                        }
+                    },
+                    "400": {
+                        "description": "Ошибка валидации или декодирования запроса",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Problem"
+                        }
+                    },
+                    "401": {
+                        "description": "Требуется аутентификация",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Problem"
+                        }
+                    },
+                    "403": {
+                        "description": "Недостаточно прав, требуется роль editor",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Внутренняя ошибка сервера",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/playlists/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "tags": [
+                    "playlists"
+                ],
+                "summary": "Получить плейлист по ID",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID плейлиста",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Плейлист",
+                        "schema": {
+                            "$ref": "#/definitions/model.Playlist"
+                        }
+                    },
+                    "400": {
+                        "description": "Некорректный запрос",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Problem"
+                        }
+                    },
+                    "401": {
+                        "description": "Требуется аутентификация",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Problem"
+                        }
+                    },
+                    "403": {
+                        "description": "Недостаточно прав, требуется роль reader",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Плейлист не найден",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Внутренняя ошибка сервера",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Problem"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Заменяет название и описание плейлиста, песни не меняются",
+                "consumes": [
+                    "application/json"
+                ],
+                "tags": [
+                    "playlists"
+                ],
+                "summary": "Обновить плейлист",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID плейлиста",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Новые данные плейлиста",
+                        "name": "playlist",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/http.PlaylistRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Плейлист успешно обновлён",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Ошибка валидации или декодирования запроса",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Problem"
+                        }
+                    },
+                    "401": {
+                        "description": "Требуется аутентификация",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Problem"
+                        }
+                    },
+                    "403": {
+                        "description": "Недостаточно прав, требуется роль editor",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Плейлист не найден",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Внутренняя ошибка сервера",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Problem"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "tags": [
+                    "playlists"
+                ],
+                "summary": "Удалить плейлист",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID плейлиста",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "Плейлист успешно удалён",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Некорректный запрос",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Problem"
+                        }
+                    },
+                    "401": {
+                        "description": "Требуется аутентификация",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Problem"
+                        }
+                    },
+                    "403": {
+                        "description": "Недостаточно прав, требуется роль admin",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Плейлист не найден",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Внутренняя ошибка сервера",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/playlists/{id}/duplicate": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Создаёт копию плейлиста с теми же песнями в том же порядке. Без названия копия получает название оригинала с пометкой \"(copy)\"",
+                "consumes": [
+                    "application/json"
+                ],
+                "tags": [
+                    "playlists"
+                ],
+                "summary": "Дублировать плейлист",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID плейлиста",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Название копии",
+                        "name": "playlist",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/http.DuplicatePlaylistRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Созданная копия",
+                        "schema": {
+                            "$ref": "#/definitions/model.Playlist"
+                        }
+                    },
+                    "400": {
+                        "description": "Ошибка валидации или декодирования запроса",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Problem"
+                        }
+                    },
+                    "401": {
+                        "description": "Требуется аутентификация",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Problem"
+                        }
+                    },
+                    "403": {
+                        "description": "Недостаточно прав, требуется роль editor",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Плейлист не найден",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Внутренняя ошибка сервера",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/playlists/{id}/songs": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Получить песни плейлиста в порядке их позиций, data содержит массив model.PlaylistEntry",
+                "tags": [
+                    "playlists"
+                ],
+                "summary": "Песни плейлиста",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID плейлиста",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "default": 1,
+                        "description": "Номер страницы",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 40,
+                        "description": "Размер страницы",
+                        "name": "size",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Песни плейлиста с пагинацией",
+                        "schema": {
+                            "$ref": "#/definitions/model.PaginatedSongs"
+                        }
+                    },
+                    "400": {
+                        "description": "Некорректный запрос",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Problem"
+                        }
+                    },
+                    "401": {
+                        "description": "Требуется аутентификация",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Problem"
+                        }
+                    },
+                    "403": {
+                        "description": "Недостаточно прав, требуется роль reader",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Плейлист не найден",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Внутренняя ошибка сервера",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Problem"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Вставляет песню на позицию, сдвигая следующие песни. Без позиции или за концом списка песня добавляется в конец",
+                "consumes": [
+                    "application/json"
+                ],
+                "tags": [
+                    "playlists"
+                ],
+                "summary": "Добавить песню в плейлист",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID плейлиста",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "ID песни и позиция",
+                        "name": "song",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/http.PlaylistSongRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Позиция добавленной песни",
+                        "schema": {
+                            "$ref": "#/definitions/http.PlaylistPosition"
+                        }
+                    },
+                    "400": {
+                        "description": "Ошибка валидации, декодирования запроса или песня не найдена",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Problem"
+                        }
+                    },
+                    "401": {
+                        "description": "Требуется аутентификация",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Problem"
+                        }
+                    },
+                    "403": {
+                        "description": "Недостаточно прав, требуется роль editor",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Плейлист не найден",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Problem"
+                        }
+                    },
+                    "409": {
+                        "description": "Песня уже есть в плейлисте или плейлист изменён параллельным запросом",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Внутренняя ошибка сервера",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/playlists/{id}/songs/{song_id}": {
+            "put": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Ставит песню на позицию, сдвигая песни между старой и новой позициями. Позиция за концом списка перемещает песню в конец",
+                "consumes": [
+                    "application/json"
+                ],
+                "tags": [
+                    "playlists"
+                ],
+                "summary": "Переместить песню в плейлисте",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID плейлиста",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "ID песни",
+                        "name": "song_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Новая позиция",
+                        "name": "position",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/http.PlaylistPosition"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Итоговая позиция песни",
+                        "schema": {
+                            "$ref": "#/definitions/http.PlaylistPosition"
+                        }
+                    },
+                    "400": {
+                        "description": "Ошибка валидации или декодирования запроса",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Problem"
+                        }
+                    },
+                    "401": {
+                        "description": "Требуется аутентификация",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Problem"
+                        }
+                    },
+                    "403": {
+                        "description": "Недостаточно прав, требуется роль editor",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Плейлист не найден или песни в нём нет",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Problem"
+                        }
+                    },
+                    "409": {
+                        "description": "Плейлист изменён параллельным запросом",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Внутренняя ошибка сервера",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Problem"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Следующие песни сдвигаются на освободившуюся позицию",
+                "tags": [
+                    "playlists"
+                ],
+                "summary": "Убрать песню из плейлиста",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID плейлиста",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "ID песни",
+                        "name": "song_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "Песня убрана из плейлиста",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Некорректный запрос",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Problem"
+                        }
+                    },
+                    "401": {
+                        "description": "Требуется аутентификация",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Problem"
+                        }
+                    },
+                    "403": {
+                        "description": "Недостаточно прав, требуется роль editor",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Плейлист не найден или песни в нём нет",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Внутренняя ошибка сервера",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/songs": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Получить список песен с возможностью фильтрации и пагинации.\nПри передаче cursor или limit используется курсорная пагинация: ответ содержит next_cursor/prev_cursor вместо номеров страниц, count - только при with_count=true",
+                "tags": [
+                    "songs"
+                ],
+                "summary": "Список песен",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "default": 1,
+                        "description": "Номер страницы",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 40,
+                        "description": "Размер страницы",
+                        "name": "size",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Фильтр по имени группы",
+                        "name": "group",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Фильтр по названию песни",
+                        "name": "song",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Фильтр по дате релиза",
+                        "name": "release_date",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Фильтр по дате релиза (минимальная)",
+                        "name": "min_release_date",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Фильтр по дате релиза (максимальная)",
+                        "name": "release_date",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Полнотекстовый поиск по тексту песни, результаты ранжируются по релевантности",
+                        "name": "q",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "example": "rock,metal",
+                        "description": "Фильтр по жанрам через запятую",
+                        "name": "genre",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "any",
+                            "all"
+                        ],
+                        "type": "string",
+                        "default": "any",
+                        "description": "Песня должна иметь любой (any) или все (all) из жанров",
+                        "name": "genre_match",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "example": "90s,workout",
+                        "description": "Фильтр по тегам через запятую",
+                        "name": "tag",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "any",
+                            "all"
+                        ],
+                        "type": "string",
+                        "default": "any",
+                        "description": "Песня должна иметь любой (any) или все (all) из тегов",
+                        "name": "tag_match",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "default": false,
+                        "description": "Добавить в ответ количество песен по жанрам и тегам для текущего фильтра",
+                        "name": "facets",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "example": "-release_date,group",
+                        "description": "Сортировка через запятую, '-' перед полем - по убыванию. Доступные поля: id, group, song, release_date, created_at, updated_at",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Курсор из next_cursor/prev_cursor, включает курсорную пагинацию",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 40,
+                        "description": "Размер страницы в курсорном режиме, включает курсорную пагинацию",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "default": false,
+                        "description": "Подсчитать общее количество песен в курсорном режиме",
+                        "name": "with_count",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Список песен с пагинацией",
+                        "schema": {
+                            "$ref": "#/definitions/model.PaginatedSongs"
+                        }
+                    },
+                    "400": {
+                        "description": "Некорректная сортировка, курсор или режим фильтра по жанрам и тегам",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Problem"
+                        }
+                    },
+                    "401": {
+                        "description": "Требуется аутентификация",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Problem"
+                        }
+                    },
+                    "403": {
+                        "description": "Недостаточно прав, требуется роль reader",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Внутренняя ошибка сервера",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Problem"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Добавить новую песню. Дата релиза, текст и ссылка загружаются из внешнего API в фоне, прогресс виден в enrichment_status.\nПесню можно сразу добавить в альбом, тогда дата релиза по умолчанию берётся из альбома",
+                "consumes": [
+                    "application/json"
+                ],
+                "tags": [
+                    "songs"
+                ],
+                "summary": "Добавить новую песню",
+                "parameters": [
+                    {
+                        "description": "Данные новой песни",
+                        "name": "song",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/http.CreateSongRequest"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "no-cache — не использовать кэш внешнего API",
+                        "name": "Cache-Control",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "ID добавленной песни",
+                        "schema": {
+                            "type": "integer"
+                        }
+                    },
+                    "400": {
+                        "description": "Ошибка валидации, декодирования запроса или альбом не найден",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Problem"
+                        }
+                    },
+                    "401": {
+                        "description": "Требуется аутентификация",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Problem"
+                        }
+                    },
+                    "403": {
+                        "description": "Недостаточно прав, требуется роль editor",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Problem"
+                        }
+                    },
+                    "409": {
+                        "description": "Песня уже существует или место в альбоме занято",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Внутренняя ошибка сервера",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/songs/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Получить информацию о песне и её тексте(пагинация).\nЕсли есть перевод на один из предпочитаемых языков, те же куплеты перевода возвращаются в translated_lyrics",
+                "tags": [
+                    "songs"
+                ],
+                "summary": "Получить песню по ID",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID песни",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "default": 1,
+                        "description": "Номер куплета для пагинации текста песни",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 40,
+                        "description": "Размер куплета для пагинации текста песни",
+                        "name": "size",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Язык перевода (BCP 47), важнее Accept-Language",
+                        "name": "lang",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Предпочитаемые языки перевода",
+                        "name": "Accept-Language",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Детали песни с пагинированным текстом",
+                        "schema": {
+                            "$ref": "#/definitions/model.PaginatedSongs"
+                        }
+                    },
+                    "400": {
+                        "description": "Некорректный запрос или язык",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Problem"
+                        }
+                    },
+                    "401": {
+                        "description": "Требуется аутентификация",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Problem"
+                        }
+                    },
+                    "403": {
+                        "description": "Недостаточно прав, требуется роль reader",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Песня не найдена",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Внутренняя ошибка сервера",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Problem"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Обновить существующую песню по ID",
+                "consumes": [
+                    "application/json"
+                ],
+                "tags": [
+                    "songs"
+                ],
+                "summary": "Обновить информацию о песне",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID песни",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Обновленные данные песни",
+                        "name": "song",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.Song"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Ошибка валидации или декодирования запроса",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Problem"
+                        }
+                    },
+                    "401": {
+                        "description": "Требуется аутентификация",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Problem"
+                        }
+                    },
+                    "403": {
+                        "description": "Недостаточно прав, требуется роль editor",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Песня не найдена",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Problem"
+                        }
+                    },
+                    "409": {
+                        "description": "Песня с такими группой и названием уже существует или число куплетов не совпадает с переводами",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Внутренняя ошибка сервера",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Problem"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Удалить существующую песню по ID",
+                "tags": [
+                    "songs"
+                ],
+                "summary": "Удалить песню",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID песни",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "Песня успешно удалена",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Некорректный запрос",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Problem"
+                        }
+                    },
+                    "401": {
+                        "description": "Требуется аутентификация",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Problem"
+                        }
+                    },
+                    "403": {
+                        "description": "Недостаточно прав, требуется роль admin",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Песня не найдена",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Внутренняя ошибка сервера",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Problem"
+                        }
+                    }
+                }
+            },
+            "patch": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Обновить только переданные поля существующей песни по ID",
+                "consumes": [
+                    "application/json"
+                ],
+                "tags": [
+                    "songs"
+                ],
+                "summary": "Частично обновить информацию о песне",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID песни",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Изменяемые поля песни",
+                        "name": "song",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.SongPatch"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Ошибка валидации или декодирования запроса",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Problem"
+                        }
+                    },
+                    "401": {
+                        "description": "Требуется аутентификация",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Problem"
+                        }
+                    },
+                    "403": {
+                        "description": "Недостаточно прав, требуется роль editor",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Песня не найдена",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Problem"
+                        }
+                    },
+                    "409": {
+                        "description": "Песня с такими группой и названием уже существует или число куплетов не совпадает с переводами",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Внутренняя ошибка сервера",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/songs/{id}/genres": {
+            "put": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Заменяет все жанры песни переданными. Пустой список снимает все жанры",
+                "consumes": [
+                    "application/json"
+                ],
+                "tags": [
+                    "songs"
+                ],
+                "summary": "Задать жанры песни",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID песни",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Названия жанров",
+                        "name": "genres",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/http.SongGenresRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Жанры успешно заданы",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Ошибка валидации, декодирования запроса или неизвестный жанр",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Problem"
+                        }
+                    },
+                    "401": {
+                        "description": "Требуется аутентификация",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Problem"
+                        }
+                    },
+                    "403": {
+                        "description": "Недостаточно прав, требуется роль editor",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Песня не найдена",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Внутренняя ошибка сервера",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/songs/{id}/lyrics.lrc": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Возвращает синхронизированный текст песни в формате LRC с тегами ar и ti",
+                "produces": [
+                    "text/plain"
+                ],
+                "tags": [
+                    "lyrics"
+                ],
+                "summary": "Скачать синхронизированный текст",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID песни",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "LRC файл",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Некорректный запрос",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Problem"
+                        }
+                    },
+                    "401": {
+                        "description": "Требуется аутентификация",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Problem"
+                        }
+                    },
+                    "403": {
+                        "description": "Недостаточно прав, требуется роль reader",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Песня не найдена или у неё нет синхронизированного текста",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Внутренняя ошибка сервера",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Problem"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Заменяет синхронизированный текст песни строками LRC файла. Поддерживаются тег offset и строки с несколькими метками времени.\nКуплеты текста песни не меняются",
+                "consumes": [
+                    "text/plain"
+                ],
+                "tags": [
+                    "lyrics"
+                ],
+                "summary": "Загрузить синхронизированный текст",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID песни",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "LRC файл",
+                        "name": "lrc",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Синхронизированный текст загружен",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Некорректный LRC файл или ошибка валидации",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Problem"
+                        }
+                    },
+                    "401": {
+                        "description": "Требуется аутентификация",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Problem"
+                        }
+                    },
+                    "403": {
+                        "description": "Недостаточно прав, требуется роль editor",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Песня не найдена",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Problem"
+                        }
+                    },
+                    "413": {
+                        "description": "Файл слишком большой",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Внутренняя ошибка сервера",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/songs/{id}/lyrics.txt": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Возвращает текст песни как обычный текст, куплеты разделены пустой строкой",
+                "produces": [
+                    "text/plain"
+                ],
+                "tags": [
+                    "lyrics"
+                ],
+                "summary": "Скачать текст песни",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID песни",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Текст песни",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Некорректный запрос",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Problem"
+                        }
+                    },
+                    "401": {
+                        "description": "Требуется аутентификация",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Problem"
+                        }
+                    },
+                    "403": {
+                        "description": "Недостаточно прав, требуется роль reader",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Песня не найдена или у неё нет текста",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Внутренняя ошибка сервера",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/songs/{id}/refresh": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Сбросить статус обогащения и поставить песню в очередь на загрузку данных из внешнего API",
+                "tags": [
+                    "songs"
+                ],
+                "summary": "Повторно обогатить песню",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID песни",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "no-cache — не использовать кэш внешнего API",
+                        "name": "Cache-Control",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "202": {
+                        "description": "Песня поставлена в очередь",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Некорректный запрос",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Problem"
+                        }
+                    },
+                    "401": {
+                        "description": "Требуется аутентификация",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Problem"
+                        }
+                    },
+                    "403": {
+                        "description": "Недостаточно прав, требуется роль editor",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Песня не найдена",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Problem"
+                        }
+                    },
+                    "409": {
+                        "description": "Песня уже обогащается",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Внутренняя ошибка сервера",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/songs/{id}/tags": {
+            "put": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Заменяет все теги песни переданными, новые теги создаются автоматически. Пустой список снимает все теги",
+                "consumes": [
+                    "application/json"
+                ],
+                "tags": [
+                    "songs"
+                ],
+                "summary": "Задать теги песни",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID песни",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Названия тегов",
+                        "name": "tags",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/http.SongTagsRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Теги успешно заданы",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Ошибка валидации или декодирования запроса",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Problem"
+                        }
+                    },
+                    "401": {
+                        "description": "Требуется аутентификация",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Problem"
+                        }
+                    },
+                    "403": {
+                        "description": "Недостаточно прав, требуется роль editor",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Песня не найдена",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Внутренняя ошибка сервера",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/songs/{id}/translations": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Добавляет перевод текста песни на язык с тегом BCP 47. Куплеты перевода соответствуют куплетам оригинала,\nпоэтому их должно быть столько же. Пустой куплет означает, что он ещё не переведён",
+                "consumes": [
+                    "application/json"
+                ],
+                "tags": [
+                    "translations"
+                ],
+                "summary": "Добавить перевод текста",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID песни",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Язык и куплеты перевода",
+                        "name": "translation",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.Translation"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Добавленный перевод",
+                        "schema": {
+                            "$ref": "#/definitions/model.Translation"
+                        }
+                    },
+                    "400": {
+                        "description": "Ошибка валидации, декодирования запроса или число куплетов не совпадает с оригиналом",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Problem"
+                        }
+                    },
+                    "401": {
+                        "description": "Требуется аутентификация",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Problem"
+                        }
+                    },
+                    "403": {
+                        "description": "Недостаточно прав, требуется роль editor",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Песня не найдена",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Problem"
+                        }
+                    },
+                    "409": {
+                        "description": "Перевод на этот язык уже существует",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Внутренняя ошибка сервера",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/songs/{id}/translations/{lang}": {
+            "put": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Заменяет куплеты перевода, их должно быть столько же, сколько куплетов в оригинале",
+                "consumes": [
+                    "application/json"
+                ],
+                "tags": [
+                    "translations"
+                ],
+                "summary": "Изменить перевод текста",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID песни",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Язык перевода (BCP 47)",
+                        "name": "lang",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Куплеты перевода",
+                        "name": "translation",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/http.TranslationLyricsRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Перевод изменён",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Ошибка валидации, декодирования запроса или число куплетов не совпадает с оригиналом",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Problem"
+                        }
+                    },
+                    "401": {
+                        "description": "Требуется аутентификация",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Problem"
+                        }
+                    },
+                    "403": {
+                        "description": "Недостаточно прав, требуется роль editor",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Песня или перевод не найдены",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Внутренняя ошибка сервера",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Problem"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "tags": [
+                    "translations"
+                ],
+                "summary": "Удалить перевод текста",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID песни",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Язык перевода (BCP 47)",
+                        "name": "lang",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "Перевод удалён",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Некорректный запрос или язык",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Problem"
+                        }
+                    },
+                    "401": {
+                        "description": "Требуется аутентификация",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Problem"
+                        }
+                    },
+                    "403": {
+                        "description": "Недостаточно прав, требуется роль editor",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Песня или перевод не найдены",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Внутренняя ошибка сервера",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/songs/{id}/verses": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Получить куплеты текста песни с их позициями, позиции начинаются с 1",
+                "tags": [
+                    "verses"
+                ],
+                "summary": "Куплеты песни",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID песни",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "default": 1,
+                        "description": "Номер страницы",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 40,
+                        "description": "Размер страницы",
+                        "name": "size",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Куплеты с пагинацией",
+                        "schema": {
+                            "$ref": "#/definitions/model.PaginatedVerses"
+                        }
+                    },
+                    "400": {
+                        "description": "Некорректный запрос",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Problem"
+                        }
+                    },
+                    "401": {
+                        "description": "Требуется аутентификация",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Problem"
+                        }
+                    },
+                    "403": {
+                        "description": "Недостаточно прав, требуется роль reader",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Песня не найдена",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Внутренняя ошибка сервера",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Problem"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Вставляет куплет на позицию, сдвигая следующие куплеты. Без позиции или за концом текста куплет добавляется в конец",
+                "consumes": [
+                    "application/json"
+                ],
+                "tags": [
+                    "verses"
+                ],
+                "summary": "Добавить куплет",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID песни",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Текст и позиция куплета",
+                        "name": "verse",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.Verse"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Добавленный куплет",
+                        "schema": {
+                            "$ref": "#/definitions/model.Verse"
+                        }
+                    },
+                    "400": {
+                        "description": "Ошибка валидации или декодирования запроса",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Problem"
+                        }
+                    },
+                    "401": {
+                        "description": "Требуется аутентификация",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Problem"
+                        }
+                    },
+                    "403": {
+                        "description": "Недостаточно прав, требуется роль editor",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Песня не найдена",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Внутренняя ошибка сервера",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/songs/{id}/verses/{n}": {
+            "put": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "tags": [
+                    "verses"
+                ],
+                "summary": "Изменить куплет",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID песни",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Позиция куплета",
+                        "name": "n",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Новый текст куплета",
+                        "name": "verse",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/http.VerseTextRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Куплет успешно изменён",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Ошибка валидации или декодирования запроса",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Problem"
+                        }
+                    },
+                    "401": {
+                        "description": "Требуется аутентификация",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Problem"
+                        }
+                    },
+                    "403": {
+                        "description": "Недостаточно прав, требуется роль editor",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Песня или куплет не найдены",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Внутренняя ошибка сервера",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Problem"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Следующие куплеты сдвигаются на освободившуюся позицию",
+                "tags": [
+                    "verses"
+                ],
+                "summary": "Удалить куплет",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID песни",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Позиция куплета",
+                        "name": "n",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "Куплет успешно удалён",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Некорректный запрос",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Problem"
+                        }
+                    },
+                    "401": {
+                        "description": "Требуется аутентификация",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Problem"
+                        }
+                    },
+                    "403": {
+                        "description": "Недостаточно прав, требуется роль editor",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Песня или куплет не найдены",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Внутренняя ошибка сервера",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/songs/{id}/verses/{n}/move": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Ставит куплет на позицию, сдвигая куплеты между старой и новой позициями. Позиция за концом текста перемещает куплет в конец",
+                "consumes": [
+                    "application/json"
+                ],
+                "tags": [
+                    "verses"
+                ],
+                "summary": "Переместить куплет",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID песни",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Текущая позиция куплета",
+                        "name": "n",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Новая позиция",
+                        "name": "position",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/http.VersePosition"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Итоговая позиция куплета",
+                        "schema": {
+                            "$ref": "#/definitions/http.VersePosition"
+                        }
+                    },
+                    "400": {
+                        "description": "Ошибка валидации или декодирования запроса",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Problem"
+                        }
+                    },
+                    "401": {
+                        "description": "Требуется аутентификация",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Problem"
+                        }
+                    },
+                    "403": {
+                        "description": "Недостаточно прав, требуется роль editor",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Песня или куплет не найдены",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Внутренняя ошибка сервера",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/tags": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Получить теги, отсортированные по названию",
+                "tags": [
+                    "tags"
+                ],
+                "summary": "Список тегов",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Фильтр по названию тега",
+                        "name": "name",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Список тегов",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/model.Tag"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Требуется аутентификация",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Problem"
+                        }
+                    },
+                    "403": {
+                        "description": "Недостаточно прав, требуется роль reader",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Внутренняя ошибка сервера",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/tags/{id}": {
+            "delete": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Тег снимается со всех песен",
+                "tags": [
+                    "tags"
+                ],
+                "summary": "Удалить тег",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID тега",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "Тег успешно удалён",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Некорректный запрос",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Problem"
+                        }
+                    },
+                    "401": {
+                        "description": "Требуется аутентификация",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Problem"
+                        }
+                    },
+                    "403": {
+                        "description": "Недостаточно прав, требуется роль admin",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Тег не найден",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Внутренняя ошибка сервера",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Problem"
+                        }
+                    }
+                }
+            }
+        }
+    },
+    "definitions": {
+        "http.AlbumRequest": {
+            "type": "object",
+            "properties": {
+                "artist": {
+                    "type": "string"
+                },
+                "cover_link": {
+                    "type": "string"
+                },
+                "release_date": {
+                    "type": "string"
+                },
+                "title": {
+                    "type": "string"
+                }
+            }
+        },
+        "http.ArtistRequest": {
+            "type": "object",
+            "properties": {
+                "name": {
+                    "type": "string"
+                }
+            }
+        },
+        "http.CreateSongRequest": {
+            "type": "object",
+            "properties": {
+                "album": {
+                    "$ref": "#/definitions/model.AlbumTrack"
+                },
+                "group": {
+                    "type": "string"
+                },
+                "song": {
+                    "type": "string"
+                }
+            }
+        },
+        "http.DuplicatePlaylistRequest": {
+            "type": "object",
+            "properties": {
+                "name": {
+                    "type": "string"
+                }
+            }
+        },
+        "http.GenreRequest": {
+            "type": "object",
+            "properties": {
+                "name": {
+                    "type": "string"
+                }
+            }
+        },
+        "http.PlaylistPosition": {
+            "type": "object",
+            "properties": {
+                "position": {
+                    "type": "integer"
+                }
+            }
+        },
+        "http.PlaylistRequest": {
+            "type": "object",
+            "properties": {
+                "description": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                }
+            }
+        },
+        "http.PlaylistSongRequest": {
+            "type": "object",
+            "properties": {
+                "position": {
+                    "type": "integer"
+                },
+                "song_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "http.SongGenresRequest": {
+            "type": "object",
+            "properties": {
+                "genres": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "http.SongTagsRequest": {
+            "type": "object",
+            "properties": {
+                "tags": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "http.TrackRequest": {
+            "type": "object",
+            "properties": {
+                "disc": {
+                    "type": "integer"
+                },
+                "track_number": {
+                    "type": "integer"
+                }
+            }
+        },
+        "http.TranslationLyricsRequest": {
+            "type": "object",
+            "properties": {
+                "lyrics": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "http.VersePosition": {
+            "type": "object",
+            "properties": {
+                "position": {
+                    "type": "integer"
+                }
+            }
+        },
+        "http.VerseTextRequest": {
+            "type": "object",
+            "properties": {
+                "text": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.Album": {
+            "type": "object",
+            "properties": {
+                "artist": {
+                    "description": "Artist is the artist name, resolved to ArtistID by the repository",
+                    "type": "string"
+                },
+                "artist_id": {
+                    "type": "integer"
+                },
+                "cover_link": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "release_date": {
+                    "type": "string"
+                },
+                "title": {
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.AlbumTrack": {
+            "type": "object",
+            "properties": {
+                "album_id": {
+                    "type": "integer"
+                },
+                "disc": {
+                    "type": "integer"
+                },
+                "track_number": {
+                    "type": "integer"
+                }
+            }
+        },
+        "model.Artist": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.Facet": {
+            "type": "object",
+            "properties": {
+                "count": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.Facets": {
+            "type": "object",
+            "properties": {
+                "genres": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.Facet"
+                    }
+                },
+                "tags": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.Facet"
+                    }
+                }
+            }
+        },
+        "model.Genre": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.PaginatedAlbums": {
+            "type": "object",
+            "properties": {
+                "count": {
+                    "type": "integer"
+                },
+                "current_page": {
+                    "type": "integer"
+                },
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.Album"
+                    }
+                },
+                "has_next_page": {
+                    "type": "boolean"
+                },
+                "total_pages": {
+                    "type": "integer"
+                }
+            }
+        },
+        "model.PaginatedArtists": {
+            "type": "object",
+            "properties": {
+                "count": {
+                    "type": "integer"
+                },
+                "current_page": {
+                    "type": "integer"
+                },
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.Artist"
+                    }
+                },
+                "has_next_page": {
+                    "type": "boolean"
+                },
+                "total_pages": {
+                    "type": "integer"
+                }
+            }
+        },
+        "model.PaginatedPlaylists": {
+            "type": "object",
+            "properties": {
+                "count": {
+                    "type": "integer"
+                },
+                "current_page": {
+                    "type": "integer"
+                },
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.Playlist"
+                    }
+                },
+                "has_next_page": {
+                    "type": "boolean"
+                },
+                "total_pages": {
+                    "type": "integer"
+                }
+            }
+        },
+        "model.PaginatedSongs": {
+            "type": "object",
+            "properties": {
+                "count": {
+                    "type": "integer"
+                },
+                "current_page": {
+                    "type": "integer"
+                },
+                "data": {},
+                "facets": {
+                    "$ref": "#/definitions/model.Facets"
+                },
+                "has_next_page": {
+                    "type": "boolean"
+                },
+                "total_pages": {
+                    "type": "integer"
+                }
+            }
+        },
+        "model.PaginatedVerses": {
+            "type": "object",
+            "properties": {
+                "count": {
+                    "type": "integer"
+                },
+                "current_page": {
+                    "type": "integer"
+                },
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.Verse"
+                    }
+                },
+                "has_next_page": {
+                    "type": "boolean"
+                },
+                "total_pages": {
+                    "type": "integer"
+                }
+            }
+        },
+        "model.Playlist": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "songs_count": {
+                    "type": "integer"
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.Song": {
+            "type": "object",
+            "properties": {
+                "album": {
+                    "description": "Album optionally places a new song on an album",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/model.AlbumTrack"
+                        }
+                    ]
+                },
+                "artist_id": {
+                    "type": "integer"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "enrichment_error": {
+                    "type": "string"
+                },
+                "enrichment_status": {
+                    "type": "string"
+                },
+                "genres": {
+                    "description": "Genres and Tags are loaded for a single song only",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "group": {
+                    "type": "string"
+                },
+                "headline": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "lang": {
+                    "type": "string"
+                },
+                "link": {
+                    "type": "string"
+                },
+                "lyrics": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "metadata_sources": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    }
+                },
+                "release_date": {
                     "type": "string"
                 },
                 "song": {
                     "type": "string"
                 },
+                "tags": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "translated_lyrics": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "translations": {
+                    "description": "Translations lists the languages the lyrics are translated to, loaded for a single song only.\nTranslatedLyrics holds the translation to Lang of the same verses as Lyrics",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
                 "updated_at": {
                     "type": "string"
                 }
             }
         },
-        "utils.ErrorResponse": {
+        "model.SongPatch": {
+            "type": "object",
+            "properties": {
+                "group": {
+                    "type": "string"
+                },
+                "link": {
+                    "type": "string"
+                },
+                "lyrics": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "release_date": {
+                    "type": "string"
+                },
+                "song": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.Tag": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.Track": {
+            "type": "object",
+            "properties": {
+                "disc": {
+                    "type": "integer"
+                },
+                "song": {
+                    "$ref": "#/definitions/model.Song"
+                },
+                "track_number": {
+                    "type": "integer"
+                }
+            }
+        },
+        "model.Translation": {
+            "type": "object",
+            "properties": {
+                "lang": {
+                    "type": "string"
+                },
+                "lyrics": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "model.Verse": {
+            "type": "object",
+            "properties": {
+                "position": {
+                    "type": "integer"
+                },
+                "text": {
+                    "type": "string"
+                }
+            }
+        },
+        "utils.FieldError": {
+            "type": "object",
+            "properties": {
+                "detail": {
+                    "type": "string"
+                },
+                "pointer": {
+                    "type": "string"
+                }
+            }
+        },
+        "utils.Problem": {
             "type": "object",
             "properties": {
-                "error": {
+                "detail": {
+                    "type": "string"
+                },
+                "errors": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/utils.FieldError"
+                    }
+                },
+                "instance": {
+                    "type": "string"
+                },
+                "request_id": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "integer"
+                },
+                "title": {
+                    "type": "string"
+                },
+                "type": {
                     "type": "string"
                 }
             }
         }
+    },
+    "securityDefinitions": {
+        "ApiKeyAuth": {
+            "description": "Статический API-ключ из AUTH_API_KEYS",
+            "type": "apiKey",
+            "name": "X-API-Key",
+            "in": "header"
+        },
+        "BearerAuth": {
+            "description": "JWT в формате \"Bearer \u003ctoken\u003e\", роль в claim AUTH_JWT_ROLE_CLAIM",
+            "type": "apiKey",
+            "name": "Authorization",
+            "in": "header"
+        }
     }
 }`
 
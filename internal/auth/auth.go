@@ -0,0 +1,185 @@
+package auth
+
+import (
+	"context"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	cfg "github.com/JMURv/effectiveMobile/pkg/config"
+	"github.com/golang-jwt/jwt/v5"
+	"os"
+)
+
+var ErrInvalidCredentials = errors.New("invalid credentials")
+var ErrUnknownRole = errors.New("unknown role")
+var ErrNoCredentials = errors.New("no api keys, jwt secret or jwt public key configured")
+
+type Role int
+
+// Roles are ordered, every role is allowed everything the lower ones are
+const (
+	RoleNone Role = iota
+	RoleReader
+	RoleEditor
+	RoleAdmin
+)
+
+func ParseRole(s string) (Role, error) {
+	switch s {
+	case "reader":
+		return RoleReader, nil
+	case "editor":
+		return RoleEditor, nil
+	case "admin":
+		return RoleAdmin, nil
+	default:
+		return RoleNone, fmt.Errorf("%w: %q", ErrUnknownRole, s)
+	}
+}
+
+func (r Role) String() string {
+	switch r {
+	case RoleReader:
+		return "reader"
+	case RoleEditor:
+		return "editor"
+	case RoleAdmin:
+		return "admin"
+	default:
+		return "none"
+	}
+}
+
+// Principal is the authenticated caller
+type Principal struct {
+	Subject string
+	Role    Role
+}
+
+type principalKey struct{}
+
+func WithPrincipal(ctx context.Context, p *Principal) context.Context {
+	return context.WithValue(ctx, principalKey{}, p)
+}
+
+func FromContext(ctx context.Context) (*Principal, bool) {
+	p, ok := ctx.Value(principalKey{}).(*Principal)
+	return p, ok
+}
+
+type apiKey struct {
+	key       []byte
+	principal *Principal
+}
+
+// Authenticator verifies static API keys and HS256/RS256 bearer tokens
+type Authenticator struct {
+	keys      []apiKey
+	secret    []byte
+	publicKey *rsa.PublicKey
+	parser    *jwt.Parser
+	roleClaim string
+}
+
+func New(conf *cfg.AuthConfig) (*Authenticator, error) {
+	a := &Authenticator{roleClaim: conf.RoleClaim}
+	for key, name := range conf.APIKeys {
+		role, err := ParseRole(name)
+		if err != nil {
+			return nil, err
+		}
+
+		sum := sha256.Sum256([]byte(key))
+		a.keys = append(a.keys, apiKey{
+			key: []byte(key),
+			principal: &Principal{
+				Subject: "api-key:" + hex.EncodeToString(sum[:4]),
+				Role:    role,
+			},
+		})
+	}
+
+	methods := make([]string, 0, 2)
+	if conf.JWTSecret != "" {
+		a.secret = []byte(conf.JWTSecret)
+		methods = append(methods, jwt.SigningMethodHS256.Alg())
+	}
+	if conf.JWTPublicKeyFile != "" {
+		pem, err := os.ReadFile(conf.JWTPublicKeyFile)
+		if err != nil {
+			return nil, err
+		}
+
+		a.publicKey, err = jwt.ParseRSAPublicKeyFromPEM(pem)
+		if err != nil {
+			return nil, err
+		}
+		methods = append(methods, jwt.SigningMethodRS256.Alg())
+	}
+
+	// Nothing could ever authenticate, which is surely a configuration mistake
+	if len(a.keys) == 0 && len(methods) == 0 {
+		return nil, ErrNoCredentials
+	}
+
+	opts := []jwt.ParserOption{jwt.WithValidMethods(methods), jwt.WithExpirationRequired()}
+	if conf.Issuer != "" {
+		opts = append(opts, jwt.WithIssuer(conf.Issuer))
+	}
+	if conf.Audience != "" {
+		opts = append(opts, jwt.WithAudience(conf.Audience))
+	}
+	a.parser = jwt.NewParser(opts...)
+	return a, nil
+}
+
+// APIKey looks the key up in constant time
+func (a *Authenticator) APIKey(key string) (*Principal, error) {
+	var res *Principal
+	for _, k := range a.keys {
+		if subtle.ConstantTimeCompare(k.key, []byte(key)) == 1 {
+			res = k.principal
+		}
+	}
+
+	if res == nil {
+		return nil, ErrInvalidCredentials
+	}
+	return res, nil
+}
+
+// Token verifies the signature, expiry, issuer and audience of a bearer token
+// and reads the role from the configured claim
+func (a *Authenticator) Token(token string) (*Principal, error) {
+	if a.secret == nil && a.publicKey == nil {
+		return nil, ErrInvalidCredentials
+	}
+
+	claims := jwt.MapClaims{}
+	if _, err := a.parser.ParseWithClaims(token, claims, a.key); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidCredentials, err)
+	}
+
+	name, _ := claims[a.roleClaim].(string)
+	role, err := ParseRole(name)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidCredentials, err)
+	}
+
+	sub, _ := claims.GetSubject()
+	return &Principal{Subject: sub, Role: role}, nil
+}
+
+func (a *Authenticator) key(token *jwt.Token) (any, error) {
+	switch token.Method.(type) {
+	case *jwt.SigningMethodHMAC:
+		return a.secret, nil
+	case *jwt.SigningMethodRSA:
+		return a.publicKey, nil
+	default:
+		return nil, ErrInvalidCredentials
+	}
+}
@@ -0,0 +1,112 @@
+package auth
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	cfg "github.com/JMURv/effectiveMobile/pkg/config"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func sign(t *testing.T, method jwt.SigningMethod, key any, claims jwt.MapClaims) string {
+	token, err := jwt.NewWithClaims(method, claims).SignedString(key)
+	require.Nil(t, err)
+	return token
+}
+
+func TestAuthenticator_APIKey(t *testing.T) {
+	a, err := New(&cfg.AuthConfig{APIKeys: map[string]string{"k1": "reader", "k2": "admin"}})
+	require.Nil(t, err)
+
+	p, err := a.APIKey("k2")
+	assert.Nil(t, err)
+	assert.Equal(t, RoleAdmin, p.Role)
+	assert.NotEmpty(t, p.Subject)
+
+	_, err = a.APIKey("k3")
+	assert.ErrorIs(t, err, ErrInvalidCredentials)
+
+	_, err = New(&cfg.AuthConfig{APIKeys: map[string]string{"k1": "owner"}})
+	assert.ErrorIs(t, err, ErrUnknownRole)
+
+	_, err = New(&cfg.AuthConfig{APIKeys: map[string]string{}})
+	assert.ErrorIs(t, err, ErrNoCredentials)
+}
+
+func TestAuthenticator_Token(t *testing.T) {
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.Nil(t, err)
+
+	der, err := x509.MarshalPKIXPublicKey(&rsaKey.PublicKey)
+	require.Nil(t, err)
+	keyFile := filepath.Join(t.TempDir(), "key.pem")
+	require.Nil(t, os.WriteFile(keyFile, pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der}), 0o600))
+
+	secret := []byte("secret")
+	a, err := New(&cfg.AuthConfig{
+		JWTSecret:        string(secret),
+		JWTPublicKeyFile: keyFile,
+		Issuer:           "issuer",
+		Audience:         "songs",
+		RoleClaim:        "role",
+	})
+	require.Nil(t, err)
+
+	claims := func(mod func(jwt.MapClaims)) jwt.MapClaims {
+		c := jwt.MapClaims{
+			"sub":  "user-1",
+			"iss":  "issuer",
+			"aud":  "songs",
+			"exp":  time.Now().Add(time.Hour).Unix(),
+			"role": "editor",
+		}
+		if mod != nil {
+			mod(c)
+		}
+		return c
+	}
+
+	t.Run("HS256", func(t *testing.T) {
+		p, err := a.Token(sign(t, jwt.SigningMethodHS256, secret, claims(nil)))
+		assert.Nil(t, err)
+		assert.Equal(t, &Principal{Subject: "user-1", Role: RoleEditor}, p)
+	})
+
+	t.Run("RS256", func(t *testing.T) {
+		p, err := a.Token(sign(t, jwt.SigningMethodRS256, rsaKey, claims(nil)))
+		assert.Nil(t, err)
+		assert.Equal(t, RoleEditor, p.Role)
+	})
+
+	tests := map[string]string{
+		"WrongSecret": sign(t, jwt.SigningMethodHS256, []byte("other"), claims(nil)),
+		"WrongMethod": sign(t, jwt.SigningMethodHS512, secret, claims(nil)),
+		"Expired":     sign(t, jwt.SigningMethodHS256, secret, claims(func(c jwt.MapClaims) { c["exp"] = time.Now().Add(-time.Minute).Unix() })),
+		"NoExpiry":    sign(t, jwt.SigningMethodHS256, secret, claims(func(c jwt.MapClaims) { delete(c, "exp") })),
+		"WrongIssuer": sign(t, jwt.SigningMethodHS256, secret, claims(func(c jwt.MapClaims) { c["iss"] = "other" })),
+		"WrongAud":    sign(t, jwt.SigningMethodHS256, secret, claims(func(c jwt.MapClaims) { c["aud"] = "other" })),
+		"UnknownRole": sign(t, jwt.SigningMethodHS256, secret, claims(func(c jwt.MapClaims) { c["role"] = "owner" })),
+		"Malformed":   "not-a-token",
+	}
+	for name, token := range tests {
+		t.Run(name, func(t *testing.T) {
+			_, err := a.Token(token)
+			assert.ErrorIs(t, err, ErrInvalidCredentials)
+		})
+	}
+
+	t.Run("NotConfigured", func(t *testing.T) {
+		a, err := New(&cfg.AuthConfig{APIKeys: map[string]string{"k1": "reader"}})
+		require.Nil(t, err)
+
+		_, err = a.Token(sign(t, jwt.SigningMethodHS256, secret, claims(nil)))
+		assert.ErrorIs(t, err, ErrInvalidCredentials)
+	})
+}
@@ -0,0 +1,242 @@
+package ctrl
+
+import (
+	"context"
+	"errors"
+	"github.com/JMURv/effectiveMobile/internal/repo"
+	"github.com/JMURv/effectiveMobile/internal/tracing"
+	"github.com/JMURv/effectiveMobile/pkg/model"
+	"github.com/JMURv/effectiveMobile/pkg/utils/logger"
+	"go.uber.org/zap"
+)
+
+func (c *Controller) ListAlbums(ctx context.Context, page, size int, title string) (*model.PaginatedAlbums, error) {
+	const op = "songs.ListAlbums.ctrl"
+
+	ctx, span := tracer.Start(ctx, op)
+	defer span.End()
+
+	res, err := c.repo.ListAlbums(ctx, page, size, title)
+	if err != nil {
+		tracing.RecordError(span, err)
+		logger.FromContext(ctx).Debug(
+			"failed to list albums",
+			zap.Error(err), zap.String("op", op),
+			zap.Int("page", page), zap.Int("size", size),
+		)
+		return nil, err
+	}
+
+	return res, nil
+}
+
+func (c *Controller) GetAlbum(ctx context.Context, id uint64) (*model.Album, error) {
+	const op = "songs.GetAlbum.ctrl"
+
+	ctx, span := tracer.Start(ctx, op)
+	defer span.End()
+
+	res, err := c.repo.GetAlbum(ctx, id)
+	if err != nil && errors.Is(err, repo.ErrNotFound) {
+		logger.FromContext(ctx).Debug(
+			"failed to find album",
+			zap.Error(err), zap.String("op", op),
+			zap.Uint64("ID", id),
+		)
+		return nil, ErrNotFound
+	} else if err != nil {
+		tracing.RecordError(span, err)
+		logger.FromContext(ctx).Debug(
+			"failed to get album",
+			zap.Error(err), zap.String("op", op),
+			zap.Uint64("ID", id),
+		)
+		return nil, err
+	}
+
+	return res, nil
+}
+
+// ListAlbumTracks lists the songs of the album in disc and track order
+func (c *Controller) ListAlbumTracks(ctx context.Context, id uint64) ([]*model.Track, error) {
+	const op = "songs.ListAlbumTracks.ctrl"
+
+	ctx, span := tracer.Start(ctx, op)
+	defer span.End()
+
+	if _, err := c.GetAlbum(ctx, id); err != nil {
+		return nil, err
+	}
+
+	res, err := c.repo.ListAlbumTracks(ctx, id)
+	if err != nil {
+		tracing.RecordError(span, err)
+		logger.FromContext(ctx).Debug(
+			"failed to list album tracks",
+			zap.Error(err), zap.String("op", op),
+			zap.Uint64("ID", id),
+		)
+		return nil, err
+	}
+
+	return res, nil
+}
+
+// SetAlbumTrack puts the song on the album, or moves it to the disc and track number
+func (c *Controller) SetAlbumTrack(ctx context.Context, id, songID uint64, disc, trackNumber int) error {
+	const op = "songs.SetAlbumTrack.ctrl"
+
+	ctx, span := tracer.Start(ctx, op)
+	defer span.End()
+
+	err := c.repo.SetAlbumTrack(ctx, id, songID, disc, trackNumber)
+	if err != nil && errors.Is(err, repo.ErrNotFound) {
+		logger.FromContext(ctx).Debug(
+			"failed to find album",
+			zap.Error(err), zap.String("op", op),
+			zap.Uint64("ID", id),
+		)
+		return ErrNotFound
+	} else if err != nil && errors.Is(err, repo.ErrSongNotFound) {
+		logger.FromContext(ctx).Debug(
+			"failed to find song",
+			zap.Error(err), zap.String("op", op),
+			zap.Uint64("ID", id), zap.Uint64("song", songID),
+		)
+		return ErrSongNotFound
+	} else if err != nil && errors.Is(err, repo.ErrTrackTaken) {
+		logger.FromContext(ctx).Debug(
+			"track position already taken",
+			zap.Error(err), zap.String("op", op),
+			zap.Uint64("ID", id), zap.Uint64("song", songID),
+			zap.Int("disc", disc), zap.Int("track", trackNumber),
+		)
+		return ErrTrackTaken
+	} else if err != nil {
+		tracing.RecordError(span, err)
+		logger.FromContext(ctx).Debug(
+			"failed to set album track",
+			zap.Error(err), zap.String("op", op),
+			zap.Uint64("ID", id), zap.Uint64("song", songID),
+			zap.Int("disc", disc), zap.Int("track", trackNumber),
+		)
+		return err
+	}
+
+	return nil
+}
+
+func (c *Controller) RemoveAlbumTrack(ctx context.Context, id, songID uint64) error {
+	const op = "songs.RemoveAlbumTrack.ctrl"
+
+	ctx, span := tracer.Start(ctx, op)
+	defer span.End()
+
+	err := c.repo.RemoveAlbumTrack(ctx, id, songID)
+	if err != nil && errors.Is(err, repo.ErrNotFound) {
+		logger.FromContext(ctx).Debug(
+			"failed to find album track",
+			zap.Error(err), zap.String("op", op),
+			zap.Uint64("ID", id), zap.Uint64("song", songID),
+		)
+		return ErrNotFound
+	} else if err != nil {
+		tracing.RecordError(span, err)
+		logger.FromContext(ctx).Debug(
+			"failed to remove album track",
+			zap.Error(err), zap.String("op", op),
+			zap.Uint64("ID", id), zap.Uint64("song", songID),
+		)
+		return err
+	}
+
+	return nil
+}
+
+func (c *Controller) CreateAlbum(ctx context.Context, req *model.Album) (*model.Album, error) {
+	const op = "songs.CreateAlbum.ctrl"
+
+	ctx, span := tracer.Start(ctx, op)
+	defer span.End()
+
+	res, err := c.repo.CreateAlbum(ctx, req)
+	if err != nil && errors.Is(err, repo.ErrAlreadyExists) {
+		logger.FromContext(ctx).Debug(
+			"album already exists",
+			zap.Error(err), zap.String("op", op),
+			zap.String("title", req.Title),
+		)
+		return nil, ErrAlreadyExists
+	} else if err != nil {
+		tracing.RecordError(span, err)
+		logger.FromContext(ctx).Debug(
+			"failed to create album",
+			zap.Error(err), zap.String("op", op),
+			zap.String("title", req.Title),
+		)
+		return nil, err
+	}
+
+	return res, nil
+}
+
+func (c *Controller) UpdateAlbum(ctx context.Context, req *model.Album) error {
+	const op = "songs.UpdateAlbum.ctrl"
+
+	ctx, span := tracer.Start(ctx, op)
+	defer span.End()
+
+	err := c.repo.UpdateAlbum(ctx, req)
+	if err != nil && errors.Is(err, repo.ErrNotFound) {
+		logger.FromContext(ctx).Debug(
+			"failed to find album",
+			zap.Error(err), zap.String("op", op),
+			zap.Uint64("ID", req.ID),
+		)
+		return ErrNotFound
+	} else if err != nil && errors.Is(err, repo.ErrAlreadyExists) {
+		logger.FromContext(ctx).Debug(
+			"album already exists",
+			zap.Error(err), zap.String("op", op),
+			zap.Uint64("ID", req.ID), zap.String("title", req.Title),
+		)
+		return ErrAlreadyExists
+	} else if err != nil {
+		tracing.RecordError(span, err)
+		logger.FromContext(ctx).Debug(
+			"failed to update album",
+			zap.Error(err), zap.String("op", op),
+			zap.Uint64("ID", req.ID), zap.String("title", req.Title),
+		)
+		return err
+	}
+
+	return nil
+}
+
+func (c *Controller) DeleteAlbum(ctx context.Context, id uint64) error {
+	const op = "songs.DeleteAlbum.ctrl"
+
+	ctx, span := tracer.Start(ctx, op)
+	defer span.End()
+
+	err := c.repo.DeleteAlbum(ctx, id)
+	if err != nil && errors.Is(err, repo.ErrNotFound) {
+		logger.FromContext(ctx).Debug(
+			"failed to find album",
+			zap.Error(err), zap.String("op", op),
+			zap.Uint64("ID", id),
+		)
+		return ErrNotFound
+	} else if err != nil {
+		tracing.RecordError(span, err)
+		logger.FromContext(ctx).Debug(
+			"failed to delete album",
+			zap.Error(err), zap.String("op", op),
+			zap.Uint64("ID", id),
+		)
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,173 @@
+package ctrl
+
+import (
+	"context"
+	"github.com/JMURv/effectiveMobile/internal/repo"
+	"github.com/JMURv/effectiveMobile/mocks"
+	"github.com/JMURv/effectiveMobile/pkg/model"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+	"testing"
+)
+
+func TestController_ListAlbumTracks(t *testing.T) {
+	ctrlMock := gomock.NewController(t)
+	defer ctrlMock.Finish()
+
+	svcRepo := mocks.NewMockRepo(ctrlMock)
+	extRepo := mocks.NewMockAPIRepo(ctrlMock)
+
+	ctrl := New(svcRepo, extRepo)
+	ctx := context.Background()
+
+	t.Run("Success", func(t *testing.T) {
+		expected := []*model.Track{{Disc: 1, TrackNumber: 1, Song: &model.Song{ID: 5}}}
+		svcRepo.EXPECT().GetAlbum(gomock.Any(), uint64(1)).Return(&model.Album{ID: 1}, nil).Times(1)
+		svcRepo.EXPECT().ListAlbumTracks(gomock.Any(), uint64(1)).Return(expected, nil).Times(1)
+
+		res, err := ctrl.ListAlbumTracks(ctx, 1)
+		assert.Nil(t, err)
+		assert.Equal(t, expected, res)
+	})
+
+	t.Run("ErrNotFound", func(t *testing.T) {
+		svcRepo.EXPECT().GetAlbum(gomock.Any(), uint64(1)).Return(nil, repo.ErrNotFound).Times(1)
+
+		res, err := ctrl.ListAlbumTracks(ctx, 1)
+		assert.Equal(t, ErrNotFound, err)
+		assert.Nil(t, res)
+	})
+}
+
+func TestController_SetAlbumTrack(t *testing.T) {
+	ctrlMock := gomock.NewController(t)
+	defer ctrlMock.Finish()
+
+	svcRepo := mocks.NewMockRepo(ctrlMock)
+	extRepo := mocks.NewMockAPIRepo(ctrlMock)
+
+	ctrl := New(svcRepo, extRepo)
+	ctx := context.Background()
+
+	t.Run("Success", func(t *testing.T) {
+		svcRepo.EXPECT().SetAlbumTrack(gomock.Any(), uint64(1), uint64(2), 1, 3).Return(nil).Times(1)
+		assert.Nil(t, ctrl.SetAlbumTrack(ctx, 1, 2, 1, 3))
+	})
+
+	t.Run("ErrNotFound", func(t *testing.T) {
+		svcRepo.EXPECT().SetAlbumTrack(gomock.Any(), uint64(9), uint64(2), 1, 3).Return(repo.ErrNotFound).Times(1)
+		assert.Equal(t, ErrNotFound, ctrl.SetAlbumTrack(ctx, 9, 2, 1, 3))
+	})
+
+	t.Run("ErrSongNotFound", func(t *testing.T) {
+		svcRepo.EXPECT().SetAlbumTrack(gomock.Any(), uint64(1), uint64(9), 1, 3).Return(repo.ErrSongNotFound).Times(1)
+		assert.Equal(t, ErrSongNotFound, ctrl.SetAlbumTrack(ctx, 1, 9, 1, 3))
+	})
+
+	t.Run("ErrTrackTaken", func(t *testing.T) {
+		svcRepo.EXPECT().SetAlbumTrack(gomock.Any(), uint64(1), uint64(2), 1, 1).Return(repo.ErrTrackTaken).Times(1)
+		assert.Equal(t, ErrTrackTaken, ctrl.SetAlbumTrack(ctx, 1, 2, 1, 1))
+	})
+}
+
+func TestController_RemoveAlbumTrack(t *testing.T) {
+	ctrlMock := gomock.NewController(t)
+	defer ctrlMock.Finish()
+
+	svcRepo := mocks.NewMockRepo(ctrlMock)
+	extRepo := mocks.NewMockAPIRepo(ctrlMock)
+
+	ctrl := New(svcRepo, extRepo)
+	ctx := context.Background()
+
+	t.Run("Success", func(t *testing.T) {
+		svcRepo.EXPECT().RemoveAlbumTrack(gomock.Any(), uint64(1), uint64(2)).Return(nil).Times(1)
+		assert.Nil(t, ctrl.RemoveAlbumTrack(ctx, 1, 2))
+	})
+
+	t.Run("ErrNotFound", func(t *testing.T) {
+		svcRepo.EXPECT().RemoveAlbumTrack(gomock.Any(), uint64(1), uint64(9)).Return(repo.ErrNotFound).Times(1)
+		assert.Equal(t, ErrNotFound, ctrl.RemoveAlbumTrack(ctx, 1, 9))
+	})
+}
+
+func TestController_CreateAlbum(t *testing.T) {
+	ctrlMock := gomock.NewController(t)
+	defer ctrlMock.Finish()
+
+	svcRepo := mocks.NewMockRepo(ctrlMock)
+	extRepo := mocks.NewMockAPIRepo(ctrlMock)
+
+	ctrl := New(svcRepo, extRepo)
+	ctx := context.Background()
+
+	t.Run("Success", func(t *testing.T) {
+		svcRepo.EXPECT().
+			CreateAlbum(gomock.Any(), &model.Album{Artist: "queen", Title: "A Night at the Opera"}).
+			Return(&model.Album{ID: 1, ArtistID: 7, Artist: "Queen", Title: "A Night at the Opera"}, nil).
+			Times(1)
+
+		res, err := ctrl.CreateAlbum(ctx, &model.Album{Artist: "queen", Title: "A Night at the Opera"})
+		assert.Nil(t, err)
+		assert.Equal(t, uint64(1), res.ID)
+	})
+
+	t.Run("ErrAlreadyExists", func(t *testing.T) {
+		svcRepo.EXPECT().CreateAlbum(gomock.Any(), gomock.Any()).Return(nil, repo.ErrAlreadyExists).Times(1)
+
+		res, err := ctrl.CreateAlbum(ctx, &model.Album{Artist: "Queen", Title: "A Night at the Opera"})
+		assert.Equal(t, ErrAlreadyExists, err)
+		assert.Nil(t, res)
+	})
+}
+
+func TestController_UpdateAlbum(t *testing.T) {
+	ctrlMock := gomock.NewController(t)
+	defer ctrlMock.Finish()
+
+	svcRepo := mocks.NewMockRepo(ctrlMock)
+	extRepo := mocks.NewMockAPIRepo(ctrlMock)
+
+	ctrl := New(svcRepo, extRepo)
+	ctx := context.Background()
+	req := &model.Album{ID: 1, Artist: "Queen", Title: "Jazz"}
+
+	t.Run("Success", func(t *testing.T) {
+		svcRepo.EXPECT().UpdateAlbum(gomock.Any(), req).Return(nil).Times(1)
+
+		assert.Nil(t, ctrl.UpdateAlbum(ctx, req))
+	})
+
+	t.Run("ErrNotFound", func(t *testing.T) {
+		svcRepo.EXPECT().UpdateAlbum(gomock.Any(), req).Return(repo.ErrNotFound).Times(1)
+
+		assert.Equal(t, ErrNotFound, ctrl.UpdateAlbum(ctx, req))
+	})
+
+	t.Run("ErrAlreadyExists", func(t *testing.T) {
+		svcRepo.EXPECT().UpdateAlbum(gomock.Any(), req).Return(repo.ErrAlreadyExists).Times(1)
+
+		assert.Equal(t, ErrAlreadyExists, ctrl.UpdateAlbum(ctx, req))
+	})
+}
+
+func TestController_DeleteAlbum(t *testing.T) {
+	ctrlMock := gomock.NewController(t)
+	defer ctrlMock.Finish()
+
+	svcRepo := mocks.NewMockRepo(ctrlMock)
+	extRepo := mocks.NewMockAPIRepo(ctrlMock)
+
+	ctrl := New(svcRepo, extRepo)
+	ctx := context.Background()
+
+	t.Run("Success", func(t *testing.T) {
+		svcRepo.EXPECT().DeleteAlbum(gomock.Any(), uint64(1)).Return(nil).Times(1)
+		assert.Nil(t, ctrl.DeleteAlbum(ctx, 1))
+	})
+
+	t.Run("ErrNotFound", func(t *testing.T) {
+		svcRepo.EXPECT().DeleteAlbum(gomock.Any(), uint64(1)).Return(repo.ErrNotFound).Times(1)
+		assert.Equal(t, ErrNotFound, ctrl.DeleteAlbum(ctx, 1))
+	})
+}
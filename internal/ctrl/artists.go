@@ -0,0 +1,179 @@
+package ctrl
+
+import (
+	"context"
+	"errors"
+	"github.com/JMURv/effectiveMobile/internal/repo"
+	"github.com/JMURv/effectiveMobile/internal/tracing"
+	"github.com/JMURv/effectiveMobile/pkg/model"
+	"github.com/JMURv/effectiveMobile/pkg/utils/logger"
+	"go.uber.org/zap"
+)
+
+func (c *Controller) ListArtists(ctx context.Context, page, size int, name string) (*model.PaginatedArtists, error) {
+	const op = "songs.ListArtists.ctrl"
+
+	ctx, span := tracer.Start(ctx, op)
+	defer span.End()
+
+	res, err := c.repo.ListArtists(ctx, page, size, name)
+	if err != nil {
+		tracing.RecordError(span, err)
+		logger.FromContext(ctx).Debug(
+			"failed to list artists",
+			zap.Error(err), zap.String("op", op),
+			zap.Int("page", page), zap.Int("size", size),
+		)
+		return nil, err
+	}
+
+	return res, nil
+}
+
+func (c *Controller) GetArtist(ctx context.Context, id uint64) (*model.Artist, error) {
+	const op = "songs.GetArtist.ctrl"
+
+	ctx, span := tracer.Start(ctx, op)
+	defer span.End()
+
+	res, err := c.repo.GetArtist(ctx, id)
+	if err != nil && errors.Is(err, repo.ErrNotFound) {
+		logger.FromContext(ctx).Debug(
+			"failed to find artist",
+			zap.Error(err), zap.String("op", op),
+			zap.Uint64("ID", id),
+		)
+		return nil, ErrNotFound
+	} else if err != nil {
+		tracing.RecordError(span, err)
+		logger.FromContext(ctx).Debug(
+			"failed to get artist",
+			zap.Error(err), zap.String("op", op),
+			zap.Uint64("ID", id),
+		)
+		return nil, err
+	}
+
+	return res, nil
+}
+
+// ListArtistSongs lists the songs of the artist, taking the same filters as ListSongs
+func (c *Controller) ListArtistSongs(ctx context.Context, id uint64, page, size int, filters map[string]any) (*model.PaginatedSongs, error) {
+	const op = "songs.ListArtistSongs.ctrl"
+
+	ctx, span := tracer.Start(ctx, op)
+	defer span.End()
+
+	if _, err := c.GetArtist(ctx, id); err != nil {
+		return nil, err
+	}
+
+	filters["artist_id"] = id
+	res, err := c.repo.ListSongs(ctx, page, size, filters)
+	if err != nil {
+		tracing.RecordError(span, err)
+		logger.FromContext(ctx).Debug(
+			"failed to list artist songs",
+			zap.Error(err), zap.String("op", op),
+			zap.Uint64("ID", id), zap.Int("page", page), zap.Int("size", size),
+		)
+		return nil, err
+	}
+
+	return res, nil
+}
+
+func (c *Controller) CreateArtist(ctx context.Context, req *model.Artist) (*model.Artist, error) {
+	const op = "songs.CreateArtist.ctrl"
+
+	ctx, span := tracer.Start(ctx, op)
+	defer span.End()
+
+	res, err := c.repo.CreateArtist(ctx, req)
+	if err != nil && errors.Is(err, repo.ErrAlreadyExists) {
+		logger.FromContext(ctx).Debug(
+			"artist already exists",
+			zap.Error(err), zap.String("op", op),
+			zap.String("name", req.Name),
+		)
+		return nil, ErrAlreadyExists
+	} else if err != nil {
+		tracing.RecordError(span, err)
+		logger.FromContext(ctx).Debug(
+			"failed to create artist",
+			zap.Error(err), zap.String("op", op),
+			zap.String("name", req.Name),
+		)
+		return nil, err
+	}
+
+	return res, nil
+}
+
+func (c *Controller) UpdateArtist(ctx context.Context, req *model.Artist) error {
+	const op = "songs.UpdateArtist.ctrl"
+
+	ctx, span := tracer.Start(ctx, op)
+	defer span.End()
+
+	err := c.repo.UpdateArtist(ctx, req)
+	if err != nil && errors.Is(err, repo.ErrNotFound) {
+		logger.FromContext(ctx).Debug(
+			"failed to find artist",
+			zap.Error(err), zap.String("op", op),
+			zap.Uint64("ID", req.ID),
+		)
+		return ErrNotFound
+	} else if err != nil && errors.Is(err, repo.ErrAlreadyExists) {
+		logger.FromContext(ctx).Debug(
+			"artist already exists",
+			zap.Error(err), zap.String("op", op),
+			zap.Uint64("ID", req.ID), zap.String("name", req.Name),
+		)
+		return ErrAlreadyExists
+	} else if err != nil {
+		tracing.RecordError(span, err)
+		logger.FromContext(ctx).Debug(
+			"failed to update artist",
+			zap.Error(err), zap.String("op", op),
+			zap.Uint64("ID", req.ID), zap.String("name", req.Name),
+		)
+		return err
+	}
+
+	return nil
+}
+
+func (c *Controller) DeleteArtist(ctx context.Context, id uint64) error {
+	const op = "songs.DeleteArtist.ctrl"
+
+	ctx, span := tracer.Start(ctx, op)
+	defer span.End()
+
+	err := c.repo.DeleteArtist(ctx, id)
+	if err != nil && errors.Is(err, repo.ErrNotFound) {
+		logger.FromContext(ctx).Debug(
+			"failed to find artist",
+			zap.Error(err), zap.String("op", op),
+			zap.Uint64("ID", id),
+		)
+		return ErrNotFound
+	} else if err != nil && errors.Is(err, repo.ErrInUse) {
+		logger.FromContext(ctx).Debug(
+			"artist still in use",
+			zap.Error(err), zap.String("op", op),
+			zap.Uint64("ID", id),
+		)
+		return ErrArtistInUse
+	} else if err != nil {
+		tracing.RecordError(span, err)
+		logger.FromContext(ctx).Debug(
+			"failed to delete artist",
+			zap.Error(err), zap.String("op", op),
+			zap.Uint64("ID", id),
+		)
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,154 @@
+package ctrl
+
+import (
+	"context"
+	"errors"
+	"github.com/JMURv/effectiveMobile/internal/repo"
+	"github.com/JMURv/effectiveMobile/mocks"
+	"github.com/JMURv/effectiveMobile/pkg/model"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+	"testing"
+)
+
+func TestController_GetArtist(t *testing.T) {
+	ctrlMock := gomock.NewController(t)
+	defer ctrlMock.Finish()
+
+	svcRepo := mocks.NewMockRepo(ctrlMock)
+	extRepo := mocks.NewMockAPIRepo(ctrlMock)
+
+	ctrl := New(svcRepo, extRepo)
+	ctx := context.Background()
+
+	t.Run("Success", func(t *testing.T) {
+		expected := &model.Artist{ID: 1, Name: "Queen"}
+		svcRepo.EXPECT().GetArtist(gomock.Any(), uint64(1)).Return(expected, nil).Times(1)
+
+		res, err := ctrl.GetArtist(ctx, 1)
+		assert.Nil(t, err)
+		assert.Equal(t, expected, res)
+	})
+
+	t.Run("ErrNotFound", func(t *testing.T) {
+		svcRepo.EXPECT().GetArtist(gomock.Any(), uint64(1)).Return(nil, repo.ErrNotFound).Times(1)
+
+		res, err := ctrl.GetArtist(ctx, 1)
+		assert.Equal(t, ErrNotFound, err)
+		assert.Nil(t, res)
+	})
+}
+
+func TestController_ListArtistSongs(t *testing.T) {
+	ctrlMock := gomock.NewController(t)
+	defer ctrlMock.Finish()
+
+	svcRepo := mocks.NewMockRepo(ctrlMock)
+	extRepo := mocks.NewMockAPIRepo(ctrlMock)
+
+	ctrl := New(svcRepo, extRepo)
+	ctx := context.Background()
+
+	t.Run("Success", func(t *testing.T) {
+		expected := &model.PaginatedSongs{Data: []*model.Song{{ID: 5, ArtistID: 1}}}
+		svcRepo.EXPECT().GetArtist(gomock.Any(), uint64(1)).Return(&model.Artist{ID: 1}, nil).Times(1)
+		svcRepo.EXPECT().
+			ListSongs(gomock.Any(), 1, 10, map[string]any{"song": "bohemian", "artist_id": uint64(1)}).
+			Return(expected, nil).
+			Times(1)
+
+		res, err := ctrl.ListArtistSongs(ctx, 1, 1, 10, map[string]any{"song": "bohemian"})
+		assert.Nil(t, err)
+		assert.Equal(t, expected, res)
+	})
+
+	t.Run("ErrNotFound", func(t *testing.T) {
+		svcRepo.EXPECT().GetArtist(gomock.Any(), uint64(1)).Return(nil, repo.ErrNotFound).Times(1)
+
+		res, err := ctrl.ListArtistSongs(ctx, 1, 1, 10, map[string]any{})
+		assert.Equal(t, ErrNotFound, err)
+		assert.Nil(t, res)
+	})
+}
+
+func TestController_CreateArtist(t *testing.T) {
+	ctrlMock := gomock.NewController(t)
+	defer ctrlMock.Finish()
+
+	svcRepo := mocks.NewMockRepo(ctrlMock)
+	extRepo := mocks.NewMockAPIRepo(ctrlMock)
+
+	ctrl := New(svcRepo, extRepo)
+	ctx := context.Background()
+	req := &model.Artist{Name: "Queen"}
+
+	t.Run("Success", func(t *testing.T) {
+		svcRepo.EXPECT().CreateArtist(gomock.Any(), req).Return(&model.Artist{ID: 1, Name: "Queen"}, nil).Times(1)
+
+		res, err := ctrl.CreateArtist(ctx, req)
+		assert.Nil(t, err)
+		assert.Equal(t, uint64(1), res.ID)
+	})
+
+	t.Run("ErrAlreadyExists", func(t *testing.T) {
+		svcRepo.EXPECT().CreateArtist(gomock.Any(), req).Return(nil, repo.ErrAlreadyExists).Times(1)
+
+		res, err := ctrl.CreateArtist(ctx, req)
+		assert.Equal(t, ErrAlreadyExists, err)
+		assert.Nil(t, res)
+	})
+}
+
+func TestController_UpdateArtist(t *testing.T) {
+	ctrlMock := gomock.NewController(t)
+	defer ctrlMock.Finish()
+
+	svcRepo := mocks.NewMockRepo(ctrlMock)
+	extRepo := mocks.NewMockAPIRepo(ctrlMock)
+
+	ctrl := New(svcRepo, extRepo)
+	ctx := context.Background()
+	req := &model.Artist{ID: 1, Name: "Queen"}
+
+	t.Run("Success", func(t *testing.T) {
+		svcRepo.EXPECT().UpdateArtist(gomock.Any(), req).Return(nil).Times(1)
+		assert.Nil(t, ctrl.UpdateArtist(ctx, req))
+	})
+
+	t.Run("ErrNotFound", func(t *testing.T) {
+		svcRepo.EXPECT().UpdateArtist(gomock.Any(), req).Return(repo.ErrNotFound).Times(1)
+		assert.Equal(t, ErrNotFound, ctrl.UpdateArtist(ctx, req))
+	})
+
+	t.Run("ErrAlreadyExists", func(t *testing.T) {
+		svcRepo.EXPECT().UpdateArtist(gomock.Any(), req).Return(repo.ErrAlreadyExists).Times(1)
+		assert.Equal(t, ErrAlreadyExists, ctrl.UpdateArtist(ctx, req))
+	})
+}
+
+func TestController_DeleteArtist(t *testing.T) {
+	ctrlMock := gomock.NewController(t)
+	defer ctrlMock.Finish()
+
+	svcRepo := mocks.NewMockRepo(ctrlMock)
+	extRepo := mocks.NewMockAPIRepo(ctrlMock)
+
+	ctrl := New(svcRepo, extRepo)
+	ctx := context.Background()
+
+	t.Run("Success", func(t *testing.T) {
+		svcRepo.EXPECT().DeleteArtist(gomock.Any(), uint64(1)).Return(nil).Times(1)
+		assert.Nil(t, ctrl.DeleteArtist(ctx, 1))
+	})
+
+	t.Run("ErrArtistInUse", func(t *testing.T) {
+		svcRepo.EXPECT().DeleteArtist(gomock.Any(), uint64(1)).Return(repo.ErrInUse).Times(1)
+		assert.Equal(t, ErrArtistInUse, ctrl.DeleteArtist(ctx, 1))
+	})
+
+	t.Run("ErrOther", func(t *testing.T) {
+		newErr := errors.New("new error")
+		svcRepo.EXPECT().DeleteArtist(gomock.Any(), uint64(1)).Return(newErr).Times(1)
+		assert.Equal(t, newErr, ctrl.DeleteArtist(ctx, 1))
+	})
+}
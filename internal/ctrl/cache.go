@@ -0,0 +1,15 @@
+package ctrl
+
+import "context"
+
+type cacheBypassKey struct{}
+
+// WithCacheBypass marks the context so that cached external data is ignored and refetched
+func WithCacheBypass(ctx context.Context) context.Context {
+	return context.WithValue(ctx, cacheBypassKey{}, true)
+}
+
+func IsCacheBypass(ctx context.Context) bool {
+	bypass, _ := ctx.Value(cacheBypassKey{}).(bool)
+	return bypass
+}
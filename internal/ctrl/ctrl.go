@@ -3,43 +3,144 @@ package ctrl
 import (
 	"context"
 	"errors"
+	"github.com/JMURv/effectiveMobile/internal/metrics"
 	"github.com/JMURv/effectiveMobile/internal/repo"
+	"github.com/JMURv/effectiveMobile/internal/tracing"
 	"github.com/JMURv/effectiveMobile/pkg/model"
+	"github.com/JMURv/effectiveMobile/pkg/utils/keyset"
+	"github.com/JMURv/effectiveMobile/pkg/utils/logger"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/trace"
 	"go.uber.org/zap"
-	"strings"
+	"golang.org/x/text/language"
+	"sync"
 	"time"
 )
 
+var tracer = otel.Tracer("github.com/JMURv/effectiveMobile/internal/ctrl")
+
 type SongsRepo interface {
 	ListSongs(ctx context.Context, page, size int, filters map[string]any) (*model.PaginatedSongs, error)
+	ListSongsCursor(ctx context.Context, cursor string, limit int, withCount bool, filters map[string]any) (*model.CursorPaginatedSongs, error)
 	GetSong(ctx context.Context, id uint64, page, size int) (*model.PaginatedSongs, error)
 	CreateSong(ctx context.Context, req *model.Song) (uint64, error)
 	UpdateSong(ctx context.Context, req *model.Song) error
+	PatchSong(ctx context.Context, id uint64, req *model.SongPatch) error
 	DeleteSong(ctx context.Context, id uint64) error
+
+	ListPendingEnrichments(ctx context.Context, limit int) ([]uint64, error)
+	RequeueStaleEnrichments(ctx context.Context, lease time.Duration) error
+	ClaimEnrichment(ctx context.Context, id uint64) (*model.Song, int, error)
+	CompleteEnrichment(ctx context.Context, req *model.Song, attempts int) error
+	RequeueEnrichment(ctx context.Context, id uint64, reason string, attempts int) error
+	FailEnrichment(ctx context.Context, id uint64, reason string, attempts int) error
+	ResetEnrichment(ctx context.Context, id uint64) error
+}
+
+type ArtistsRepo interface {
+	ListArtists(ctx context.Context, page, size int, name string) (*model.PaginatedArtists, error)
+	GetArtist(ctx context.Context, id uint64) (*model.Artist, error)
+	CreateArtist(ctx context.Context, req *model.Artist) (*model.Artist, error)
+	UpdateArtist(ctx context.Context, req *model.Artist) error
+	DeleteArtist(ctx context.Context, id uint64) error
+}
+
+type AlbumsRepo interface {
+	ListAlbums(ctx context.Context, page, size int, title string) (*model.PaginatedAlbums, error)
+	GetAlbum(ctx context.Context, id uint64) (*model.Album, error)
+	CreateAlbum(ctx context.Context, req *model.Album) (*model.Album, error)
+	UpdateAlbum(ctx context.Context, req *model.Album) error
+	DeleteAlbum(ctx context.Context, id uint64) error
+	ListAlbumTracks(ctx context.Context, id uint64) ([]*model.Track, error)
+	SetAlbumTrack(ctx context.Context, id, songID uint64, disc, trackNumber int) error
+	RemoveAlbumTrack(ctx context.Context, id, songID uint64) error
+}
+
+type LabelsRepo interface {
+	ListGenres(ctx context.Context) ([]*model.Genre, error)
+	CreateGenre(ctx context.Context, req *model.Genre) (*model.Genre, error)
+	DeleteGenre(ctx context.Context, id uint64) error
+	ListTags(ctx context.Context, name string) ([]*model.Tag, error)
+	DeleteTag(ctx context.Context, id uint64) error
+	SetSongGenres(ctx context.Context, id uint64, names []string) error
+	SetSongTags(ctx context.Context, id uint64, names []string) error
+}
+
+type PlaylistsRepo interface {
+	ListPlaylists(ctx context.Context, page, size int, name string) (*model.PaginatedPlaylists, error)
+	GetPlaylist(ctx context.Context, id uint64) (*model.Playlist, error)
+	CreatePlaylist(ctx context.Context, req *model.Playlist) (*model.Playlist, error)
+	UpdatePlaylist(ctx context.Context, req *model.Playlist) error
+	DeletePlaylist(ctx context.Context, id uint64) error
+	DuplicatePlaylist(ctx context.Context, id uint64, name string) (*model.Playlist, error)
+	ListPlaylistSongs(ctx context.Context, id uint64, page, size int) (*model.PaginatedSongs, error)
+	AddPlaylistSong(ctx context.Context, id, songID uint64, position int) (int, error)
+	MovePlaylistSong(ctx context.Context, id, songID uint64, position int) (int, error)
+	RemovePlaylistSong(ctx context.Context, id, songID uint64) error
+}
+
+type VersesRepo interface {
+	ListVerses(ctx context.Context, id uint64, page, size int) (*model.PaginatedVerses, error)
+	InsertVerse(ctx context.Context, id uint64, position int, text string) (int, error)
+	UpdateVerse(ctx context.Context, id uint64, position int, text string) error
+	DeleteVerse(ctx context.Context, id uint64, position int) error
+	MoveVerse(ctx context.Context, id uint64, position, to int) (int, error)
+}
+
+type LyricsRepo interface {
+	GetSongLyrics(ctx context.Context, id uint64) (*model.SongLyrics, error)
+	SetSyncedLyrics(ctx context.Context, id uint64, lines []*model.SyncedLine) error
+}
+
+type TranslationsRepo interface {
+	GetTranslation(ctx context.Context, id uint64, lang string, page, size int) ([]string, error)
+	CreateTranslation(ctx context.Context, id uint64, req *model.Translation) error
+	UpdateTranslation(ctx context.Context, id uint64, req *model.Translation) error
+	DeleteTranslation(ctx context.Context, id uint64, lang string) error
+}
+
+// Repo is the storage the controller works with
+type Repo interface {
+	SongsRepo
+	ArtistsRepo
+	AlbumsRepo
+	LabelsRepo
+	PlaylistsRepo
+	VersesRepo
+	LyricsRepo
+	TranslationsRepo
 }
 
 type APIRepo interface {
-	FetchSongDetail(group, song string) (*model.SongDetail, error)
+	FetchSongDetail(ctx context.Context, group, song string) (*model.SongDetail, error)
 }
 
 type Controller struct {
-	repo SongsRepo
+	repo Repo
 	api  APIRepo
+	jobs chan enrichmentJob
+	// workers tracks the enrichment goroutines so shutdown can wait for them
+	workers sync.WaitGroup
 }
 
-func New(repo SongsRepo, api APIRepo) *Controller {
+func New(repo Repo, api APIRepo) *Controller {
 	return &Controller{
 		repo: repo,
 		api:  api,
+		jobs: make(chan enrichmentJob, enrichmentQueueSize),
 	}
 }
 
 func (c *Controller) ListSongs(ctx context.Context, page, size int, filters map[string]any) (*model.PaginatedSongs, error) {
 	const op = "songs.ListSongs.ctrl"
 
+	ctx, span := tracer.Start(ctx, op)
+	defer span.End()
+
 	res, err := c.repo.ListSongs(ctx, page, size, filters)
 	if err != nil {
-		zap.L().Debug(
+		tracing.RecordError(span, err)
+		logger.FromContext(ctx).Debug(
 			"failed to list songs",
 			zap.Error(err), zap.String("op", op),
 			zap.Int("page", page), zap.Int("size", size),
@@ -50,19 +151,52 @@ func (c *Controller) ListSongs(ctx context.Context, page, size int, filters map[
 	return res, nil
 }
 
-func (c *Controller) GetSong(ctx context.Context, id uint64, page, size int) (*model.PaginatedSongs, error) {
+func (c *Controller) ListSongsCursor(ctx context.Context, cursor string, limit int, withCount bool, filters map[string]any) (*model.CursorPaginatedSongs, error) {
+	const op = "songs.ListSongsCursor.ctrl"
+
+	ctx, span := tracer.Start(ctx, op)
+	defer span.End()
+
+	res, err := c.repo.ListSongsCursor(ctx, cursor, limit, withCount, filters)
+	if err != nil && errors.Is(err, keyset.ErrInvalidCursor) {
+		logger.FromContext(ctx).Debug(
+			"invalid cursor",
+			zap.Error(err), zap.String("op", op),
+			zap.String("cursor", cursor),
+		)
+		return nil, ErrInvalidCursor
+	} else if err != nil {
+		tracing.RecordError(span, err)
+		logger.FromContext(ctx).Debug(
+			"failed to list songs",
+			zap.Error(err), zap.String("op", op),
+			zap.String("cursor", cursor), zap.Int("limit", limit),
+		)
+		return nil, err
+	}
+
+	return res, nil
+}
+
+// GetSong returns the song with the page of its verses. When one of the preferred languages
+// matches a translation, the translated verses of the same page are returned along with them
+func (c *Controller) GetSong(ctx context.Context, id uint64, page, size int, langs []language.Tag) (*model.PaginatedSongs, error) {
 	const op = "songs.GetSong.ctrl"
 
+	ctx, span := tracer.Start(ctx, op)
+	defer span.End()
+
 	res, err := c.repo.GetSong(ctx, id, page, size)
 	if err != nil && errors.Is(err, repo.ErrNotFound) {
-		zap.L().Debug(
+		logger.FromContext(ctx).Debug(
 			"failed to find song",
 			zap.Error(err), zap.String("op", op),
 			zap.Uint64("ID", id), zap.Int("page", page), zap.Int("size", size),
 		)
 		return nil, ErrNotFound
 	} else if err != nil {
-		zap.L().Debug(
+		tracing.RecordError(span, err)
+		logger.FromContext(ctx).Debug(
 			"failed to get song",
 			zap.Error(err), zap.String("op", op),
 			zap.Uint64("ID", id), zap.Int("page", page), zap.Int("size", size),
@@ -70,64 +204,117 @@ func (c *Controller) GetSong(ctx context.Context, id uint64, page, size int) (*m
 		return nil, err
 	}
 
-	return res, nil
-}
+	song, ok := res.Data.(*model.Song)
+	if !ok {
+		return res, nil
+	}
 
-func (c *Controller) CreateSong(ctx context.Context, req *model.Song) (uint64, error) {
-	const op = "songs.CreateSong.ctrl"
+	lang := matchTranslation(song.Translations, langs)
+	if lang == "" {
+		return res, nil
+	}
 
-	details, err := c.api.FetchSongDetail(req.Group, req.Song)
-	if err != nil {
-		zap.L().Debug(
-			"failed to fetch song details",
+	verses, err := c.repo.GetTranslation(ctx, id, lang, page, size)
+	if err != nil && errors.Is(err, repo.ErrNotFound) {
+		// The translation was deleted in between, the original is still worth returning
+		logger.FromContext(ctx).Debug(
+			"failed to find translation",
 			zap.Error(err), zap.String("op", op),
+			zap.Uint64("ID", id), zap.String("lang", lang),
 		)
-		return 0, err
-	}
-
-	parsedDate, err := time.Parse("02.01.2006", details.ReleaseDate)
-	if err != nil {
-		zap.L().Debug(
-			"failed to parse date",
+		return res, nil
+	} else if err != nil {
+		tracing.RecordError(span, err)
+		logger.FromContext(ctx).Debug(
+			"failed to get translation",
 			zap.Error(err), zap.String("op", op),
+			zap.Uint64("ID", id), zap.String("lang", lang),
 		)
-		return 0, err
+		return nil, err
 	}
-	req.ReleaseDate = parsedDate
-	req.Lyrics = strings.Split(details.Text, "\n\n")
-	req.Link = details.Link
 
+	song.Lang, song.TranslatedLyrics = lang, verses
+	return res, nil
+}
+
+func (c *Controller) CreateSong(ctx context.Context, req *model.Song) (uint64, error) {
+	const op = "songs.CreateSong.ctrl"
+
+	ctx, span := tracer.Start(ctx, op)
+	defer span.End()
+
+	req.EnrichmentStatus = model.EnrichmentPending
 	res, err := c.repo.CreateSong(ctx, req)
 	if err != nil && errors.Is(err, repo.ErrAlreadyExists) {
-		zap.L().Debug(
+		logger.FromContext(ctx).Debug(
 			"song already exists",
 			zap.Error(err), zap.String("op", op),
 		)
 		return 0, ErrAlreadyExists
+	} else if err != nil && errors.Is(err, repo.ErrNotFound) {
+		logger.FromContext(ctx).Debug(
+			"failed to find album",
+			zap.Error(err), zap.String("op", op),
+			zap.Uint64("album", req.Album.AlbumID),
+		)
+		return 0, ErrAlbumNotFound
+	} else if err != nil && errors.Is(err, repo.ErrTrackTaken) {
+		logger.FromContext(ctx).Debug(
+			"track already taken",
+			zap.Error(err), zap.String("op", op),
+			zap.Uint64("album", req.Album.AlbumID),
+			zap.Int("disc", req.Album.Disc), zap.Int("track", req.Album.TrackNumber),
+		)
+		return 0, ErrTrackTaken
 	} else if err != nil {
-		zap.L().Debug(
+		tracing.RecordError(span, err)
+		logger.FromContext(ctx).Debug(
 			"failed to create song",
 			zap.Error(err), zap.String("op", op),
 		)
 		return 0, err
 	}
 
+	metrics.SongsChanged.WithLabelValues("created").Inc()
+	c.enqueueEnrichment(enrichmentJob{
+		id:          res,
+		bypassCache: IsCacheBypass(ctx),
+		link:        trace.SpanContextFromContext(ctx),
+	})
 	return res, nil
 }
 
 func (c *Controller) UpdateSong(ctx context.Context, req *model.Song) error {
 	const op = "songs.UpdateSong.ctrl"
 
+	ctx, span := tracer.Start(ctx, op)
+	defer span.End()
+
 	err := c.repo.UpdateSong(ctx, req)
 	if err != nil && errors.Is(err, repo.ErrNotFound) {
-		zap.L().Debug(
+		logger.FromContext(ctx).Debug(
 			"failed to find song",
 			zap.Error(err), zap.String("op", op),
 			zap.Uint64("ID", req.ID), zap.String("song", req.Song),
 		)
 		return ErrNotFound
+	} else if err != nil && errors.Is(err, repo.ErrAlreadyExists) {
+		logger.FromContext(ctx).Debug(
+			"song already exists",
+			zap.Error(err), zap.String("op", op),
+			zap.Uint64("ID", req.ID), zap.String("song", req.Song),
+		)
+		return ErrAlreadyExists
+	} else if err != nil && errors.Is(err, repo.ErrTranslationsMisaligned) {
+		logger.FromContext(ctx).Debug(
+			"lyrics are not aligned with the translations",
+			zap.Error(err), zap.String("op", op),
+			zap.Uint64("ID", req.ID), zap.String("song", req.Song),
+		)
+		return ErrTranslationsMisaligned
 	} else if err != nil {
-		zap.L().Debug(
+		tracing.RecordError(span, err)
+		logger.FromContext(ctx).Debug(
 			"failed to update song",
 			zap.Error(err), zap.String("op", op),
 			zap.Uint64("ID", req.ID), zap.String("song", req.Song),
@@ -135,22 +322,69 @@ func (c *Controller) UpdateSong(ctx context.Context, req *model.Song) error {
 		return err
 	}
 
+	metrics.SongsChanged.WithLabelValues("updated").Inc()
+	return nil
+}
+
+func (c *Controller) PatchSong(ctx context.Context, id uint64, req *model.SongPatch) error {
+	const op = "songs.PatchSong.ctrl"
+
+	ctx, span := tracer.Start(ctx, op)
+	defer span.End()
+
+	err := c.repo.PatchSong(ctx, id, req)
+	if err != nil && errors.Is(err, repo.ErrNotFound) {
+		logger.FromContext(ctx).Debug(
+			"failed to find song",
+			zap.Error(err), zap.String("op", op),
+			zap.Uint64("ID", id),
+		)
+		return ErrNotFound
+	} else if err != nil && errors.Is(err, repo.ErrAlreadyExists) {
+		logger.FromContext(ctx).Debug(
+			"song already exists",
+			zap.Error(err), zap.String("op", op),
+			zap.Uint64("ID", id),
+		)
+		return ErrAlreadyExists
+	} else if err != nil && errors.Is(err, repo.ErrTranslationsMisaligned) {
+		logger.FromContext(ctx).Debug(
+			"lyrics are not aligned with the translations",
+			zap.Error(err), zap.String("op", op),
+			zap.Uint64("ID", id),
+		)
+		return ErrTranslationsMisaligned
+	} else if err != nil {
+		tracing.RecordError(span, err)
+		logger.FromContext(ctx).Debug(
+			"failed to patch song",
+			zap.Error(err), zap.String("op", op),
+			zap.Uint64("ID", id),
+		)
+		return err
+	}
+
+	metrics.SongsChanged.WithLabelValues("updated").Inc()
 	return nil
 }
 
 func (c *Controller) DeleteSong(ctx context.Context, id uint64) error {
 	const op = "songs.DeleteSong.ctrl"
 
+	ctx, span := tracer.Start(ctx, op)
+	defer span.End()
+
 	err := c.repo.DeleteSong(ctx, id)
 	if err != nil && errors.Is(err, repo.ErrNotFound) {
-		zap.L().Debug(
+		logger.FromContext(ctx).Debug(
 			"failed to find song",
 			zap.Error(err), zap.String("op", op),
 			zap.Uint64("ID", id),
 		)
 		return ErrNotFound
 	} else if err != nil {
-		zap.L().Debug(
+		tracing.RecordError(span, err)
+		logger.FromContext(ctx).Debug(
 			"failed to delete song",
 			zap.Error(err), zap.String("op", op),
 			zap.Uint64("ID", id),
@@ -158,5 +392,6 @@ func (c *Controller) DeleteSong(ctx context.Context, id uint64) error {
 		return err
 	}
 
+	metrics.SongsChanged.WithLabelValues("deleted").Inc()
 	return nil
 }
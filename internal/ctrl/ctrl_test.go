@@ -3,20 +3,21 @@ package ctrl
 import (
 	"context"
 	"errors"
+	"fmt"
 	"github.com/JMURv/effectiveMobile/internal/repo"
 	"github.com/JMURv/effectiveMobile/mocks"
 	"github.com/JMURv/effectiveMobile/pkg/model"
+	"github.com/JMURv/effectiveMobile/pkg/utils/keyset"
 	"github.com/stretchr/testify/assert"
 	"go.uber.org/mock/gomock"
 	"testing"
-	"time"
 )
 
 func TestController_ListSongs(t *testing.T) {
 	ctrlMock := gomock.NewController(t)
 	defer ctrlMock.Finish()
 
-	svcRepo := mocks.NewMockSongsRepo(ctrlMock)
+	svcRepo := mocks.NewMockRepo(ctrlMock)
 	extRepo := mocks.NewMockAPIRepo(ctrlMock)
 
 	ctrl := New(svcRepo, extRepo)
@@ -43,11 +44,49 @@ func TestController_ListSongs(t *testing.T) {
 
 }
 
+func TestController_ListSongsCursor(t *testing.T) {
+	ctrlMock := gomock.NewController(t)
+	defer ctrlMock.Finish()
+
+	svcRepo := mocks.NewMockRepo(ctrlMock)
+	extRepo := mocks.NewMockAPIRepo(ctrlMock)
+
+	ctrl := New(svcRepo, extRepo)
+	ctx := context.Background()
+
+	cursor, limit, filters := "cursor", 10, map[string]any{}
+
+	t.Run("Success", func(t *testing.T) {
+		svcRepo.EXPECT().ListSongsCursor(gomock.Any(), cursor, limit, false, filters).Return(&model.CursorPaginatedSongs{}, nil).Times(1)
+
+		res, err := ctrl.ListSongsCursor(ctx, cursor, limit, false, filters)
+		assert.Nil(t, err)
+		assert.NotNil(t, res)
+	})
+
+	t.Run("ErrInvalidCursor", func(t *testing.T) {
+		svcRepo.EXPECT().ListSongsCursor(gomock.Any(), cursor, limit, false, filters).Return(nil, keyset.ErrInvalidCursor).Times(1)
+
+		res, err := ctrl.ListSongsCursor(ctx, cursor, limit, false, filters)
+		assert.Equal(t, ErrInvalidCursor, err)
+		assert.Nil(t, res)
+	})
+
+	t.Run("ErrOther", func(t *testing.T) {
+		newErr := errors.New("new error")
+		svcRepo.EXPECT().ListSongsCursor(gomock.Any(), cursor, limit, false, filters).Return(nil, newErr).Times(1)
+
+		res, err := ctrl.ListSongsCursor(ctx, cursor, limit, false, filters)
+		assert.IsType(t, newErr, err)
+		assert.Nil(t, res)
+	})
+}
+
 func TestController_GetSong(t *testing.T) {
 	ctrlMock := gomock.NewController(t)
 	defer ctrlMock.Finish()
 
-	svcRepo := mocks.NewMockSongsRepo(ctrlMock)
+	svcRepo := mocks.NewMockRepo(ctrlMock)
 	extRepo := mocks.NewMockAPIRepo(ctrlMock)
 
 	ctrl := New(svcRepo, extRepo)
@@ -58,7 +97,7 @@ func TestController_GetSong(t *testing.T) {
 	t.Run("Success", func(t *testing.T) {
 		svcRepo.EXPECT().GetSong(gomock.Any(), idx, page, size).Return(&model.PaginatedSongs{}, nil).Times(1)
 
-		res, err := ctrl.GetSong(ctx, idx, page, size)
+		res, err := ctrl.GetSong(ctx, idx, page, size, nil)
 		assert.Nil(t, err)
 		assert.NotNil(t, res)
 	})
@@ -66,7 +105,7 @@ func TestController_GetSong(t *testing.T) {
 	t.Run("ErrNotFound", func(t *testing.T) {
 		svcRepo.EXPECT().GetSong(gomock.Any(), idx, page, size).Return(nil, repo.ErrNotFound).Times(1)
 
-		res, err := ctrl.GetSong(ctx, idx, page, size)
+		res, err := ctrl.GetSong(ctx, idx, page, size, nil)
 		assert.IsType(t, ErrNotFound, err)
 		assert.Nil(t, res)
 	})
@@ -75,7 +114,7 @@ func TestController_GetSong(t *testing.T) {
 		newErr := errors.New("new error")
 		svcRepo.EXPECT().GetSong(gomock.Any(), idx, page, size).Return(nil, newErr).Times(1)
 
-		res, err := ctrl.GetSong(ctx, idx, page, size)
+		res, err := ctrl.GetSong(ctx, idx, page, size, nil)
 		assert.IsType(t, newErr, err)
 		assert.Nil(t, res)
 	})
@@ -85,71 +124,64 @@ func TestController_CreateSong(t *testing.T) {
 	ctrlMock := gomock.NewController(t)
 	defer ctrlMock.Finish()
 
-	svcRepo := mocks.NewMockSongsRepo(ctrlMock)
+	svcRepo := mocks.NewMockRepo(ctrlMock)
 	extRepo := mocks.NewMockAPIRepo(ctrlMock)
 
 	ctrl := New(svcRepo, extRepo)
 	ctx := context.Background()
-	details := &model.SongDetail{
-		ReleaseDate: "16.07.2006",
-		Text:        "test text\n\ntest text",
-		Link:        "https://example.com",
-	}
 
 	expCreate := &model.Song{
-		Lyrics:      []string{"test text", "test text"},
-		Link:        "https://example.com",
-		ReleaseDate: time.Date(2006, 7, 16, 0, 0, 0, 0, time.UTC),
+		Group:            "group",
+		Song:             "song",
+		EnrichmentStatus: model.EnrichmentPending,
 	}
 
 	t.Run("Success", func(t *testing.T) {
-		extRepo.EXPECT().FetchSongDetail(gomock.Any(), gomock.Any()).Return(details, nil).Times(1)
-
 		svcRepo.EXPECT().CreateSong(gomock.Any(), expCreate).Return(uint64(1), nil).Times(1)
 
-		idx, err := ctrl.CreateSong(ctx, &model.Song{})
+		idx, err := ctrl.CreateSong(ctx, &model.Song{Group: "group", Song: "song"})
 		assert.Nil(t, err)
 		assert.Equal(t, uint64(1), idx)
+		assert.Equal(t, enrichmentJob{id: 1}, <-ctrl.jobs)
 	})
 
 	t.Run("ErrAlreadyExists", func(t *testing.T) {
-		extRepo.EXPECT().FetchSongDetail(gomock.Any(), gomock.Any()).Return(details, nil).Times(1)
 		svcRepo.EXPECT().CreateSong(gomock.Any(), expCreate).Return(uint64(0), repo.ErrAlreadyExists).Times(1)
 
-		idx, err := ctrl.CreateSong(ctx, &model.Song{})
+		idx, err := ctrl.CreateSong(ctx, &model.Song{Group: "group", Song: "song"})
 		assert.IsType(t, repo.ErrAlreadyExists, err)
 		assert.Equal(t, uint64(0), idx)
+		assert.Empty(t, ctrl.jobs)
 	})
 
-	t.Run("ErrOther", func(t *testing.T) {
-		newErr := errors.New("new error")
-		extRepo.EXPECT().FetchSongDetail(gomock.Any(), gomock.Any()).Return(details, nil).Times(1)
-		svcRepo.EXPECT().CreateSong(gomock.Any(), expCreate).Return(uint64(0), newErr).Times(1)
+	t.Run("ErrAlbumNotFound", func(t *testing.T) {
+		track := &model.AlbumTrack{AlbumID: 2, Disc: 1, TrackNumber: 1}
+		svcRepo.EXPECT().CreateSong(gomock.Any(), gomock.Any()).Return(uint64(0), repo.ErrNotFound).Times(1)
 
-		idx, err := ctrl.CreateSong(ctx, &model.Song{})
-		assert.IsType(t, newErr, err)
+		idx, err := ctrl.CreateSong(ctx, &model.Song{Group: "group", Song: "song", Album: track})
+		assert.Equal(t, ErrAlbumNotFound, err)
 		assert.Equal(t, uint64(0), idx)
+		assert.Empty(t, ctrl.jobs)
 	})
 
-	t.Run("ErrFetchDetails", func(t *testing.T) {
-		newErr := errors.New("new error")
-		extRepo.EXPECT().FetchSongDetail(gomock.Any(), gomock.Any()).Return(nil, newErr).Times(1)
+	t.Run("ErrTrackTaken", func(t *testing.T) {
+		track := &model.AlbumTrack{AlbumID: 2, Disc: 1, TrackNumber: 1}
+		svcRepo.EXPECT().CreateSong(gomock.Any(), gomock.Any()).Return(uint64(0), repo.ErrTrackTaken).Times(1)
 
-		idx, err := ctrl.CreateSong(ctx, &model.Song{})
-		assert.IsType(t, newErr, err)
+		idx, err := ctrl.CreateSong(ctx, &model.Song{Group: "group", Song: "song", Album: track})
+		assert.Equal(t, ErrTrackTaken, err)
 		assert.Equal(t, uint64(0), idx)
+		assert.Empty(t, ctrl.jobs)
 	})
 
-	t.Run("ErrParseDate", func(t *testing.T) {
-		extRepo.EXPECT().FetchSongDetail(gomock.Any(), gomock.Any()).Return(&model.SongDetail{
-			ReleaseDate: "bad-time",
-			Text:        "test text\n\ntest text",
-			Link:        "https://example.com",
-		}, nil).Times(1)
+	t.Run("ErrOther", func(t *testing.T) {
+		newErr := errors.New("new error")
+		svcRepo.EXPECT().CreateSong(gomock.Any(), expCreate).Return(uint64(0), newErr).Times(1)
 
-		idx, err := ctrl.CreateSong(ctx, &model.Song{})
-		assert.NotNil(t, err)
+		idx, err := ctrl.CreateSong(ctx, &model.Song{Group: "group", Song: "song"})
+		assert.IsType(t, newErr, err)
 		assert.Equal(t, uint64(0), idx)
+		assert.Empty(t, ctrl.jobs)
 	})
 }
 
@@ -157,7 +189,7 @@ func TestController_UpdateSong(t *testing.T) {
 	ctrlMock := gomock.NewController(t)
 	defer ctrlMock.Finish()
 
-	svcRepo := mocks.NewMockSongsRepo(ctrlMock)
+	svcRepo := mocks.NewMockRepo(ctrlMock)
 	extRepo := mocks.NewMockAPIRepo(ctrlMock)
 
 	ctrl := New(svcRepo, extRepo)
@@ -183,6 +215,20 @@ func TestController_UpdateSong(t *testing.T) {
 		assert.IsType(t, ErrNotFound, err)
 	})
 
+	t.Run("ErrAlreadyExists", func(t *testing.T) {
+		svcRepo.EXPECT().UpdateSong(gomock.Any(), req).Return(repo.ErrAlreadyExists).Times(1)
+
+		err := ctrl.UpdateSong(ctx, req)
+		assert.Equal(t, ErrAlreadyExists, err)
+	})
+
+	t.Run("ErrTranslationsMisaligned", func(t *testing.T) {
+		svcRepo.EXPECT().UpdateSong(gomock.Any(), req).Return(fmt.Errorf("%w: de", repo.ErrTranslationsMisaligned)).Times(1)
+
+		err := ctrl.UpdateSong(ctx, req)
+		assert.Equal(t, ErrTranslationsMisaligned, err)
+	})
+
 	t.Run("ErrOther", func(t *testing.T) {
 		newErr := errors.New("new error")
 		svcRepo.EXPECT().UpdateSong(gomock.Any(), req).Return(newErr).Times(1)
@@ -192,11 +238,71 @@ func TestController_UpdateSong(t *testing.T) {
 	})
 }
 
+func TestController_PatchSong(t *testing.T) {
+	ctrlMock := gomock.NewController(t)
+	defer ctrlMock.Finish()
+
+	svcRepo := mocks.NewMockRepo(ctrlMock)
+	extRepo := mocks.NewMockAPIRepo(ctrlMock)
+
+	ctrl := New(svcRepo, extRepo)
+	ctx := context.Background()
+
+	idx := uint64(1)
+	link := "https://example.com/patched"
+	req := &model.SongPatch{Link: &link}
+
+	t.Run("Success", func(t *testing.T) {
+		svcRepo.EXPECT().PatchSong(gomock.Any(), idx, req).Return(nil).Times(1)
+
+		err := ctrl.PatchSong(ctx, idx, req)
+		assert.Nil(t, err)
+	})
+
+	t.Run("SuccessWithGroup", func(t *testing.T) {
+		group := "group"
+		patch := &model.SongPatch{Group: &group}
+		svcRepo.EXPECT().PatchSong(gomock.Any(), idx, patch).Return(nil).Times(1)
+
+		err := ctrl.PatchSong(ctx, idx, patch)
+		assert.Nil(t, err)
+	})
+
+	t.Run("ErrNotFound", func(t *testing.T) {
+		svcRepo.EXPECT().PatchSong(gomock.Any(), idx, req).Return(repo.ErrNotFound).Times(1)
+
+		err := ctrl.PatchSong(ctx, idx, req)
+		assert.IsType(t, ErrNotFound, err)
+	})
+
+	t.Run("ErrAlreadyExists", func(t *testing.T) {
+		svcRepo.EXPECT().PatchSong(gomock.Any(), idx, req).Return(repo.ErrAlreadyExists).Times(1)
+
+		err := ctrl.PatchSong(ctx, idx, req)
+		assert.Equal(t, ErrAlreadyExists, err)
+	})
+
+	t.Run("ErrTranslationsMisaligned", func(t *testing.T) {
+		svcRepo.EXPECT().PatchSong(gomock.Any(), idx, req).Return(fmt.Errorf("%w: de", repo.ErrTranslationsMisaligned)).Times(1)
+
+		err := ctrl.PatchSong(ctx, idx, req)
+		assert.Equal(t, ErrTranslationsMisaligned, err)
+	})
+
+	t.Run("ErrOther", func(t *testing.T) {
+		newErr := errors.New("new error")
+		svcRepo.EXPECT().PatchSong(gomock.Any(), idx, req).Return(newErr).Times(1)
+
+		err := ctrl.PatchSong(ctx, idx, req)
+		assert.IsType(t, newErr, err)
+	})
+}
+
 func TestController_DeleteSong(t *testing.T) {
 	ctrlMock := gomock.NewController(t)
 	defer ctrlMock.Finish()
 
-	svcRepo := mocks.NewMockSongsRepo(ctrlMock)
+	svcRepo := mocks.NewMockRepo(ctrlMock)
 	extRepo := mocks.NewMockAPIRepo(ctrlMock)
 
 	ctrl := New(svcRepo, extRepo)
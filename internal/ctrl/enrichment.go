@@ -0,0 +1,287 @@
+package ctrl
+
+import (
+	"context"
+	"errors"
+	"github.com/JMURv/effectiveMobile/internal/repo"
+	"github.com/JMURv/effectiveMobile/internal/tracing"
+	cfg "github.com/JMURv/effectiveMobile/pkg/config"
+	"github.com/JMURv/effectiveMobile/pkg/model"
+	"github.com/JMURv/effectiveMobile/pkg/utils/logger"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+	"maps"
+	"strings"
+	"time"
+)
+
+const enrichmentQueueSize = 256
+
+type enrichmentJob struct {
+	id          uint64
+	bypassCache bool
+	// link points to the request that queued the job, the enrichment runs in its own trace
+	link trace.SpanContext
+}
+
+// StartEnrichment runs the workers filling in pending songs from the external API.
+// Songs that do not fit into the queue, or whose claim outlived the lease because
+// their instance stopped, are picked up by a periodic sweep. The workers stop
+// once ctx is canceled, WaitEnrichment blocks until they are done
+func (c *Controller) StartEnrichment(ctx context.Context, conf *cfg.EnrichmentConfig) {
+	c.workers.Add(conf.Workers + 1)
+	for i := 0; i < conf.Workers; i++ {
+		go func() {
+			defer c.workers.Done()
+			for {
+				select {
+				case <-ctx.Done():
+					return
+				case job := <-c.jobs:
+					c.enrich(ctx, job, conf)
+				}
+			}
+		}()
+	}
+
+	go func() {
+		defer c.workers.Done()
+		c.sweepEnrichments(ctx, conf)
+
+		ticker := time.NewTicker(conf.SweepInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				c.sweepEnrichments(ctx, conf)
+			}
+		}
+	}()
+}
+
+// WaitEnrichment waits for the enrichment workers and the sweep to stop
+func (c *Controller) WaitEnrichment() {
+	c.workers.Wait()
+}
+
+func (c *Controller) RefreshSong(ctx context.Context, id uint64) error {
+	const op = "songs.RefreshSong.ctrl"
+
+	ctx, span := tracer.Start(ctx, op)
+	defer span.End()
+
+	err := c.repo.ResetEnrichment(ctx, id)
+	if err != nil && errors.Is(err, repo.ErrNotFound) {
+		logger.FromContext(ctx).Debug(
+			"failed to find song",
+			zap.Error(err), zap.String("op", op),
+			zap.Uint64("ID", id),
+		)
+		return ErrNotFound
+	} else if err != nil && errors.Is(err, repo.ErrEnrichmentInProgress) {
+		logger.FromContext(ctx).Debug(
+			"song is being enriched",
+			zap.Error(err), zap.String("op", op),
+			zap.Uint64("ID", id),
+		)
+		return ErrEnrichmentInProgress
+	} else if err != nil {
+		tracing.RecordError(span, err)
+		logger.FromContext(ctx).Debug(
+			"failed to reset enrichment",
+			zap.Error(err), zap.String("op", op),
+			zap.Uint64("ID", id),
+		)
+		return err
+	}
+
+	c.enqueueEnrichment(enrichmentJob{
+		id:          id,
+		bypassCache: IsCacheBypass(ctx),
+		link:        trace.SpanContextFromContext(ctx),
+	})
+	return nil
+}
+
+func (c *Controller) enqueueEnrichment(job enrichmentJob) {
+	select {
+	case c.jobs <- job:
+	default:
+		zap.L().Debug("enrichment queue is full, leaving song to the sweep", zap.Uint64("ID", job.id))
+	}
+}
+
+func (c *Controller) sweepEnrichments(ctx context.Context, conf *cfg.EnrichmentConfig) {
+	const op = "songs.sweepEnrichments.ctrl"
+
+	ctx, span := tracer.Start(ctx, op)
+	defer span.End()
+
+	if err := c.repo.RequeueStaleEnrichments(ctx, conf.Lease); err != nil {
+		tracing.RecordError(span, err)
+		logger.FromContext(ctx).Error(
+			"failed to requeue stale enrichments",
+			zap.Error(err), zap.String("op", op),
+		)
+	}
+
+	ids, err := c.repo.ListPendingEnrichments(ctx, cap(c.jobs)-len(c.jobs))
+	if err != nil {
+		tracing.RecordError(span, err)
+		logger.FromContext(ctx).Error(
+			"failed to list pending enrichments",
+			zap.Error(err), zap.String("op", op),
+		)
+		return
+	}
+
+	for _, id := range ids {
+		c.enqueueEnrichment(enrichmentJob{id: id})
+	}
+}
+
+func (c *Controller) enrich(ctx context.Context, job enrichmentJob, conf *cfg.EnrichmentConfig) {
+	const op = "songs.enrich.ctrl"
+
+	ctx, span := tracer.Start(
+		ctx, op,
+		trace.WithLinks(trace.Link{SpanContext: job.link}),
+		trace.WithAttributes(attribute.Int64("song.id", int64(job.id))),
+	)
+	defer span.End()
+
+	id := job.id
+	fetchCtx := ctx
+	if job.bypassCache {
+		fetchCtx = WithCacheBypass(ctx)
+	}
+
+	song, attempts, err := c.repo.ClaimEnrichment(ctx, id)
+	if err != nil && errors.Is(err, repo.ErrNotFound) {
+		// Already taken by another worker or no longer pending
+		return
+	} else if err != nil {
+		tracing.RecordError(span, err)
+		logger.FromContext(ctx).Error(
+			"failed to claim enrichment",
+			zap.Error(err), zap.String("op", op),
+			zap.Uint64("ID", id),
+		)
+		return
+	}
+
+	// The provider retries on its own, a song is only tried again
+	// once the provider gave up on it or its breaker is open
+	attempts++
+	details, err := c.api.FetchSongDetail(fetchCtx, song.Group, song.Song)
+	if err != nil && ctx.Err() != nil {
+		// Left in processing state, requeued once the lease expires
+		return
+	} else if err != nil && (errors.Is(err, ErrExtRetriesExhausted) || errors.Is(err, ErrExtCircuitOpen)) && attempts < conf.MaxAttempts {
+		logger.FromContext(ctx).Debug(
+			"failed to fetch song details, requeueing",
+			zap.Error(err), zap.String("op", op),
+			zap.Uint64("ID", id), zap.Int("attempts", attempts),
+		)
+		c.requeueEnrichment(ctx, id, err.Error(), attempts)
+		return
+	}
+
+	if err == nil {
+		err = fillSongDetail(song, details)
+	}
+
+	if err != nil {
+		tracing.RecordError(span, err)
+		logger.FromContext(ctx).Debug(
+			"failed to enrich song",
+			zap.Error(err), zap.String("op", op),
+			zap.Uint64("ID", id), zap.Int("attempts", attempts),
+		)
+		c.failEnrichment(ctx, id, err.Error(), attempts)
+		return
+	}
+
+	err = c.repo.CompleteEnrichment(ctx, song, attempts)
+	if err != nil && errors.Is(err, repo.ErrTranslationsMisaligned) {
+		// Nobody is there to fix the translations, the song keeps its lyrics
+		logger.FromContext(ctx).Debug(
+			"fetched lyrics do not fit the translations",
+			zap.Error(err), zap.String("op", op),
+			zap.Uint64("ID", id),
+		)
+		c.failEnrichment(ctx, id, err.Error(), attempts)
+	} else if err != nil && errors.Is(err, repo.ErrNotFound) {
+		// Either edited since the claim or gone, in the latter case there is nothing to record
+		logger.FromContext(ctx).Debug(
+			"song changed while being enriched",
+			zap.Error(err), zap.String("op", op),
+			zap.Uint64("ID", id),
+		)
+		c.failEnrichment(ctx, id, ErrChangedDuringEnrichment.Error(), attempts)
+	} else if err != nil {
+		tracing.RecordError(span, err)
+		logger.FromContext(ctx).Error(
+			"failed to save enrichment",
+			zap.Error(err), zap.String("op", op),
+			zap.Uint64("ID", id),
+		)
+	}
+}
+
+// failEnrichment records why the song could not be enriched
+func (c *Controller) failEnrichment(ctx context.Context, id uint64, reason string, attempts int) {
+	const op = "songs.failEnrichment.ctrl"
+
+	if err := c.repo.FailEnrichment(ctx, id, reason, attempts); err != nil && !errors.Is(err, repo.ErrNotFound) {
+		logger.FromContext(ctx).Error(
+			"failed to record enrichment failure",
+			zap.Error(err), zap.String("op", op),
+			zap.Uint64("ID", id),
+		)
+	}
+}
+
+// requeueEnrichment returns the song to pending, the next sweep picks it up again
+func (c *Controller) requeueEnrichment(ctx context.Context, id uint64, reason string, attempts int) {
+	const op = "songs.requeueEnrichment.ctrl"
+
+	if err := c.repo.RequeueEnrichment(ctx, id, reason, attempts); err != nil && !errors.Is(err, repo.ErrNotFound) {
+		logger.FromContext(ctx).Error(
+			"failed to requeue enrichment",
+			zap.Error(err), zap.String("op", op),
+			zap.Uint64("ID", id),
+		)
+	}
+}
+
+// fillSongDetail copies the fetched details into the song. Providers may leave
+// some fields empty, those stay unset and the song keeps its current values.
+// A missing release date falls back to the album one the song was claimed with
+func fillSongDetail(song *model.Song, details *model.SongDetail) error {
+	if details.ReleaseDate != "" {
+		parsedDate, err := time.Parse(model.SongDetailDateLayout, details.ReleaseDate)
+		if err != nil {
+			return err
+		}
+		song.ReleaseDate = parsedDate
+	}
+
+	if details.Text != "" {
+		song.Lyrics = strings.Split(details.Text, "\n\n")
+	}
+
+	song.Link = details.Link
+	song.MetadataSources = details.Sources
+	if details.ReleaseDate == "" && !song.ReleaseDate.IsZero() {
+		song.MetadataSources = maps.Clone(details.Sources)
+		if song.MetadataSources == nil {
+			song.MetadataSources = map[string]string{}
+		}
+		song.MetadataSources[model.FieldReleaseDate] = model.SourceAlbum
+	}
+	return nil
+}
@@ -0,0 +1,329 @@
+package ctrl
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/JMURv/effectiveMobile/internal/repo"
+	"github.com/JMURv/effectiveMobile/mocks"
+	cfg "github.com/JMURv/effectiveMobile/pkg/config"
+	"github.com/JMURv/effectiveMobile/pkg/model"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+	"testing"
+	"time"
+)
+
+var testEnrichmentConf = &cfg.EnrichmentConfig{
+	Workers:       1,
+	MaxAttempts:   3,
+	SweepInterval: time.Hour,
+	Lease:         time.Minute,
+}
+
+func TestController_RefreshSong(t *testing.T) {
+	ctrlMock := gomock.NewController(t)
+	defer ctrlMock.Finish()
+
+	svcRepo := mocks.NewMockRepo(ctrlMock)
+	extRepo := mocks.NewMockAPIRepo(ctrlMock)
+
+	ctrl := New(svcRepo, extRepo)
+	ctx := context.Background()
+	idx := uint64(1)
+
+	t.Run("Success", func(t *testing.T) {
+		svcRepo.EXPECT().ResetEnrichment(gomock.Any(), idx).Return(nil).Times(1)
+
+		err := ctrl.RefreshSong(ctx, idx)
+		assert.Nil(t, err)
+		assert.Equal(t, enrichmentJob{id: idx}, <-ctrl.jobs)
+	})
+
+	t.Run("CacheBypass", func(t *testing.T) {
+		svcRepo.EXPECT().ResetEnrichment(gomock.Any(), idx).Return(nil).Times(1)
+
+		err := ctrl.RefreshSong(WithCacheBypass(ctx), idx)
+		assert.Nil(t, err)
+		assert.Equal(t, enrichmentJob{id: idx, bypassCache: true}, <-ctrl.jobs)
+	})
+
+	t.Run("ErrNotFound", func(t *testing.T) {
+		svcRepo.EXPECT().ResetEnrichment(gomock.Any(), idx).Return(repo.ErrNotFound).Times(1)
+
+		err := ctrl.RefreshSong(ctx, idx)
+		assert.Equal(t, ErrNotFound, err)
+		assert.Empty(t, ctrl.jobs)
+	})
+
+	t.Run("ErrEnrichmentInProgress", func(t *testing.T) {
+		svcRepo.EXPECT().ResetEnrichment(gomock.Any(), idx).Return(repo.ErrEnrichmentInProgress).Times(1)
+
+		err := ctrl.RefreshSong(ctx, idx)
+		assert.Equal(t, ErrEnrichmentInProgress, err)
+		assert.Empty(t, ctrl.jobs)
+	})
+
+	t.Run("ErrOther", func(t *testing.T) {
+		newErr := errors.New("new error")
+		svcRepo.EXPECT().ResetEnrichment(gomock.Any(), idx).Return(newErr).Times(1)
+
+		err := ctrl.RefreshSong(ctx, idx)
+		assert.Equal(t, newErr, err)
+		assert.Empty(t, ctrl.jobs)
+	})
+}
+
+func TestController_Enrich(t *testing.T) {
+	ctrlMock := gomock.NewController(t)
+	defer ctrlMock.Finish()
+
+	svcRepo := mocks.NewMockRepo(ctrlMock)
+	extRepo := mocks.NewMockAPIRepo(ctrlMock)
+
+	ctrl := New(svcRepo, extRepo)
+	ctx := context.Background()
+	idx := uint64(1)
+
+	details := &model.SongDetail{
+		ReleaseDate: "16.07.2006",
+		Text:        "test text\n\ntest text",
+		Link:        "https://example.com",
+	}
+	claimed := func() *model.Song {
+		return &model.Song{ID: idx, Group: "group", Song: "song", EnrichmentStatus: model.EnrichmentProcessing}
+	}
+	expComplete := &model.Song{
+		ID:               idx,
+		Group:            "group",
+		Song:             "song",
+		Lyrics:           []string{"test text", "test text"},
+		Link:             "https://example.com",
+		ReleaseDate:      time.Date(2006, 7, 16, 0, 0, 0, 0, time.UTC),
+		EnrichmentStatus: model.EnrichmentProcessing,
+	}
+
+	t.Run("Success", func(t *testing.T) {
+		svcRepo.EXPECT().ClaimEnrichment(gomock.Any(), idx).Return(claimed(), 0, nil).Times(1)
+		extRepo.EXPECT().FetchSongDetail(gomock.Any(), "group", "song").Return(details, nil).Times(1)
+		svcRepo.EXPECT().CompleteEnrichment(gomock.Any(), expComplete, 1).Return(nil).Times(1)
+
+		ctrl.enrich(ctx, enrichmentJob{id: idx}, testEnrichmentConf)
+	})
+
+	t.Run("CacheBypass", func(t *testing.T) {
+		svcRepo.EXPECT().ClaimEnrichment(gomock.Any(), idx).Return(claimed(), 0, nil).Times(1)
+		extRepo.EXPECT().FetchSongDetail(gomock.Any(), "group", "song").DoAndReturn(
+			func(ctx context.Context, group, song string) (*model.SongDetail, error) {
+				assert.True(t, IsCacheBypass(ctx))
+				return details, nil
+			},
+		).Times(1)
+		svcRepo.EXPECT().CompleteEnrichment(gomock.Any(), expComplete, 1).Return(nil).Times(1)
+
+		ctrl.enrich(ctx, enrichmentJob{id: idx, bypassCache: true}, testEnrichmentConf)
+	})
+
+	t.Run("PartialDetails", func(t *testing.T) {
+		sources := map[string]string{model.FieldLink: "fixtures"}
+
+		svcRepo.EXPECT().ClaimEnrichment(gomock.Any(), idx).Return(claimed(), 0, nil).Times(1)
+		extRepo.EXPECT().FetchSongDetail(gomock.Any(), "group", "song").Return(&model.SongDetail{
+			Link:    "https://example.com",
+			Sources: sources,
+		}, nil).Times(1)
+		svcRepo.EXPECT().CompleteEnrichment(gomock.Any(), &model.Song{
+			ID:               idx,
+			Group:            "group",
+			Song:             "song",
+			Link:             "https://example.com",
+			EnrichmentStatus: model.EnrichmentProcessing,
+			MetadataSources:  sources,
+		}, 1).Return(nil).Times(1)
+
+		ctrl.enrich(ctx, enrichmentJob{id: idx}, testEnrichmentConf)
+	})
+
+	t.Run("AlbumReleaseDate", func(t *testing.T) {
+		sources := map[string]string{model.FieldLink: "fixtures"}
+		albumDate := time.Date(1975, 11, 21, 0, 0, 0, 0, time.UTC)
+		song := claimed()
+		song.ReleaseDate = albumDate
+
+		svcRepo.EXPECT().ClaimEnrichment(gomock.Any(), idx).Return(song, 0, nil).Times(1)
+		extRepo.EXPECT().FetchSongDetail(gomock.Any(), "group", "song").Return(&model.SongDetail{
+			Link:    "https://example.com",
+			Sources: sources,
+		}, nil).Times(1)
+		svcRepo.EXPECT().CompleteEnrichment(gomock.Any(), &model.Song{
+			ID:               idx,
+			Group:            "group",
+			Song:             "song",
+			ReleaseDate:      albumDate,
+			Link:             "https://example.com",
+			EnrichmentStatus: model.EnrichmentProcessing,
+			MetadataSources:  map[string]string{model.FieldLink: "fixtures", model.FieldReleaseDate: model.SourceAlbum},
+		}, 1).Return(nil).Times(1)
+
+		ctrl.enrich(ctx, enrichmentJob{id: idx}, testEnrichmentConf)
+		assert.Len(t, sources, 1)
+	})
+
+	t.Run("ProviderReleaseDateOverridesAlbum", func(t *testing.T) {
+		song := claimed()
+		song.ReleaseDate = time.Date(1975, 11, 21, 0, 0, 0, 0, time.UTC)
+
+		svcRepo.EXPECT().ClaimEnrichment(gomock.Any(), idx).Return(song, 0, nil).Times(1)
+		extRepo.EXPECT().FetchSongDetail(gomock.Any(), "group", "song").Return(details, nil).Times(1)
+		svcRepo.EXPECT().CompleteEnrichment(gomock.Any(), expComplete, 1).Return(nil).Times(1)
+
+		ctrl.enrich(ctx, enrichmentJob{id: idx}, testEnrichmentConf)
+	})
+
+	t.Run("RequeueExhausted", func(t *testing.T) {
+		exhausted := fmt.Errorf("%w: %w", ErrExtRetriesExhausted, ExtSrvErr)
+
+		svcRepo.EXPECT().ClaimEnrichment(gomock.Any(), idx).Return(claimed(), 1, nil).Times(1)
+		extRepo.EXPECT().FetchSongDetail(gomock.Any(), "group", "song").Return(nil, exhausted).Times(1)
+		svcRepo.EXPECT().RequeueEnrichment(gomock.Any(), idx, exhausted.Error(), 2).Return(nil).Times(1)
+
+		ctrl.enrich(ctx, enrichmentJob{id: idx}, testEnrichmentConf)
+	})
+
+	t.Run("RequeueCircuitOpen", func(t *testing.T) {
+		svcRepo.EXPECT().ClaimEnrichment(gomock.Any(), idx).Return(claimed(), 0, nil).Times(1)
+		extRepo.EXPECT().FetchSongDetail(gomock.Any(), "group", "song").Return(nil, ErrExtCircuitOpen).Times(1)
+		svcRepo.EXPECT().RequeueEnrichment(gomock.Any(), idx, ErrExtCircuitOpen.Error(), 1).Return(nil).Times(1)
+
+		ctrl.enrich(ctx, enrichmentJob{id: idx}, testEnrichmentConf)
+	})
+
+	t.Run("SuccessAfterRequeue", func(t *testing.T) {
+		svcRepo.EXPECT().ClaimEnrichment(gomock.Any(), idx).Return(claimed(), 2, nil).Times(1)
+		extRepo.EXPECT().FetchSongDetail(gomock.Any(), "group", "song").Return(details, nil).Times(1)
+		svcRepo.EXPECT().CompleteEnrichment(gomock.Any(), expComplete, 3).Return(nil).Times(1)
+
+		ctrl.enrich(ctx, enrichmentJob{id: idx}, testEnrichmentConf)
+	})
+
+	t.Run("FailAfterMaxAttempts", func(t *testing.T) {
+		exhausted := fmt.Errorf("%w: %w", ErrExtRetriesExhausted, ErrExtUnreachable)
+
+		svcRepo.EXPECT().ClaimEnrichment(gomock.Any(), idx).Return(claimed(), 2, nil).Times(1)
+		extRepo.EXPECT().FetchSongDetail(gomock.Any(), "group", "song").Return(nil, exhausted).Times(1)
+		svcRepo.EXPECT().FailEnrichment(gomock.Any(), idx, exhausted.Error(), 3).Return(nil).Times(1)
+
+		ctrl.enrich(ctx, enrichmentJob{id: idx}, testEnrichmentConf)
+	})
+
+	t.Run("PermanentErrIsNotRequeued", func(t *testing.T) {
+		svcRepo.EXPECT().ClaimEnrichment(gomock.Any(), idx).Return(claimed(), 0, nil).Times(1)
+		extRepo.EXPECT().FetchSongDetail(gomock.Any(), "group", "song").Return(nil, ErrExtUnreachable).Times(1)
+		svcRepo.EXPECT().FailEnrichment(gomock.Any(), idx, ErrExtUnreachable.Error(), 1).Return(nil).Times(1)
+
+		ctrl.enrich(ctx, enrichmentJob{id: idx}, testEnrichmentConf)
+	})
+
+	t.Run("Canceled", func(t *testing.T) {
+		cancelCtx, cancel := context.WithCancel(ctx)
+
+		svcRepo.EXPECT().ClaimEnrichment(gomock.Any(), idx).Return(claimed(), 0, nil).Times(1)
+		extRepo.EXPECT().FetchSongDetail(gomock.Any(), "group", "song").DoAndReturn(
+			func(ctx context.Context, group, song string) (*model.SongDetail, error) {
+				cancel()
+				return nil, ctx.Err()
+			},
+		).Times(1)
+
+		ctrl.enrich(cancelCtx, enrichmentJob{id: idx}, testEnrichmentConf)
+	})
+
+	t.Run("ErrBadExtReqIsNotRetried", func(t *testing.T) {
+		svcRepo.EXPECT().ClaimEnrichment(gomock.Any(), idx).Return(claimed(), 0, nil).Times(1)
+		extRepo.EXPECT().FetchSongDetail(gomock.Any(), "group", "song").Return(nil, ErrBadExtReq).Times(1)
+		svcRepo.EXPECT().FailEnrichment(gomock.Any(), idx, ErrBadExtReq.Error(), 1).Return(nil).Times(1)
+
+		ctrl.enrich(ctx, enrichmentJob{id: idx}, testEnrichmentConf)
+	})
+
+	t.Run("ErrParseDate", func(t *testing.T) {
+		svcRepo.EXPECT().ClaimEnrichment(gomock.Any(), idx).Return(claimed(), 0, nil).Times(1)
+		extRepo.EXPECT().FetchSongDetail(gomock.Any(), "group", "song").Return(&model.SongDetail{
+			ReleaseDate: "bad-time",
+			Text:        "test text\n\ntest text",
+			Link:        "https://example.com",
+		}, nil).Times(1)
+		svcRepo.EXPECT().FailEnrichment(gomock.Any(), idx, gomock.Any(), 1).Return(nil).Times(1)
+
+		ctrl.enrich(ctx, enrichmentJob{id: idx}, testEnrichmentConf)
+	})
+
+	t.Run("ErrTranslationsMisaligned", func(t *testing.T) {
+		misaligned := fmt.Errorf("%w: de", repo.ErrTranslationsMisaligned)
+
+		svcRepo.EXPECT().ClaimEnrichment(gomock.Any(), idx).Return(claimed(), 0, nil).Times(1)
+		extRepo.EXPECT().FetchSongDetail(gomock.Any(), "group", "song").Return(details, nil).Times(1)
+		svcRepo.EXPECT().CompleteEnrichment(gomock.Any(), expComplete, 1).Return(misaligned).Times(1)
+		svcRepo.EXPECT().FailEnrichment(gomock.Any(), idx, misaligned.Error(), 1).Return(nil).Times(1)
+
+		ctrl.enrich(ctx, enrichmentJob{id: idx}, testEnrichmentConf)
+	})
+
+	t.Run("ChangedDuringEnrichment", func(t *testing.T) {
+		svcRepo.EXPECT().ClaimEnrichment(gomock.Any(), idx).Return(claimed(), 0, nil).Times(1)
+		extRepo.EXPECT().FetchSongDetail(gomock.Any(), "group", "song").Return(details, nil).Times(1)
+		svcRepo.EXPECT().CompleteEnrichment(gomock.Any(), expComplete, 1).Return(repo.ErrNotFound).Times(1)
+		svcRepo.EXPECT().FailEnrichment(gomock.Any(), idx, ErrChangedDuringEnrichment.Error(), 1).Return(nil).Times(1)
+
+		ctrl.enrich(ctx, enrichmentJob{id: idx}, testEnrichmentConf)
+	})
+
+	t.Run("AlreadyClaimed", func(t *testing.T) {
+		svcRepo.EXPECT().ClaimEnrichment(gomock.Any(), idx).Return(nil, 0, repo.ErrNotFound).Times(1)
+
+		ctrl.enrich(ctx, enrichmentJob{id: idx}, testEnrichmentConf)
+	})
+}
+
+func TestController_StartEnrichment(t *testing.T) {
+	ctrlMock := gomock.NewController(t)
+	defer ctrlMock.Finish()
+
+	svcRepo := mocks.NewMockRepo(ctrlMock)
+	extRepo := mocks.NewMockAPIRepo(ctrlMock)
+
+	ctrl := New(svcRepo, extRepo)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	done := make(chan struct{})
+	svcRepo.EXPECT().RequeueStaleEnrichments(gomock.Any(), testEnrichmentConf.Lease).Return(nil).Times(1)
+	svcRepo.EXPECT().ListPendingEnrichments(gomock.Any(), enrichmentQueueSize).Return([]uint64{7}, nil).Times(1)
+	svcRepo.EXPECT().ClaimEnrichment(gomock.Any(), uint64(7)).DoAndReturn(
+		func(context.Context, uint64) (*model.Song, int, error) {
+			close(done)
+			return nil, 0, repo.ErrNotFound
+		},
+	).Times(1)
+
+	ctrl.StartEnrichment(ctx, testEnrichmentConf)
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("pending song was not picked up by the sweep")
+	}
+
+	stopped := make(chan struct{})
+	go func() {
+		ctrl.WaitEnrichment()
+		close(stopped)
+	}()
+
+	cancel()
+	select {
+	case <-stopped:
+	case <-time.After(time.Second):
+		t.Fatal("enrichment workers did not stop")
+	}
+}
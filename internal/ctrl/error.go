@@ -4,6 +4,22 @@ import "errors"
 
 var ErrNotFound = errors.New("not found")
 var ErrAlreadyExists = errors.New("already exists")
+var ErrArtistInUse = errors.New("artist still has songs or albums")
+var ErrAlbumNotFound = errors.New("album not found")
+var ErrTrackTaken = errors.New("track position already taken")
+var ErrUnknownGenre = errors.New("unknown genre")
+var ErrSongNotFound = errors.New("song not found")
+var ErrAlreadyInPlaylist = errors.New("song is already in the playlist")
+var ErrPlaylistChanged = errors.New("playlist was changed concurrently, retry the request")
+var ErrVerseNotFound = errors.New("verse not found")
+var ErrVerseCountMismatch = errors.New("verse count differs from the original lyrics")
+var ErrTranslationsMisaligned = errors.New("translations have a different number of verses than the lyrics")
+var ErrEnrichmentInProgress = errors.New("song is being enriched")
+var ErrChangedDuringEnrichment = errors.New("song was changed while being enriched")
+var ErrInvalidCursor = errors.New("invalid cursor")
 var ErrBadExtReq = errors.New("bad external request")
 var ExtSrvErr = errors.New("external service error")
 var ErrExtUnreachable = errors.New("unreachable")
+var ErrExtRateLimited = errors.New("external service rate limit exceeded")
+var ErrExtCircuitOpen = errors.New("external service circuit breaker is open")
+var ErrExtRetriesExhausted = errors.New("external service retries exhausted")
@@ -0,0 +1,178 @@
+package external
+
+import (
+	"container/list"
+	"context"
+	"errors"
+	"github.com/JMURv/effectiveMobile/internal/ctrl"
+	"github.com/JMURv/effectiveMobile/internal/metrics"
+	"github.com/JMURv/effectiveMobile/internal/repo"
+	cfg "github.com/JMURv/effectiveMobile/pkg/config"
+	"github.com/JMURv/effectiveMobile/pkg/model"
+	"github.com/JMURv/effectiveMobile/pkg/utils/logger"
+	"go.uber.org/zap"
+	"maps"
+	"strings"
+	"sync"
+	"time"
+)
+
+// DetailCacheRepo persists fetched song details so they survive restarts
+type DetailCacheRepo interface {
+	GetCachedSongDetail(ctx context.Context, group, song string) (*model.SongDetail, error)
+	SaveCachedSongDetail(ctx context.Context, group, song string, req *model.SongDetail, ttl time.Duration) error
+}
+
+type cacheEntry struct {
+	key       string
+	detail    *model.SongDetail
+	err       error
+	expiresAt time.Time
+}
+
+// Cached wraps an APIRepo with a bounded in-memory LRU cache.
+// Rejected lookups are cached for a shorter time, and when a store
+// is given, fetched details are also kept in it
+type Cached struct {
+	api   ctrl.APIRepo
+	store DetailCacheRepo
+	conf  *cfg.CacheConfig
+
+	mu    sync.Mutex
+	items map[string]*list.Element
+	order *list.List
+}
+
+// NewCached creates the caching decorator. The store is optional
+func NewCached(api ctrl.APIRepo, store DetailCacheRepo, conf *cfg.CacheConfig) *Cached {
+	return &Cached{
+		api:   api,
+		store: store,
+		conf:  conf,
+		items: make(map[string]*list.Element, conf.Size),
+		order: list.New(),
+	}
+}
+
+func (c *Cached) FetchSongDetail(ctx context.Context, group, song string) (*model.SongDetail, error) {
+	const op = "songs.FetchSongDetail.cache"
+
+	groupKey, songKey := normalizeKey(group), normalizeKey(song)
+	key := detailKey(group, song)
+
+	if !ctrl.IsCacheBypass(ctx) {
+		if entry, ok := c.get(key); ok {
+			c.hit()
+			return copyDetail(entry.detail), entry.err
+		}
+
+		if c.store != nil {
+			detail, err := c.store.GetCachedSongDetail(ctx, groupKey, songKey)
+			if err == nil {
+				c.hit()
+				c.set(key, detail, nil, c.conf.TTL)
+				return copyDetail(detail), nil
+			} else if !errors.Is(err, repo.ErrNotFound) {
+				logger.FromContext(ctx).Debug(
+					"failed to read cached song detail",
+					zap.Error(err), zap.String("op", op),
+					zap.String("group", group), zap.String("song", song),
+				)
+			}
+		}
+	}
+
+	metrics.ExternalCacheLookups.WithLabelValues("miss").Inc()
+	detail, err := c.api.FetchSongDetail(ctx, group, song)
+	if err != nil && errors.Is(err, ctrl.ErrBadExtReq) {
+		c.set(key, nil, err, c.conf.NegativeTTL)
+		return nil, err
+	} else if err != nil {
+		return nil, err
+	}
+
+	c.set(key, detail, nil, c.conf.TTL)
+	if c.store != nil {
+		if err := c.store.SaveCachedSongDetail(ctx, groupKey, songKey, detail, c.conf.TTL); err != nil {
+			logger.FromContext(ctx).Debug(
+				"failed to save cached song detail",
+				zap.Error(err), zap.String("op", op),
+				zap.String("group", group), zap.String("song", song),
+			)
+		}
+	}
+
+	return copyDetail(detail), nil
+}
+
+func (c *Cached) hit() {
+	metrics.ExternalCacheLookups.WithLabelValues("hit").Inc()
+}
+
+func (c *Cached) get(key string) (*cacheEntry, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	el, ok := c.items[key]
+	if !ok {
+		return nil, false
+	}
+
+	entry := el.Value.(*cacheEntry)
+	if time.Now().After(entry.expiresAt) {
+		c.order.Remove(el)
+		delete(c.items, key)
+		return nil, false
+	}
+
+	c.order.MoveToFront(el)
+	return entry, true
+}
+
+func (c *Cached) set(key string, detail *model.SongDetail, err error, ttl time.Duration) {
+	if c.conf.Size <= 0 || ttl <= 0 {
+		return
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	entry := &cacheEntry{
+		key:       key,
+		detail:    copyDetail(detail),
+		err:       err,
+		expiresAt: time.Now().Add(ttl),
+	}
+
+	if el, ok := c.items[key]; ok {
+		el.Value = entry
+		c.order.MoveToFront(el)
+		return
+	}
+
+	c.items[key] = c.order.PushFront(entry)
+	for c.order.Len() > c.conf.Size {
+		oldest := c.order.Back()
+		c.order.Remove(oldest)
+		delete(c.items, oldest.Value.(*cacheEntry).key)
+		metrics.ExternalCacheEvictions.Inc()
+	}
+}
+
+func detailKey(group, song string) string {
+	return normalizeKey(group) + "\x00" + normalizeKey(song)
+}
+
+func normalizeKey(s string) string {
+	return strings.ToLower(strings.TrimSpace(s))
+}
+
+func copyDetail(detail *model.SongDetail) *model.SongDetail {
+	if detail == nil {
+		return nil
+	}
+
+	res := *detail
+	res.Sources = maps.Clone(detail.Sources)
+	return &res
+}
@@ -0,0 +1,223 @@
+package external
+
+import (
+	"context"
+	"errors"
+	errs "github.com/JMURv/effectiveMobile/internal/ctrl"
+	"github.com/JMURv/effectiveMobile/internal/metrics"
+	"github.com/JMURv/effectiveMobile/internal/repo"
+	"github.com/JMURv/effectiveMobile/mocks"
+	cfg "github.com/JMURv/effectiveMobile/pkg/config"
+	"github.com/JMURv/effectiveMobile/pkg/model"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+	"testing"
+	"time"
+)
+
+func testCacheConf() *cfg.CacheConfig {
+	return &cfg.CacheConfig{
+		Size:        2,
+		TTL:         time.Hour,
+		NegativeTTL: 20 * time.Millisecond,
+	}
+}
+
+// cacheCounts reads the hit, miss and eviction counters, the tests compare the deltas
+func cacheCounts() (hits, misses, evictions float64) {
+	return testutil.ToFloat64(metrics.ExternalCacheLookups.WithLabelValues("hit")),
+		testutil.ToFloat64(metrics.ExternalCacheLookups.WithLabelValues("miss")),
+		testutil.ToFloat64(metrics.ExternalCacheEvictions)
+}
+
+func TestCached_FetchSongDetail(t *testing.T) {
+	ctrlMock := gomock.NewController(t)
+	defer ctrlMock.Finish()
+
+	ctx := context.Background()
+	detail := &model.SongDetail{ReleaseDate: "16.07.2006", Text: "text", Link: "https://example.com"}
+
+	t.Run("Hit", func(t *testing.T) {
+		api := mocks.NewMockAPIRepo(ctrlMock)
+		c := NewCached(api, nil, testCacheConf())
+
+		api.EXPECT().FetchSongDetail(gomock.Any(), "Muse", "Hysteria").Return(detail, nil).Times(1)
+		hits, misses, _ := cacheCounts()
+
+		res, err := c.FetchSongDetail(ctx, "Muse", "Hysteria")
+		assert.Nil(t, err)
+		assert.Equal(t, detail, res)
+
+		res, err = c.FetchSongDetail(ctx, " muse ", "HYSTERIA")
+		assert.Nil(t, err)
+		assert.Equal(t, detail, res)
+		assert.Equal(t, 1, c.order.Len())
+
+		newHits, newMisses, _ := cacheCounts()
+		assert.Equal(t, float64(1), newHits-hits)
+		assert.Equal(t, float64(1), newMisses-misses)
+	})
+
+	t.Run("NegativeCaching", func(t *testing.T) {
+		api := mocks.NewMockAPIRepo(ctrlMock)
+		c := NewCached(api, nil, testCacheConf())
+
+		api.EXPECT().FetchSongDetail(gomock.Any(), "Muse", "Unknown").Return(nil, errs.ErrBadExtReq).Times(2)
+
+		for i := 0; i < 2; i++ {
+			res, err := c.FetchSongDetail(ctx, "Muse", "Unknown")
+			assert.Equal(t, errs.ErrBadExtReq, err)
+			assert.Nil(t, res)
+		}
+
+		time.Sleep(testCacheConf().NegativeTTL)
+		_, err := c.FetchSongDetail(ctx, "Muse", "Unknown")
+		assert.Equal(t, errs.ErrBadExtReq, err)
+	})
+
+	t.Run("ErrorsNotCached", func(t *testing.T) {
+		api := mocks.NewMockAPIRepo(ctrlMock)
+		c := NewCached(api, nil, testCacheConf())
+
+		gomock.InOrder(
+			api.EXPECT().FetchSongDetail(gomock.Any(), "Muse", "Hysteria").Return(nil, errs.ExtSrvErr),
+			api.EXPECT().FetchSongDetail(gomock.Any(), "Muse", "Hysteria").Return(detail, nil),
+		)
+
+		_, err := c.FetchSongDetail(ctx, "Muse", "Hysteria")
+		assert.Equal(t, errs.ExtSrvErr, err)
+
+		res, err := c.FetchSongDetail(ctx, "Muse", "Hysteria")
+		assert.Nil(t, err)
+		assert.Equal(t, detail, res)
+	})
+
+	t.Run("EvictsLeastRecentlyUsed", func(t *testing.T) {
+		api := mocks.NewMockAPIRepo(ctrlMock)
+		c := NewCached(api, nil, testCacheConf())
+
+		api.EXPECT().FetchSongDetail(gomock.Any(), "a", "1").Return(detail, nil).Times(2)
+		api.EXPECT().FetchSongDetail(gomock.Any(), "b", "2").Return(detail, nil).Times(1)
+		api.EXPECT().FetchSongDetail(gomock.Any(), "c", "3").Return(detail, nil).Times(1)
+		hits, misses, evictions := cacheCounts()
+
+		c.FetchSongDetail(ctx, "a", "1")
+		c.FetchSongDetail(ctx, "b", "2")
+		c.FetchSongDetail(ctx, "b", "2")
+		c.FetchSongDetail(ctx, "c", "3")
+		c.FetchSongDetail(ctx, "b", "2")
+		c.FetchSongDetail(ctx, "a", "1")
+
+		assert.Equal(t, 2, c.order.Len())
+
+		newHits, newMisses, newEvictions := cacheCounts()
+		assert.Equal(t, float64(2), newHits-hits)
+		assert.Equal(t, float64(4), newMisses-misses)
+		assert.Equal(t, float64(2), newEvictions-evictions)
+	})
+
+	t.Run("Bypass", func(t *testing.T) {
+		api := mocks.NewMockAPIRepo(ctrlMock)
+		c := NewCached(api, nil, testCacheConf())
+
+		updated := &model.SongDetail{ReleaseDate: "17.07.2006"}
+		gomock.InOrder(
+			api.EXPECT().FetchSongDetail(gomock.Any(), "Muse", "Hysteria").Return(detail, nil),
+			api.EXPECT().FetchSongDetail(gomock.Any(), "Muse", "Hysteria").Return(updated, nil),
+		)
+
+		c.FetchSongDetail(ctx, "Muse", "Hysteria")
+		res, err := c.FetchSongDetail(errs.WithCacheBypass(ctx), "Muse", "Hysteria")
+		assert.Nil(t, err)
+		assert.Equal(t, updated, res)
+
+		res, err = c.FetchSongDetail(ctx, "Muse", "Hysteria")
+		assert.Nil(t, err)
+		assert.Equal(t, updated, res)
+	})
+
+	t.Run("ReturnsCopy", func(t *testing.T) {
+		api := mocks.NewMockAPIRepo(ctrlMock)
+		c := NewCached(api, nil, testCacheConf())
+
+		api.EXPECT().FetchSongDetail(gomock.Any(), "Muse", "Hysteria").
+			Return(&model.SongDetail{ReleaseDate: "16.07.2006"}, nil).Times(1)
+
+		res, _ := c.FetchSongDetail(ctx, "Muse", "Hysteria")
+		res.ReleaseDate = "changed"
+
+		res, _ = c.FetchSongDetail(ctx, "Muse", "Hysteria")
+		assert.Equal(t, "16.07.2006", res.ReleaseDate)
+	})
+}
+
+func TestCached_FetchSongDetail_Store(t *testing.T) {
+	ctrlMock := gomock.NewController(t)
+	defer ctrlMock.Finish()
+
+	ctx := context.Background()
+	conf := testCacheConf()
+	detail := &model.SongDetail{ReleaseDate: "16.07.2006", Text: "text", Link: "https://example.com"}
+
+	t.Run("StoreHit", func(t *testing.T) {
+		api := mocks.NewMockAPIRepo(ctrlMock)
+		store := mocks.NewMockDetailCacheRepo(ctrlMock)
+		c := NewCached(api, store, conf)
+
+		store.EXPECT().GetCachedSongDetail(gomock.Any(), "muse", "hysteria").Return(detail, nil).Times(1)
+		hits, misses, _ := cacheCounts()
+
+		for i := 0; i < 2; i++ {
+			res, err := c.FetchSongDetail(ctx, "Muse", "Hysteria")
+			assert.Nil(t, err)
+			assert.Equal(t, detail, res)
+		}
+		assert.Equal(t, 1, c.order.Len())
+
+		newHits, newMisses, _ := cacheCounts()
+		assert.Equal(t, float64(2), newHits-hits)
+		assert.Equal(t, float64(0), newMisses-misses)
+	})
+
+	t.Run("StoreMiss", func(t *testing.T) {
+		api := mocks.NewMockAPIRepo(ctrlMock)
+		store := mocks.NewMockDetailCacheRepo(ctrlMock)
+		c := NewCached(api, store, conf)
+
+		store.EXPECT().GetCachedSongDetail(gomock.Any(), "muse", "hysteria").Return(nil, repo.ErrNotFound).Times(1)
+		api.EXPECT().FetchSongDetail(gomock.Any(), "Muse", "Hysteria").Return(detail, nil).Times(1)
+		store.EXPECT().SaveCachedSongDetail(gomock.Any(), "muse", "hysteria", detail, conf.TTL).Return(nil).Times(1)
+
+		res, err := c.FetchSongDetail(ctx, "Muse", "Hysteria")
+		assert.Nil(t, err)
+		assert.Equal(t, detail, res)
+	})
+
+	t.Run("StoreErrors", func(t *testing.T) {
+		api := mocks.NewMockAPIRepo(ctrlMock)
+		store := mocks.NewMockDetailCacheRepo(ctrlMock)
+		c := NewCached(api, store, conf)
+
+		dbErr := errors.New("db error")
+		store.EXPECT().GetCachedSongDetail(gomock.Any(), "muse", "hysteria").Return(nil, dbErr).Times(1)
+		api.EXPECT().FetchSongDetail(gomock.Any(), "Muse", "Hysteria").Return(detail, nil).Times(1)
+		store.EXPECT().SaveCachedSongDetail(gomock.Any(), "muse", "hysteria", detail, conf.TTL).Return(dbErr).Times(1)
+
+		res, err := c.FetchSongDetail(ctx, "Muse", "Hysteria")
+		assert.Nil(t, err)
+		assert.Equal(t, detail, res)
+	})
+
+	t.Run("NegativeNotStored", func(t *testing.T) {
+		api := mocks.NewMockAPIRepo(ctrlMock)
+		store := mocks.NewMockDetailCacheRepo(ctrlMock)
+		c := NewCached(api, store, conf)
+
+		store.EXPECT().GetCachedSongDetail(gomock.Any(), "muse", "unknown").Return(nil, repo.ErrNotFound).Times(1)
+		api.EXPECT().FetchSongDetail(gomock.Any(), "Muse", "Unknown").Return(nil, errs.ErrBadExtReq).Times(1)
+
+		_, err := c.FetchSongDetail(ctx, "Muse", "Unknown")
+		assert.Equal(t, errs.ErrBadExtReq, err)
+	})
+}
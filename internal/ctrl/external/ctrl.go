@@ -1,38 +1,84 @@
 package external
 
 import (
+	"context"
 	"encoding/json"
-	"fmt"
 	"github.com/JMURv/effectiveMobile/internal/ctrl"
+	"github.com/JMURv/effectiveMobile/internal/tracing"
+	cfg "github.com/JMURv/effectiveMobile/pkg/config"
 	"github.com/JMURv/effectiveMobile/pkg/model"
+	"github.com/JMURv/effectiveMobile/pkg/utils/logger"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/propagation"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"go.opentelemetry.io/otel/trace"
 	"go.uber.org/zap"
+	"net"
 	"net/http"
+	"net/url"
+	"strconv"
+	"time"
 )
 
+var tracer = otel.Tracer("github.com/JMURv/effectiveMobile/internal/ctrl/external")
+
 type Controller struct {
-	port int
+	baseURL *url.URL
+	client  *http.Client
 }
 
-func New(port int) *Controller {
+// New creates the music info API client. When client is nil,
+// a default one with the configured timeout is used
+func New(conf *cfg.ExternalAPIConfig, client *http.Client) *Controller {
+	if client == nil {
+		client = &http.Client{Timeout: conf.Timeout}
+	}
+
 	return &Controller{
-		port: port,
+		baseURL: &url.URL{
+			Scheme: conf.Scheme,
+			Host:   net.JoinHostPort(conf.Host, strconv.Itoa(conf.Port)),
+		},
+		client: client,
 	}
 }
 
-func (c *Controller) FetchSongDetail(group, song string) (*model.SongDetail, error) {
-	get, err := http.Get(fmt.Sprintf("http://localhost:%d/info?group=%s&song=%s", c.port, group, song))
+func (c *Controller) FetchSongDetail(ctx context.Context, group, song string) (*model.SongDetail, error) {
+	const op = "songs.FetchSongDetail.external"
+
+	ctx, span := tracer.Start(ctx, op, trace.WithSpanKind(trace.SpanKindClient))
+	defer span.End()
+
+	u := c.baseURL.JoinPath("info")
+	u.RawQuery = url.Values{
+		"group": {group},
+		"song":  {song},
+	}.Encode()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u.String(), nil)
 	if err != nil {
-		zap.L().Error("failed to fetch song detail", zap.Error(err))
 		return nil, err
 	}
+	otel.GetTextMapPropagator().Inject(ctx, propagation.HeaderCarrier(req.Header))
 
-	switch get.StatusCode {
-	case http.StatusBadRequest:
+	get, err := c.client.Do(req)
+	if err != nil {
+		tracing.RecordError(span, err)
+		logger.FromContext(ctx).Error("failed to fetch song detail", zap.Error(err), zap.String("op", op))
+		return nil, err
+	}
+	defer get.Body.Close()
+	span.SetAttributes(semconv.HTTPResponseStatusCode(get.StatusCode))
+
+	switch {
+	case get.StatusCode == http.StatusBadRequest:
 		return nil, ctrl.ErrBadExtReq
-	case http.StatusInternalServerError:
-		return nil, ctrl.ExtSrvErr
-	case http.StatusOK:
-		defer get.Body.Close()
+	case get.StatusCode == http.StatusTooManyRequests:
+		return nil, withRetryAfter(ctrl.ErrExtRateLimited, get.Header.Get("Retry-After"))
+	case get.StatusCode >= http.StatusInternalServerError:
+		tracing.RecordError(span, ctrl.ExtSrvErr)
+		return nil, withRetryAfter(ctrl.ExtSrvErr, get.Header.Get("Retry-After"))
+	case get.StatusCode == http.StatusOK:
 		res := &model.SongDetail{}
 		if err := json.NewDecoder(get.Body).Decode(res); err != nil {
 			return nil, err
@@ -42,3 +88,32 @@ func (c *Controller) FetchSongDetail(group, song string) (*model.SongDetail, err
 		return nil, ctrl.ErrExtUnreachable
 	}
 }
+
+// RetryAfterError carries the delay the upstream asked for in the Retry-After header
+type RetryAfterError struct {
+	Err   error
+	After time.Duration
+}
+
+func (e *RetryAfterError) Error() string {
+	return e.Err.Error()
+}
+
+func (e *RetryAfterError) Unwrap() error {
+	return e.Err
+}
+
+func withRetryAfter(err error, header string) error {
+	if header == "" {
+		return err
+	}
+
+	if secs, convErr := strconv.Atoi(header); convErr == nil && secs >= 0 {
+		return &RetryAfterError{Err: err, After: time.Duration(secs) * time.Second}
+	}
+
+	if at, parseErr := http.ParseTime(header); parseErr == nil {
+		return &RetryAfterError{Err: err, After: max(time.Until(at), 0)}
+	}
+	return err
+}
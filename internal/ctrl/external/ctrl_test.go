@@ -1,18 +1,40 @@
 package external
 
 import (
+	"context"
 	"encoding/json"
 	errs "github.com/JMURv/effectiveMobile/internal/ctrl"
+	cfg "github.com/JMURv/effectiveMobile/pkg/config"
 	"github.com/JMURv/effectiveMobile/pkg/model"
 	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/propagation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
 	"go.uber.org/mock/gomock"
 	"net/http"
 	"net/http/httptest"
+	"net/url"
 	"strconv"
-	"strings"
 	"testing"
+	"time"
 )
 
+func newTestConfig(t *testing.T, serverURL string) *cfg.ExternalAPIConfig {
+	u, err := url.Parse(serverURL)
+	assert.Nil(t, err)
+
+	port, err := strconv.Atoi(u.Port())
+	assert.Nil(t, err)
+
+	return &cfg.ExternalAPIConfig{
+		Scheme:  u.Scheme,
+		Host:    u.Hostname(),
+		Port:    port,
+		Timeout: time.Second,
+	}
+}
+
 func TestController_FetchSongDetail(t *testing.T) {
 	ctrlMock := gomock.NewController(t)
 	defer ctrlMock.Finish()
@@ -31,11 +53,11 @@ func TestController_FetchSongDetail(t *testing.T) {
 	}))
 	defer server.Close()
 
-	s, _ := strconv.Atoi(strings.Split(server.URL, ":")[2])
-	ctrl := New(s)
+	ctrl := New(newTestConfig(t, server.URL), nil)
+	ctx := context.Background()
 
 	t.Run("Success", func(t *testing.T) {
-		result, err := ctrl.FetchSongDetail("test-group", "test-song")
+		result, err := ctrl.FetchSongDetail(ctx, "test-group", "test-song")
 		assert.Nil(t, err)
 
 		expectedResponse := &model.SongDetail{
@@ -47,12 +69,26 @@ func TestController_FetchSongDetail(t *testing.T) {
 		assert.Equal(t, expectedResponse, result)
 	})
 
+	t.Run("EscapesQuery", func(t *testing.T) {
+		server.Config.Handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, "AC/DC & Friends", r.URL.Query().Get("group"))
+			assert.Equal(t, "Rock #1?", r.URL.Query().Get("song"))
+
+			w.WriteHeader(http.StatusOK)
+			json.NewEncoder(w).Encode(&model.SongDetail{ReleaseDate: "16.07.2006"})
+		})
+
+		result, err := ctrl.FetchSongDetail(ctx, "AC/DC & Friends", "Rock #1?")
+		assert.Nil(t, err)
+		assert.Equal(t, "16.07.2006", result.ReleaseDate)
+	})
+
 	t.Run("StatusBadRequest", func(t *testing.T) {
 		server.Config.Handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 			w.WriteHeader(http.StatusBadRequest)
 		})
 
-		result, err := ctrl.FetchSongDetail("test-group", "test-song")
+		result, err := ctrl.FetchSongDetail(ctx, "test-group", "test-song")
 		assert.NotNil(t, err)
 		assert.Equal(t, errs.ErrBadExtReq, err)
 		assert.Nil(t, result)
@@ -63,7 +99,7 @@ func TestController_FetchSongDetail(t *testing.T) {
 			w.WriteHeader(http.StatusInternalServerError)
 		})
 
-		result, err := ctrl.FetchSongDetail("test-group", "test-song")
+		result, err := ctrl.FetchSongDetail(ctx, "test-group", "test-song")
 		assert.NotNil(t, err)
 		assert.Equal(t, errs.ExtSrvErr, err)
 		assert.Nil(t, result)
@@ -75,7 +111,7 @@ func TestController_FetchSongDetail(t *testing.T) {
 			w.Write([]byte("invalid json"))
 		})
 
-		result, err := ctrl.FetchSongDetail("test-group", "test-song")
+		result, err := ctrl.FetchSongDetail(ctx, "test-group", "test-song")
 		assert.NotNil(t, err)
 		assert.Nil(t, result)
 	})
@@ -85,9 +121,112 @@ func TestController_FetchSongDetail(t *testing.T) {
 			w.WriteHeader(http.StatusTeapot)
 		})
 
-		result, err := ctrl.FetchSongDetail("test-group", "test-song")
+		result, err := ctrl.FetchSongDetail(ctx, "test-group", "test-song")
 		assert.NotNil(t, err)
 		assert.Equal(t, errs.ErrExtUnreachable, err)
 		assert.Nil(t, result)
 	})
+
+	t.Run("ContextCanceled", func(t *testing.T) {
+		canceled, cancel := context.WithCancel(ctx)
+		cancel()
+
+		result, err := ctrl.FetchSongDetail(canceled, "test-group", "test-song")
+		assert.ErrorIs(t, err, context.Canceled)
+		assert.Nil(t, result)
+	})
+
+	t.Run("Timeout", func(t *testing.T) {
+		server.Config.Handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			time.Sleep(100 * time.Millisecond)
+			w.WriteHeader(http.StatusOK)
+		})
+
+		client := New(newTestConfig(t, server.URL), &http.Client{Timeout: 10 * time.Millisecond})
+		result, err := client.FetchSongDetail(ctx, "test-group", "test-song")
+		assert.NotNil(t, err)
+		assert.Nil(t, result)
+	})
+}
+
+func TestController_FetchSongDetail_RetryAfter(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("TooManyRequests", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Retry-After", "2")
+			w.WriteHeader(http.StatusTooManyRequests)
+		}))
+		defer server.Close()
+
+		result, err := New(newTestConfig(t, server.URL), nil).FetchSongDetail(ctx, "test-group", "test-song")
+		assert.ErrorIs(t, err, errs.ErrExtRateLimited)
+		assert.Nil(t, result)
+
+		var retryAfter *RetryAfterError
+		assert.ErrorAs(t, err, &retryAfter)
+		assert.Equal(t, 2*time.Second, retryAfter.After)
+	})
+
+	t.Run("ServiceUnavailableHTTPDate", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Retry-After", time.Now().Add(time.Hour).UTC().Format(http.TimeFormat))
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}))
+		defer server.Close()
+
+		result, err := New(newTestConfig(t, server.URL), nil).FetchSongDetail(ctx, "test-group", "test-song")
+		assert.ErrorIs(t, err, errs.ExtSrvErr)
+		assert.Nil(t, result)
+
+		var retryAfter *RetryAfterError
+		assert.ErrorAs(t, err, &retryAfter)
+		assert.Greater(t, retryAfter.After, 59*time.Minute)
+	})
+
+	t.Run("InvalidHeader", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Retry-After", "soon")
+			w.WriteHeader(http.StatusBadGateway)
+		}))
+		defer server.Close()
+
+		result, err := New(newTestConfig(t, server.URL), nil).FetchSongDetail(ctx, "test-group", "test-song")
+		assert.Equal(t, errs.ExtSrvErr, err)
+		assert.Nil(t, result)
+	})
+}
+
+func TestController_FetchSongDetail_TraceContext(t *testing.T) {
+	sr := tracetest.NewSpanRecorder()
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(sr))
+	defer tp.Shutdown(context.Background())
+
+	prevTP, prevProp := otel.GetTracerProvider(), otel.GetTextMapPropagator()
+	otel.SetTracerProvider(tp)
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+	defer func() {
+		otel.SetTracerProvider(prevTP)
+		otel.SetTextMapPropagator(prevProp)
+	}()
+
+	var traceparent string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		traceparent = r.Header.Get("traceparent")
+		json.NewEncoder(w).Encode(&model.SongDetail{})
+	}))
+	defer server.Close()
+
+	ctx, parent := tp.Tracer("test").Start(context.Background(), "parent")
+	_, err := New(newTestConfig(t, server.URL), nil).FetchSongDetail(ctx, "g", "s")
+	parent.End()
+	assert.Nil(t, err)
+
+	spans := sr.Ended()
+	assert.Len(t, spans, 2)
+	child := spans[0]
+	assert.Equal(t, "songs.FetchSongDetail.external", child.Name())
+	assert.Equal(t, parent.SpanContext().TraceID(), child.Parent().TraceID())
+	assert.Contains(t, traceparent, child.SpanContext().TraceID().String())
+	assert.Contains(t, traceparent, child.SpanContext().SpanID().String())
 }
@@ -0,0 +1,81 @@
+package external
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/JMURv/effectiveMobile/internal/ctrl"
+	"github.com/JMURv/effectiveMobile/pkg/model"
+	"gopkg.in/yaml.v3"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+type fixture struct {
+	Group       string `json:"group" yaml:"group"`
+	Song        string `json:"song" yaml:"song"`
+	ReleaseDate string `json:"releaseDate" yaml:"releaseDate"`
+	Text        string `json:"text" yaml:"text"`
+	Link        string `json:"link" yaml:"link"`
+}
+
+// Fixtures serves song details from a local directory of JSON or YAML files,
+// each holding a list of songs
+type Fixtures struct {
+	songs map[string]*model.SongDetail
+}
+
+// NewFixtures loads every .json, .yaml and .yml file in dir
+func NewFixtures(dir string) (*Fixtures, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	res := &Fixtures{songs: make(map[string]*model.SongDetail)}
+	for _, entry := range entries {
+		if entry.IsDir() {
+			continue
+		}
+
+		var unmarshal func([]byte, any) error
+		switch strings.ToLower(filepath.Ext(entry.Name())) {
+		case ".json":
+			unmarshal = json.Unmarshal
+		case ".yaml", ".yml":
+			unmarshal = yaml.Unmarshal
+		default:
+			continue
+		}
+
+		path := filepath.Join(dir, entry.Name())
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, err
+		}
+
+		var fixtures []fixture
+		if err := unmarshal(data, &fixtures); err != nil {
+			return nil, fmt.Errorf("%s: %w", path, err)
+		}
+
+		for _, f := range fixtures {
+			res.songs[detailKey(f.Group, f.Song)] = &model.SongDetail{
+				ReleaseDate: f.ReleaseDate,
+				Text:        f.Text,
+				Link:        f.Link,
+			}
+		}
+	}
+
+	return res, nil
+}
+
+func (f *Fixtures) FetchSongDetail(ctx context.Context, group, song string) (*model.SongDetail, error) {
+	detail, ok := f.songs[detailKey(group, song)]
+	if !ok {
+		return nil, ctrl.ErrBadExtReq
+	}
+	return copyDetail(detail), nil
+}
@@ -0,0 +1,70 @@
+package external
+
+import (
+	"context"
+	errs "github.com/JMURv/effectiveMobile/internal/ctrl"
+	"github.com/JMURv/effectiveMobile/pkg/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestFixtures_FetchSongDetail(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "muse.json"), []byte(`[
+		{"group": "Muse", "song": "Supermassive Black Hole", "releaseDate": "16.07.2006", "text": "Ooh baby", "link": "https://example.com"}
+	]`), 0o644))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "queen.yaml"), []byte(`
+- group: Queen
+  song: Bohemian Rhapsody
+  releaseDate: 31.10.1975
+  link: https://example.com/queen
+`), 0o644))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "README.md"), []byte("ignored"), 0o644))
+
+	fixtures, err := NewFixtures(dir)
+	require.NoError(t, err)
+	ctx := context.Background()
+
+	t.Run("JSON", func(t *testing.T) {
+		res, err := fixtures.FetchSongDetail(ctx, "muse", " Supermassive Black Hole ")
+		assert.Nil(t, err)
+		assert.Equal(t, &model.SongDetail{
+			ReleaseDate: "16.07.2006",
+			Text:        "Ooh baby",
+			Link:        "https://example.com",
+		}, res)
+	})
+
+	t.Run("YAML", func(t *testing.T) {
+		res, err := fixtures.FetchSongDetail(ctx, "Queen", "Bohemian Rhapsody")
+		assert.Nil(t, err)
+		assert.Equal(t, &model.SongDetail{
+			ReleaseDate: "31.10.1975",
+			Link:        "https://example.com/queen",
+		}, res)
+	})
+
+	t.Run("NotFound", func(t *testing.T) {
+		res, err := fixtures.FetchSongDetail(ctx, "Queen", "Unknown")
+		assert.Equal(t, errs.ErrBadExtReq, err)
+		assert.Nil(t, res)
+	})
+}
+
+func TestNewFixtures(t *testing.T) {
+	t.Run("MissingDir", func(t *testing.T) {
+		_, err := NewFixtures(filepath.Join(t.TempDir(), "missing"))
+		assert.NotNil(t, err)
+	})
+
+	t.Run("InvalidFile", func(t *testing.T) {
+		dir := t.TempDir()
+		require.NoError(t, os.WriteFile(filepath.Join(dir, "broken.json"), []byte(`{`), 0o644))
+
+		_, err := NewFixtures(dir)
+		assert.ErrorContains(t, err, "broken.json")
+	})
+}
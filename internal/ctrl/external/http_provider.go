@@ -0,0 +1,148 @@
+package external
+
+import (
+	"context"
+	"encoding/json"
+	"github.com/JMURv/effectiveMobile/internal/ctrl"
+	"github.com/JMURv/effectiveMobile/internal/tracing"
+	cfg "github.com/JMURv/effectiveMobile/pkg/config"
+	"github.com/JMURv/effectiveMobile/pkg/model"
+	"github.com/JMURv/effectiveMobile/pkg/utils/logger"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/propagation"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// HTTPProvider fetches song details from an arbitrary JSON API,
+// mapping the response fields as configured
+type HTTPProvider struct {
+	conf   *cfg.HTTPProviderConfig
+	client *http.Client
+}
+
+// NewHTTPProvider creates a generic provider. When client is nil,
+// a default one with the configured timeout is used
+func NewHTTPProvider(conf *cfg.HTTPProviderConfig, client *http.Client) *HTTPProvider {
+	if client == nil {
+		client = &http.Client{Timeout: conf.Timeout}
+	}
+
+	return &HTTPProvider{
+		conf:   conf,
+		client: client,
+	}
+}
+
+func (p *HTTPProvider) FetchSongDetail(ctx context.Context, group, song string) (*model.SongDetail, error) {
+	const op = "songs.FetchSongDetail.http_provider"
+
+	ctx, span := tracer.Start(ctx, op, trace.WithSpanKind(trace.SpanKindClient))
+	defer span.End()
+
+	u := strings.NewReplacer(
+		"{group}", url.QueryEscape(group),
+		"{song}", url.QueryEscape(song),
+	).Replace(p.conf.URL)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u, nil)
+	if err != nil {
+		return nil, err
+	}
+	otel.GetTextMapPropagator().Inject(ctx, propagation.HeaderCarrier(req.Header))
+
+	get, err := p.client.Do(req)
+	if err != nil {
+		tracing.RecordError(span, err)
+		logger.FromContext(ctx).Error("failed to fetch song detail", zap.Error(err), zap.String("op", op))
+		return nil, err
+	}
+	defer get.Body.Close()
+	span.SetAttributes(semconv.HTTPResponseStatusCode(get.StatusCode))
+
+	switch {
+	case get.StatusCode == http.StatusBadRequest, get.StatusCode == http.StatusNotFound:
+		return nil, ctrl.ErrBadExtReq
+	case get.StatusCode == http.StatusTooManyRequests:
+		return nil, withRetryAfter(ctrl.ErrExtRateLimited, get.Header.Get("Retry-After"))
+	case get.StatusCode >= http.StatusInternalServerError:
+		return nil, withRetryAfter(ctrl.ExtSrvErr, get.Header.Get("Retry-After"))
+	case get.StatusCode != http.StatusOK:
+		return nil, ctrl.ErrExtUnreachable
+	}
+
+	var body any
+	if err := json.NewDecoder(get.Body).Decode(&body); err != nil {
+		return nil, err
+	}
+
+	res := &model.SongDetail{
+		ReleaseDate: lookupField(body, p.conf.ReleaseDateField),
+		Text:        lookupField(body, p.conf.TextField),
+		Link:        lookupField(body, p.conf.LinkField),
+	}
+
+	// A date that does not fit the layout is dropped, the rest of the details are still usable
+	if res.ReleaseDate != "" {
+		parsed, err := time.Parse(p.conf.ReleaseDateLayout, res.ReleaseDate)
+		if err != nil {
+			logger.FromContext(ctx).Warn(
+				"failed to parse release date",
+				zap.Error(err), zap.String("op", op),
+				zap.String("release_date", res.ReleaseDate),
+			)
+			res.ReleaseDate = ""
+		} else {
+			res.ReleaseDate = parsed.Format(model.SongDetailDateLayout)
+		}
+	}
+
+	return res, nil
+}
+
+// lookupField follows a dot-separated path through the decoded JSON.
+// Numeric segments index into arrays, a list of strings is joined into verses
+func lookupField(body any, path string) string {
+	if path == "" {
+		return ""
+	}
+
+	cur := body
+	for _, key := range strings.Split(path, ".") {
+		switch v := cur.(type) {
+		case map[string]any:
+			cur = v[key]
+		case []any:
+			idx, err := strconv.Atoi(key)
+			if err != nil || idx < 0 || idx >= len(v) {
+				return ""
+			}
+			cur = v[idx]
+		default:
+			return ""
+		}
+	}
+
+	switch v := cur.(type) {
+	case string:
+		return v
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	case []any:
+		verses := make([]string, 0, len(v))
+		for _, item := range v {
+			if s, ok := item.(string); ok {
+				verses = append(verses, s)
+			}
+		}
+		return strings.Join(verses, "\n\n")
+	default:
+		return ""
+	}
+}
@@ -0,0 +1,108 @@
+package external
+
+import (
+	"context"
+	errs "github.com/JMURv/effectiveMobile/internal/ctrl"
+	cfg "github.com/JMURv/effectiveMobile/pkg/config"
+	"github.com/JMURv/effectiveMobile/pkg/model"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestHTTPProvider_FetchSongDetail(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/tracks", r.URL.Path)
+		assert.Equal(t, "AC/DC", r.URL.Query().Get("artist"))
+		assert.Equal(t, "T.N.T. & more", r.URL.Query().Get("title"))
+
+		w.Write([]byte(`{
+			"track": {
+				"released": "1975-12-01",
+				"lyrics": ["First verse", "Second verse"],
+				"links": [{"url": "https://example.com/tnt"}]
+			}
+		}`))
+	}))
+	defer server.Close()
+
+	conf := &cfg.HTTPProviderConfig{
+		URL:               server.URL + "/tracks?artist={group}&title={song}",
+		Timeout:           time.Second,
+		ReleaseDateField:  "track.released",
+		ReleaseDateLayout: "2006-01-02",
+		TextField:         "track.lyrics",
+		LinkField:         "track.links.0.url",
+	}
+	provider := NewHTTPProvider(conf, nil)
+	ctx := context.Background()
+
+	t.Run("Success", func(t *testing.T) {
+		res, err := provider.FetchSongDetail(ctx, "AC/DC", "T.N.T. & more")
+		assert.Nil(t, err)
+		assert.Equal(t, &model.SongDetail{
+			ReleaseDate: "01.12.1975",
+			Text:        "First verse\n\nSecond verse",
+			Link:        "https://example.com/tnt",
+		}, res)
+	})
+
+	t.Run("MissingFields", func(t *testing.T) {
+		server.Config.Handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Write([]byte(`{"track": {"released": "not a date"}}`))
+		})
+
+		res, err := provider.FetchSongDetail(ctx, "AC/DC", "T.N.T. & more")
+		assert.Nil(t, err)
+		assert.Equal(t, &model.SongDetail{}, res)
+	})
+
+	t.Run("UnparseableReleaseDate", func(t *testing.T) {
+		server.Config.Handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Write([]byte(`{"track": {"released": "someday", "lyrics": "Verse", "links": [{"url": "https://example.com/tnt"}]}}`))
+		})
+
+		defaultConf := *conf
+		defaultConf.ReleaseDateLayout = model.SongDetailDateLayout
+		for _, p := range []*HTTPProvider{provider, NewHTTPProvider(&defaultConf, nil)} {
+			res, err := p.FetchSongDetail(ctx, "AC/DC", "T.N.T. & more")
+			assert.Nil(t, err)
+			assert.Equal(t, &model.SongDetail{
+				Text: "Verse",
+				Link: "https://example.com/tnt",
+			}, res)
+		}
+	})
+
+	t.Run("StatusNotFound", func(t *testing.T) {
+		server.Config.Handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusNotFound)
+		})
+
+		res, err := provider.FetchSongDetail(ctx, "AC/DC", "T.N.T. & more")
+		assert.Equal(t, errs.ErrBadExtReq, err)
+		assert.Nil(t, res)
+	})
+
+	t.Run("StatusServiceUnavailable", func(t *testing.T) {
+		server.Config.Handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusServiceUnavailable)
+		})
+
+		res, err := provider.FetchSongDetail(ctx, "AC/DC", "T.N.T. & more")
+		assert.Equal(t, errs.ExtSrvErr, err)
+		assert.Nil(t, res)
+	})
+
+	t.Run("ErrorDecoding", func(t *testing.T) {
+		server.Config.Handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Write([]byte("invalid json"))
+		})
+
+		res, err := provider.FetchSongDetail(ctx, "AC/DC", "T.N.T. & more")
+		assert.NotNil(t, err)
+		assert.Nil(t, res)
+	})
+}
@@ -0,0 +1,58 @@
+package external
+
+import (
+	"context"
+	"errors"
+	"github.com/JMURv/effectiveMobile/internal/ctrl"
+	"github.com/JMURv/effectiveMobile/internal/metrics"
+	"github.com/JMURv/effectiveMobile/pkg/model"
+	"net"
+	"time"
+)
+
+// Instrumented records the latency and outcome of every call to a provider
+type Instrumented struct {
+	name string
+	api  ctrl.APIRepo
+}
+
+func NewInstrumented(name string, api ctrl.APIRepo) *Instrumented {
+	return &Instrumented{name: name, api: api}
+}
+
+func (i *Instrumented) FetchSongDetail(ctx context.Context, group, song string) (*model.SongDetail, error) {
+	start := time.Now()
+	res, err := i.api.FetchSongDetail(ctx, group, song)
+
+	outcome := outcome(err)
+	metrics.ExternalRequests.WithLabelValues(i.name, outcome).Inc()
+	metrics.ExternalRequestDuration.WithLabelValues(i.name, outcome).Observe(time.Since(start).Seconds())
+	return res, err
+}
+
+// outcome names the result of a provider call after the ctrl error it maps to
+func outcome(err error) string {
+	var netErr net.Error
+	switch {
+	case err == nil:
+		return "ok"
+	case errors.Is(err, ctrl.ErrBadExtReq):
+		return "bad_request"
+	case errors.Is(err, ctrl.ErrExtRateLimited):
+		return "rate_limited"
+	case errors.Is(err, ctrl.ExtSrvErr):
+		return "server_error"
+	case errors.Is(err, ctrl.ErrExtCircuitOpen):
+		return "circuit_open"
+	case errors.Is(err, ctrl.ErrExtUnreachable):
+		return "unreachable"
+	case errors.Is(err, context.Canceled):
+		return "canceled"
+	case errors.Is(err, context.DeadlineExceeded), errors.As(err, &netErr) && netErr.Timeout():
+		return "timeout"
+	case errors.As(err, &netErr):
+		return "network_error"
+	default:
+		return "error"
+	}
+}
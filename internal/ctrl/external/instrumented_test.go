@@ -0,0 +1,64 @@
+package external
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	errs "github.com/JMURv/effectiveMobile/internal/ctrl"
+	"github.com/JMURv/effectiveMobile/internal/metrics"
+	"github.com/JMURv/effectiveMobile/mocks"
+	"github.com/JMURv/effectiveMobile/pkg/model"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+	"net"
+	"testing"
+)
+
+func TestInstrumented_FetchSongDetail(t *testing.T) {
+	ctrlMock := gomock.NewController(t)
+	defer ctrlMock.Finish()
+
+	api := mocks.NewMockAPIRepo(ctrlMock)
+	i := NewInstrumented("instrumented_test", api)
+
+	ctx := context.Background()
+	ok := metrics.ExternalRequests.WithLabelValues("instrumented_test", "ok")
+	bad := metrics.ExternalRequests.WithLabelValues("instrumented_test", "bad_request")
+
+	api.EXPECT().FetchSongDetail(gomock.Any(), "g", "s").Return(&model.SongDetail{}, nil)
+	_, err := i.FetchSongDetail(ctx, "g", "s")
+	assert.Nil(t, err)
+
+	api.EXPECT().FetchSongDetail(gomock.Any(), "g", "s").Return(nil, errs.ErrBadExtReq)
+	_, err = i.FetchSongDetail(ctx, "g", "s")
+	assert.ErrorIs(t, err, errs.ErrBadExtReq)
+
+	assert.Equal(t, float64(1), testutil.ToFloat64(ok))
+	assert.Equal(t, float64(1), testutil.ToFloat64(bad))
+}
+
+func TestOutcome(t *testing.T) {
+	tests := []struct {
+		err  error
+		want string
+	}{
+		{nil, "ok"},
+		{errs.ErrBadExtReq, "bad_request"},
+		{withRetryAfter(errs.ErrExtRateLimited, "1"), "rate_limited"},
+		{fmt.Errorf("wrapped: %w", errs.ExtSrvErr), "server_error"},
+		{errs.ErrExtCircuitOpen, "circuit_open"},
+		{errs.ErrExtUnreachable, "unreachable"},
+		{context.Canceled, "canceled"},
+		{context.DeadlineExceeded, "timeout"},
+		{&net.DNSError{IsTimeout: true}, "timeout"},
+		{&net.OpError{Op: "dial", Err: errors.New("refused")}, "network_error"},
+		{errors.New("other"), "error"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.want, func(t *testing.T) {
+			assert.Equal(t, tt.want, outcome(tt.err))
+		})
+	}
+}
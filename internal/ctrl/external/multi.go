@@ -0,0 +1,80 @@
+package external
+
+import (
+	"context"
+	"errors"
+	"github.com/JMURv/effectiveMobile/internal/ctrl"
+	"github.com/JMURv/effectiveMobile/pkg/model"
+	"github.com/JMURv/effectiveMobile/pkg/utils/logger"
+	"go.uber.org/zap"
+)
+
+// Provider is a named source of song metadata
+type Provider struct {
+	Name string
+	API  ctrl.APIRepo
+}
+
+// Multi asks the providers in priority order and merges their results field by field:
+// every field is taken from the first provider that has it
+type Multi struct {
+	providers []Provider
+}
+
+func NewMulti(providers ...Provider) *Multi {
+	return &Multi{providers: providers}
+}
+
+func (m *Multi) FetchSongDetail(ctx context.Context, group, song string) (*model.SongDetail, error) {
+	const op = "songs.FetchSongDetail.multi"
+
+	res := &model.SongDetail{Sources: make(map[string]string, 3)}
+	var lastErr error
+	for _, p := range m.providers {
+		if len(res.Sources) == 3 {
+			break
+		}
+
+		detail, err := p.API.FetchSongDetail(ctx, group, song)
+		if err != nil {
+			if ctxErr := ctx.Err(); ctxErr != nil {
+				return nil, ctxErr
+			}
+
+			logger.FromContext(ctx).Debug(
+				"provider failed to fetch song detail",
+				zap.Error(err), zap.String("op", op),
+				zap.String("provider", p.Name),
+				zap.String("group", group), zap.String("song", song),
+			)
+
+			// A provider not knowing the song is less interesting than one being down
+			if lastErr == nil || errors.Is(lastErr, ctrl.ErrBadExtReq) {
+				lastErr = err
+			}
+			continue
+		}
+
+		mergeField(res, model.FieldReleaseDate, &res.ReleaseDate, detail.ReleaseDate, p.Name)
+		mergeField(res, model.FieldLyrics, &res.Text, detail.Text, p.Name)
+		mergeField(res, model.FieldLink, &res.Link, detail.Link, p.Name)
+	}
+
+	if len(res.Sources) == 0 {
+		if lastErr == nil {
+			lastErr = ctrl.ErrBadExtReq
+		}
+		return nil, lastErr
+	}
+
+	return res, nil
+}
+
+func mergeField(res *model.SongDetail, field string, dst *string, val, provider string) {
+	if *dst != "" || val == "" {
+		return
+	}
+
+	*dst = val
+	res.Sources[field] = provider
+}
@@ -0,0 +1,121 @@
+package external
+
+import (
+	"context"
+	errs "github.com/JMURv/effectiveMobile/internal/ctrl"
+	"github.com/JMURv/effectiveMobile/mocks"
+	"github.com/JMURv/effectiveMobile/pkg/model"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+	"testing"
+)
+
+func TestMulti_FetchSongDetail(t *testing.T) {
+	ctrlMock := gomock.NewController(t)
+	defer ctrlMock.Finish()
+
+	ctx := context.Background()
+	group, song := "test-group", "test-song"
+
+	t.Run("FirstProviderComplete", func(t *testing.T) {
+		first := mocks.NewMockAPIRepo(ctrlMock)
+		second := mocks.NewMockAPIRepo(ctrlMock)
+		m := NewMulti(Provider{Name: "info", API: first}, Provider{Name: "fixtures", API: second})
+
+		first.EXPECT().FetchSongDetail(gomock.Any(), group, song).Return(&model.SongDetail{
+			ReleaseDate: "16.07.2006",
+			Text:        "text",
+			Link:        "https://example.com",
+		}, nil).Times(1)
+
+		res, err := m.FetchSongDetail(ctx, group, song)
+		assert.Nil(t, err)
+		assert.Equal(t, &model.SongDetail{
+			ReleaseDate: "16.07.2006",
+			Text:        "text",
+			Link:        "https://example.com",
+			Sources: map[string]string{
+				model.FieldReleaseDate: "info",
+				model.FieldLyrics:      "info",
+				model.FieldLink:        "info",
+			},
+		}, res)
+	})
+
+	t.Run("MergesFields", func(t *testing.T) {
+		first := mocks.NewMockAPIRepo(ctrlMock)
+		second := mocks.NewMockAPIRepo(ctrlMock)
+		m := NewMulti(Provider{Name: "info", API: first}, Provider{Name: "fixtures", API: second})
+
+		first.EXPECT().FetchSongDetail(gomock.Any(), group, song).
+			Return(&model.SongDetail{Text: "text"}, nil).Times(1)
+		second.EXPECT().FetchSongDetail(gomock.Any(), group, song).
+			Return(&model.SongDetail{ReleaseDate: "16.07.2006", Text: "other text", Link: "https://example.com"}, nil).Times(1)
+
+		res, err := m.FetchSongDetail(ctx, group, song)
+		assert.Nil(t, err)
+		assert.Equal(t, "text", res.Text)
+		assert.Equal(t, "16.07.2006", res.ReleaseDate)
+		assert.Equal(t, map[string]string{
+			model.FieldReleaseDate: "fixtures",
+			model.FieldLyrics:      "info",
+			model.FieldLink:        "fixtures",
+		}, res.Sources)
+	})
+
+	t.Run("FallbackOnError", func(t *testing.T) {
+		first := mocks.NewMockAPIRepo(ctrlMock)
+		second := mocks.NewMockAPIRepo(ctrlMock)
+		m := NewMulti(Provider{Name: "info", API: first}, Provider{Name: "http", API: second})
+
+		first.EXPECT().FetchSongDetail(gomock.Any(), group, song).Return(nil, errs.ExtSrvErr).Times(1)
+		second.EXPECT().FetchSongDetail(gomock.Any(), group, song).
+			Return(&model.SongDetail{Link: "https://example.com"}, nil).Times(1)
+
+		res, err := m.FetchSongDetail(ctx, group, song)
+		assert.Nil(t, err)
+		assert.Equal(t, map[string]string{model.FieldLink: "http"}, res.Sources)
+	})
+
+	t.Run("AllFailedPrefersServerError", func(t *testing.T) {
+		first := mocks.NewMockAPIRepo(ctrlMock)
+		second := mocks.NewMockAPIRepo(ctrlMock)
+		m := NewMulti(Provider{Name: "info", API: first}, Provider{Name: "fixtures", API: second})
+
+		first.EXPECT().FetchSongDetail(gomock.Any(), group, song).Return(nil, errs.ExtSrvErr).Times(1)
+		second.EXPECT().FetchSongDetail(gomock.Any(), group, song).Return(nil, errs.ErrBadExtReq).Times(1)
+
+		res, err := m.FetchSongDetail(ctx, group, song)
+		assert.Equal(t, errs.ExtSrvErr, err)
+		assert.Nil(t, res)
+	})
+
+	t.Run("NothingFound", func(t *testing.T) {
+		first := mocks.NewMockAPIRepo(ctrlMock)
+		m := NewMulti(Provider{Name: "info", API: first})
+
+		first.EXPECT().FetchSongDetail(gomock.Any(), group, song).Return(&model.SongDetail{}, nil).Times(1)
+
+		res, err := m.FetchSongDetail(ctx, group, song)
+		assert.Equal(t, errs.ErrBadExtReq, err)
+		assert.Nil(t, res)
+	})
+
+	t.Run("ContextCanceled", func(t *testing.T) {
+		first := mocks.NewMockAPIRepo(ctrlMock)
+		second := mocks.NewMockAPIRepo(ctrlMock)
+		m := NewMulti(Provider{Name: "info", API: first}, Provider{Name: "fixtures", API: second})
+
+		canceled, cancel := context.WithCancel(ctx)
+		first.EXPECT().FetchSongDetail(gomock.Any(), group, song).DoAndReturn(
+			func(context.Context, string, string) (*model.SongDetail, error) {
+				cancel()
+				return nil, context.Canceled
+			},
+		).Times(1)
+
+		res, err := m.FetchSongDetail(canceled, group, song)
+		assert.ErrorIs(t, err, context.Canceled)
+		assert.Nil(t, res)
+	})
+}
@@ -0,0 +1,261 @@
+package external
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/JMURv/effectiveMobile/internal/ctrl"
+	"github.com/JMURv/effectiveMobile/internal/metrics"
+	cfg "github.com/JMURv/effectiveMobile/pkg/config"
+	"github.com/JMURv/effectiveMobile/pkg/model"
+	"github.com/JMURv/effectiveMobile/pkg/utils/logger"
+	"go.uber.org/zap"
+	"math/rand/v2"
+	"net"
+	"sync"
+	"time"
+)
+
+const (
+	BreakerClosed   = "closed"
+	BreakerOpen     = "open"
+	BreakerHalfOpen = "half-open"
+)
+
+// Resilient wraps an APIRepo with retries, a circuit breaker
+// and a client-side token bucket rate limiter. A failure that is still
+// worth retrying later is reported as ErrExtRetriesExhausted or ErrExtCircuitOpen
+type Resilient struct {
+	name string
+	api  ctrl.APIRepo
+	conf *cfg.ResilienceConfig
+
+	mu          sync.Mutex
+	state       string
+	failures    int
+	openedAt    time.Time
+	trialActive bool
+	tokens      float64
+	refilledAt  time.Time
+}
+
+// NewResilient wraps the provider with the given name, used to label its metrics
+func NewResilient(name string, api ctrl.APIRepo, conf *cfg.ResilienceConfig) *Resilient {
+	metrics.ExternalBreakerState.WithLabelValues(name).Set(breakerStateValue(BreakerClosed))
+	return &Resilient{
+		name:       name,
+		api:        api,
+		conf:       conf,
+		state:      BreakerClosed,
+		tokens:     bucketSize(conf),
+		refilledAt: time.Now(),
+	}
+}
+
+func (r *Resilient) FetchSongDetail(ctx context.Context, group, song string) (*model.SongDetail, error) {
+	const op = "songs.FetchSongDetail.resilient"
+
+	for attempt := 0; ; attempt++ {
+		if err := r.allow(); err != nil {
+			return nil, err
+		}
+
+		if err := r.wait(ctx); err != nil {
+			r.release()
+			return nil, err
+		}
+
+		res, err := r.api.FetchSongDetail(ctx, group, song)
+		r.record(err)
+		if err == nil {
+			return res, nil
+		}
+
+		if !retryable(ctx, err) {
+			if ctx.Err() != nil {
+				return nil, ctx.Err()
+			}
+			return nil, err
+		}
+
+		if attempt >= r.conf.MaxRetries {
+			return nil, fmt.Errorf("%w: %w", ctrl.ErrExtRetriesExhausted, err)
+		}
+
+		delay := r.backoff(attempt)
+		var retryAfter *RetryAfterError
+		if errors.As(err, &retryAfter) {
+			if retryAfter.After > r.conf.MaxBackoff {
+				return nil, fmt.Errorf("%w: %w", ctrl.ErrExtRetriesExhausted, err)
+			}
+			delay = retryAfter.After
+		}
+
+		metrics.ExternalRetries.WithLabelValues(r.name).Inc()
+		logger.FromContext(ctx).Debug(
+			"retrying external request",
+			zap.Error(err), zap.String("op", op),
+			zap.Int("attempt", attempt+1), zap.Duration("delay", delay),
+		)
+
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(delay):
+		}
+	}
+}
+
+// allow asks the circuit breaker for permission to send a request.
+// While half-open only a single trial request is let through
+func (r *Resilient) allow() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	switch r.state {
+	case BreakerOpen:
+		if time.Since(r.openedAt) < r.conf.OpenTimeout {
+			return ctrl.ErrExtCircuitOpen
+		}
+		r.setState(BreakerHalfOpen)
+		r.trialActive = true
+		return nil
+	case BreakerHalfOpen:
+		if r.trialActive {
+			return ctrl.ErrExtCircuitOpen
+		}
+		r.trialActive = true
+		return nil
+	default:
+		return nil
+	}
+}
+
+// release gives back the half-open trial slot when no request was made
+func (r *Resilient) release() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.trialActive = false
+}
+
+func (r *Resilient) record(err error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.trialActive = false
+	if err == nil || !upstreamFailure(err) {
+		r.failures = 0
+		if r.state != BreakerClosed {
+			r.setState(BreakerClosed)
+		}
+		return
+	}
+
+	r.failures++
+	if r.state == BreakerHalfOpen || r.failures >= r.conf.FailureThreshold {
+		r.openedAt = time.Now()
+		metrics.ExternalBreakerOpens.WithLabelValues(r.name).Inc()
+		r.setState(BreakerOpen)
+	}
+}
+
+func (r *Resilient) setState(state string) {
+	if r.state == state {
+		return
+	}
+
+	zap.L().Warn(
+		"circuit breaker state changed",
+		zap.String("provider", r.name),
+		zap.String("from", r.state), zap.String("to", state),
+		zap.Int("failures", r.failures),
+	)
+	r.state = state
+	metrics.ExternalBreakerState.WithLabelValues(r.name).Set(breakerStateValue(state))
+}
+
+func breakerStateValue(state string) float64 {
+	switch state {
+	case BreakerHalfOpen:
+		return 1
+	case BreakerOpen:
+		return 2
+	default:
+		return 0
+	}
+}
+
+// wait blocks until the token bucket has a token for the request.
+// A non-positive rate disables the limiter
+func (r *Resilient) wait(ctx context.Context) error {
+	if r.conf.RateLimit <= 0 {
+		return nil
+	}
+
+	for {
+		r.mu.Lock()
+		now := time.Now()
+		r.tokens = min(
+			bucketSize(r.conf),
+			r.tokens+now.Sub(r.refilledAt).Seconds()*r.conf.RateLimit,
+		)
+		r.refilledAt = now
+
+		if r.tokens >= 1 {
+			r.tokens--
+			r.mu.Unlock()
+			return nil
+		}
+
+		delay := time.Duration((1 - r.tokens) / r.conf.RateLimit * float64(time.Second))
+		r.mu.Unlock()
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(delay):
+		}
+	}
+}
+
+// bucketSize is the number of tokens the bucket holds. It holds at least one,
+// otherwise no request would ever get through
+func bucketSize(conf *cfg.ResilienceConfig) float64 {
+	return max(float64(conf.Burst), 1)
+}
+
+// backoff returns an exponentially growing delay with full jitter
+func (r *Resilient) backoff(attempt int) time.Duration {
+	ceiling := r.conf.BaseBackoff
+	for i := 0; i < attempt && ceiling < r.conf.MaxBackoff; i++ {
+		ceiling *= 2
+	}
+	ceiling = min(ceiling, r.conf.MaxBackoff)
+
+	if ceiling <= 0 {
+		return 0
+	}
+	return time.Duration(rand.Int64N(int64(ceiling)) + 1)
+}
+
+// retryable tells whether another attempt makes sense. Only the caller's context
+// stops the retries, a deadline hit by a single attempt is a slow upstream
+func retryable(ctx context.Context, err error) bool {
+	if ctx.Err() != nil || errors.Is(err, context.Canceled) {
+		return false
+	}
+
+	var netErr net.Error
+	return errors.Is(err, ctrl.ExtSrvErr) ||
+		errors.Is(err, ctrl.ErrExtRateLimited) ||
+		errors.Is(err, context.DeadlineExceeded) ||
+		errors.As(err, &netErr)
+}
+
+// upstreamFailure tells whether the error says something about the upstream health.
+// Rejected requests are the caller's fault and do not trip the breaker
+func upstreamFailure(err error) bool {
+	return !errors.Is(err, ctrl.ErrBadExtReq) &&
+		!errors.Is(err, context.Canceled)
+}
@@ -0,0 +1,289 @@
+package external
+
+import (
+	"context"
+	"errors"
+	errs "github.com/JMURv/effectiveMobile/internal/ctrl"
+	"github.com/JMURv/effectiveMobile/mocks"
+	cfg "github.com/JMURv/effectiveMobile/pkg/config"
+	"github.com/JMURv/effectiveMobile/pkg/model"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func testResilienceConf() *cfg.ResilienceConfig {
+	return &cfg.ResilienceConfig{
+		MaxRetries:       2,
+		BaseBackoff:      time.Millisecond,
+		MaxBackoff:       10 * time.Millisecond,
+		FailureThreshold: 3,
+		OpenTimeout:      50 * time.Millisecond,
+		RateLimit:        1000,
+		Burst:            100,
+	}
+}
+
+func TestResilient_FetchSongDetail(t *testing.T) {
+	ctrlMock := gomock.NewController(t)
+	defer ctrlMock.Finish()
+
+	ctx := context.Background()
+	group, song := "test-group", "test-song"
+	detail := &model.SongDetail{ReleaseDate: "16.07.2006"}
+
+	t.Run("Success", func(t *testing.T) {
+		api := mocks.NewMockAPIRepo(ctrlMock)
+		r := NewResilient("test", api, testResilienceConf())
+
+		api.EXPECT().FetchSongDetail(gomock.Any(), group, song).Return(detail, nil).Times(1)
+
+		res, err := r.FetchSongDetail(ctx, group, song)
+		assert.Nil(t, err)
+		assert.Equal(t, detail, res)
+	})
+
+	t.Run("RetriesServerError", func(t *testing.T) {
+		api := mocks.NewMockAPIRepo(ctrlMock)
+		r := NewResilient("test", api, testResilienceConf())
+
+		gomock.InOrder(
+			api.EXPECT().FetchSongDetail(gomock.Any(), group, song).Return(nil, errs.ExtSrvErr),
+			api.EXPECT().FetchSongDetail(gomock.Any(), group, song).Return(nil, errs.ErrExtRateLimited),
+			api.EXPECT().FetchSongDetail(gomock.Any(), group, song).Return(detail, nil),
+		)
+
+		res, err := r.FetchSongDetail(ctx, group, song)
+		assert.Nil(t, err)
+		assert.Equal(t, detail, res)
+	})
+
+	t.Run("RetriesExhausted", func(t *testing.T) {
+		api := mocks.NewMockAPIRepo(ctrlMock)
+		r := NewResilient("test", api, testResilienceConf())
+
+		api.EXPECT().FetchSongDetail(gomock.Any(), group, song).Return(nil, errs.ExtSrvErr).Times(3)
+
+		res, err := r.FetchSongDetail(ctx, group, song)
+		assert.ErrorIs(t, err, errs.ExtSrvErr)
+		assert.ErrorIs(t, err, errs.ErrExtRetriesExhausted)
+		assert.Nil(t, res)
+	})
+
+	t.Run("BadRequestNotRetried", func(t *testing.T) {
+		api := mocks.NewMockAPIRepo(ctrlMock)
+		r := NewResilient("test", api, testResilienceConf())
+
+		api.EXPECT().FetchSongDetail(gomock.Any(), group, song).Return(nil, errs.ErrBadExtReq).Times(1)
+
+		res, err := r.FetchSongDetail(ctx, group, song)
+		assert.Equal(t, errs.ErrBadExtReq, err)
+		assert.NotErrorIs(t, err, errs.ErrExtRetriesExhausted)
+		assert.Nil(t, res)
+		assert.Equal(t, BreakerClosed, r.state)
+	})
+
+	t.Run("RespectsRetryAfter", func(t *testing.T) {
+		api := mocks.NewMockAPIRepo(ctrlMock)
+		r := NewResilient("test", api, testResilienceConf())
+
+		gomock.InOrder(
+			api.EXPECT().FetchSongDetail(gomock.Any(), group, song).
+				Return(nil, &RetryAfterError{Err: errs.ErrExtRateLimited, After: 5 * time.Millisecond}),
+			api.EXPECT().FetchSongDetail(gomock.Any(), group, song).Return(detail, nil),
+		)
+
+		start := time.Now()
+		res, err := r.FetchSongDetail(ctx, group, song)
+		assert.Nil(t, err)
+		assert.Equal(t, detail, res)
+		assert.GreaterOrEqual(t, time.Since(start), 5*time.Millisecond)
+	})
+
+	t.Run("RetryAfterTooLong", func(t *testing.T) {
+		api := mocks.NewMockAPIRepo(ctrlMock)
+		r := NewResilient("test", api, testResilienceConf())
+
+		api.EXPECT().FetchSongDetail(gomock.Any(), group, song).
+			Return(nil, &RetryAfterError{Err: errs.ErrExtRateLimited, After: time.Minute}).Times(1)
+
+		res, err := r.FetchSongDetail(ctx, group, song)
+		assert.ErrorIs(t, err, errs.ErrExtRateLimited)
+		assert.ErrorIs(t, err, errs.ErrExtRetriesExhausted)
+		assert.Nil(t, res)
+	})
+
+	t.Run("ContextCanceled", func(t *testing.T) {
+		api := mocks.NewMockAPIRepo(ctrlMock)
+		r := NewResilient("test", api, testResilienceConf())
+
+		canceled, cancel := context.WithCancel(ctx)
+		api.EXPECT().FetchSongDetail(gomock.Any(), group, song).DoAndReturn(
+			func(context.Context, string, string) (*model.SongDetail, error) {
+				cancel()
+				return nil, errs.ExtSrvErr
+			},
+		).Times(1)
+
+		res, err := r.FetchSongDetail(canceled, group, song)
+		assert.ErrorIs(t, err, context.Canceled)
+		assert.Nil(t, res)
+	})
+}
+
+func TestResilient_FetchSongDetail_SlowUpstream(t *testing.T) {
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if calls.Add(1) == 1 {
+			select {
+			case <-r.Context().Done():
+			case <-time.After(time.Second):
+			}
+			return
+		}
+		w.Write([]byte(`{"releaseDate": "16.07.2006"}`))
+	}))
+	defer server.Close()
+
+	provider := NewHTTPProvider(&cfg.HTTPProviderConfig{
+		URL:               server.URL,
+		Timeout:           20 * time.Millisecond,
+		ReleaseDateField:  "releaseDate",
+		ReleaseDateLayout: model.SongDetailDateLayout,
+	}, nil)
+
+	t.Run("RetriesClientTimeout", func(t *testing.T) {
+		r := NewResilient("test", provider, testResilienceConf())
+
+		res, err := r.FetchSongDetail(context.Background(), "test-group", "test-song")
+		assert.Nil(t, err)
+		assert.Equal(t, &model.SongDetail{ReleaseDate: "16.07.2006"}, res)
+		assert.Equal(t, int32(2), calls.Load())
+	})
+
+	t.Run("CallerDeadline", func(t *testing.T) {
+		calls.Store(0)
+		r := NewResilient("test", provider, testResilienceConf())
+
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Millisecond)
+		defer cancel()
+
+		res, err := r.FetchSongDetail(ctx, "test-group", "test-song")
+		assert.ErrorIs(t, err, context.DeadlineExceeded)
+		assert.Nil(t, res)
+		assert.Equal(t, int32(1), calls.Load())
+	})
+}
+
+func TestResilient_CircuitBreaker(t *testing.T) {
+	ctrlMock := gomock.NewController(t)
+	defer ctrlMock.Finish()
+
+	ctx := context.Background()
+	group, song := "test-group", "test-song"
+	conf := testResilienceConf()
+	conf.MaxRetries = 0
+
+	api := mocks.NewMockAPIRepo(ctrlMock)
+	r := NewResilient("test", api, conf)
+
+	t.Run("Opens", func(t *testing.T) {
+		api.EXPECT().FetchSongDetail(gomock.Any(), group, song).Return(nil, errs.ExtSrvErr).Times(3)
+		for i := 0; i < 3; i++ {
+			_, err := r.FetchSongDetail(ctx, group, song)
+			assert.ErrorIs(t, err, errs.ExtSrvErr)
+		}
+
+		_, err := r.FetchSongDetail(ctx, group, song)
+		assert.Equal(t, errs.ErrExtCircuitOpen, err)
+
+		assert.Equal(t, BreakerOpen, r.state)
+	})
+
+	t.Run("HalfOpenFailureReopens", func(t *testing.T) {
+		time.Sleep(conf.OpenTimeout)
+
+		api.EXPECT().FetchSongDetail(gomock.Any(), group, song).Return(nil, errs.ExtSrvErr).Times(1)
+		_, err := r.FetchSongDetail(ctx, group, song)
+		assert.ErrorIs(t, err, errs.ExtSrvErr)
+		assert.Equal(t, BreakerOpen, r.state)
+
+		_, err = r.FetchSongDetail(ctx, group, song)
+		assert.Equal(t, errs.ErrExtCircuitOpen, err)
+	})
+
+	t.Run("HalfOpenSuccessCloses", func(t *testing.T) {
+		time.Sleep(conf.OpenTimeout)
+
+		api.EXPECT().FetchSongDetail(gomock.Any(), group, song).Return(&model.SongDetail{}, nil).Times(1)
+		_, err := r.FetchSongDetail(ctx, group, song)
+		assert.Nil(t, err)
+		assert.Equal(t, BreakerClosed, r.state)
+	})
+}
+
+func TestResilient_RateLimit(t *testing.T) {
+	ctrlMock := gomock.NewController(t)
+	defer ctrlMock.Finish()
+
+	conf := testResilienceConf()
+	conf.RateLimit = 50
+	conf.Burst = 1
+
+	api := mocks.NewMockAPIRepo(ctrlMock)
+	r := NewResilient("test", api, conf)
+
+	t.Run("WaitsForToken", func(t *testing.T) {
+		api.EXPECT().FetchSongDetail(gomock.Any(), gomock.Any(), gomock.Any()).Return(&model.SongDetail{}, nil).Times(2)
+
+		start := time.Now()
+		for i := 0; i < 2; i++ {
+			_, err := r.FetchSongDetail(context.Background(), "test-group", "test-song")
+			assert.Nil(t, err)
+		}
+		assert.GreaterOrEqual(t, time.Since(start), 15*time.Millisecond)
+	})
+
+	t.Run("ContextDeadline", func(t *testing.T) {
+		ctx, cancel := context.WithTimeout(context.Background(), time.Millisecond)
+		defer cancel()
+
+		_, err := r.FetchSongDetail(ctx, "test-group", "test-song")
+		assert.True(t, errors.Is(err, context.DeadlineExceeded))
+	})
+
+	t.Run("ZeroRateUnlimited", func(t *testing.T) {
+		conf := testResilienceConf()
+		conf.RateLimit = 0
+		conf.Burst = 0
+
+		api := mocks.NewMockAPIRepo(ctrlMock)
+		r := NewResilient("test", api, conf)
+		api.EXPECT().FetchSongDetail(gomock.Any(), gomock.Any(), gomock.Any()).Return(&model.SongDetail{}, nil).Times(3)
+
+		for i := 0; i < 3; i++ {
+			_, err := r.FetchSongDetail(context.Background(), "test-group", "test-song")
+			assert.Nil(t, err)
+		}
+	})
+
+	t.Run("ZeroBurst", func(t *testing.T) {
+		conf := testResilienceConf()
+		conf.Burst = 0
+
+		api := mocks.NewMockAPIRepo(ctrlMock)
+		r := NewResilient("test", api, conf)
+		api.EXPECT().FetchSongDetail(gomock.Any(), gomock.Any(), gomock.Any()).Return(&model.SongDetail{}, nil).Times(2)
+
+		ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+		defer cancel()
+		for i := 0; i < 2; i++ {
+			_, err := r.FetchSongDetail(ctx, "test-group", "test-song")
+			assert.Nil(t, err)
+		}
+	})
+}
@@ -0,0 +1,194 @@
+package ctrl
+
+import (
+	"context"
+	"errors"
+	"github.com/JMURv/effectiveMobile/internal/repo"
+	"github.com/JMURv/effectiveMobile/internal/tracing"
+	"github.com/JMURv/effectiveMobile/pkg/model"
+	"github.com/JMURv/effectiveMobile/pkg/utils/logger"
+	"go.uber.org/zap"
+)
+
+func (c *Controller) ListGenres(ctx context.Context) ([]*model.Genre, error) {
+	const op = "songs.ListGenres.ctrl"
+
+	ctx, span := tracer.Start(ctx, op)
+	defer span.End()
+
+	res, err := c.repo.ListGenres(ctx)
+	if err != nil {
+		tracing.RecordError(span, err)
+		logger.FromContext(ctx).Debug(
+			"failed to list genres",
+			zap.Error(err), zap.String("op", op),
+		)
+		return nil, err
+	}
+
+	return res, nil
+}
+
+func (c *Controller) CreateGenre(ctx context.Context, req *model.Genre) (*model.Genre, error) {
+	const op = "songs.CreateGenre.ctrl"
+
+	ctx, span := tracer.Start(ctx, op)
+	defer span.End()
+
+	res, err := c.repo.CreateGenre(ctx, req)
+	if err != nil && errors.Is(err, repo.ErrAlreadyExists) {
+		logger.FromContext(ctx).Debug(
+			"genre already exists",
+			zap.Error(err), zap.String("op", op),
+			zap.String("name", req.Name),
+		)
+		return nil, ErrAlreadyExists
+	} else if err != nil {
+		tracing.RecordError(span, err)
+		logger.FromContext(ctx).Debug(
+			"failed to create genre",
+			zap.Error(err), zap.String("op", op),
+			zap.String("name", req.Name),
+		)
+		return nil, err
+	}
+
+	return res, nil
+}
+
+func (c *Controller) DeleteGenre(ctx context.Context, id uint64) error {
+	const op = "songs.DeleteGenre.ctrl"
+
+	ctx, span := tracer.Start(ctx, op)
+	defer span.End()
+
+	err := c.repo.DeleteGenre(ctx, id)
+	if err != nil && errors.Is(err, repo.ErrNotFound) {
+		logger.FromContext(ctx).Debug(
+			"failed to find genre",
+			zap.Error(err), zap.String("op", op),
+			zap.Uint64("ID", id),
+		)
+		return ErrNotFound
+	} else if err != nil {
+		tracing.RecordError(span, err)
+		logger.FromContext(ctx).Debug(
+			"failed to delete genre",
+			zap.Error(err), zap.String("op", op),
+			zap.Uint64("ID", id),
+		)
+		return err
+	}
+
+	return nil
+}
+
+func (c *Controller) ListTags(ctx context.Context, name string) ([]*model.Tag, error) {
+	const op = "songs.ListTags.ctrl"
+
+	ctx, span := tracer.Start(ctx, op)
+	defer span.End()
+
+	res, err := c.repo.ListTags(ctx, name)
+	if err != nil {
+		tracing.RecordError(span, err)
+		logger.FromContext(ctx).Debug(
+			"failed to list tags",
+			zap.Error(err), zap.String("op", op),
+			zap.String("name", name),
+		)
+		return nil, err
+	}
+
+	return res, nil
+}
+
+func (c *Controller) DeleteTag(ctx context.Context, id uint64) error {
+	const op = "songs.DeleteTag.ctrl"
+
+	ctx, span := tracer.Start(ctx, op)
+	defer span.End()
+
+	err := c.repo.DeleteTag(ctx, id)
+	if err != nil && errors.Is(err, repo.ErrNotFound) {
+		logger.FromContext(ctx).Debug(
+			"failed to find tag",
+			zap.Error(err), zap.String("op", op),
+			zap.Uint64("ID", id),
+		)
+		return ErrNotFound
+	} else if err != nil {
+		tracing.RecordError(span, err)
+		logger.FromContext(ctx).Debug(
+			"failed to delete tag",
+			zap.Error(err), zap.String("op", op),
+			zap.Uint64("ID", id),
+		)
+		return err
+	}
+
+	return nil
+}
+
+// SetSongGenres replaces the genres of the song, they have to be created beforehand
+func (c *Controller) SetSongGenres(ctx context.Context, id uint64, names []string) error {
+	const op = "songs.SetSongGenres.ctrl"
+
+	ctx, span := tracer.Start(ctx, op)
+	defer span.End()
+
+	err := c.repo.SetSongGenres(ctx, id, names)
+	if err != nil && errors.Is(err, repo.ErrNotFound) {
+		logger.FromContext(ctx).Debug(
+			"failed to find song",
+			zap.Error(err), zap.String("op", op),
+			zap.Uint64("ID", id),
+		)
+		return ErrNotFound
+	} else if err != nil && errors.Is(err, repo.ErrUnknownGenre) {
+		logger.FromContext(ctx).Debug(
+			"unknown genre",
+			zap.Error(err), zap.String("op", op),
+			zap.Uint64("ID", id), zap.Strings("genres", names),
+		)
+		return ErrUnknownGenre
+	} else if err != nil {
+		tracing.RecordError(span, err)
+		logger.FromContext(ctx).Debug(
+			"failed to set song genres",
+			zap.Error(err), zap.String("op", op),
+			zap.Uint64("ID", id), zap.Strings("genres", names),
+		)
+		return err
+	}
+
+	return nil
+}
+
+// SetSongTags replaces the tags of the song, new ones are created along the way
+func (c *Controller) SetSongTags(ctx context.Context, id uint64, names []string) error {
+	const op = "songs.SetSongTags.ctrl"
+
+	ctx, span := tracer.Start(ctx, op)
+	defer span.End()
+
+	err := c.repo.SetSongTags(ctx, id, names)
+	if err != nil && errors.Is(err, repo.ErrNotFound) {
+		logger.FromContext(ctx).Debug(
+			"failed to find song",
+			zap.Error(err), zap.String("op", op),
+			zap.Uint64("ID", id),
+		)
+		return ErrNotFound
+	} else if err != nil {
+		tracing.RecordError(span, err)
+		logger.FromContext(ctx).Debug(
+			"failed to set song tags",
+			zap.Error(err), zap.String("op", op),
+			zap.Uint64("ID", id), zap.Strings("tags", names),
+		)
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,120 @@
+package ctrl
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/JMURv/effectiveMobile/internal/repo"
+	"github.com/JMURv/effectiveMobile/mocks"
+	"github.com/JMURv/effectiveMobile/pkg/model"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+	"testing"
+)
+
+func TestController_CreateGenre(t *testing.T) {
+	ctrlMock := gomock.NewController(t)
+	defer ctrlMock.Finish()
+
+	svcRepo := mocks.NewMockRepo(ctrlMock)
+	extRepo := mocks.NewMockAPIRepo(ctrlMock)
+
+	ctrl := New(svcRepo, extRepo)
+	ctx := context.Background()
+	req := &model.Genre{Name: "Rock"}
+
+	t.Run("Success", func(t *testing.T) {
+		expected := &model.Genre{ID: 1, Name: "Rock"}
+		svcRepo.EXPECT().CreateGenre(gomock.Any(), req).Return(expected, nil).Times(1)
+
+		res, err := ctrl.CreateGenre(ctx, req)
+		assert.Nil(t, err)
+		assert.Equal(t, expected, res)
+	})
+
+	t.Run("ErrAlreadyExists", func(t *testing.T) {
+		svcRepo.EXPECT().CreateGenre(gomock.Any(), req).Return(nil, repo.ErrAlreadyExists).Times(1)
+
+		res, err := ctrl.CreateGenre(ctx, req)
+		assert.Equal(t, ErrAlreadyExists, err)
+		assert.Nil(t, res)
+	})
+}
+
+func TestController_DeleteTag(t *testing.T) {
+	ctrlMock := gomock.NewController(t)
+	defer ctrlMock.Finish()
+
+	svcRepo := mocks.NewMockRepo(ctrlMock)
+	extRepo := mocks.NewMockAPIRepo(ctrlMock)
+
+	ctrl := New(svcRepo, extRepo)
+	ctx := context.Background()
+
+	t.Run("Success", func(t *testing.T) {
+		svcRepo.EXPECT().DeleteTag(gomock.Any(), uint64(1)).Return(nil).Times(1)
+		assert.Nil(t, ctrl.DeleteTag(ctx, 1))
+	})
+
+	t.Run("ErrNotFound", func(t *testing.T) {
+		svcRepo.EXPECT().DeleteTag(gomock.Any(), uint64(2)).Return(repo.ErrNotFound).Times(1)
+		assert.Equal(t, ErrNotFound, ctrl.DeleteTag(ctx, 2))
+	})
+}
+
+func TestController_SetSongGenres(t *testing.T) {
+	ctrlMock := gomock.NewController(t)
+	defer ctrlMock.Finish()
+
+	svcRepo := mocks.NewMockRepo(ctrlMock)
+	extRepo := mocks.NewMockAPIRepo(ctrlMock)
+
+	ctrl := New(svcRepo, extRepo)
+	ctx := context.Background()
+	names := []string{"Rock", "Metal"}
+
+	t.Run("Success", func(t *testing.T) {
+		svcRepo.EXPECT().SetSongGenres(gomock.Any(), uint64(1), names).Return(nil).Times(1)
+		assert.Nil(t, ctrl.SetSongGenres(ctx, 1, names))
+	})
+
+	t.Run("ErrNotFound", func(t *testing.T) {
+		svcRepo.EXPECT().SetSongGenres(gomock.Any(), uint64(1), names).Return(repo.ErrNotFound).Times(1)
+		assert.Equal(t, ErrNotFound, ctrl.SetSongGenres(ctx, 1, names))
+	})
+
+	t.Run("ErrUnknownGenre", func(t *testing.T) {
+		svcRepo.EXPECT().SetSongGenres(gomock.Any(), uint64(1), names).
+			Return(fmt.Errorf("%w: Metal", repo.ErrUnknownGenre)).
+			Times(1)
+		assert.Equal(t, ErrUnknownGenre, ctrl.SetSongGenres(ctx, 1, names))
+	})
+
+	t.Run("ErrOther", func(t *testing.T) {
+		newErr := errors.New("new error")
+		svcRepo.EXPECT().SetSongGenres(gomock.Any(), uint64(1), names).Return(newErr).Times(1)
+		assert.Equal(t, newErr, ctrl.SetSongGenres(ctx, 1, names))
+	})
+}
+
+func TestController_SetSongTags(t *testing.T) {
+	ctrlMock := gomock.NewController(t)
+	defer ctrlMock.Finish()
+
+	svcRepo := mocks.NewMockRepo(ctrlMock)
+	extRepo := mocks.NewMockAPIRepo(ctrlMock)
+
+	ctrl := New(svcRepo, extRepo)
+	ctx := context.Background()
+	names := []string{"90s", "workout"}
+
+	t.Run("Success", func(t *testing.T) {
+		svcRepo.EXPECT().SetSongTags(gomock.Any(), uint64(1), names).Return(nil).Times(1)
+		assert.Nil(t, ctrl.SetSongTags(ctx, 1, names))
+	})
+
+	t.Run("ErrNotFound", func(t *testing.T) {
+		svcRepo.EXPECT().SetSongTags(gomock.Any(), uint64(1), names).Return(repo.ErrNotFound).Times(1)
+		assert.Equal(t, ErrNotFound, ctrl.SetSongTags(ctx, 1, names))
+	})
+}
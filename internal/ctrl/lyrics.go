@@ -0,0 +1,68 @@
+package ctrl
+
+import (
+	"context"
+	"errors"
+	"github.com/JMURv/effectiveMobile/internal/metrics"
+	"github.com/JMURv/effectiveMobile/internal/repo"
+	"github.com/JMURv/effectiveMobile/internal/tracing"
+	"github.com/JMURv/effectiveMobile/pkg/model"
+	"github.com/JMURv/effectiveMobile/pkg/utils/logger"
+	"go.uber.org/zap"
+)
+
+func (c *Controller) GetSongLyrics(ctx context.Context, id uint64) (*model.SongLyrics, error) {
+	const op = "songs.GetSongLyrics.ctrl"
+
+	ctx, span := tracer.Start(ctx, op)
+	defer span.End()
+
+	res, err := c.repo.GetSongLyrics(ctx, id)
+	if err != nil && errors.Is(err, repo.ErrNotFound) {
+		logger.FromContext(ctx).Debug(
+			"failed to find song",
+			zap.Error(err), zap.String("op", op),
+			zap.Uint64("ID", id),
+		)
+		return nil, ErrNotFound
+	} else if err != nil {
+		tracing.RecordError(span, err)
+		logger.FromContext(ctx).Debug(
+			"failed to get song lyrics",
+			zap.Error(err), zap.String("op", op),
+			zap.Uint64("ID", id),
+		)
+		return nil, err
+	}
+
+	return res, nil
+}
+
+// SetSyncedLyrics replaces the time-synced lines of the song, the verses stay as they are
+func (c *Controller) SetSyncedLyrics(ctx context.Context, id uint64, lines []*model.SyncedLine) error {
+	const op = "songs.SetSyncedLyrics.ctrl"
+
+	ctx, span := tracer.Start(ctx, op)
+	defer span.End()
+
+	err := c.repo.SetSyncedLyrics(ctx, id, lines)
+	if err != nil && errors.Is(err, repo.ErrNotFound) {
+		logger.FromContext(ctx).Debug(
+			"failed to find song",
+			zap.Error(err), zap.String("op", op),
+			zap.Uint64("ID", id),
+		)
+		return ErrNotFound
+	} else if err != nil {
+		tracing.RecordError(span, err)
+		logger.FromContext(ctx).Debug(
+			"failed to set synced lyrics",
+			zap.Error(err), zap.String("op", op),
+			zap.Uint64("ID", id), zap.Int("lines", len(lines)),
+		)
+		return err
+	}
+
+	metrics.SongsChanged.WithLabelValues("updated").Inc()
+	return nil
+}
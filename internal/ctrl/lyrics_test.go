@@ -0,0 +1,68 @@
+package ctrl
+
+import (
+	"context"
+	"errors"
+	"github.com/JMURv/effectiveMobile/internal/repo"
+	"github.com/JMURv/effectiveMobile/mocks"
+	"github.com/JMURv/effectiveMobile/pkg/model"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+	"testing"
+)
+
+func TestController_GetSongLyrics(t *testing.T) {
+	ctrlMock := gomock.NewController(t)
+	defer ctrlMock.Finish()
+
+	svcRepo := mocks.NewMockRepo(ctrlMock)
+	extRepo := mocks.NewMockAPIRepo(ctrlMock)
+
+	ctrl := New(svcRepo, extRepo)
+	ctx := context.Background()
+
+	t.Run("Success", func(t *testing.T) {
+		expected := &model.SongLyrics{Group: "Group", Song: "Song", Verses: []string{"verse"}}
+		svcRepo.EXPECT().GetSongLyrics(gomock.Any(), uint64(1)).Return(expected, nil).Times(1)
+
+		res, err := ctrl.GetSongLyrics(ctx, 1)
+		assert.Nil(t, err)
+		assert.Equal(t, expected, res)
+	})
+
+	t.Run("ErrNotFound", func(t *testing.T) {
+		svcRepo.EXPECT().GetSongLyrics(gomock.Any(), uint64(2)).Return(nil, repo.ErrNotFound).Times(1)
+
+		res, err := ctrl.GetSongLyrics(ctx, 2)
+		assert.Equal(t, ErrNotFound, err)
+		assert.Nil(t, res)
+	})
+}
+
+func TestController_SetSyncedLyrics(t *testing.T) {
+	ctrlMock := gomock.NewController(t)
+	defer ctrlMock.Finish()
+
+	svcRepo := mocks.NewMockRepo(ctrlMock)
+	extRepo := mocks.NewMockAPIRepo(ctrlMock)
+
+	ctrl := New(svcRepo, extRepo)
+	ctx := context.Background()
+	lines := []*model.SyncedLine{{TimeMs: 1000, Text: "line"}}
+
+	t.Run("Success", func(t *testing.T) {
+		svcRepo.EXPECT().SetSyncedLyrics(gomock.Any(), uint64(1), lines).Return(nil).Times(1)
+		assert.Nil(t, ctrl.SetSyncedLyrics(ctx, 1, lines))
+	})
+
+	t.Run("ErrNotFound", func(t *testing.T) {
+		svcRepo.EXPECT().SetSyncedLyrics(gomock.Any(), uint64(2), lines).Return(repo.ErrNotFound).Times(1)
+		assert.Equal(t, ErrNotFound, ctrl.SetSyncedLyrics(ctx, 2, lines))
+	})
+
+	t.Run("Error", func(t *testing.T) {
+		expectedErr := errors.New("db error")
+		svcRepo.EXPECT().SetSyncedLyrics(gomock.Any(), uint64(1), lines).Return(expectedErr).Times(1)
+		assert.Equal(t, expectedErr, ctrl.SetSyncedLyrics(ctx, 1, lines))
+	})
+}
@@ -0,0 +1,297 @@
+package ctrl
+
+import (
+	"context"
+	"errors"
+	"github.com/JMURv/effectiveMobile/internal/repo"
+	"github.com/JMURv/effectiveMobile/internal/tracing"
+	"github.com/JMURv/effectiveMobile/pkg/model"
+	"github.com/JMURv/effectiveMobile/pkg/utils/logger"
+	"go.uber.org/zap"
+)
+
+func (c *Controller) ListPlaylists(ctx context.Context, page, size int, name string) (*model.PaginatedPlaylists, error) {
+	const op = "songs.ListPlaylists.ctrl"
+
+	ctx, span := tracer.Start(ctx, op)
+	defer span.End()
+
+	res, err := c.repo.ListPlaylists(ctx, page, size, name)
+	if err != nil {
+		tracing.RecordError(span, err)
+		logger.FromContext(ctx).Debug(
+			"failed to list playlists",
+			zap.Error(err), zap.String("op", op),
+			zap.Int("page", page), zap.Int("size", size),
+		)
+		return nil, err
+	}
+
+	return res, nil
+}
+
+func (c *Controller) GetPlaylist(ctx context.Context, id uint64) (*model.Playlist, error) {
+	const op = "songs.GetPlaylist.ctrl"
+
+	ctx, span := tracer.Start(ctx, op)
+	defer span.End()
+
+	res, err := c.repo.GetPlaylist(ctx, id)
+	if err != nil && errors.Is(err, repo.ErrNotFound) {
+		logger.FromContext(ctx).Debug(
+			"failed to find playlist",
+			zap.Error(err), zap.String("op", op),
+			zap.Uint64("ID", id),
+		)
+		return nil, ErrNotFound
+	} else if err != nil {
+		tracing.RecordError(span, err)
+		logger.FromContext(ctx).Debug(
+			"failed to get playlist",
+			zap.Error(err), zap.String("op", op),
+			zap.Uint64("ID", id),
+		)
+		return nil, err
+	}
+
+	return res, nil
+}
+
+// ListPlaylistSongs lists the playlist entries in their order
+func (c *Controller) ListPlaylistSongs(ctx context.Context, id uint64, page, size int) (*model.PaginatedSongs, error) {
+	const op = "songs.ListPlaylistSongs.ctrl"
+
+	ctx, span := tracer.Start(ctx, op)
+	defer span.End()
+
+	if _, err := c.GetPlaylist(ctx, id); err != nil {
+		return nil, err
+	}
+
+	res, err := c.repo.ListPlaylistSongs(ctx, id, page, size)
+	if err != nil {
+		tracing.RecordError(span, err)
+		logger.FromContext(ctx).Debug(
+			"failed to list playlist songs",
+			zap.Error(err), zap.String("op", op),
+			zap.Uint64("ID", id), zap.Int("page", page), zap.Int("size", size),
+		)
+		return nil, err
+	}
+
+	return res, nil
+}
+
+func (c *Controller) CreatePlaylist(ctx context.Context, req *model.Playlist) (*model.Playlist, error) {
+	const op = "songs.CreatePlaylist.ctrl"
+
+	ctx, span := tracer.Start(ctx, op)
+	defer span.End()
+
+	res, err := c.repo.CreatePlaylist(ctx, req)
+	if err != nil {
+		tracing.RecordError(span, err)
+		logger.FromContext(ctx).Debug(
+			"failed to create playlist",
+			zap.Error(err), zap.String("op", op),
+			zap.String("name", req.Name),
+		)
+		return nil, err
+	}
+
+	return res, nil
+}
+
+func (c *Controller) UpdatePlaylist(ctx context.Context, req *model.Playlist) error {
+	const op = "songs.UpdatePlaylist.ctrl"
+
+	ctx, span := tracer.Start(ctx, op)
+	defer span.End()
+
+	err := c.repo.UpdatePlaylist(ctx, req)
+	if err != nil && errors.Is(err, repo.ErrNotFound) {
+		logger.FromContext(ctx).Debug(
+			"failed to find playlist",
+			zap.Error(err), zap.String("op", op),
+			zap.Uint64("ID", req.ID),
+		)
+		return ErrNotFound
+	} else if err != nil {
+		tracing.RecordError(span, err)
+		logger.FromContext(ctx).Debug(
+			"failed to update playlist",
+			zap.Error(err), zap.String("op", op),
+			zap.Uint64("ID", req.ID), zap.String("name", req.Name),
+		)
+		return err
+	}
+
+	return nil
+}
+
+func (c *Controller) DeletePlaylist(ctx context.Context, id uint64) error {
+	const op = "songs.DeletePlaylist.ctrl"
+
+	ctx, span := tracer.Start(ctx, op)
+	defer span.End()
+
+	err := c.repo.DeletePlaylist(ctx, id)
+	if err != nil && errors.Is(err, repo.ErrNotFound) {
+		logger.FromContext(ctx).Debug(
+			"failed to find playlist",
+			zap.Error(err), zap.String("op", op),
+			zap.Uint64("ID", id),
+		)
+		return ErrNotFound
+	} else if err != nil {
+		tracing.RecordError(span, err)
+		logger.FromContext(ctx).Debug(
+			"failed to delete playlist",
+			zap.Error(err), zap.String("op", op),
+			zap.Uint64("ID", id),
+		)
+		return err
+	}
+
+	return nil
+}
+
+// DuplicatePlaylist copies the playlist with its entries under the new name
+func (c *Controller) DuplicatePlaylist(ctx context.Context, id uint64, name string) (*model.Playlist, error) {
+	const op = "songs.DuplicatePlaylist.ctrl"
+
+	ctx, span := tracer.Start(ctx, op)
+	defer span.End()
+
+	res, err := c.repo.DuplicatePlaylist(ctx, id, name)
+	if err != nil && errors.Is(err, repo.ErrNotFound) {
+		logger.FromContext(ctx).Debug(
+			"failed to find playlist",
+			zap.Error(err), zap.String("op", op),
+			zap.Uint64("ID", id),
+		)
+		return nil, ErrNotFound
+	} else if err != nil {
+		tracing.RecordError(span, err)
+		logger.FromContext(ctx).Debug(
+			"failed to duplicate playlist",
+			zap.Error(err), zap.String("op", op),
+			zap.Uint64("ID", id), zap.String("name", name),
+		)
+		return nil, err
+	}
+
+	return res, nil
+}
+
+// AddPlaylistSong inserts the song at the position, 0 appends it.
+// Returns the position the song ended up at
+func (c *Controller) AddPlaylistSong(ctx context.Context, id, songID uint64, position int) (int, error) {
+	const op = "songs.AddPlaylistSong.ctrl"
+
+	ctx, span := tracer.Start(ctx, op)
+	defer span.End()
+
+	res, err := c.repo.AddPlaylistSong(ctx, id, songID, position)
+	if err != nil && errors.Is(err, repo.ErrNotFound) {
+		logger.FromContext(ctx).Debug(
+			"failed to find playlist",
+			zap.Error(err), zap.String("op", op),
+			zap.Uint64("ID", id),
+		)
+		return 0, ErrNotFound
+	} else if err != nil && errors.Is(err, repo.ErrSongNotFound) {
+		logger.FromContext(ctx).Debug(
+			"failed to find song",
+			zap.Error(err), zap.String("op", op),
+			zap.Uint64("ID", id), zap.Uint64("song", songID),
+		)
+		return 0, ErrSongNotFound
+	} else if err != nil && errors.Is(err, repo.ErrAlreadyExists) {
+		logger.FromContext(ctx).Debug(
+			"song already in playlist",
+			zap.Error(err), zap.String("op", op),
+			zap.Uint64("ID", id), zap.Uint64("song", songID),
+		)
+		return 0, ErrAlreadyInPlaylist
+	} else if err != nil && errors.Is(err, repo.ErrPlaylistChanged) {
+		logger.FromContext(ctx).Debug(
+			"playlist changed concurrently",
+			zap.Error(err), zap.String("op", op),
+			zap.Uint64("ID", id), zap.Uint64("song", songID),
+		)
+		return 0, ErrPlaylistChanged
+	} else if err != nil {
+		tracing.RecordError(span, err)
+		logger.FromContext(ctx).Debug(
+			"failed to add playlist song",
+			zap.Error(err), zap.String("op", op),
+			zap.Uint64("ID", id), zap.Uint64("song", songID), zap.Int("position", position),
+		)
+		return 0, err
+	}
+
+	return res, nil
+}
+
+// MovePlaylistSong moves the song to the position. Returns the position it ended up at
+func (c *Controller) MovePlaylistSong(ctx context.Context, id, songID uint64, position int) (int, error) {
+	const op = "songs.MovePlaylistSong.ctrl"
+
+	ctx, span := tracer.Start(ctx, op)
+	defer span.End()
+
+	res, err := c.repo.MovePlaylistSong(ctx, id, songID, position)
+	if err != nil && errors.Is(err, repo.ErrNotFound) {
+		logger.FromContext(ctx).Debug(
+			"failed to find playlist song",
+			zap.Error(err), zap.String("op", op),
+			zap.Uint64("ID", id), zap.Uint64("song", songID),
+		)
+		return 0, ErrNotFound
+	} else if err != nil && errors.Is(err, repo.ErrPlaylistChanged) {
+		logger.FromContext(ctx).Debug(
+			"playlist changed concurrently",
+			zap.Error(err), zap.String("op", op),
+			zap.Uint64("ID", id), zap.Uint64("song", songID),
+		)
+		return 0, ErrPlaylistChanged
+	} else if err != nil {
+		tracing.RecordError(span, err)
+		logger.FromContext(ctx).Debug(
+			"failed to move playlist song",
+			zap.Error(err), zap.String("op", op),
+			zap.Uint64("ID", id), zap.Uint64("song", songID), zap.Int("position", position),
+		)
+		return 0, err
+	}
+
+	return res, nil
+}
+
+func (c *Controller) RemovePlaylistSong(ctx context.Context, id, songID uint64) error {
+	const op = "songs.RemovePlaylistSong.ctrl"
+
+	ctx, span := tracer.Start(ctx, op)
+	defer span.End()
+
+	err := c.repo.RemovePlaylistSong(ctx, id, songID)
+	if err != nil && errors.Is(err, repo.ErrNotFound) {
+		logger.FromContext(ctx).Debug(
+			"failed to find playlist song",
+			zap.Error(err), zap.String("op", op),
+			zap.Uint64("ID", id), zap.Uint64("song", songID),
+		)
+		return ErrNotFound
+	} else if err != nil {
+		tracing.RecordError(span, err)
+		logger.FromContext(ctx).Debug(
+			"failed to remove playlist song",
+			zap.Error(err), zap.String("op", op),
+			zap.Uint64("ID", id), zap.Uint64("song", songID),
+		)
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,183 @@
+package ctrl
+
+import (
+	"context"
+	"errors"
+	"github.com/JMURv/effectiveMobile/internal/repo"
+	"github.com/JMURv/effectiveMobile/mocks"
+	"github.com/JMURv/effectiveMobile/pkg/model"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+	"testing"
+)
+
+func TestController_ListPlaylistSongs(t *testing.T) {
+	ctrlMock := gomock.NewController(t)
+	defer ctrlMock.Finish()
+
+	svcRepo := mocks.NewMockRepo(ctrlMock)
+	extRepo := mocks.NewMockAPIRepo(ctrlMock)
+
+	ctrl := New(svcRepo, extRepo)
+	ctx := context.Background()
+
+	t.Run("Success", func(t *testing.T) {
+		expected := &model.PaginatedSongs{
+			Data:        []*model.PlaylistEntry{{Position: 1, Song: &model.Song{ID: 2}}},
+			Count:       1,
+			TotalPages:  1,
+			CurrentPage: 1,
+		}
+		svcRepo.EXPECT().GetPlaylist(gomock.Any(), uint64(1)).Return(&model.Playlist{ID: 1}, nil).Times(1)
+		svcRepo.EXPECT().ListPlaylistSongs(gomock.Any(), uint64(1), 1, 10).Return(expected, nil).Times(1)
+
+		res, err := ctrl.ListPlaylistSongs(ctx, 1, 1, 10)
+		assert.Nil(t, err)
+		assert.Equal(t, expected, res)
+	})
+
+	t.Run("ErrNotFound", func(t *testing.T) {
+		svcRepo.EXPECT().GetPlaylist(gomock.Any(), uint64(2)).Return(nil, repo.ErrNotFound).Times(1)
+
+		res, err := ctrl.ListPlaylistSongs(ctx, 2, 1, 10)
+		assert.Equal(t, ErrNotFound, err)
+		assert.Nil(t, res)
+	})
+}
+
+func TestController_DuplicatePlaylist(t *testing.T) {
+	ctrlMock := gomock.NewController(t)
+	defer ctrlMock.Finish()
+
+	svcRepo := mocks.NewMockRepo(ctrlMock)
+	extRepo := mocks.NewMockAPIRepo(ctrlMock)
+
+	ctrl := New(svcRepo, extRepo)
+	ctx := context.Background()
+
+	t.Run("Success", func(t *testing.T) {
+		expected := &model.Playlist{ID: 2, Name: "Road trip (copy)", SongsCount: 3}
+		svcRepo.EXPECT().DuplicatePlaylist(gomock.Any(), uint64(1), "").Return(expected, nil).Times(1)
+
+		res, err := ctrl.DuplicatePlaylist(ctx, 1, "")
+		assert.Nil(t, err)
+		assert.Equal(t, expected, res)
+	})
+
+	t.Run("ErrNotFound", func(t *testing.T) {
+		svcRepo.EXPECT().DuplicatePlaylist(gomock.Any(), uint64(3), "Copy").Return(nil, repo.ErrNotFound).Times(1)
+
+		res, err := ctrl.DuplicatePlaylist(ctx, 3, "Copy")
+		assert.Equal(t, ErrNotFound, err)
+		assert.Nil(t, res)
+	})
+}
+
+func TestController_AddPlaylistSong(t *testing.T) {
+	ctrlMock := gomock.NewController(t)
+	defer ctrlMock.Finish()
+
+	svcRepo := mocks.NewMockRepo(ctrlMock)
+	extRepo := mocks.NewMockAPIRepo(ctrlMock)
+
+	ctrl := New(svcRepo, extRepo)
+	ctx := context.Background()
+
+	t.Run("Success", func(t *testing.T) {
+		svcRepo.EXPECT().AddPlaylistSong(gomock.Any(), uint64(1), uint64(2), 0).Return(4, nil).Times(1)
+
+		res, err := ctrl.AddPlaylistSong(ctx, 1, 2, 0)
+		assert.Nil(t, err)
+		assert.Equal(t, 4, res)
+	})
+
+	t.Run("ErrNotFound", func(t *testing.T) {
+		svcRepo.EXPECT().AddPlaylistSong(gomock.Any(), uint64(1), uint64(2), 1).Return(0, repo.ErrNotFound).Times(1)
+
+		_, err := ctrl.AddPlaylistSong(ctx, 1, 2, 1)
+		assert.Equal(t, ErrNotFound, err)
+	})
+
+	t.Run("ErrSongNotFound", func(t *testing.T) {
+		svcRepo.EXPECT().AddPlaylistSong(gomock.Any(), uint64(1), uint64(2), 1).Return(0, repo.ErrSongNotFound).Times(1)
+
+		_, err := ctrl.AddPlaylistSong(ctx, 1, 2, 1)
+		assert.Equal(t, ErrSongNotFound, err)
+	})
+
+	t.Run("ErrAlreadyInPlaylist", func(t *testing.T) {
+		svcRepo.EXPECT().AddPlaylistSong(gomock.Any(), uint64(1), uint64(2), 1).Return(0, repo.ErrAlreadyExists).Times(1)
+
+		_, err := ctrl.AddPlaylistSong(ctx, 1, 2, 1)
+		assert.Equal(t, ErrAlreadyInPlaylist, err)
+	})
+
+	t.Run("ErrPlaylistChanged", func(t *testing.T) {
+		svcRepo.EXPECT().AddPlaylistSong(gomock.Any(), uint64(1), uint64(2), 0).Return(0, repo.ErrPlaylistChanged).Times(1)
+
+		_, err := ctrl.AddPlaylistSong(ctx, 1, 2, 0)
+		assert.Equal(t, ErrPlaylistChanged, err)
+	})
+
+	t.Run("Error", func(t *testing.T) {
+		expectedErr := errors.New("db error")
+		svcRepo.EXPECT().AddPlaylistSong(gomock.Any(), uint64(1), uint64(2), 1).Return(0, expectedErr).Times(1)
+
+		_, err := ctrl.AddPlaylistSong(ctx, 1, 2, 1)
+		assert.Equal(t, expectedErr, err)
+	})
+}
+
+func TestController_MovePlaylistSong(t *testing.T) {
+	ctrlMock := gomock.NewController(t)
+	defer ctrlMock.Finish()
+
+	svcRepo := mocks.NewMockRepo(ctrlMock)
+	extRepo := mocks.NewMockAPIRepo(ctrlMock)
+
+	ctrl := New(svcRepo, extRepo)
+	ctx := context.Background()
+
+	t.Run("Success", func(t *testing.T) {
+		svcRepo.EXPECT().MovePlaylistSong(gomock.Any(), uint64(1), uint64(2), 10).Return(3, nil).Times(1)
+
+		res, err := ctrl.MovePlaylistSong(ctx, 1, 2, 10)
+		assert.Nil(t, err)
+		assert.Equal(t, 3, res)
+	})
+
+	t.Run("ErrNotFound", func(t *testing.T) {
+		svcRepo.EXPECT().MovePlaylistSong(gomock.Any(), uint64(1), uint64(5), 1).Return(0, repo.ErrNotFound).Times(1)
+
+		_, err := ctrl.MovePlaylistSong(ctx, 1, 5, 1)
+		assert.Equal(t, ErrNotFound, err)
+	})
+
+	t.Run("ErrPlaylistChanged", func(t *testing.T) {
+		svcRepo.EXPECT().MovePlaylistSong(gomock.Any(), uint64(1), uint64(2), 1).Return(0, repo.ErrPlaylistChanged).Times(1)
+
+		_, err := ctrl.MovePlaylistSong(ctx, 1, 2, 1)
+		assert.Equal(t, ErrPlaylistChanged, err)
+	})
+}
+
+func TestController_RemovePlaylistSong(t *testing.T) {
+	ctrlMock := gomock.NewController(t)
+	defer ctrlMock.Finish()
+
+	svcRepo := mocks.NewMockRepo(ctrlMock)
+	extRepo := mocks.NewMockAPIRepo(ctrlMock)
+
+	ctrl := New(svcRepo, extRepo)
+	ctx := context.Background()
+
+	t.Run("Success", func(t *testing.T) {
+		svcRepo.EXPECT().RemovePlaylistSong(gomock.Any(), uint64(1), uint64(2)).Return(nil).Times(1)
+		assert.Nil(t, ctrl.RemovePlaylistSong(ctx, 1, 2))
+	})
+
+	t.Run("ErrNotFound", func(t *testing.T) {
+		svcRepo.EXPECT().RemovePlaylistSong(gomock.Any(), uint64(1), uint64(5)).Return(repo.ErrNotFound).Times(1)
+		assert.Equal(t, ErrNotFound, ctrl.RemovePlaylistSong(ctx, 1, 5))
+	})
+}
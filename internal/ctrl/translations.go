@@ -0,0 +1,146 @@
+package ctrl
+
+import (
+	"context"
+	"errors"
+	"github.com/JMURv/effectiveMobile/internal/metrics"
+	"github.com/JMURv/effectiveMobile/internal/repo"
+	"github.com/JMURv/effectiveMobile/internal/tracing"
+	"github.com/JMURv/effectiveMobile/pkg/model"
+	"github.com/JMURv/effectiveMobile/pkg/utils/logger"
+	"go.uber.org/zap"
+	"golang.org/x/text/language"
+)
+
+// matchTranslation picks the translation that fits the preferred languages best,
+// returns an empty string when none of them is close enough
+func matchTranslation(translations []string, langs []language.Tag) string {
+	if len(translations) == 0 || len(langs) == 0 {
+		return ""
+	}
+
+	// The first supported tag is what the matcher falls back to, so it stands for the original lyrics
+	supported := []language.Tag{language.Und}
+	available := []string{""}
+	for _, lang := range translations {
+		tag, err := language.Parse(lang)
+		if err != nil {
+			continue
+		}
+		supported = append(supported, tag)
+		available = append(available, lang)
+	}
+
+	_, idx, confidence := language.NewMatcher(supported).Match(langs...)
+	if confidence == language.No {
+		return ""
+	}
+	return available[idx]
+}
+
+// CreateTranslation adds the translation of the lyrics to a new language.
+// It must have as many verses as the original lyrics
+func (c *Controller) CreateTranslation(ctx context.Context, id uint64, req *model.Translation) error {
+	const op = "songs.CreateTranslation.ctrl"
+
+	ctx, span := tracer.Start(ctx, op)
+	defer span.End()
+
+	err := c.repo.CreateTranslation(ctx, id, req)
+	if err != nil && errors.Is(err, repo.ErrNotFound) {
+		logger.FromContext(ctx).Debug(
+			"failed to find song",
+			zap.Error(err), zap.String("op", op),
+			zap.Uint64("ID", id),
+		)
+		return ErrNotFound
+	} else if err != nil && errors.Is(err, repo.ErrAlreadyExists) {
+		logger.FromContext(ctx).Debug(
+			"translation already exists",
+			zap.Error(err), zap.String("op", op),
+			zap.Uint64("ID", id), zap.String("lang", req.Lang),
+		)
+		return ErrAlreadyExists
+	} else if err != nil && errors.Is(err, repo.ErrVerseCountMismatch) {
+		logger.FromContext(ctx).Debug(
+			"translation is not aligned with the lyrics",
+			zap.Error(err), zap.String("op", op),
+			zap.Uint64("ID", id), zap.String("lang", req.Lang),
+		)
+		return ErrVerseCountMismatch
+	} else if err != nil {
+		tracing.RecordError(span, err)
+		logger.FromContext(ctx).Debug(
+			"failed to create translation",
+			zap.Error(err), zap.String("op", op),
+			zap.Uint64("ID", id), zap.String("lang", req.Lang),
+		)
+		return err
+	}
+
+	metrics.SongsChanged.WithLabelValues("updated").Inc()
+	return nil
+}
+
+func (c *Controller) UpdateTranslation(ctx context.Context, id uint64, req *model.Translation) error {
+	const op = "songs.UpdateTranslation.ctrl"
+
+	ctx, span := tracer.Start(ctx, op)
+	defer span.End()
+
+	err := c.repo.UpdateTranslation(ctx, id, req)
+	if err != nil && errors.Is(err, repo.ErrNotFound) {
+		logger.FromContext(ctx).Debug(
+			"failed to find translation",
+			zap.Error(err), zap.String("op", op),
+			zap.Uint64("ID", id), zap.String("lang", req.Lang),
+		)
+		return ErrNotFound
+	} else if err != nil && errors.Is(err, repo.ErrVerseCountMismatch) {
+		logger.FromContext(ctx).Debug(
+			"translation is not aligned with the lyrics",
+			zap.Error(err), zap.String("op", op),
+			zap.Uint64("ID", id), zap.String("lang", req.Lang),
+		)
+		return ErrVerseCountMismatch
+	} else if err != nil {
+		tracing.RecordError(span, err)
+		logger.FromContext(ctx).Debug(
+			"failed to update translation",
+			zap.Error(err), zap.String("op", op),
+			zap.Uint64("ID", id), zap.String("lang", req.Lang),
+		)
+		return err
+	}
+
+	metrics.SongsChanged.WithLabelValues("updated").Inc()
+	return nil
+}
+
+func (c *Controller) DeleteTranslation(ctx context.Context, id uint64, lang string) error {
+	const op = "songs.DeleteTranslation.ctrl"
+
+	ctx, span := tracer.Start(ctx, op)
+	defer span.End()
+
+	err := c.repo.DeleteTranslation(ctx, id, lang)
+	if err != nil && errors.Is(err, repo.ErrNotFound) {
+		logger.FromContext(ctx).Debug(
+			"failed to find translation",
+			zap.Error(err), zap.String("op", op),
+			zap.Uint64("ID", id), zap.String("lang", lang),
+		)
+		return ErrNotFound
+	} else if err != nil {
+		tracing.RecordError(span, err)
+		logger.FromContext(ctx).Debug(
+			"failed to delete translation",
+			zap.Error(err), zap.String("op", op),
+			zap.Uint64("ID", id), zap.String("lang", lang),
+		)
+		return err
+	}
+
+	metrics.SongsChanged.WithLabelValues("updated").Inc()
+	return nil
+}
@@ -0,0 +1,179 @@
+package ctrl
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/JMURv/effectiveMobile/internal/repo"
+	"github.com/JMURv/effectiveMobile/mocks"
+	"github.com/JMURv/effectiveMobile/pkg/model"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+	"golang.org/x/text/language"
+	"testing"
+)
+
+func TestMatchTranslation(t *testing.T) {
+	translations := []string{"de", "en-GB", "pt-BR"}
+
+	assert.Equal(t, "de", matchTranslation(translations, []language.Tag{language.German}))
+	assert.Equal(t, "en-GB", matchTranslation(translations, []language.Tag{language.AmericanEnglish}))
+	assert.Equal(t, "pt-BR", matchTranslation(translations, []language.Tag{language.Japanese, language.Portuguese}))
+	assert.Equal(t, "", matchTranslation(translations, []language.Tag{language.Japanese}))
+	assert.Equal(t, "", matchTranslation(translations, nil))
+	assert.Equal(t, "", matchTranslation(nil, []language.Tag{language.German}))
+}
+
+func TestController_GetSong_Translation(t *testing.T) {
+	ctrlMock := gomock.NewController(t)
+	defer ctrlMock.Finish()
+
+	svcRepo := mocks.NewMockRepo(ctrlMock)
+	extRepo := mocks.NewMockAPIRepo(ctrlMock)
+
+	ctrl := New(svcRepo, extRepo)
+	ctx := context.Background()
+
+	song := func() *model.PaginatedSongs {
+		return &model.PaginatedSongs{Data: &model.Song{ID: 1, Lyrics: []string{"b", "c"}, Translations: []string{"de", "fr"}}}
+	}
+
+	t.Run("Success", func(t *testing.T) {
+		svcRepo.EXPECT().GetSong(gomock.Any(), uint64(1), 2, 2).Return(song(), nil).Times(1)
+		svcRepo.EXPECT().GetTranslation(gomock.Any(), uint64(1), "de", 2, 2).Return([]string{"B", "C"}, nil).Times(1)
+
+		res, err := ctrl.GetSong(ctx, 1, 2, 2, []language.Tag{language.Japanese, language.MustParse("de-AT")})
+		assert.Nil(t, err)
+		assert.Equal(t, "de", res.Data.(*model.Song).Lang)
+		assert.Equal(t, []string{"B", "C"}, res.Data.(*model.Song).TranslatedLyrics)
+	})
+
+	t.Run("NoMatch", func(t *testing.T) {
+		svcRepo.EXPECT().GetSong(gomock.Any(), uint64(1), 1, 40).Return(song(), nil).Times(1)
+
+		res, err := ctrl.GetSong(ctx, 1, 1, 40, []language.Tag{language.Japanese})
+		assert.Nil(t, err)
+		assert.Empty(t, res.Data.(*model.Song).Lang)
+		assert.Nil(t, res.Data.(*model.Song).TranslatedLyrics)
+	})
+
+	t.Run("TranslationDeleted", func(t *testing.T) {
+		svcRepo.EXPECT().GetSong(gomock.Any(), uint64(1), 1, 40).Return(song(), nil).Times(1)
+		svcRepo.EXPECT().GetTranslation(gomock.Any(), uint64(1), "fr", 1, 40).Return(nil, repo.ErrNotFound).Times(1)
+
+		res, err := ctrl.GetSong(ctx, 1, 1, 40, []language.Tag{language.French})
+		assert.Nil(t, err)
+		assert.Empty(t, res.Data.(*model.Song).Lang)
+	})
+
+	t.Run("ErrOther", func(t *testing.T) {
+		newErr := errors.New("new error")
+		svcRepo.EXPECT().GetSong(gomock.Any(), uint64(1), 1, 40).Return(song(), nil).Times(1)
+		svcRepo.EXPECT().GetTranslation(gomock.Any(), uint64(1), "fr", 1, 40).Return(nil, newErr).Times(1)
+
+		res, err := ctrl.GetSong(ctx, 1, 1, 40, []language.Tag{language.French})
+		assert.Equal(t, newErr, err)
+		assert.Nil(t, res)
+	})
+}
+
+func TestController_CreateTranslation(t *testing.T) {
+	ctrlMock := gomock.NewController(t)
+	defer ctrlMock.Finish()
+
+	svcRepo := mocks.NewMockRepo(ctrlMock)
+	extRepo := mocks.NewMockAPIRepo(ctrlMock)
+
+	ctrl := New(svcRepo, extRepo)
+	ctx := context.Background()
+
+	req := &model.Translation{Lang: "de", Lyrics: []string{"eins", "zwei"}}
+
+	t.Run("Success", func(t *testing.T) {
+		svcRepo.EXPECT().CreateTranslation(gomock.Any(), uint64(1), req).Return(nil).Times(1)
+
+		err := ctrl.CreateTranslation(ctx, 1, req)
+		assert.Nil(t, err)
+	})
+
+	t.Run("ErrNotFound", func(t *testing.T) {
+		svcRepo.EXPECT().CreateTranslation(gomock.Any(), uint64(2), req).Return(repo.ErrNotFound).Times(1)
+
+		err := ctrl.CreateTranslation(ctx, 2, req)
+		assert.Equal(t, ErrNotFound, err)
+	})
+
+	t.Run("ErrAlreadyExists", func(t *testing.T) {
+		svcRepo.EXPECT().CreateTranslation(gomock.Any(), uint64(1), req).Return(repo.ErrAlreadyExists).Times(1)
+
+		err := ctrl.CreateTranslation(ctx, 1, req)
+		assert.Equal(t, ErrAlreadyExists, err)
+	})
+
+	t.Run("ErrVerseCountMismatch", func(t *testing.T) {
+		svcRepo.EXPECT().CreateTranslation(gomock.Any(), uint64(1), req).
+			Return(fmt.Errorf("%w: 2 instead of 3", repo.ErrVerseCountMismatch)).Times(1)
+
+		err := ctrl.CreateTranslation(ctx, 1, req)
+		assert.Equal(t, ErrVerseCountMismatch, err)
+	})
+}
+
+func TestController_UpdateTranslation(t *testing.T) {
+	ctrlMock := gomock.NewController(t)
+	defer ctrlMock.Finish()
+
+	svcRepo := mocks.NewMockRepo(ctrlMock)
+	extRepo := mocks.NewMockAPIRepo(ctrlMock)
+
+	ctrl := New(svcRepo, extRepo)
+	ctx := context.Background()
+
+	req := &model.Translation{Lang: "de", Lyrics: []string{"eins", ""}}
+
+	t.Run("Success", func(t *testing.T) {
+		svcRepo.EXPECT().UpdateTranslation(gomock.Any(), uint64(1), req).Return(nil).Times(1)
+
+		err := ctrl.UpdateTranslation(ctx, 1, req)
+		assert.Nil(t, err)
+	})
+
+	t.Run("ErrNotFound", func(t *testing.T) {
+		svcRepo.EXPECT().UpdateTranslation(gomock.Any(), uint64(1), req).Return(repo.ErrNotFound).Times(1)
+
+		err := ctrl.UpdateTranslation(ctx, 1, req)
+		assert.Equal(t, ErrNotFound, err)
+	})
+
+	t.Run("ErrVerseCountMismatch", func(t *testing.T) {
+		svcRepo.EXPECT().UpdateTranslation(gomock.Any(), uint64(1), req).Return(repo.ErrVerseCountMismatch).Times(1)
+
+		err := ctrl.UpdateTranslation(ctx, 1, req)
+		assert.Equal(t, ErrVerseCountMismatch, err)
+	})
+}
+
+func TestController_DeleteTranslation(t *testing.T) {
+	ctrlMock := gomock.NewController(t)
+	defer ctrlMock.Finish()
+
+	svcRepo := mocks.NewMockRepo(ctrlMock)
+	extRepo := mocks.NewMockAPIRepo(ctrlMock)
+
+	ctrl := New(svcRepo, extRepo)
+	ctx := context.Background()
+
+	t.Run("Success", func(t *testing.T) {
+		svcRepo.EXPECT().DeleteTranslation(gomock.Any(), uint64(1), "de").Return(nil).Times(1)
+
+		err := ctrl.DeleteTranslation(ctx, 1, "de")
+		assert.Nil(t, err)
+	})
+
+	t.Run("ErrNotFound", func(t *testing.T) {
+		svcRepo.EXPECT().DeleteTranslation(gomock.Any(), uint64(1), "fr").Return(repo.ErrNotFound).Times(1)
+
+		err := ctrl.DeleteTranslation(ctx, 1, "fr")
+		assert.Equal(t, ErrNotFound, err)
+	})
+}
@@ -0,0 +1,162 @@
+package ctrl
+
+import (
+	"context"
+	"errors"
+	"github.com/JMURv/effectiveMobile/internal/metrics"
+	"github.com/JMURv/effectiveMobile/internal/repo"
+	"github.com/JMURv/effectiveMobile/internal/tracing"
+	"github.com/JMURv/effectiveMobile/pkg/model"
+	"github.com/JMURv/effectiveMobile/pkg/utils/logger"
+	"go.uber.org/zap"
+)
+
+func (c *Controller) ListVerses(ctx context.Context, id uint64, page, size int) (*model.PaginatedVerses, error) {
+	const op = "songs.ListVerses.ctrl"
+
+	ctx, span := tracer.Start(ctx, op)
+	defer span.End()
+
+	res, err := c.repo.ListVerses(ctx, id, page, size)
+	if err != nil && errors.Is(err, repo.ErrNotFound) {
+		logger.FromContext(ctx).Debug(
+			"failed to find song",
+			zap.Error(err), zap.String("op", op),
+			zap.Uint64("ID", id),
+		)
+		return nil, ErrNotFound
+	} else if err != nil {
+		tracing.RecordError(span, err)
+		logger.FromContext(ctx).Debug(
+			"failed to list verses",
+			zap.Error(err), zap.String("op", op),
+			zap.Uint64("ID", id), zap.Int("page", page), zap.Int("size", size),
+		)
+		return nil, err
+	}
+
+	return res, nil
+}
+
+// InsertVerse inserts the verse at the position, 0 appends it.
+// Returns the position the verse ended up at
+func (c *Controller) InsertVerse(ctx context.Context, id uint64, position int, text string) (int, error) {
+	const op = "songs.InsertVerse.ctrl"
+
+	ctx, span := tracer.Start(ctx, op)
+	defer span.End()
+
+	res, err := c.repo.InsertVerse(ctx, id, position, text)
+	if err != nil && errors.Is(err, repo.ErrNotFound) {
+		logger.FromContext(ctx).Debug(
+			"failed to find song",
+			zap.Error(err), zap.String("op", op),
+			zap.Uint64("ID", id),
+		)
+		return 0, ErrNotFound
+	} else if err != nil {
+		tracing.RecordError(span, err)
+		logger.FromContext(ctx).Debug(
+			"failed to insert verse",
+			zap.Error(err), zap.String("op", op),
+			zap.Uint64("ID", id), zap.Int("position", position),
+		)
+		return 0, err
+	}
+
+	metrics.SongsChanged.WithLabelValues("updated").Inc()
+	return res, nil
+}
+
+func (c *Controller) UpdateVerse(ctx context.Context, id uint64, position int, text string) error {
+	const op = "songs.UpdateVerse.ctrl"
+
+	ctx, span := tracer.Start(ctx, op)
+	defer span.End()
+
+	err := c.repo.UpdateVerse(ctx, id, position, text)
+	if err != nil && (errors.Is(err, repo.ErrNotFound) || errors.Is(err, repo.ErrVerseNotFound)) {
+		logger.FromContext(ctx).Debug(
+			"failed to find verse",
+			zap.Error(err), zap.String("op", op),
+			zap.Uint64("ID", id), zap.Int("position", position),
+		)
+		return verseErr(err)
+	} else if err != nil {
+		tracing.RecordError(span, err)
+		logger.FromContext(ctx).Debug(
+			"failed to update verse",
+			zap.Error(err), zap.String("op", op),
+			zap.Uint64("ID", id), zap.Int("position", position),
+		)
+		return err
+	}
+
+	metrics.SongsChanged.WithLabelValues("updated").Inc()
+	return nil
+}
+
+func (c *Controller) DeleteVerse(ctx context.Context, id uint64, position int) error {
+	const op = "songs.DeleteVerse.ctrl"
+
+	ctx, span := tracer.Start(ctx, op)
+	defer span.End()
+
+	err := c.repo.DeleteVerse(ctx, id, position)
+	if err != nil && (errors.Is(err, repo.ErrNotFound) || errors.Is(err, repo.ErrVerseNotFound)) {
+		logger.FromContext(ctx).Debug(
+			"failed to find verse",
+			zap.Error(err), zap.String("op", op),
+			zap.Uint64("ID", id), zap.Int("position", position),
+		)
+		return verseErr(err)
+	} else if err != nil {
+		tracing.RecordError(span, err)
+		logger.FromContext(ctx).Debug(
+			"failed to delete verse",
+			zap.Error(err), zap.String("op", op),
+			zap.Uint64("ID", id), zap.Int("position", position),
+		)
+		return err
+	}
+
+	metrics.SongsChanged.WithLabelValues("updated").Inc()
+	return nil
+}
+
+// MoveVerse moves the verse to the new position. Returns the position it ended up at
+func (c *Controller) MoveVerse(ctx context.Context, id uint64, position, to int) (int, error) {
+	const op = "songs.MoveVerse.ctrl"
+
+	ctx, span := tracer.Start(ctx, op)
+	defer span.End()
+
+	res, err := c.repo.MoveVerse(ctx, id, position, to)
+	if err != nil && (errors.Is(err, repo.ErrNotFound) || errors.Is(err, repo.ErrVerseNotFound)) {
+		logger.FromContext(ctx).Debug(
+			"failed to find verse",
+			zap.Error(err), zap.String("op", op),
+			zap.Uint64("ID", id), zap.Int("position", position),
+		)
+		return 0, verseErr(err)
+	} else if err != nil {
+		tracing.RecordError(span, err)
+		logger.FromContext(ctx).Debug(
+			"failed to move verse",
+			zap.Error(err), zap.String("op", op),
+			zap.Uint64("ID", id), zap.Int("position", position), zap.Int("to", to),
+		)
+		return 0, err
+	}
+
+	metrics.SongsChanged.WithLabelValues("updated").Inc()
+	return res, nil
+}
+
+// verseErr tells a missing song from a missing verse of an existing one
+func verseErr(err error) error {
+	if errors.Is(err, repo.ErrVerseNotFound) {
+		return ErrVerseNotFound
+	}
+	return ErrNotFound
+}
@@ -0,0 +1,147 @@
+package ctrl
+
+import (
+	"context"
+	"errors"
+	"github.com/JMURv/effectiveMobile/internal/repo"
+	"github.com/JMURv/effectiveMobile/mocks"
+	"github.com/JMURv/effectiveMobile/pkg/model"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+	"testing"
+)
+
+func TestController_ListVerses(t *testing.T) {
+	ctrlMock := gomock.NewController(t)
+	defer ctrlMock.Finish()
+
+	svcRepo := mocks.NewMockRepo(ctrlMock)
+	extRepo := mocks.NewMockAPIRepo(ctrlMock)
+
+	ctrl := New(svcRepo, extRepo)
+	ctx := context.Background()
+
+	t.Run("Success", func(t *testing.T) {
+		expected := &model.PaginatedVerses{Data: []*model.Verse{{Position: 1, Text: "a"}}, Count: 1, TotalPages: 1, CurrentPage: 1}
+		svcRepo.EXPECT().ListVerses(gomock.Any(), uint64(1), 1, 10).Return(expected, nil).Times(1)
+
+		res, err := ctrl.ListVerses(ctx, 1, 1, 10)
+		assert.Nil(t, err)
+		assert.Equal(t, expected, res)
+	})
+
+	t.Run("ErrNotFound", func(t *testing.T) {
+		svcRepo.EXPECT().ListVerses(gomock.Any(), uint64(2), 1, 10).Return(nil, repo.ErrNotFound).Times(1)
+
+		res, err := ctrl.ListVerses(ctx, 2, 1, 10)
+		assert.Equal(t, ErrNotFound, err)
+		assert.Nil(t, res)
+	})
+}
+
+func TestController_InsertVerse(t *testing.T) {
+	ctrlMock := gomock.NewController(t)
+	defer ctrlMock.Finish()
+
+	svcRepo := mocks.NewMockRepo(ctrlMock)
+	extRepo := mocks.NewMockAPIRepo(ctrlMock)
+
+	ctrl := New(svcRepo, extRepo)
+	ctx := context.Background()
+
+	t.Run("Success", func(t *testing.T) {
+		svcRepo.EXPECT().InsertVerse(gomock.Any(), uint64(1), 0, "chorus").Return(3, nil).Times(1)
+
+		res, err := ctrl.InsertVerse(ctx, 1, 0, "chorus")
+		assert.Nil(t, err)
+		assert.Equal(t, 3, res)
+	})
+
+	t.Run("ErrNotFound", func(t *testing.T) {
+		svcRepo.EXPECT().InsertVerse(gomock.Any(), uint64(2), 1, "chorus").Return(0, repo.ErrNotFound).Times(1)
+
+		_, err := ctrl.InsertVerse(ctx, 2, 1, "chorus")
+		assert.Equal(t, ErrNotFound, err)
+	})
+
+	t.Run("Error", func(t *testing.T) {
+		expectedErr := errors.New("db error")
+		svcRepo.EXPECT().InsertVerse(gomock.Any(), uint64(1), 1, "chorus").Return(0, expectedErr).Times(1)
+
+		_, err := ctrl.InsertVerse(ctx, 1, 1, "chorus")
+		assert.Equal(t, expectedErr, err)
+	})
+}
+
+func TestController_UpdateVerse(t *testing.T) {
+	ctrlMock := gomock.NewController(t)
+	defer ctrlMock.Finish()
+
+	svcRepo := mocks.NewMockRepo(ctrlMock)
+	extRepo := mocks.NewMockAPIRepo(ctrlMock)
+
+	ctrl := New(svcRepo, extRepo)
+	ctx := context.Background()
+
+	t.Run("Success", func(t *testing.T) {
+		svcRepo.EXPECT().UpdateVerse(gomock.Any(), uint64(1), 2, "bridge").Return(nil).Times(1)
+		assert.Nil(t, ctrl.UpdateVerse(ctx, 1, 2, "bridge"))
+	})
+
+	t.Run("ErrNotFound", func(t *testing.T) {
+		svcRepo.EXPECT().UpdateVerse(gomock.Any(), uint64(2), 1, "bridge").Return(repo.ErrNotFound).Times(1)
+		assert.Equal(t, ErrNotFound, ctrl.UpdateVerse(ctx, 2, 1, "bridge"))
+	})
+
+	t.Run("ErrVerseNotFound", func(t *testing.T) {
+		svcRepo.EXPECT().UpdateVerse(gomock.Any(), uint64(1), 9, "bridge").Return(repo.ErrVerseNotFound).Times(1)
+		assert.Equal(t, ErrVerseNotFound, ctrl.UpdateVerse(ctx, 1, 9, "bridge"))
+	})
+}
+
+func TestController_DeleteVerse(t *testing.T) {
+	ctrlMock := gomock.NewController(t)
+	defer ctrlMock.Finish()
+
+	svcRepo := mocks.NewMockRepo(ctrlMock)
+	extRepo := mocks.NewMockAPIRepo(ctrlMock)
+
+	ctrl := New(svcRepo, extRepo)
+	ctx := context.Background()
+
+	t.Run("Success", func(t *testing.T) {
+		svcRepo.EXPECT().DeleteVerse(gomock.Any(), uint64(1), 2).Return(nil).Times(1)
+		assert.Nil(t, ctrl.DeleteVerse(ctx, 1, 2))
+	})
+
+	t.Run("ErrVerseNotFound", func(t *testing.T) {
+		svcRepo.EXPECT().DeleteVerse(gomock.Any(), uint64(1), 9).Return(repo.ErrVerseNotFound).Times(1)
+		assert.Equal(t, ErrVerseNotFound, ctrl.DeleteVerse(ctx, 1, 9))
+	})
+}
+
+func TestController_MoveVerse(t *testing.T) {
+	ctrlMock := gomock.NewController(t)
+	defer ctrlMock.Finish()
+
+	svcRepo := mocks.NewMockRepo(ctrlMock)
+	extRepo := mocks.NewMockAPIRepo(ctrlMock)
+
+	ctrl := New(svcRepo, extRepo)
+	ctx := context.Background()
+
+	t.Run("Success", func(t *testing.T) {
+		svcRepo.EXPECT().MoveVerse(gomock.Any(), uint64(1), 3, 10).Return(4, nil).Times(1)
+
+		res, err := ctrl.MoveVerse(ctx, 1, 3, 10)
+		assert.Nil(t, err)
+		assert.Equal(t, 4, res)
+	})
+
+	t.Run("ErrVerseNotFound", func(t *testing.T) {
+		svcRepo.EXPECT().MoveVerse(gomock.Any(), uint64(1), 9, 1).Return(0, repo.ErrVerseNotFound).Times(1)
+
+		_, err := ctrl.MoveVerse(ctx, 1, 9, 1)
+		assert.Equal(t, ErrVerseNotFound, err)
+	})
+}
@@ -6,3 +6,16 @@ var ErrInternal = errors.New("internal error")
 var ErrDecodeRequest = errors.New("failed to decode request")
 var ErrMissingSongID = errors.New("missing song ID")
 var ErrMethodNotAllowed = errors.New("method not allowed")
+var ErrRouteNotFound = errors.New("route not found")
+var ErrUnauthorized = errors.New("authentication required")
+var ErrForbidden = errors.New("insufficient role")
+var ErrMissingArtistID = errors.New("missing artist ID")
+var ErrMissingAlbumID = errors.New("missing album ID")
+var ErrMissingGenreID = errors.New("missing genre ID")
+var ErrMissingTagID = errors.New("missing tag ID")
+var ErrMissingPlaylistID = errors.New("missing playlist ID")
+var ErrMissingVerse = errors.New("missing verse position")
+var ErrInvalidLanguage = errors.New("invalid language tag")
+var ErrRequestTooLarge = errors.New("request body is too large")
+var ErrNoLyrics = errors.New("song has no lyrics")
+var ErrNoSyncedLyrics = errors.New("song has no synced lyrics")
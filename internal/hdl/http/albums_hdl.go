@@ -0,0 +1,440 @@
+package http
+
+import (
+	"encoding/json"
+	"errors"
+	"github.com/JMURv/effectiveMobile/internal/ctrl"
+	"github.com/JMURv/effectiveMobile/internal/hdl"
+	"github.com/JMURv/effectiveMobile/internal/validation"
+	"github.com/JMURv/effectiveMobile/pkg/model"
+	utils "github.com/JMURv/effectiveMobile/pkg/utils/http"
+	"github.com/JMURv/effectiveMobile/pkg/utils/logger"
+	"go.uber.org/zap"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+type AlbumRequest struct {
+	Artist      string    `json:"artist"`
+	Title       string    `json:"title"`
+	ReleaseDate time.Time `json:"release_date"`
+	CoverLink   string    `json:"cover_link"`
+}
+
+// TrackRequest places a song on an album, the disc defaults to the first one
+type TrackRequest struct {
+	Disc        int `json:"disc"`
+	TrackNumber int `json:"track_number"`
+}
+
+// ListAlbums
+// @Summary Список альбомов
+// @Description Получить список альбомов, новые выше
+// @Tags albums
+// @Param page query int false "Номер страницы" default(1)
+// @Param size query int false "Размер страницы" default(40)
+// @Param title query string false "Фильтр по названию альбома"
+// @Success 200 {object} model.PaginatedAlbums "Список альбомов с пагинацией"
+// @Failure 400 {object} utils.Problem "Некорректный номер или размер страницы"
+// @Security ApiKeyAuth
+// @Security BearerAuth
+// @Failure 401 {object} utils.Problem "Требуется аутентификация"
+// @Failure 403 {object} utils.Problem "Недостаточно прав, требуется роль reader"
+// @Failure 500 {object} utils.Problem "Внутренняя ошибка сервера"
+// @Router /api/albums [get]
+func (h *Handler) ListAlbums(w http.ResponseWriter, r *http.Request) {
+	const op = "songs.ListAlbums.hdl"
+
+	page, err := strconv.Atoi(r.URL.Query().Get("page"))
+	if err != nil {
+		page = 1
+	}
+
+	size, err := strconv.Atoi(r.URL.Query().Get("size"))
+	if err != nil {
+		size = 40
+	}
+
+	if err := validation.ValidatePage(page, size); err != nil {
+		logger.FromContext(r.Context()).Debug(
+			"failed to validate page",
+			zap.Error(err), zap.String("op", op),
+		)
+		utils.ErrResponse(w, r, http.StatusBadRequest, err)
+		return
+	}
+
+	res, err := h.ctrl.ListAlbums(r.Context(), page, size, r.URL.Query().Get("title"))
+	if err != nil {
+		utils.ErrResponse(w, r, http.StatusInternalServerError, hdl.ErrInternal)
+		return
+	}
+
+	utils.SuccessPaginatedResponse(w, http.StatusOK, res)
+}
+
+// GetAlbum
+// @Summary Получить альбом по ID
+// @Tags albums
+// @Param id path int true "ID альбома"
+// @Success 200 {object} model.Album "Альбом"
+// @Failure 400 {object} utils.Problem "Некорректный запрос"
+// @Failure 404 {object} utils.Problem "Альбом не найден"
+// @Security ApiKeyAuth
+// @Security BearerAuth
+// @Failure 401 {object} utils.Problem "Требуется аутентификация"
+// @Failure 403 {object} utils.Problem "Недостаточно прав, требуется роль reader"
+// @Failure 500 {object} utils.Problem "Внутренняя ошибка сервера"
+// @Router /api/albums/{id} [get]
+func (h *Handler) GetAlbum(w http.ResponseWriter, r *http.Request) {
+	const op = "songs.GetAlbum.hdl"
+
+	id, err := strconv.ParseUint(r.PathValue("id"), 10, 64)
+	if err != nil {
+		logger.FromContext(r.Context()).Debug(
+			"failed to extract param",
+			zap.Error(err), zap.String("op", op),
+		)
+		utils.ErrResponse(w, r, http.StatusBadRequest, hdl.ErrMissingAlbumID)
+		return
+	}
+
+	res, err := h.ctrl.GetAlbum(r.Context(), id)
+	if err != nil && errors.Is(err, ctrl.ErrNotFound) {
+		utils.ErrResponse(w, r, http.StatusNotFound, err)
+		return
+	} else if err != nil {
+		utils.ErrResponse(w, r, http.StatusInternalServerError, hdl.ErrInternal)
+		return
+	}
+
+	utils.SuccessResponse(w, http.StatusOK, res)
+}
+
+// ListAlbumTracks
+// @Summary Треклист альбома
+// @Description Получить песни альбома в порядке дисков и номеров треков
+// @Tags albums
+// @Param id path int true "ID альбома"
+// @Success 200 {object} []model.Track "Треки альбома"
+// @Failure 400 {object} utils.Problem "Некорректный запрос"
+// @Failure 404 {object} utils.Problem "Альбом не найден"
+// @Security ApiKeyAuth
+// @Security BearerAuth
+// @Failure 401 {object} utils.Problem "Требуется аутентификация"
+// @Failure 403 {object} utils.Problem "Недостаточно прав, требуется роль reader"
+// @Failure 500 {object} utils.Problem "Внутренняя ошибка сервера"
+// @Router /api/albums/{id}/tracks [get]
+func (h *Handler) ListAlbumTracks(w http.ResponseWriter, r *http.Request) {
+	const op = "songs.ListAlbumTracks.hdl"
+
+	id, err := strconv.ParseUint(r.PathValue("id"), 10, 64)
+	if err != nil {
+		logger.FromContext(r.Context()).Debug(
+			"failed to extract param",
+			zap.Error(err), zap.String("op", op),
+		)
+		utils.ErrResponse(w, r, http.StatusBadRequest, hdl.ErrMissingAlbumID)
+		return
+	}
+
+	res, err := h.ctrl.ListAlbumTracks(r.Context(), id)
+	if err != nil && errors.Is(err, ctrl.ErrNotFound) {
+		utils.ErrResponse(w, r, http.StatusNotFound, err)
+		return
+	} else if err != nil {
+		utils.ErrResponse(w, r, http.StatusInternalServerError, hdl.ErrInternal)
+		return
+	}
+
+	utils.SuccessResponse(w, http.StatusOK, res)
+}
+
+// CreateAlbum
+// @Summary Добавить альбом
+// @Description Исполнитель указывается по имени и создаётся, если его ещё нет
+// @Tags albums
+// @Accept json
+// @Param album body AlbumRequest true "Данные альбома"
+// @Success 201 {object} model.Album "Добавленный альбом"
+// @Failure 400 {object} utils.Problem "Ошибка валидации или декодирования запроса"
+// @Failure 409 {object} utils.Problem "У исполнителя уже есть альбом с таким названием"
+// @Security ApiKeyAuth
+// @Security BearerAuth
+// @Failure 401 {object} utils.Problem "Требуется аутентификация"
+// @Failure 403 {object} utils.Problem "Недостаточно прав, требуется роль editor"
+// @Failure 500 {object} utils.Problem "Внутренняя ошибка сервера"
+// @Router /api/albums [post]
+func (h *Handler) CreateAlbum(w http.ResponseWriter, r *http.Request) {
+	const op = "songs.CreateAlbum.hdl"
+
+	req := &model.Album{}
+	if err := json.NewDecoder(r.Body).Decode(req); err != nil {
+		logger.FromContext(r.Context()).Debug(
+			"failed to decode request",
+			zap.Error(err), zap.String("op", op),
+		)
+		utils.ErrResponse(w, r, http.StatusBadRequest, hdl.ErrDecodeRequest)
+		return
+	}
+
+	validation.NormalizeAlbum(req)
+	if err := validation.ValidateAlbum(req); err != nil {
+		logger.FromContext(r.Context()).Debug(
+			"failed to validate request",
+			zap.Error(err), zap.String("op", op),
+			zap.Any("req", req),
+		)
+		utils.ErrResponse(w, r, http.StatusBadRequest, err)
+		return
+	}
+
+	res, err := h.ctrl.CreateAlbum(r.Context(), req)
+	if err != nil && errors.Is(err, ctrl.ErrAlreadyExists) {
+		utils.ErrResponse(w, r, http.StatusConflict, err)
+		return
+	} else if err != nil {
+		utils.ErrResponse(w, r, http.StatusInternalServerError, hdl.ErrInternal)
+		return
+	}
+
+	utils.SuccessResponse(w, http.StatusCreated, res)
+}
+
+// UpdateAlbum
+// @Summary Обновить альбом
+// @Tags albums
+// @Accept json
+// @Param id path int true "ID альбома"
+// @Param album body AlbumRequest true "Новые данные альбома"
+// @Success 200 {object} string "Альбом успешно обновлён"
+// @Failure 400 {object} utils.Problem "Ошибка валидации или декодирования запроса"
+// @Failure 404 {object} utils.Problem "Альбом не найден"
+// @Failure 409 {object} utils.Problem "У исполнителя уже есть альбом с таким названием"
+// @Security ApiKeyAuth
+// @Security BearerAuth
+// @Failure 401 {object} utils.Problem "Требуется аутентификация"
+// @Failure 403 {object} utils.Problem "Недостаточно прав, требуется роль editor"
+// @Failure 500 {object} utils.Problem "Внутренняя ошибка сервера"
+// @Router /api/albums/{id} [put]
+func (h *Handler) UpdateAlbum(w http.ResponseWriter, r *http.Request) {
+	const op = "songs.UpdateAlbum.hdl"
+
+	id, err := strconv.ParseUint(r.PathValue("id"), 10, 64)
+	if err != nil {
+		logger.FromContext(r.Context()).Debug(
+			"failed to extract param",
+			zap.Error(err), zap.String("op", op),
+		)
+		utils.ErrResponse(w, r, http.StatusBadRequest, hdl.ErrMissingAlbumID)
+		return
+	}
+
+	req := &model.Album{}
+	if err := json.NewDecoder(r.Body).Decode(req); err != nil {
+		logger.FromContext(r.Context()).Debug(
+			"failed to decode request",
+			zap.Error(err), zap.String("op", op),
+		)
+		utils.ErrResponse(w, r, http.StatusBadRequest, hdl.ErrDecodeRequest)
+		return
+	}
+	req.ID = id
+
+	validation.NormalizeAlbum(req)
+	if err := validation.ValidateAlbum(req); err != nil {
+		logger.FromContext(r.Context()).Debug(
+			"failed to validate request",
+			zap.Error(err), zap.String("op", op),
+			zap.Any("req", req),
+		)
+		utils.ErrResponse(w, r, http.StatusBadRequest, err)
+		return
+	}
+
+	err = h.ctrl.UpdateAlbum(r.Context(), req)
+	if err != nil && errors.Is(err, ctrl.ErrNotFound) {
+		utils.ErrResponse(w, r, http.StatusNotFound, err)
+		return
+	} else if err != nil && errors.Is(err, ctrl.ErrAlreadyExists) {
+		utils.ErrResponse(w, r, http.StatusConflict, err)
+		return
+	} else if err != nil {
+		utils.ErrResponse(w, r, http.StatusInternalServerError, hdl.ErrInternal)
+		return
+	}
+
+	utils.SuccessResponse(w, http.StatusOK, "OK")
+}
+
+// DeleteAlbum
+// @Summary Удалить альбом
+// @Description Удаляется альбом и его треклист, сами песни остаются
+// @Tags albums
+// @Param id path int true "ID альбома"
+// @Success 204 {object} string "Альбом успешно удалён"
+// @Failure 400 {object} utils.Problem "Некорректный запрос"
+// @Failure 404 {object} utils.Problem "Альбом не найден"
+// @Security ApiKeyAuth
+// @Security BearerAuth
+// @Failure 401 {object} utils.Problem "Требуется аутентификация"
+// @Failure 403 {object} utils.Problem "Недостаточно прав, требуется роль admin"
+// @Failure 500 {object} utils.Problem "Внутренняя ошибка сервера"
+// @Router /api/albums/{id} [delete]
+func (h *Handler) DeleteAlbum(w http.ResponseWriter, r *http.Request) {
+	const op = "songs.DeleteAlbum.hdl"
+
+	id, err := strconv.ParseUint(r.PathValue("id"), 10, 64)
+	if err != nil {
+		logger.FromContext(r.Context()).Debug(
+			"failed to extract param",
+			zap.Error(err), zap.String("op", op),
+		)
+		utils.ErrResponse(w, r, http.StatusBadRequest, hdl.ErrMissingAlbumID)
+		return
+	}
+
+	err = h.ctrl.DeleteAlbum(r.Context(), id)
+	if err != nil && errors.Is(err, ctrl.ErrNotFound) {
+		utils.ErrResponse(w, r, http.StatusNotFound, err)
+		return
+	} else if err != nil {
+		utils.ErrResponse(w, r, http.StatusInternalServerError, hdl.ErrInternal)
+		return
+	}
+
+	utils.SuccessResponse(w, http.StatusNoContent, "OK")
+}
+
+// SetAlbumTrack
+// @Summary Поставить песню в треклист альбома
+// @Description Добавляет песню в альбом или переносит её на другой диск и номер трека. Без диска песня попадает на первый
+// @Tags albums
+// @Accept json
+// @Param id path int true "ID альбома"
+// @Param song_id path int true "ID песни"
+// @Param track body TrackRequest true "Диск и номер трека"
+// @Success 200 {object} string "Трек успешно сохранён"
+// @Failure 400 {object} utils.Problem "Ошибка валидации, декодирования запроса или песня не найдена"
+// @Failure 404 {object} utils.Problem "Альбом не найден"
+// @Failure 409 {object} utils.Problem "Позиция в треклисте уже занята"
+// @Security ApiKeyAuth
+// @Security BearerAuth
+// @Failure 401 {object} utils.Problem "Требуется аутентификация"
+// @Failure 403 {object} utils.Problem "Недостаточно прав, требуется роль editor"
+// @Failure 500 {object} utils.Problem "Внутренняя ошибка сервера"
+// @Router /api/albums/{id}/tracks/{song_id} [put]
+func (h *Handler) SetAlbumTrack(w http.ResponseWriter, r *http.Request) {
+	const op = "songs.SetAlbumTrack.hdl"
+
+	id, err := strconv.ParseUint(r.PathValue("id"), 10, 64)
+	if err != nil {
+		logger.FromContext(r.Context()).Debug(
+			"failed to extract param",
+			zap.Error(err), zap.String("op", op),
+		)
+		utils.ErrResponse(w, r, http.StatusBadRequest, hdl.ErrMissingAlbumID)
+		return
+	}
+
+	songID, err := strconv.ParseUint(r.PathValue("song_id"), 10, 64)
+	if err != nil {
+		logger.FromContext(r.Context()).Debug(
+			"failed to extract param",
+			zap.Error(err), zap.String("op", op),
+		)
+		utils.ErrResponse(w, r, http.StatusBadRequest, hdl.ErrMissingSongID)
+		return
+	}
+
+	req := &TrackRequest{}
+	if err := json.NewDecoder(r.Body).Decode(req); err != nil {
+		logger.FromContext(r.Context()).Debug(
+			"failed to decode request",
+			zap.Error(err), zap.String("op", op),
+		)
+		utils.ErrResponse(w, r, http.StatusBadRequest, hdl.ErrDecodeRequest)
+		return
+	}
+
+	if req.Disc == 0 {
+		req.Disc = 1
+	}
+	if err := validation.ValidateTrack(req.Disc, req.TrackNumber); err != nil {
+		logger.FromContext(r.Context()).Debug(
+			"failed to validate request",
+			zap.Error(err), zap.String("op", op),
+			zap.Any("req", req),
+		)
+		utils.ErrResponse(w, r, http.StatusBadRequest, err)
+		return
+	}
+
+	err = h.ctrl.SetAlbumTrack(r.Context(), id, songID, req.Disc, req.TrackNumber)
+	if err != nil && errors.Is(err, ctrl.ErrNotFound) {
+		utils.ErrResponse(w, r, http.StatusNotFound, err)
+		return
+	} else if err != nil && errors.Is(err, ctrl.ErrSongNotFound) {
+		utils.ErrResponse(w, r, http.StatusBadRequest, err)
+		return
+	} else if err != nil && errors.Is(err, ctrl.ErrTrackTaken) {
+		utils.ErrResponse(w, r, http.StatusConflict, err)
+		return
+	} else if err != nil {
+		utils.ErrResponse(w, r, http.StatusInternalServerError, hdl.ErrInternal)
+		return
+	}
+
+	utils.SuccessResponse(w, http.StatusOK, "OK")
+}
+
+// RemoveAlbumTrack
+// @Summary Убрать песню из треклиста альбома
+// @Description Сама песня остаётся, номера остальных треков не меняются
+// @Tags albums
+// @Param id path int true "ID альбома"
+// @Param song_id path int true "ID песни"
+// @Success 204 {object} string "Песня убрана из альбома"
+// @Failure 400 {object} utils.Problem "Некорректный запрос"
+// @Failure 404 {object} utils.Problem "Альбом не найден или песни в нём нет"
+// @Security ApiKeyAuth
+// @Security BearerAuth
+// @Failure 401 {object} utils.Problem "Требуется аутентификация"
+// @Failure 403 {object} utils.Problem "Недостаточно прав, требуется роль editor"
+// @Failure 500 {object} utils.Problem "Внутренняя ошибка сервера"
+// @Router /api/albums/{id}/tracks/{song_id} [delete]
+func (h *Handler) RemoveAlbumTrack(w http.ResponseWriter, r *http.Request) {
+	const op = "songs.RemoveAlbumTrack.hdl"
+
+	id, err := strconv.ParseUint(r.PathValue("id"), 10, 64)
+	if err != nil {
+		logger.FromContext(r.Context()).Debug(
+			"failed to extract param",
+			zap.Error(err), zap.String("op", op),
+		)
+		utils.ErrResponse(w, r, http.StatusBadRequest, hdl.ErrMissingAlbumID)
+		return
+	}
+
+	songID, err := strconv.ParseUint(r.PathValue("song_id"), 10, 64)
+	if err != nil {
+		logger.FromContext(r.Context()).Debug(
+			"failed to extract param",
+			zap.Error(err), zap.String("op", op),
+		)
+		utils.ErrResponse(w, r, http.StatusBadRequest, hdl.ErrMissingSongID)
+		return
+	}
+
+	err = h.ctrl.RemoveAlbumTrack(r.Context(), id, songID)
+	if err != nil && errors.Is(err, ctrl.ErrNotFound) {
+		utils.ErrResponse(w, r, http.StatusNotFound, err)
+		return
+	} else if err != nil {
+		utils.ErrResponse(w, r, http.StatusInternalServerError, hdl.ErrInternal)
+		return
+	}
+
+	utils.SuccessResponse(w, http.StatusNoContent, "OK")
+}
@@ -0,0 +1,380 @@
+package http
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"github.com/JMURv/effectiveMobile/internal/ctrl"
+	"github.com/JMURv/effectiveMobile/mocks"
+	"github.com/JMURv/effectiveMobile/pkg/model"
+	utils "github.com/JMURv/effectiveMobile/pkg/utils/http"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestHandler_ListAlbums(t *testing.T) {
+	ctrlMock := gomock.NewController(t)
+	defer ctrlMock.Finish()
+
+	ctrlRepo := mocks.NewMockCtrl(ctrlMock)
+	hdl := New(ctrlRepo)
+
+	ctx := context.Background()
+
+	t.Run("Success", func(t *testing.T) {
+		ctrlRepo.EXPECT().ListAlbums(ctx, 1, 40, "jazz").Return(&model.PaginatedAlbums{
+			Data: []*model.Album{{ID: 1, Artist: "Queen", Title: "Jazz"}},
+		}, nil).Times(1)
+
+		req := httptest.NewRequest(http.MethodGet, "/api/albums?title=jazz", nil)
+		req = req.WithContext(ctx)
+
+		w := httptest.NewRecorder()
+		hdl.routes().ServeHTTP(w, req)
+		assert.Equal(t, http.StatusOK, w.Result().StatusCode)
+	})
+
+	t.Run("ErrInternalError", func(t *testing.T) {
+		ctrlRepo.EXPECT().ListAlbums(ctx, 1, 40, "").Return(nil, errors.New("other error")).Times(1)
+
+		req := httptest.NewRequest(http.MethodGet, "/api/albums", nil)
+		req = req.WithContext(ctx)
+
+		w := httptest.NewRecorder()
+		hdl.routes().ServeHTTP(w, req)
+		assert.Equal(t, http.StatusInternalServerError, w.Result().StatusCode)
+	})
+
+	t.Run("InvalidPage", func(t *testing.T) {
+		for _, query := range []string{"size=0", "size=-1", "page=0"} {
+			req := httptest.NewRequest(http.MethodGet, "/api/albums?"+query, nil)
+			req = req.WithContext(ctx)
+
+			w := httptest.NewRecorder()
+			hdl.routes().ServeHTTP(w, req)
+			assert.Equal(t, http.StatusBadRequest, w.Result().StatusCode, query)
+		}
+	})
+}
+
+func TestHandler_GetAlbum(t *testing.T) {
+	ctrlMock := gomock.NewController(t)
+	defer ctrlMock.Finish()
+
+	ctrlRepo := mocks.NewMockCtrl(ctrlMock)
+	hdl := New(ctrlRepo)
+
+	ctx := context.Background()
+
+	t.Run("Success", func(t *testing.T) {
+		ctrlRepo.EXPECT().GetAlbum(ctx, uint64(1)).Return(&model.Album{ID: 1, Title: "Jazz"}, nil).Times(1)
+
+		req := httptest.NewRequest(http.MethodGet, "/api/albums/1", nil)
+		req = req.WithContext(ctx)
+
+		w := httptest.NewRecorder()
+		hdl.routes().ServeHTTP(w, req)
+		assert.Equal(t, http.StatusOK, w.Result().StatusCode)
+	})
+
+	t.Run("ErrNotFound", func(t *testing.T) {
+		ctrlRepo.EXPECT().GetAlbum(ctx, uint64(1)).Return(nil, ctrl.ErrNotFound).Times(1)
+
+		req := httptest.NewRequest(http.MethodGet, "/api/albums/1", nil)
+		req = req.WithContext(ctx)
+
+		w := httptest.NewRecorder()
+		hdl.routes().ServeHTTP(w, req)
+		assert.Equal(t, http.StatusNotFound, w.Result().StatusCode)
+	})
+
+	t.Run("InvalidAlbumID", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/api/albums/invalid", nil)
+		req = req.WithContext(ctx)
+
+		w := httptest.NewRecorder()
+		hdl.routes().ServeHTTP(w, req)
+		assert.Equal(t, http.StatusBadRequest, w.Result().StatusCode)
+	})
+}
+
+func TestHandler_ListAlbumTracks(t *testing.T) {
+	ctrlMock := gomock.NewController(t)
+	defer ctrlMock.Finish()
+
+	ctrlRepo := mocks.NewMockCtrl(ctrlMock)
+	hdl := New(ctrlRepo)
+
+	ctx := context.Background()
+
+	t.Run("Success", func(t *testing.T) {
+		ctrlRepo.EXPECT().ListAlbumTracks(ctx, uint64(1)).Return([]*model.Track{
+			{Disc: 1, TrackNumber: 1, Song: &model.Song{ID: 10, Song: "Mustapha"}},
+		}, nil).Times(1)
+
+		req := httptest.NewRequest(http.MethodGet, "/api/albums/1/tracks", nil)
+		req = req.WithContext(ctx)
+
+		w := httptest.NewRecorder()
+		hdl.routes().ServeHTTP(w, req)
+		assert.Equal(t, http.StatusOK, w.Result().StatusCode)
+
+		res := &struct {
+			Data []*model.Track `json:"data"`
+		}{}
+		assert.Nil(t, json.NewDecoder(w.Body).Decode(res))
+		assert.Equal(t, "Mustapha", res.Data[0].Song.Song)
+	})
+
+	t.Run("ErrNotFound", func(t *testing.T) {
+		ctrlRepo.EXPECT().ListAlbumTracks(ctx, uint64(1)).Return(nil, ctrl.ErrNotFound).Times(1)
+
+		req := httptest.NewRequest(http.MethodGet, "/api/albums/1/tracks", nil)
+		req = req.WithContext(ctx)
+
+		w := httptest.NewRecorder()
+		hdl.routes().ServeHTTP(w, req)
+		assert.Equal(t, http.StatusNotFound, w.Result().StatusCode)
+	})
+}
+
+func TestHandler_SetAlbumTrack(t *testing.T) {
+	ctrlMock := gomock.NewController(t)
+	defer ctrlMock.Finish()
+
+	ctrlRepo := mocks.NewMockCtrl(ctrlMock)
+	hdl := New(ctrlRepo)
+
+	ctx := context.Background()
+
+	t.Run("Success", func(t *testing.T) {
+		ctrlRepo.EXPECT().SetAlbumTrack(ctx, uint64(1), uint64(2), 1, 3).Return(nil).Times(1)
+
+		req := httptest.NewRequest(http.MethodPut, "/api/albums/1/tracks/2", bytes.NewBufferString(`{"track_number": 3}`))
+		req = req.WithContext(ctx)
+
+		w := httptest.NewRecorder()
+		hdl.routes().ServeHTTP(w, req)
+		assert.Equal(t, http.StatusOK, w.Result().StatusCode)
+	})
+
+	t.Run("ErrValidation", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodPut, "/api/albums/1/tracks/2", bytes.NewBufferString(`{"disc": -1}`))
+		req = req.WithContext(ctx)
+
+		w := httptest.NewRecorder()
+		hdl.routes().ServeHTTP(w, req)
+		assert.Equal(t, http.StatusBadRequest, w.Result().StatusCode)
+
+		res := &utils.Problem{}
+		assert.Nil(t, json.NewDecoder(w.Body).Decode(res))
+		assert.Len(t, res.Errors, 2)
+	})
+
+	t.Run("ErrMissingSongID", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodPut, "/api/albums/1/tracks/abc", bytes.NewBufferString(`{"track_number": 3}`))
+		req = req.WithContext(ctx)
+
+		w := httptest.NewRecorder()
+		hdl.routes().ServeHTTP(w, req)
+		assert.Equal(t, http.StatusBadRequest, w.Result().StatusCode)
+	})
+
+	t.Run("ErrNotFound", func(t *testing.T) {
+		ctrlRepo.EXPECT().SetAlbumTrack(ctx, uint64(9), uint64(2), 2, 3).Return(ctrl.ErrNotFound).Times(1)
+
+		req := httptest.NewRequest(http.MethodPut, "/api/albums/9/tracks/2", bytes.NewBufferString(`{"disc": 2, "track_number": 3}`))
+		req = req.WithContext(ctx)
+
+		w := httptest.NewRecorder()
+		hdl.routes().ServeHTTP(w, req)
+		assert.Equal(t, http.StatusNotFound, w.Result().StatusCode)
+	})
+
+	t.Run("ErrSongNotFound", func(t *testing.T) {
+		ctrlRepo.EXPECT().SetAlbumTrack(ctx, uint64(1), uint64(9), 1, 3).Return(ctrl.ErrSongNotFound).Times(1)
+
+		req := httptest.NewRequest(http.MethodPut, "/api/albums/1/tracks/9", bytes.NewBufferString(`{"track_number": 3}`))
+		req = req.WithContext(ctx)
+
+		w := httptest.NewRecorder()
+		hdl.routes().ServeHTTP(w, req)
+		assert.Equal(t, http.StatusBadRequest, w.Result().StatusCode)
+	})
+
+	t.Run("ErrTrackTaken", func(t *testing.T) {
+		ctrlRepo.EXPECT().SetAlbumTrack(ctx, uint64(1), uint64(2), 1, 1).Return(ctrl.ErrTrackTaken).Times(1)
+
+		req := httptest.NewRequest(http.MethodPut, "/api/albums/1/tracks/2", bytes.NewBufferString(`{"track_number": 1}`))
+		req = req.WithContext(ctx)
+
+		w := httptest.NewRecorder()
+		hdl.routes().ServeHTTP(w, req)
+		assert.Equal(t, http.StatusConflict, w.Result().StatusCode)
+	})
+}
+
+func TestHandler_RemoveAlbumTrack(t *testing.T) {
+	ctrlMock := gomock.NewController(t)
+	defer ctrlMock.Finish()
+
+	ctrlRepo := mocks.NewMockCtrl(ctrlMock)
+	hdl := New(ctrlRepo)
+
+	ctx := context.Background()
+
+	t.Run("Success", func(t *testing.T) {
+		ctrlRepo.EXPECT().RemoveAlbumTrack(ctx, uint64(1), uint64(2)).Return(nil).Times(1)
+
+		req := httptest.NewRequest(http.MethodDelete, "/api/albums/1/tracks/2", nil)
+		req = req.WithContext(ctx)
+
+		w := httptest.NewRecorder()
+		hdl.routes().ServeHTTP(w, req)
+		assert.Equal(t, http.StatusNoContent, w.Result().StatusCode)
+	})
+
+	t.Run("ErrNotFound", func(t *testing.T) {
+		ctrlRepo.EXPECT().RemoveAlbumTrack(ctx, uint64(1), uint64(9)).Return(ctrl.ErrNotFound).Times(1)
+
+		req := httptest.NewRequest(http.MethodDelete, "/api/albums/1/tracks/9", nil)
+		req = req.WithContext(ctx)
+
+		w := httptest.NewRecorder()
+		hdl.routes().ServeHTTP(w, req)
+		assert.Equal(t, http.StatusNotFound, w.Result().StatusCode)
+	})
+}
+
+func TestHandler_CreateAlbum(t *testing.T) {
+	ctrlMock := gomock.NewController(t)
+	defer ctrlMock.Finish()
+
+	ctrlRepo := mocks.NewMockCtrl(ctrlMock)
+	hdl := New(ctrlRepo)
+
+	ctx := context.Background()
+	releaseDate := time.Date(1978, 11, 10, 0, 0, 0, 0, time.UTC)
+	success := &model.Album{Artist: "Queen", Title: "Jazz", ReleaseDate: releaseDate}
+
+	t.Run("Success", func(t *testing.T) {
+		ctrlRepo.EXPECT().CreateAlbum(ctx, success).Return(&model.Album{ID: 1, ArtistID: 7, Artist: "Queen", Title: "Jazz"}, nil).Times(1)
+
+		req := httptest.NewRequest(http.MethodPost, "/api/albums", bytes.NewBufferString(`{"artist":" Queen","title":"Jazz ","release_date":"1978-11-10T00:00:00Z"}`))
+		req.Header.Set("Content-Type", "application/json")
+		req = req.WithContext(ctx)
+
+		w := httptest.NewRecorder()
+		hdl.CreateAlbum(w, req)
+		assert.Equal(t, http.StatusCreated, w.Result().StatusCode)
+	})
+
+	t.Run("ErrAlreadyExists", func(t *testing.T) {
+		ctrlRepo.EXPECT().CreateAlbum(ctx, success).Return(nil, ctrl.ErrAlreadyExists).Times(1)
+
+		payload, _ := json.Marshal(success)
+		req := httptest.NewRequest(http.MethodPost, "/api/albums", bytes.NewBuffer(payload))
+		req.Header.Set("Content-Type", "application/json")
+		req = req.WithContext(ctx)
+
+		w := httptest.NewRecorder()
+		hdl.CreateAlbum(w, req)
+		assert.Equal(t, http.StatusConflict, w.Result().StatusCode)
+	})
+
+	t.Run("ErrValidation", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodPost, "/api/albums", bytes.NewBufferString(`{"title":"Jazz","cover_link":"ftp://example.com/jazz.jpg"}`))
+		req.Header.Set("Content-Type", "application/json")
+		req = req.WithContext(ctx)
+
+		w := httptest.NewRecorder()
+		hdl.CreateAlbum(w, req)
+		assert.Equal(t, http.StatusBadRequest, w.Result().StatusCode)
+
+		res := &utils.Problem{}
+		assert.Nil(t, json.NewDecoder(w.Body).Decode(res))
+		assert.Len(t, res.Errors, 2)
+	})
+}
+
+func TestHandler_UpdateAlbum(t *testing.T) {
+	ctrlMock := gomock.NewController(t)
+	defer ctrlMock.Finish()
+
+	ctrlRepo := mocks.NewMockCtrl(ctrlMock)
+	hdl := New(ctrlRepo)
+
+	ctx := context.Background()
+	success := &model.Album{ID: 1, Artist: "Queen", Title: "Jazz"}
+
+	t.Run("Success", func(t *testing.T) {
+		ctrlRepo.EXPECT().UpdateAlbum(ctx, success).Return(nil).Times(1)
+
+		req := httptest.NewRequest(http.MethodPut, "/api/albums/1", bytes.NewBufferString(`{"artist":"Queen","title":"Jazz"}`))
+		req.Header.Set("Content-Type", "application/json")
+		req = req.WithContext(ctx)
+
+		w := httptest.NewRecorder()
+		hdl.routes().ServeHTTP(w, req)
+		assert.Equal(t, http.StatusOK, w.Result().StatusCode)
+	})
+
+	t.Run("ErrNotFound", func(t *testing.T) {
+		ctrlRepo.EXPECT().UpdateAlbum(ctx, success).Return(ctrl.ErrNotFound).Times(1)
+
+		req := httptest.NewRequest(http.MethodPut, "/api/albums/1", bytes.NewBufferString(`{"artist":"Queen","title":"Jazz"}`))
+		req.Header.Set("Content-Type", "application/json")
+		req = req.WithContext(ctx)
+
+		w := httptest.NewRecorder()
+		hdl.routes().ServeHTTP(w, req)
+		assert.Equal(t, http.StatusNotFound, w.Result().StatusCode)
+	})
+
+	t.Run("InvalidAlbumID", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodPut, "/api/albums/invalid", bytes.NewBufferString(`{"artist":"Queen","title":"Jazz"}`))
+		req.Header.Set("Content-Type", "application/json")
+		req = req.WithContext(ctx)
+
+		w := httptest.NewRecorder()
+		hdl.routes().ServeHTTP(w, req)
+		assert.Equal(t, http.StatusBadRequest, w.Result().StatusCode)
+	})
+}
+
+func TestHandler_DeleteAlbum(t *testing.T) {
+	ctrlMock := gomock.NewController(t)
+	defer ctrlMock.Finish()
+
+	ctrlRepo := mocks.NewMockCtrl(ctrlMock)
+	hdl := New(ctrlRepo)
+
+	ctx := context.Background()
+
+	t.Run("Success", func(t *testing.T) {
+		ctrlRepo.EXPECT().DeleteAlbum(ctx, uint64(1)).Return(nil).Times(1)
+
+		req := httptest.NewRequest(http.MethodDelete, "/api/albums/1", nil)
+		req = req.WithContext(ctx)
+
+		w := httptest.NewRecorder()
+		hdl.routes().ServeHTTP(w, req)
+		assert.Equal(t, http.StatusNoContent, w.Result().StatusCode)
+	})
+
+	t.Run("ErrNotFound", func(t *testing.T) {
+		ctrlRepo.EXPECT().DeleteAlbum(ctx, uint64(1)).Return(ctrl.ErrNotFound).Times(1)
+
+		req := httptest.NewRequest(http.MethodDelete, "/api/albums/1", nil)
+		req = req.WithContext(ctx)
+
+		w := httptest.NewRecorder()
+		hdl.routes().ServeHTTP(w, req)
+		assert.Equal(t, http.StatusNotFound, w.Result().StatusCode)
+	})
+}
@@ -0,0 +1,334 @@
+package http
+
+import (
+	"encoding/json"
+	"errors"
+	"github.com/JMURv/effectiveMobile/internal/ctrl"
+	"github.com/JMURv/effectiveMobile/internal/hdl"
+	"github.com/JMURv/effectiveMobile/internal/validation"
+	"github.com/JMURv/effectiveMobile/pkg/model"
+	utils "github.com/JMURv/effectiveMobile/pkg/utils/http"
+	"github.com/JMURv/effectiveMobile/pkg/utils/logger"
+	"go.uber.org/zap"
+	"net/http"
+	"strconv"
+)
+
+type ArtistRequest struct {
+	Name string `json:"name"`
+}
+
+// ListArtists
+// @Summary Список исполнителей
+// @Description Получить список исполнителей, отсортированный по имени
+// @Tags artists
+// @Param page query int false "Номер страницы" default(1)
+// @Param size query int false "Размер страницы" default(40)
+// @Param name query string false "Фильтр по имени исполнителя"
+// @Success 200 {object} model.PaginatedArtists "Список исполнителей с пагинацией"
+// @Failure 400 {object} utils.Problem "Некорректный номер или размер страницы"
+// @Security ApiKeyAuth
+// @Security BearerAuth
+// @Failure 401 {object} utils.Problem "Требуется аутентификация"
+// @Failure 403 {object} utils.Problem "Недостаточно прав, требуется роль reader"
+// @Failure 500 {object} utils.Problem "Внутренняя ошибка сервера"
+// @Router /api/artists [get]
+func (h *Handler) ListArtists(w http.ResponseWriter, r *http.Request) {
+	const op = "songs.ListArtists.hdl"
+
+	page, err := strconv.Atoi(r.URL.Query().Get("page"))
+	if err != nil {
+		page = 1
+	}
+
+	size, err := strconv.Atoi(r.URL.Query().Get("size"))
+	if err != nil {
+		size = 40
+	}
+
+	if err := validation.ValidatePage(page, size); err != nil {
+		logger.FromContext(r.Context()).Debug(
+			"failed to validate page",
+			zap.Error(err), zap.String("op", op),
+		)
+		utils.ErrResponse(w, r, http.StatusBadRequest, err)
+		return
+	}
+
+	res, err := h.ctrl.ListArtists(r.Context(), page, size, r.URL.Query().Get("name"))
+	if err != nil {
+		utils.ErrResponse(w, r, http.StatusInternalServerError, hdl.ErrInternal)
+		return
+	}
+
+	utils.SuccessPaginatedResponse(w, http.StatusOK, res)
+}
+
+// GetArtist
+// @Summary Получить исполнителя по ID
+// @Tags artists
+// @Param id path int true "ID исполнителя"
+// @Success 200 {object} model.Artist "Исполнитель"
+// @Failure 400 {object} utils.Problem "Некорректный запрос"
+// @Failure 404 {object} utils.Problem "Исполнитель не найден"
+// @Security ApiKeyAuth
+// @Security BearerAuth
+// @Failure 401 {object} utils.Problem "Требуется аутентификация"
+// @Failure 403 {object} utils.Problem "Недостаточно прав, требуется роль reader"
+// @Failure 500 {object} utils.Problem "Внутренняя ошибка сервера"
+// @Router /api/artists/{id} [get]
+func (h *Handler) GetArtist(w http.ResponseWriter, r *http.Request) {
+	const op = "songs.GetArtist.hdl"
+
+	id, err := strconv.ParseUint(r.PathValue("id"), 10, 64)
+	if err != nil {
+		logger.FromContext(r.Context()).Debug(
+			"failed to extract param",
+			zap.Error(err), zap.String("op", op),
+		)
+		utils.ErrResponse(w, r, http.StatusBadRequest, hdl.ErrMissingArtistID)
+		return
+	}
+
+	res, err := h.ctrl.GetArtist(r.Context(), id)
+	if err != nil && errors.Is(err, ctrl.ErrNotFound) {
+		utils.ErrResponse(w, r, http.StatusNotFound, err)
+		return
+	} else if err != nil {
+		utils.ErrResponse(w, r, http.StatusInternalServerError, hdl.ErrInternal)
+		return
+	}
+
+	utils.SuccessResponse(w, http.StatusOK, res)
+}
+
+// ListArtistSongs
+// @Summary Песни исполнителя
+// @Description Получить песни исполнителя с теми же фильтрами и сортировкой, что и в списке песен
+// @Tags artists
+// @Param id path int true "ID исполнителя"
+// @Param page query int false "Номер страницы" default(1)
+// @Param size query int false "Размер страницы" default(40)
+// @Param song query string false "Фильтр по названию песни"
+// @Param sort query string false "Сортировка через запятую, '-' перед полем - по убыванию" example(-release_date)
+// @Success 200 {object} model.PaginatedSongs "Список песен с пагинацией"
+// @Failure 400 {object} utils.Problem "Некорректный запрос"
+// @Failure 404 {object} utils.Problem "Исполнитель не найден"
+// @Security ApiKeyAuth
+// @Security BearerAuth
+// @Failure 401 {object} utils.Problem "Требуется аутентификация"
+// @Failure 403 {object} utils.Problem "Недостаточно прав, требуется роль reader"
+// @Failure 500 {object} utils.Problem "Внутренняя ошибка сервера"
+// @Router /api/artists/{id}/songs [get]
+func (h *Handler) ListArtistSongs(w http.ResponseWriter, r *http.Request) {
+	const op = "songs.ListArtistSongs.hdl"
+
+	id, err := strconv.ParseUint(r.PathValue("id"), 10, 64)
+	if err != nil {
+		logger.FromContext(r.Context()).Debug(
+			"failed to extract param",
+			zap.Error(err), zap.String("op", op),
+		)
+		utils.ErrResponse(w, r, http.StatusBadRequest, hdl.ErrMissingArtistID)
+		return
+	}
+
+	if err := validation.ValidateSort(r.URL.Query().Get("sort")); err != nil {
+		logger.FromContext(r.Context()).Debug(
+			"failed to validate sort",
+			zap.Error(err), zap.String("op", op),
+		)
+		utils.ErrResponse(w, r, http.StatusBadRequest, err)
+		return
+	}
+
+	page, err := strconv.Atoi(r.URL.Query().Get("page"))
+	if err != nil {
+		page = 1
+	}
+
+	size, err := strconv.Atoi(r.URL.Query().Get("size"))
+	if err != nil {
+		size = 40
+	}
+
+	if err := validation.ValidatePage(page, size); err != nil {
+		logger.FromContext(r.Context()).Debug(
+			"failed to validate page",
+			zap.Error(err), zap.String("op", op),
+		)
+		utils.ErrResponse(w, r, http.StatusBadRequest, err)
+		return
+	}
+
+	res, err := h.ctrl.ListArtistSongs(r.Context(), id, page, size, utils.ParseFiltersByURL(r))
+	if err != nil && errors.Is(err, ctrl.ErrNotFound) {
+		utils.ErrResponse(w, r, http.StatusNotFound, err)
+		return
+	} else if err != nil {
+		utils.ErrResponse(w, r, http.StatusInternalServerError, hdl.ErrInternal)
+		return
+	}
+
+	utils.SuccessPaginatedResponse(w, http.StatusOK, res)
+}
+
+// CreateArtist
+// @Summary Добавить исполнителя
+// @Tags artists
+// @Accept json
+// @Param artist body ArtistRequest true "Данные исполнителя"
+// @Success 201 {object} model.Artist "Добавленный исполнитель"
+// @Failure 400 {object} utils.Problem "Ошибка валидации или декодирования запроса"
+// @Failure 409 {object} utils.Problem "Исполнитель уже существует"
+// @Security ApiKeyAuth
+// @Security BearerAuth
+// @Failure 401 {object} utils.Problem "Требуется аутентификация"
+// @Failure 403 {object} utils.Problem "Недостаточно прав, требуется роль editor"
+// @Failure 500 {object} utils.Problem "Внутренняя ошибка сервера"
+// @Router /api/artists [post]
+func (h *Handler) CreateArtist(w http.ResponseWriter, r *http.Request) {
+	const op = "songs.CreateArtist.hdl"
+
+	req := &model.Artist{}
+	if err := json.NewDecoder(r.Body).Decode(req); err != nil {
+		logger.FromContext(r.Context()).Debug(
+			"failed to decode request",
+			zap.Error(err), zap.String("op", op),
+		)
+		utils.ErrResponse(w, r, http.StatusBadRequest, hdl.ErrDecodeRequest)
+		return
+	}
+
+	req.Name = validation.NormalizeName(req.Name)
+	if err := validation.ValidateArtist(req); err != nil {
+		logger.FromContext(r.Context()).Debug(
+			"failed to validate request",
+			zap.Error(err), zap.String("op", op),
+			zap.Any("req", req),
+		)
+		utils.ErrResponse(w, r, http.StatusBadRequest, err)
+		return
+	}
+
+	res, err := h.ctrl.CreateArtist(r.Context(), req)
+	if err != nil && errors.Is(err, ctrl.ErrAlreadyExists) {
+		utils.ErrResponse(w, r, http.StatusConflict, err)
+		return
+	} else if err != nil {
+		utils.ErrResponse(w, r, http.StatusInternalServerError, hdl.ErrInternal)
+		return
+	}
+
+	utils.SuccessResponse(w, http.StatusCreated, res)
+}
+
+// UpdateArtist
+// @Summary Переименовать исполнителя
+// @Description Новое имя применяется и к группе всех песен исполнителя
+// @Tags artists
+// @Accept json
+// @Param id path int true "ID исполнителя"
+// @Param artist body ArtistRequest true "Новые данные исполнителя"
+// @Success 200 {object} string "Исполнитель успешно обновлён"
+// @Failure 400 {object} utils.Problem "Ошибка валидации или декодирования запроса"
+// @Failure 404 {object} utils.Problem "Исполнитель не найден"
+// @Failure 409 {object} utils.Problem "Исполнитель с таким именем уже существует"
+// @Security ApiKeyAuth
+// @Security BearerAuth
+// @Failure 401 {object} utils.Problem "Требуется аутентификация"
+// @Failure 403 {object} utils.Problem "Недостаточно прав, требуется роль editor"
+// @Failure 500 {object} utils.Problem "Внутренняя ошибка сервера"
+// @Router /api/artists/{id} [put]
+func (h *Handler) UpdateArtist(w http.ResponseWriter, r *http.Request) {
+	const op = "songs.UpdateArtist.hdl"
+
+	id, err := strconv.ParseUint(r.PathValue("id"), 10, 64)
+	if err != nil {
+		logger.FromContext(r.Context()).Debug(
+			"failed to extract param",
+			zap.Error(err), zap.String("op", op),
+		)
+		utils.ErrResponse(w, r, http.StatusBadRequest, hdl.ErrMissingArtistID)
+		return
+	}
+
+	req := &model.Artist{ID: id}
+	if err := json.NewDecoder(r.Body).Decode(req); err != nil {
+		logger.FromContext(r.Context()).Debug(
+			"failed to decode request",
+			zap.Error(err), zap.String("op", op),
+		)
+		utils.ErrResponse(w, r, http.StatusBadRequest, hdl.ErrDecodeRequest)
+		return
+	}
+	req.ID = id
+
+	req.Name = validation.NormalizeName(req.Name)
+	if err := validation.ValidateArtist(req); err != nil {
+		logger.FromContext(r.Context()).Debug(
+			"failed to validate request",
+			zap.Error(err), zap.String("op", op),
+			zap.Any("req", req),
+		)
+		utils.ErrResponse(w, r, http.StatusBadRequest, err)
+		return
+	}
+
+	err = h.ctrl.UpdateArtist(r.Context(), req)
+	if err != nil && errors.Is(err, ctrl.ErrNotFound) {
+		utils.ErrResponse(w, r, http.StatusNotFound, err)
+		return
+	} else if err != nil && errors.Is(err, ctrl.ErrAlreadyExists) {
+		utils.ErrResponse(w, r, http.StatusConflict, err)
+		return
+	} else if err != nil {
+		utils.ErrResponse(w, r, http.StatusInternalServerError, hdl.ErrInternal)
+		return
+	}
+
+	utils.SuccessResponse(w, http.StatusOK, "OK")
+}
+
+// DeleteArtist
+// @Summary Удалить исполнителя
+// @Description Удалить можно только исполнителя без песен и альбомов
+// @Tags artists
+// @Param id path int true "ID исполнителя"
+// @Success 204 {object} string "Исполнитель успешно удалён"
+// @Failure 400 {object} utils.Problem "Некорректный запрос"
+// @Failure 404 {object} utils.Problem "Исполнитель не найден"
+// @Failure 409 {object} utils.Problem "У исполнителя есть песни или альбомы"
+// @Security ApiKeyAuth
+// @Security BearerAuth
+// @Failure 401 {object} utils.Problem "Требуется аутентификация"
+// @Failure 403 {object} utils.Problem "Недостаточно прав, требуется роль admin"
+// @Failure 500 {object} utils.Problem "Внутренняя ошибка сервера"
+// @Router /api/artists/{id} [delete]
+func (h *Handler) DeleteArtist(w http.ResponseWriter, r *http.Request) {
+	const op = "songs.DeleteArtist.hdl"
+
+	id, err := strconv.ParseUint(r.PathValue("id"), 10, 64)
+	if err != nil {
+		logger.FromContext(r.Context()).Debug(
+			"failed to extract param",
+			zap.Error(err), zap.String("op", op),
+		)
+		utils.ErrResponse(w, r, http.StatusBadRequest, hdl.ErrMissingArtistID)
+		return
+	}
+
+	err = h.ctrl.DeleteArtist(r.Context(), id)
+	if err != nil && errors.Is(err, ctrl.ErrNotFound) {
+		utils.ErrResponse(w, r, http.StatusNotFound, err)
+		return
+	} else if err != nil && errors.Is(err, ctrl.ErrArtistInUse) {
+		utils.ErrResponse(w, r, http.StatusConflict, err)
+		return
+	} else if err != nil {
+		utils.ErrResponse(w, r, http.StatusInternalServerError, hdl.ErrInternal)
+		return
+	}
+
+	utils.SuccessResponse(w, http.StatusNoContent, "OK")
+}
@@ -0,0 +1,330 @@
+package http
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"github.com/JMURv/effectiveMobile/internal/ctrl"
+	"github.com/JMURv/effectiveMobile/mocks"
+	"github.com/JMURv/effectiveMobile/pkg/model"
+	utils "github.com/JMURv/effectiveMobile/pkg/utils/http"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestHandler_ListArtists(t *testing.T) {
+	ctrlMock := gomock.NewController(t)
+	defer ctrlMock.Finish()
+
+	ctrlRepo := mocks.NewMockCtrl(ctrlMock)
+	hdl := New(ctrlRepo)
+
+	ctx := context.Background()
+
+	t.Run("Success", func(t *testing.T) {
+		ctrlRepo.EXPECT().ListArtists(ctx, 2, 10, "queen").Return(&model.PaginatedArtists{
+			Data:        []*model.Artist{{ID: 1, Name: "Queen"}},
+			Count:       11,
+			TotalPages:  2,
+			CurrentPage: 2,
+		}, nil).Times(1)
+
+		req := httptest.NewRequest(http.MethodGet, "/api/artists?page=2&size=10&name=queen", nil)
+		req = req.WithContext(ctx)
+
+		w := httptest.NewRecorder()
+		hdl.routes().ServeHTTP(w, req)
+		assert.Equal(t, http.StatusOK, w.Result().StatusCode)
+
+		res := &model.PaginatedArtists{}
+		assert.Nil(t, json.NewDecoder(w.Body).Decode(res))
+		assert.Equal(t, "Queen", res.Data[0].Name)
+	})
+
+	t.Run("ErrInternalError", func(t *testing.T) {
+		ctrlRepo.EXPECT().ListArtists(ctx, 1, 40, "").Return(nil, errors.New("other error")).Times(1)
+
+		req := httptest.NewRequest(http.MethodGet, "/api/artists", nil)
+		req = req.WithContext(ctx)
+
+		w := httptest.NewRecorder()
+		hdl.routes().ServeHTTP(w, req)
+		assert.Equal(t, http.StatusInternalServerError, w.Result().StatusCode)
+	})
+
+	t.Run("InvalidPage", func(t *testing.T) {
+		for _, query := range []string{"size=0", "size=-1", "page=0"} {
+			req := httptest.NewRequest(http.MethodGet, "/api/artists?"+query, nil)
+			req = req.WithContext(ctx)
+
+			w := httptest.NewRecorder()
+			hdl.routes().ServeHTTP(w, req)
+			assert.Equal(t, http.StatusBadRequest, w.Result().StatusCode, query)
+		}
+	})
+}
+
+func TestHandler_GetArtist(t *testing.T) {
+	ctrlMock := gomock.NewController(t)
+	defer ctrlMock.Finish()
+
+	ctrlRepo := mocks.NewMockCtrl(ctrlMock)
+	hdl := New(ctrlRepo)
+
+	ctx := context.Background()
+
+	t.Run("Success", func(t *testing.T) {
+		ctrlRepo.EXPECT().GetArtist(ctx, uint64(1)).Return(&model.Artist{ID: 1, Name: "Queen"}, nil).Times(1)
+
+		req := httptest.NewRequest(http.MethodGet, "/api/artists/1", nil)
+		req = req.WithContext(ctx)
+
+		w := httptest.NewRecorder()
+		hdl.routes().ServeHTTP(w, req)
+		assert.Equal(t, http.StatusOK, w.Result().StatusCode)
+	})
+
+	t.Run("ErrNotFound", func(t *testing.T) {
+		ctrlRepo.EXPECT().GetArtist(ctx, uint64(1)).Return(nil, ctrl.ErrNotFound).Times(1)
+
+		req := httptest.NewRequest(http.MethodGet, "/api/artists/1", nil)
+		req = req.WithContext(ctx)
+
+		w := httptest.NewRecorder()
+		hdl.routes().ServeHTTP(w, req)
+		assert.Equal(t, http.StatusNotFound, w.Result().StatusCode)
+	})
+
+	t.Run("InvalidArtistID", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/api/artists/invalid", nil)
+		req = req.WithContext(ctx)
+
+		w := httptest.NewRecorder()
+		hdl.routes().ServeHTTP(w, req)
+		assert.Equal(t, http.StatusBadRequest, w.Result().StatusCode)
+	})
+}
+
+func TestHandler_ListArtistSongs(t *testing.T) {
+	ctrlMock := gomock.NewController(t)
+	defer ctrlMock.Finish()
+
+	ctrlRepo := mocks.NewMockCtrl(ctrlMock)
+	hdl := New(ctrlRepo)
+
+	ctx := context.Background()
+
+	t.Run("Success", func(t *testing.T) {
+		ctrlRepo.EXPECT().
+			ListArtistSongs(ctx, uint64(1), 1, 40, map[string]any{"song": "bohemian"}).
+			Return(&model.PaginatedSongs{Data: []*model.Song{{ID: 5, ArtistID: 1}}}, nil).
+			Times(1)
+
+		req := httptest.NewRequest(http.MethodGet, "/api/artists/1/songs?song=bohemian", nil)
+		req = req.WithContext(ctx)
+
+		w := httptest.NewRecorder()
+		hdl.routes().ServeHTTP(w, req)
+		assert.Equal(t, http.StatusOK, w.Result().StatusCode)
+	})
+
+	t.Run("ErrNotFound", func(t *testing.T) {
+		ctrlRepo.EXPECT().
+			ListArtistSongs(ctx, uint64(1), 1, 40, map[string]any{}).
+			Return(nil, ctrl.ErrNotFound).
+			Times(1)
+
+		req := httptest.NewRequest(http.MethodGet, "/api/artists/1/songs", nil)
+		req = req.WithContext(ctx)
+
+		w := httptest.NewRecorder()
+		hdl.routes().ServeHTTP(w, req)
+		assert.Equal(t, http.StatusNotFound, w.Result().StatusCode)
+	})
+
+	t.Run("InvalidSort", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/api/artists/1/songs?sort=unknown", nil)
+		req = req.WithContext(ctx)
+
+		w := httptest.NewRecorder()
+		hdl.routes().ServeHTTP(w, req)
+		assert.Equal(t, http.StatusBadRequest, w.Result().StatusCode)
+	})
+
+	t.Run("InvalidPage", func(t *testing.T) {
+		for _, query := range []string{"size=0", "size=-1", "page=0"} {
+			req := httptest.NewRequest(http.MethodGet, "/api/artists/1/songs?"+query, nil)
+			req = req.WithContext(ctx)
+
+			w := httptest.NewRecorder()
+			hdl.routes().ServeHTTP(w, req)
+			assert.Equal(t, http.StatusBadRequest, w.Result().StatusCode, query)
+		}
+	})
+}
+
+func TestHandler_CreateArtist(t *testing.T) {
+	ctrlMock := gomock.NewController(t)
+	defer ctrlMock.Finish()
+
+	ctrlRepo := mocks.NewMockCtrl(ctrlMock)
+	hdl := New(ctrlRepo)
+
+	ctx := context.Background()
+
+	t.Run("Success", func(t *testing.T) {
+		ctrlRepo.EXPECT().
+			CreateArtist(ctx, &model.Artist{Name: "Queen"}).
+			Return(&model.Artist{ID: 1, Name: "Queen"}, nil).
+			Times(1)
+
+		req := httptest.NewRequest(http.MethodPost, "/api/artists", bytes.NewBufferString(`{"name":"  Queen "}`))
+		req.Header.Set("Content-Type", "application/json")
+		req = req.WithContext(ctx)
+
+		w := httptest.NewRecorder()
+		hdl.CreateArtist(w, req)
+		assert.Equal(t, http.StatusCreated, w.Result().StatusCode)
+	})
+
+	t.Run("ErrAlreadyExists", func(t *testing.T) {
+		ctrlRepo.EXPECT().CreateArtist(ctx, &model.Artist{Name: "Queen"}).Return(nil, ctrl.ErrAlreadyExists).Times(1)
+
+		req := httptest.NewRequest(http.MethodPost, "/api/artists", bytes.NewBufferString(`{"name":"Queen"}`))
+		req.Header.Set("Content-Type", "application/json")
+		req = req.WithContext(ctx)
+
+		w := httptest.NewRecorder()
+		hdl.CreateArtist(w, req)
+		assert.Equal(t, http.StatusConflict, w.Result().StatusCode)
+	})
+
+	t.Run("ErrMissingName", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodPost, "/api/artists", bytes.NewBufferString(`{"name":"   "}`))
+		req.Header.Set("Content-Type", "application/json")
+		req = req.WithContext(ctx)
+
+		w := httptest.NewRecorder()
+		hdl.CreateArtist(w, req)
+		assert.Equal(t, http.StatusBadRequest, w.Result().StatusCode)
+
+		res := &utils.Problem{}
+		assert.Nil(t, json.NewDecoder(w.Body).Decode(res))
+		assert.Equal(t, utils.ValidationProblemType, res.Type)
+		assert.Equal(t, "/name", res.Errors[0].Pointer)
+	})
+
+	t.Run("ErrDecodeRequest", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodPost, "/api/artists", bytes.NewBufferString(`{`))
+		req.Header.Set("Content-Type", "application/json")
+		req = req.WithContext(ctx)
+
+		w := httptest.NewRecorder()
+		hdl.CreateArtist(w, req)
+		assert.Equal(t, http.StatusBadRequest, w.Result().StatusCode)
+	})
+}
+
+func TestHandler_UpdateArtist(t *testing.T) {
+	ctrlMock := gomock.NewController(t)
+	defer ctrlMock.Finish()
+
+	ctrlRepo := mocks.NewMockCtrl(ctrlMock)
+	hdl := New(ctrlRepo)
+
+	ctx := context.Background()
+	success := &model.Artist{ID: 1, Name: "Queen"}
+
+	t.Run("Success", func(t *testing.T) {
+		ctrlRepo.EXPECT().UpdateArtist(ctx, success).Return(nil).Times(1)
+
+		req := httptest.NewRequest(http.MethodPut, "/api/artists/1", bytes.NewBufferString(`{"id":5,"name":"Queen"}`))
+		req.Header.Set("Content-Type", "application/json")
+		req = req.WithContext(ctx)
+
+		w := httptest.NewRecorder()
+		hdl.routes().ServeHTTP(w, req)
+		assert.Equal(t, http.StatusOK, w.Result().StatusCode)
+	})
+
+	t.Run("ErrNotFound", func(t *testing.T) {
+		ctrlRepo.EXPECT().UpdateArtist(ctx, success).Return(ctrl.ErrNotFound).Times(1)
+
+		req := httptest.NewRequest(http.MethodPut, "/api/artists/1", bytes.NewBufferString(`{"name":"Queen"}`))
+		req.Header.Set("Content-Type", "application/json")
+		req = req.WithContext(ctx)
+
+		w := httptest.NewRecorder()
+		hdl.routes().ServeHTTP(w, req)
+		assert.Equal(t, http.StatusNotFound, w.Result().StatusCode)
+	})
+
+	t.Run("ErrAlreadyExists", func(t *testing.T) {
+		ctrlRepo.EXPECT().UpdateArtist(ctx, success).Return(ctrl.ErrAlreadyExists).Times(1)
+
+		req := httptest.NewRequest(http.MethodPut, "/api/artists/1", bytes.NewBufferString(`{"name":"Queen"}`))
+		req.Header.Set("Content-Type", "application/json")
+		req = req.WithContext(ctx)
+
+		w := httptest.NewRecorder()
+		hdl.routes().ServeHTTP(w, req)
+		assert.Equal(t, http.StatusConflict, w.Result().StatusCode)
+	})
+
+	t.Run("InvalidArtistID", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodPut, "/api/artists/invalid", bytes.NewBufferString(`{"name":"Queen"}`))
+		req.Header.Set("Content-Type", "application/json")
+		req = req.WithContext(ctx)
+
+		w := httptest.NewRecorder()
+		hdl.routes().ServeHTTP(w, req)
+		assert.Equal(t, http.StatusBadRequest, w.Result().StatusCode)
+	})
+}
+
+func TestHandler_DeleteArtist(t *testing.T) {
+	ctrlMock := gomock.NewController(t)
+	defer ctrlMock.Finish()
+
+	ctrlRepo := mocks.NewMockCtrl(ctrlMock)
+	hdl := New(ctrlRepo)
+
+	ctx := context.Background()
+
+	t.Run("Success", func(t *testing.T) {
+		ctrlRepo.EXPECT().DeleteArtist(ctx, uint64(1)).Return(nil).Times(1)
+
+		req := httptest.NewRequest(http.MethodDelete, "/api/artists/1", nil)
+		req = req.WithContext(ctx)
+
+		w := httptest.NewRecorder()
+		hdl.routes().ServeHTTP(w, req)
+		assert.Equal(t, http.StatusNoContent, w.Result().StatusCode)
+	})
+
+	t.Run("ErrArtistInUse", func(t *testing.T) {
+		ctrlRepo.EXPECT().DeleteArtist(ctx, uint64(1)).Return(ctrl.ErrArtistInUse).Times(1)
+
+		req := httptest.NewRequest(http.MethodDelete, "/api/artists/1", nil)
+		req = req.WithContext(ctx)
+
+		w := httptest.NewRecorder()
+		hdl.routes().ServeHTTP(w, req)
+		assert.Equal(t, http.StatusConflict, w.Result().StatusCode)
+	})
+
+	t.Run("ErrInternalError", func(t *testing.T) {
+		ctrlRepo.EXPECT().DeleteArtist(ctx, uint64(1)).Return(errors.New("other error")).Times(1)
+
+		req := httptest.NewRequest(http.MethodDelete, "/api/artists/1", nil)
+		req = req.WithContext(ctx)
+
+		w := httptest.NewRecorder()
+		hdl.routes().ServeHTTP(w, req)
+		assert.Equal(t, http.StatusInternalServerError, w.Result().StatusCode)
+	})
+}
@@ -0,0 +1,73 @@
+package http
+
+import (
+	"errors"
+	"github.com/JMURv/effectiveMobile/internal/auth"
+	"github.com/JMURv/effectiveMobile/internal/hdl"
+	utils "github.com/JMURv/effectiveMobile/pkg/utils/http"
+	"github.com/JMURv/effectiveMobile/pkg/utils/logger"
+	"go.uber.org/zap"
+	"net/http"
+	"strings"
+)
+
+const apiKeyHeader = "X-API-Key"
+
+// require lets the request through only for callers with at least the given role.
+// The caller is taken from the X-API-Key header or an Authorization bearer token
+func (h *Handler) require(role auth.Role, next http.HandlerFunc) http.HandlerFunc {
+	const op = "songs.require.hdl"
+
+	return func(w http.ResponseWriter, r *http.Request) {
+		if h.auth == nil {
+			next(w, r)
+			return
+		}
+
+		p, err := h.principal(r)
+		if err != nil && errors.Is(err, hdl.ErrUnauthorized) {
+			w.Header().Set("WWW-Authenticate", "Bearer")
+			utils.ErrResponse(w, r, http.StatusUnauthorized, err)
+			return
+		} else if err != nil {
+			logger.FromContext(r.Context()).Debug(
+				"failed to authenticate",
+				zap.Error(err), zap.String("op", op),
+			)
+			w.Header().Set("WWW-Authenticate", `Bearer error="invalid_token"`)
+			utils.ErrResponse(w, r, http.StatusUnauthorized, auth.ErrInvalidCredentials)
+			return
+		}
+
+		if p.Role < role {
+			logger.FromContext(r.Context()).Debug(
+				"insufficient role",
+				zap.String("op", op), zap.String("subject", p.Subject),
+				zap.Stringer("role", p.Role), zap.Stringer("required", role),
+			)
+			utils.ErrResponse(w, r, http.StatusForbidden, hdl.ErrForbidden)
+			return
+		}
+
+		ctx := auth.WithPrincipal(r.Context(), p)
+		ctx = logger.WithLogger(ctx, logger.FromContext(ctx).With(zap.String("subject", p.Subject)))
+		next(w, r.WithContext(ctx))
+	}
+}
+
+func (h *Handler) principal(r *http.Request) (*auth.Principal, error) {
+	if key := r.Header.Get(apiKeyHeader); key != "" {
+		return h.auth.APIKey(key)
+	}
+
+	header := r.Header.Get("Authorization")
+	if header == "" {
+		return nil, hdl.ErrUnauthorized
+	}
+
+	scheme, token, ok := strings.Cut(header, " ")
+	if !ok || !strings.EqualFold(scheme, "Bearer") || token == "" {
+		return nil, auth.ErrInvalidCredentials
+	}
+	return h.auth.Token(token)
+}
@@ -0,0 +1,122 @@
+package http
+
+import (
+	"encoding/json"
+	"github.com/JMURv/effectiveMobile/internal/auth"
+	"github.com/JMURv/effectiveMobile/internal/hdl"
+	"github.com/JMURv/effectiveMobile/mocks"
+	cfg "github.com/JMURv/effectiveMobile/pkg/config"
+	utils "github.com/JMURv/effectiveMobile/pkg/utils/http"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestHandler_Auth(t *testing.T) {
+	ctrlMock := gomock.NewController(t)
+	defer ctrlMock.Finish()
+
+	authn, err := auth.New(&cfg.AuthConfig{
+		APIKeys:   map[string]string{"reader-key": "reader", "admin-key": "admin"},
+		JWTSecret: "secret",
+		RoleClaim: "role",
+	})
+	require.Nil(t, err)
+
+	mctrl := mocks.NewMockCtrl(ctrlMock)
+	h := New(mctrl)
+	h.UseAuth(authn)
+	h.Mount("GET /metrics", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	routes := h.routes()
+
+	serve := func(method, target string, header map[string]string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(method, target, nil)
+		for k, v := range header {
+			req.Header.Set(k, v)
+		}
+		w := httptest.NewRecorder()
+		routes.ServeHTTP(w, req)
+		return w
+	}
+
+	assertErr := func(t *testing.T, w *httptest.ResponseRecorder, status int, want error) {
+		assert.Equal(t, status, w.Result().StatusCode)
+		res := &utils.Problem{}
+		assert.Nil(t, json.NewDecoder(w.Body).Decode(res))
+		assert.Equal(t, want.Error(), res.Detail)
+	}
+
+	t.Run("Public", func(t *testing.T) {
+		w := serve(http.MethodGet, "/api/health-check", nil)
+		assert.Equal(t, http.StatusOK, w.Result().StatusCode)
+	})
+
+	t.Run("Missing", func(t *testing.T) {
+		w := serve(http.MethodDelete, "/api/songs/1", nil)
+		assertErr(t, w, http.StatusUnauthorized, hdl.ErrUnauthorized)
+		assert.Equal(t, "Bearer", w.Header().Get("WWW-Authenticate"))
+	})
+
+	t.Run("InvalidKey", func(t *testing.T) {
+		w := serve(http.MethodGet, "/api/songs/1", map[string]string{apiKeyHeader: "other"})
+		assertErr(t, w, http.StatusUnauthorized, auth.ErrInvalidCredentials)
+	})
+
+	t.Run("InvalidScheme", func(t *testing.T) {
+		w := serve(http.MethodGet, "/api/songs/1", map[string]string{"Authorization": "Basic dXNlcjpwYXNz"})
+		assertErr(t, w, http.StatusUnauthorized, auth.ErrInvalidCredentials)
+	})
+
+	t.Run("InsufficientRole", func(t *testing.T) {
+		w := serve(http.MethodDelete, "/api/songs/1", map[string]string{apiKeyHeader: "reader-key"})
+		assertErr(t, w, http.StatusForbidden, hdl.ErrForbidden)
+	})
+
+	t.Run("AdminKey", func(t *testing.T) {
+		mctrl.EXPECT().DeleteSong(gomock.Any(), uint64(1)).Return(nil)
+		w := serve(http.MethodDelete, "/api/songs/1", map[string]string{apiKeyHeader: "admin-key"})
+		assert.Equal(t, http.StatusNoContent, w.Result().StatusCode)
+	})
+
+	t.Run("EditorToken", func(t *testing.T) {
+		token, err := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{
+			"sub":  "user-1",
+			"exp":  time.Now().Add(time.Hour).Unix(),
+			"role": "editor",
+		}).SignedString([]byte("secret"))
+		require.Nil(t, err)
+
+		mctrl.EXPECT().RefreshSong(gomock.Any(), uint64(1)).Return(nil)
+		w := serve(http.MethodPost, "/api/songs/1/refresh", map[string]string{"Authorization": "Bearer " + token})
+		assert.Equal(t, http.StatusAccepted, w.Result().StatusCode)
+
+		w = serve(http.MethodDelete, "/api/songs/1", map[string]string{"Authorization": "Bearer " + token})
+		assertErr(t, w, http.StatusForbidden, hdl.ErrForbidden)
+	})
+
+	t.Run("Swagger", func(t *testing.T) {
+		w := serve(http.MethodGet, "/swagger/index.html", nil)
+		assertErr(t, w, http.StatusUnauthorized, hdl.ErrUnauthorized)
+
+		w = serve(http.MethodGet, "/swagger/index.html", map[string]string{apiKeyHeader: "reader-key"})
+		assert.Equal(t, http.StatusOK, w.Result().StatusCode)
+	})
+
+	t.Run("Mount", func(t *testing.T) {
+		w := serve(http.MethodGet, "/metrics", nil)
+		assertErr(t, w, http.StatusUnauthorized, hdl.ErrUnauthorized)
+
+		w = serve(http.MethodGet, "/metrics", map[string]string{apiKeyHeader: "reader-key"})
+		assertErr(t, w, http.StatusForbidden, hdl.ErrForbidden)
+
+		w = serve(http.MethodGet, "/metrics", map[string]string{apiKeyHeader: "admin-key"})
+		assert.Equal(t, http.StatusOK, w.Result().StatusCode)
+	})
+}
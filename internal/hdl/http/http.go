@@ -4,68 +4,102 @@ import (
 	"context"
 	"fmt"
 	_ "github.com/JMURv/effectiveMobile/docs"
-	"github.com/JMURv/effectiveMobile/internal/hdl"
+	"github.com/JMURv/effectiveMobile/internal/auth"
 	"github.com/JMURv/effectiveMobile/pkg/model"
 	utils "github.com/JMURv/effectiveMobile/pkg/utils/http"
 	httpSwagger "github.com/swaggo/http-swagger"
 	"go.uber.org/zap"
+	"golang.org/x/text/language"
 	"net/http"
 	"time"
 )
 
 type Ctrl interface {
 	ListSongs(ctx context.Context, page int, size int, filters map[string]any) (*model.PaginatedSongs, error)
-	GetSong(ctx context.Context, id uint64, page int, size int) (*model.PaginatedSongs, error)
+	ListSongsCursor(ctx context.Context, cursor string, limit int, withCount bool, filters map[string]any) (*model.CursorPaginatedSongs, error)
+	GetSong(ctx context.Context, id uint64, page int, size int, langs []language.Tag) (*model.PaginatedSongs, error)
 	CreateSong(ctx context.Context, req *model.Song) (uint64, error)
 	UpdateSong(ctx context.Context, req *model.Song) error
+	PatchSong(ctx context.Context, id uint64, req *model.SongPatch) error
 	DeleteSong(ctx context.Context, id uint64) error
+	RefreshSong(ctx context.Context, id uint64) error
+
+	ListArtists(ctx context.Context, page, size int, name string) (*model.PaginatedArtists, error)
+	GetArtist(ctx context.Context, id uint64) (*model.Artist, error)
+	ListArtistSongs(ctx context.Context, id uint64, page, size int, filters map[string]any) (*model.PaginatedSongs, error)
+	CreateArtist(ctx context.Context, req *model.Artist) (*model.Artist, error)
+	UpdateArtist(ctx context.Context, req *model.Artist) error
+	DeleteArtist(ctx context.Context, id uint64) error
+
+	ListAlbums(ctx context.Context, page, size int, title string) (*model.PaginatedAlbums, error)
+	GetAlbum(ctx context.Context, id uint64) (*model.Album, error)
+	ListAlbumTracks(ctx context.Context, id uint64) ([]*model.Track, error)
+	SetAlbumTrack(ctx context.Context, id, songID uint64, disc, trackNumber int) error
+	RemoveAlbumTrack(ctx context.Context, id, songID uint64) error
+	CreateAlbum(ctx context.Context, req *model.Album) (*model.Album, error)
+	UpdateAlbum(ctx context.Context, req *model.Album) error
+	DeleteAlbum(ctx context.Context, id uint64) error
+
+	ListGenres(ctx context.Context) ([]*model.Genre, error)
+	CreateGenre(ctx context.Context, req *model.Genre) (*model.Genre, error)
+	DeleteGenre(ctx context.Context, id uint64) error
+	ListTags(ctx context.Context, name string) ([]*model.Tag, error)
+	DeleteTag(ctx context.Context, id uint64) error
+	SetSongGenres(ctx context.Context, id uint64, names []string) error
+	SetSongTags(ctx context.Context, id uint64, names []string) error
+
+	ListPlaylists(ctx context.Context, page, size int, name string) (*model.PaginatedPlaylists, error)
+	GetPlaylist(ctx context.Context, id uint64) (*model.Playlist, error)
+	ListPlaylistSongs(ctx context.Context, id uint64, page, size int) (*model.PaginatedSongs, error)
+	CreatePlaylist(ctx context.Context, req *model.Playlist) (*model.Playlist, error)
+	UpdatePlaylist(ctx context.Context, req *model.Playlist) error
+	DeletePlaylist(ctx context.Context, id uint64) error
+	DuplicatePlaylist(ctx context.Context, id uint64, name string) (*model.Playlist, error)
+	AddPlaylistSong(ctx context.Context, id, songID uint64, position int) (int, error)
+	MovePlaylistSong(ctx context.Context, id, songID uint64, position int) (int, error)
+	RemovePlaylistSong(ctx context.Context, id, songID uint64) error
+
+	ListVerses(ctx context.Context, id uint64, page, size int) (*model.PaginatedVerses, error)
+	InsertVerse(ctx context.Context, id uint64, position int, text string) (int, error)
+	UpdateVerse(ctx context.Context, id uint64, position int, text string) error
+	DeleteVerse(ctx context.Context, id uint64, position int) error
+	MoveVerse(ctx context.Context, id uint64, position, to int) (int, error)
+
+	GetSongLyrics(ctx context.Context, id uint64) (*model.SongLyrics, error)
+	SetSyncedLyrics(ctx context.Context, id uint64, lines []*model.SyncedLine) error
+
+	CreateTranslation(ctx context.Context, id uint64, req *model.Translation) error
+	UpdateTranslation(ctx context.Context, id uint64, req *model.Translation) error
+	DeleteTranslation(ctx context.Context, id uint64, lang string) error
 }
 
 type Handler struct {
-	srv  *http.Server
-	ctrl Ctrl
+	srv    *http.Server
+	ctrl   Ctrl
+	mounts map[string]http.Handler
+	auth   *auth.Authenticator
 }
 
 func New(ctrl Ctrl) *Handler {
 	return &Handler{
-		ctrl: ctrl,
+		ctrl:   ctrl,
+		mounts: make(map[string]http.Handler),
 	}
 }
 
-func (h *Handler) Start(port int) {
-	mux := http.NewServeMux()
-	mux.HandleFunc("/swagger/", httpSwagger.WrapHandler)
-
-	mux.HandleFunc("/api/health-check", func(w http.ResponseWriter, r *http.Request) {
-		utils.SuccessResponse(w, http.StatusOK, "OK")
-	})
-
-	mux.HandleFunc("/api/songs", func(w http.ResponseWriter, r *http.Request) {
-		switch r.Method {
-		case http.MethodGet:
-			h.ListSongs(w, r)
-		case http.MethodPost:
-			h.CreateSong(w, r)
-		default:
-			utils.ErrResponse(w, http.StatusMethodNotAllowed, hdl.ErrMethodNotAllowed)
-		}
-	})
+// UseAuth protects every route except the health check. Without it every route is public
+func (h *Handler) UseAuth(a *auth.Authenticator) {
+	h.auth = a
+}
 
-	mux.HandleFunc("/api/songs/", func(w http.ResponseWriter, r *http.Request) {
-		switch r.Method {
-		case http.MethodGet:
-			h.GetSong(w, r)
-		case http.MethodPut:
-			h.UpdateSong(w, r)
-		case http.MethodDelete:
-			h.DeleteSong(w, r)
-		default:
-			utils.ErrResponse(w, http.StatusMethodNotAllowed, hdl.ErrMethodNotAllowed)
-		}
-	})
+// Mount serves an extra handler, e.g. metrics, on the API server. Only admins may reach it
+func (h *Handler) Mount(pattern string, handler http.Handler) {
+	h.mounts[pattern] = handler
+}
 
+func (h *Handler) Start(port int) {
 	h.srv = &http.Server{
-		Handler:      mux,
+		Handler:      chain(h.routes(), requestID, traced, accessLog, instrument, recoverer),
 		Addr:         fmt.Sprintf(":%v", port),
 		WriteTimeout: 15 * time.Second,
 		ReadTimeout:  15 * time.Second,
@@ -77,6 +111,74 @@ func (h *Handler) Start(port int) {
 	}
 }
 
+func (h *Handler) routes() http.Handler {
+	r := newRouter()
+	r.HandleFunc("/swagger/", h.require(auth.RoleReader, httpSwagger.WrapHandler))
+	for pattern, handler := range h.mounts {
+		r.HandleFunc(pattern, h.require(auth.RoleAdmin, handler.ServeHTTP))
+	}
+
+	r.HandleFunc("GET /api/health-check", func(w http.ResponseWriter, r *http.Request) {
+		utils.SuccessResponse(w, http.StatusOK, "OK")
+	})
+
+	r.HandleFunc("GET /api/songs", h.require(auth.RoleReader, h.ListSongs))
+	r.HandleFunc("POST /api/songs", h.require(auth.RoleEditor, h.CreateSong))
+	r.HandleFunc("GET /api/songs/{id}", h.require(auth.RoleReader, h.GetSong))
+	r.HandleFunc("PUT /api/songs/{id}", h.require(auth.RoleEditor, h.UpdateSong))
+	r.HandleFunc("PATCH /api/songs/{id}", h.require(auth.RoleEditor, h.PatchSong))
+	r.HandleFunc("DELETE /api/songs/{id}", h.require(auth.RoleAdmin, h.DeleteSong))
+	r.HandleFunc("POST /api/songs/{id}/refresh", h.require(auth.RoleEditor, h.RefreshSong))
+	r.HandleFunc("PUT /api/songs/{id}/genres", h.require(auth.RoleEditor, h.SetSongGenres))
+	r.HandleFunc("PUT /api/songs/{id}/tags", h.require(auth.RoleEditor, h.SetSongTags))
+	r.HandleFunc("GET /api/songs/{id}/verses", h.require(auth.RoleReader, h.ListVerses))
+	r.HandleFunc("POST /api/songs/{id}/verses", h.require(auth.RoleEditor, h.InsertVerse))
+	r.HandleFunc("PUT /api/songs/{id}/verses/{n}", h.require(auth.RoleEditor, h.UpdateVerse))
+	r.HandleFunc("DELETE /api/songs/{id}/verses/{n}", h.require(auth.RoleEditor, h.DeleteVerse))
+	r.HandleFunc("POST /api/songs/{id}/verses/{n}/move", h.require(auth.RoleEditor, h.MoveVerse))
+	r.HandleFunc("GET /api/songs/{id}/lyrics.lrc", h.require(auth.RoleReader, h.ExportLRC))
+	r.HandleFunc("PUT /api/songs/{id}/lyrics.lrc", h.require(auth.RoleEditor, h.ImportLRC))
+	r.HandleFunc("GET /api/songs/{id}/lyrics.txt", h.require(auth.RoleReader, h.ExportText))
+	r.HandleFunc("POST /api/songs/{id}/translations", h.require(auth.RoleEditor, h.CreateTranslation))
+	r.HandleFunc("PUT /api/songs/{id}/translations/{lang}", h.require(auth.RoleEditor, h.UpdateTranslation))
+	r.HandleFunc("DELETE /api/songs/{id}/translations/{lang}", h.require(auth.RoleEditor, h.DeleteTranslation))
+
+	r.HandleFunc("GET /api/artists", h.require(auth.RoleReader, h.ListArtists))
+	r.HandleFunc("POST /api/artists", h.require(auth.RoleEditor, h.CreateArtist))
+	r.HandleFunc("GET /api/artists/{id}", h.require(auth.RoleReader, h.GetArtist))
+	r.HandleFunc("PUT /api/artists/{id}", h.require(auth.RoleEditor, h.UpdateArtist))
+	r.HandleFunc("DELETE /api/artists/{id}", h.require(auth.RoleAdmin, h.DeleteArtist))
+	r.HandleFunc("GET /api/artists/{id}/songs", h.require(auth.RoleReader, h.ListArtistSongs))
+
+	r.HandleFunc("GET /api/albums", h.require(auth.RoleReader, h.ListAlbums))
+	r.HandleFunc("POST /api/albums", h.require(auth.RoleEditor, h.CreateAlbum))
+	r.HandleFunc("GET /api/albums/{id}", h.require(auth.RoleReader, h.GetAlbum))
+	r.HandleFunc("PUT /api/albums/{id}", h.require(auth.RoleEditor, h.UpdateAlbum))
+	r.HandleFunc("DELETE /api/albums/{id}", h.require(auth.RoleAdmin, h.DeleteAlbum))
+	r.HandleFunc("GET /api/albums/{id}/tracks", h.require(auth.RoleReader, h.ListAlbumTracks))
+	r.HandleFunc("PUT /api/albums/{id}/tracks/{song_id}", h.require(auth.RoleEditor, h.SetAlbumTrack))
+	r.HandleFunc("DELETE /api/albums/{id}/tracks/{song_id}", h.require(auth.RoleEditor, h.RemoveAlbumTrack))
+
+	r.HandleFunc("GET /api/genres", h.require(auth.RoleReader, h.ListGenres))
+	r.HandleFunc("POST /api/genres", h.require(auth.RoleEditor, h.CreateGenre))
+	r.HandleFunc("DELETE /api/genres/{id}", h.require(auth.RoleAdmin, h.DeleteGenre))
+
+	r.HandleFunc("GET /api/tags", h.require(auth.RoleReader, h.ListTags))
+	r.HandleFunc("DELETE /api/tags/{id}", h.require(auth.RoleAdmin, h.DeleteTag))
+
+	r.HandleFunc("GET /api/playlists", h.require(auth.RoleReader, h.ListPlaylists))
+	r.HandleFunc("POST /api/playlists", h.require(auth.RoleEditor, h.CreatePlaylist))
+	r.HandleFunc("GET /api/playlists/{id}", h.require(auth.RoleReader, h.GetPlaylist))
+	r.HandleFunc("PUT /api/playlists/{id}", h.require(auth.RoleEditor, h.UpdatePlaylist))
+	r.HandleFunc("DELETE /api/playlists/{id}", h.require(auth.RoleAdmin, h.DeletePlaylist))
+	r.HandleFunc("POST /api/playlists/{id}/duplicate", h.require(auth.RoleEditor, h.DuplicatePlaylist))
+	r.HandleFunc("GET /api/playlists/{id}/songs", h.require(auth.RoleReader, h.ListPlaylistSongs))
+	r.HandleFunc("POST /api/playlists/{id}/songs", h.require(auth.RoleEditor, h.AddPlaylistSong))
+	r.HandleFunc("PUT /api/playlists/{id}/songs/{song_id}", h.require(auth.RoleEditor, h.MovePlaylistSong))
+	r.HandleFunc("DELETE /api/playlists/{id}/songs/{song_id}", h.require(auth.RoleEditor, h.RemovePlaylistSong))
+	return r
+}
+
 func (h *Handler) Close() error {
 	if err := h.srv.Shutdown(context.Background()); err != nil {
 		return err
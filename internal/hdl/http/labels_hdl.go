@@ -0,0 +1,326 @@
+package http
+
+import (
+	"encoding/json"
+	"errors"
+	"github.com/JMURv/effectiveMobile/internal/ctrl"
+	"github.com/JMURv/effectiveMobile/internal/hdl"
+	"github.com/JMURv/effectiveMobile/internal/validation"
+	"github.com/JMURv/effectiveMobile/pkg/model"
+	utils "github.com/JMURv/effectiveMobile/pkg/utils/http"
+	"github.com/JMURv/effectiveMobile/pkg/utils/logger"
+	"go.uber.org/zap"
+	"net/http"
+	"strconv"
+)
+
+type GenreRequest struct {
+	Name string `json:"name"`
+}
+
+type SongGenresRequest struct {
+	Genres []string `json:"genres"`
+}
+
+type SongTagsRequest struct {
+	Tags []string `json:"tags"`
+}
+
+// ListGenres
+// @Summary Список жанров
+// @Description Получить все жанры, отсортированные по названию
+// @Tags genres
+// @Success 200 {array} model.Genre "Список жанров"
+// @Security ApiKeyAuth
+// @Security BearerAuth
+// @Failure 401 {object} utils.Problem "Требуется аутентификация"
+// @Failure 403 {object} utils.Problem "Недостаточно прав, требуется роль reader"
+// @Failure 500 {object} utils.Problem "Внутренняя ошибка сервера"
+// @Router /api/genres [get]
+func (h *Handler) ListGenres(w http.ResponseWriter, r *http.Request) {
+	res, err := h.ctrl.ListGenres(r.Context())
+	if err != nil {
+		utils.ErrResponse(w, r, http.StatusInternalServerError, hdl.ErrInternal)
+		return
+	}
+
+	utils.SuccessResponse(w, http.StatusOK, res)
+}
+
+// CreateGenre
+// @Summary Добавить жанр
+// @Description Жанры ведутся кураторами, песням можно назначить только существующие
+// @Tags genres
+// @Accept json
+// @Param genre body GenreRequest true "Данные жанра"
+// @Success 201 {object} model.Genre "Добавленный жанр"
+// @Failure 400 {object} utils.Problem "Ошибка валидации или декодирования запроса"
+// @Failure 409 {object} utils.Problem "Жанр уже существует"
+// @Security ApiKeyAuth
+// @Security BearerAuth
+// @Failure 401 {object} utils.Problem "Требуется аутентификация"
+// @Failure 403 {object} utils.Problem "Недостаточно прав, требуется роль editor"
+// @Failure 500 {object} utils.Problem "Внутренняя ошибка сервера"
+// @Router /api/genres [post]
+func (h *Handler) CreateGenre(w http.ResponseWriter, r *http.Request) {
+	const op = "songs.CreateGenre.hdl"
+
+	req := &model.Genre{}
+	if err := json.NewDecoder(r.Body).Decode(req); err != nil {
+		logger.FromContext(r.Context()).Debug(
+			"failed to decode request",
+			zap.Error(err), zap.String("op", op),
+		)
+		utils.ErrResponse(w, r, http.StatusBadRequest, hdl.ErrDecodeRequest)
+		return
+	}
+
+	req.Name = validation.NormalizeName(req.Name)
+	if err := validation.ValidateGenre(req); err != nil {
+		logger.FromContext(r.Context()).Debug(
+			"failed to validate request",
+			zap.Error(err), zap.String("op", op),
+			zap.Any("req", req),
+		)
+		utils.ErrResponse(w, r, http.StatusBadRequest, err)
+		return
+	}
+
+	res, err := h.ctrl.CreateGenre(r.Context(), req)
+	if err != nil && errors.Is(err, ctrl.ErrAlreadyExists) {
+		utils.ErrResponse(w, r, http.StatusConflict, err)
+		return
+	} else if err != nil {
+		utils.ErrResponse(w, r, http.StatusInternalServerError, hdl.ErrInternal)
+		return
+	}
+
+	utils.SuccessResponse(w, http.StatusCreated, res)
+}
+
+// DeleteGenre
+// @Summary Удалить жанр
+// @Description Жанр снимается со всех песен
+// @Tags genres
+// @Param id path int true "ID жанра"
+// @Success 204 {object} string "Жанр успешно удалён"
+// @Failure 400 {object} utils.Problem "Некорректный запрос"
+// @Failure 404 {object} utils.Problem "Жанр не найден"
+// @Security ApiKeyAuth
+// @Security BearerAuth
+// @Failure 401 {object} utils.Problem "Требуется аутентификация"
+// @Failure 403 {object} utils.Problem "Недостаточно прав, требуется роль admin"
+// @Failure 500 {object} utils.Problem "Внутренняя ошибка сервера"
+// @Router /api/genres/{id} [delete]
+func (h *Handler) DeleteGenre(w http.ResponseWriter, r *http.Request) {
+	const op = "songs.DeleteGenre.hdl"
+
+	id, err := strconv.ParseUint(r.PathValue("id"), 10, 64)
+	if err != nil {
+		logger.FromContext(r.Context()).Debug(
+			"failed to extract param",
+			zap.Error(err), zap.String("op", op),
+		)
+		utils.ErrResponse(w, r, http.StatusBadRequest, hdl.ErrMissingGenreID)
+		return
+	}
+
+	err = h.ctrl.DeleteGenre(r.Context(), id)
+	if err != nil && errors.Is(err, ctrl.ErrNotFound) {
+		utils.ErrResponse(w, r, http.StatusNotFound, err)
+		return
+	} else if err != nil {
+		utils.ErrResponse(w, r, http.StatusInternalServerError, hdl.ErrInternal)
+		return
+	}
+
+	utils.SuccessResponse(w, http.StatusNoContent, "OK")
+}
+
+// ListTags
+// @Summary Список тегов
+// @Description Получить теги, отсортированные по названию
+// @Tags tags
+// @Param name query string false "Фильтр по названию тега"
+// @Success 200 {array} model.Tag "Список тегов"
+// @Security ApiKeyAuth
+// @Security BearerAuth
+// @Failure 401 {object} utils.Problem "Требуется аутентификация"
+// @Failure 403 {object} utils.Problem "Недостаточно прав, требуется роль reader"
+// @Failure 500 {object} utils.Problem "Внутренняя ошибка сервера"
+// @Router /api/tags [get]
+func (h *Handler) ListTags(w http.ResponseWriter, r *http.Request) {
+	res, err := h.ctrl.ListTags(r.Context(), r.URL.Query().Get("name"))
+	if err != nil {
+		utils.ErrResponse(w, r, http.StatusInternalServerError, hdl.ErrInternal)
+		return
+	}
+
+	utils.SuccessResponse(w, http.StatusOK, res)
+}
+
+// DeleteTag
+// @Summary Удалить тег
+// @Description Тег снимается со всех песен
+// @Tags tags
+// @Param id path int true "ID тега"
+// @Success 204 {object} string "Тег успешно удалён"
+// @Failure 400 {object} utils.Problem "Некорректный запрос"
+// @Failure 404 {object} utils.Problem "Тег не найден"
+// @Security ApiKeyAuth
+// @Security BearerAuth
+// @Failure 401 {object} utils.Problem "Требуется аутентификация"
+// @Failure 403 {object} utils.Problem "Недостаточно прав, требуется роль admin"
+// @Failure 500 {object} utils.Problem "Внутренняя ошибка сервера"
+// @Router /api/tags/{id} [delete]
+func (h *Handler) DeleteTag(w http.ResponseWriter, r *http.Request) {
+	const op = "songs.DeleteTag.hdl"
+
+	id, err := strconv.ParseUint(r.PathValue("id"), 10, 64)
+	if err != nil {
+		logger.FromContext(r.Context()).Debug(
+			"failed to extract param",
+			zap.Error(err), zap.String("op", op),
+		)
+		utils.ErrResponse(w, r, http.StatusBadRequest, hdl.ErrMissingTagID)
+		return
+	}
+
+	err = h.ctrl.DeleteTag(r.Context(), id)
+	if err != nil && errors.Is(err, ctrl.ErrNotFound) {
+		utils.ErrResponse(w, r, http.StatusNotFound, err)
+		return
+	} else if err != nil {
+		utils.ErrResponse(w, r, http.StatusInternalServerError, hdl.ErrInternal)
+		return
+	}
+
+	utils.SuccessResponse(w, http.StatusNoContent, "OK")
+}
+
+// SetSongGenres
+// @Summary Задать жанры песни
+// @Description Заменяет все жанры песни переданными. Пустой список снимает все жанры
+// @Tags songs
+// @Accept json
+// @Param id path int true "ID песни"
+// @Param genres body SongGenresRequest true "Названия жанров"
+// @Success 200 {object} string "Жанры успешно заданы"
+// @Failure 400 {object} utils.Problem "Ошибка валидации, декодирования запроса или неизвестный жанр"
+// @Failure 404 {object} utils.Problem "Песня не найдена"
+// @Security ApiKeyAuth
+// @Security BearerAuth
+// @Failure 401 {object} utils.Problem "Требуется аутентификация"
+// @Failure 403 {object} utils.Problem "Недостаточно прав, требуется роль editor"
+// @Failure 500 {object} utils.Problem "Внутренняя ошибка сервера"
+// @Router /api/songs/{id}/genres [put]
+func (h *Handler) SetSongGenres(w http.ResponseWriter, r *http.Request) {
+	const op = "songs.SetSongGenres.hdl"
+
+	id, err := strconv.ParseUint(r.PathValue("id"), 10, 64)
+	if err != nil {
+		logger.FromContext(r.Context()).Debug(
+			"failed to extract param",
+			zap.Error(err), zap.String("op", op),
+		)
+		utils.ErrResponse(w, r, http.StatusBadRequest, hdl.ErrMissingSongID)
+		return
+	}
+
+	req := &SongGenresRequest{}
+	if err := json.NewDecoder(r.Body).Decode(req); err != nil {
+		logger.FromContext(r.Context()).Debug(
+			"failed to decode request",
+			zap.Error(err), zap.String("op", op),
+		)
+		utils.ErrResponse(w, r, http.StatusBadRequest, hdl.ErrDecodeRequest)
+		return
+	}
+
+	validation.NormalizeLabels(req.Genres)
+	if err := validation.ValidateLabels("/genres", req.Genres); err != nil {
+		logger.FromContext(r.Context()).Debug(
+			"failed to validate request",
+			zap.Error(err), zap.String("op", op),
+			zap.Any("req", req),
+		)
+		utils.ErrResponse(w, r, http.StatusBadRequest, err)
+		return
+	}
+
+	err = h.ctrl.SetSongGenres(r.Context(), id, req.Genres)
+	if err != nil && errors.Is(err, ctrl.ErrNotFound) {
+		utils.ErrResponse(w, r, http.StatusNotFound, err)
+		return
+	} else if err != nil && errors.Is(err, ctrl.ErrUnknownGenre) {
+		utils.ErrResponse(w, r, http.StatusBadRequest, err)
+		return
+	} else if err != nil {
+		utils.ErrResponse(w, r, http.StatusInternalServerError, hdl.ErrInternal)
+		return
+	}
+
+	utils.SuccessResponse(w, http.StatusOK, "OK")
+}
+
+// SetSongTags
+// @Summary Задать теги песни
+// @Description Заменяет все теги песни переданными, новые теги создаются автоматически. Пустой список снимает все теги
+// @Tags songs
+// @Accept json
+// @Param id path int true "ID песни"
+// @Param tags body SongTagsRequest true "Названия тегов"
+// @Success 200 {object} string "Теги успешно заданы"
+// @Failure 400 {object} utils.Problem "Ошибка валидации или декодирования запроса"
+// @Failure 404 {object} utils.Problem "Песня не найдена"
+// @Security ApiKeyAuth
+// @Security BearerAuth
+// @Failure 401 {object} utils.Problem "Требуется аутентификация"
+// @Failure 403 {object} utils.Problem "Недостаточно прав, требуется роль editor"
+// @Failure 500 {object} utils.Problem "Внутренняя ошибка сервера"
+// @Router /api/songs/{id}/tags [put]
+func (h *Handler) SetSongTags(w http.ResponseWriter, r *http.Request) {
+	const op = "songs.SetSongTags.hdl"
+
+	id, err := strconv.ParseUint(r.PathValue("id"), 10, 64)
+	if err != nil {
+		logger.FromContext(r.Context()).Debug(
+			"failed to extract param",
+			zap.Error(err), zap.String("op", op),
+		)
+		utils.ErrResponse(w, r, http.StatusBadRequest, hdl.ErrMissingSongID)
+		return
+	}
+
+	req := &SongTagsRequest{}
+	if err := json.NewDecoder(r.Body).Decode(req); err != nil {
+		logger.FromContext(r.Context()).Debug(
+			"failed to decode request",
+			zap.Error(err), zap.String("op", op),
+		)
+		utils.ErrResponse(w, r, http.StatusBadRequest, hdl.ErrDecodeRequest)
+		return
+	}
+
+	validation.NormalizeLabels(req.Tags)
+	if err := validation.ValidateLabels("/tags", req.Tags); err != nil {
+		logger.FromContext(r.Context()).Debug(
+			"failed to validate request",
+			zap.Error(err), zap.String("op", op),
+			zap.Any("req", req),
+		)
+		utils.ErrResponse(w, r, http.StatusBadRequest, err)
+		return
+	}
+
+	err = h.ctrl.SetSongTags(r.Context(), id, req.Tags)
+	if err != nil && errors.Is(err, ctrl.ErrNotFound) {
+		utils.ErrResponse(w, r, http.StatusNotFound, err)
+		return
+	} else if err != nil {
+		utils.ErrResponse(w, r, http.StatusInternalServerError, hdl.ErrInternal)
+		return
+	}
+
+	utils.SuccessResponse(w, http.StatusOK, "OK")
+}
@@ -0,0 +1,278 @@
+package http
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"github.com/JMURv/effectiveMobile/internal/ctrl"
+	"github.com/JMURv/effectiveMobile/mocks"
+	"github.com/JMURv/effectiveMobile/pkg/model"
+	utils "github.com/JMURv/effectiveMobile/pkg/utils/http"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestHandler_ListGenres(t *testing.T) {
+	ctrlMock := gomock.NewController(t)
+	defer ctrlMock.Finish()
+
+	ctrlRepo := mocks.NewMockCtrl(ctrlMock)
+	hdl := New(ctrlRepo)
+
+	ctx := context.Background()
+
+	t.Run("Success", func(t *testing.T) {
+		ctrlRepo.EXPECT().ListGenres(ctx).Return([]*model.Genre{{ID: 1, Name: "Rock"}}, nil).Times(1)
+
+		req := httptest.NewRequest(http.MethodGet, "/api/genres", nil)
+		req = req.WithContext(ctx)
+
+		w := httptest.NewRecorder()
+		hdl.routes().ServeHTTP(w, req)
+		assert.Equal(t, http.StatusOK, w.Result().StatusCode)
+	})
+
+	t.Run("ErrInternalError", func(t *testing.T) {
+		ctrlRepo.EXPECT().ListGenres(ctx).Return(nil, errors.New("other error")).Times(1)
+
+		req := httptest.NewRequest(http.MethodGet, "/api/genres", nil)
+		req = req.WithContext(ctx)
+
+		w := httptest.NewRecorder()
+		hdl.routes().ServeHTTP(w, req)
+		assert.Equal(t, http.StatusInternalServerError, w.Result().StatusCode)
+	})
+}
+
+func TestHandler_CreateGenre(t *testing.T) {
+	ctrlMock := gomock.NewController(t)
+	defer ctrlMock.Finish()
+
+	ctrlRepo := mocks.NewMockCtrl(ctrlMock)
+	hdl := New(ctrlRepo)
+
+	ctx := context.Background()
+
+	t.Run("Success", func(t *testing.T) {
+		ctrlRepo.EXPECT().CreateGenre(ctx, &model.Genre{Name: "Hard Rock"}).Return(&model.Genre{ID: 1, Name: "Hard Rock"}, nil).Times(1)
+
+		req := httptest.NewRequest(http.MethodPost, "/api/genres", bytes.NewBufferString(`{"name": "  Hard   Rock "}`))
+		req = req.WithContext(ctx)
+
+		w := httptest.NewRecorder()
+		hdl.routes().ServeHTTP(w, req)
+		assert.Equal(t, http.StatusCreated, w.Result().StatusCode)
+	})
+
+	t.Run("ErrValidation", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodPost, "/api/genres", bytes.NewBufferString(`{"name": " "}`))
+		req = req.WithContext(ctx)
+
+		w := httptest.NewRecorder()
+		hdl.routes().ServeHTTP(w, req)
+		assert.Equal(t, http.StatusBadRequest, w.Result().StatusCode)
+	})
+
+	t.Run("ErrAlreadyExists", func(t *testing.T) {
+		ctrlRepo.EXPECT().CreateGenre(ctx, &model.Genre{Name: "rock"}).Return(nil, ctrl.ErrAlreadyExists).Times(1)
+
+		req := httptest.NewRequest(http.MethodPost, "/api/genres", bytes.NewBufferString(`{"name": "rock"}`))
+		req = req.WithContext(ctx)
+
+		w := httptest.NewRecorder()
+		hdl.routes().ServeHTTP(w, req)
+		assert.Equal(t, http.StatusConflict, w.Result().StatusCode)
+	})
+}
+
+func TestHandler_DeleteGenre(t *testing.T) {
+	ctrlMock := gomock.NewController(t)
+	defer ctrlMock.Finish()
+
+	ctrlRepo := mocks.NewMockCtrl(ctrlMock)
+	hdl := New(ctrlRepo)
+
+	ctx := context.Background()
+
+	t.Run("Success", func(t *testing.T) {
+		ctrlRepo.EXPECT().DeleteGenre(ctx, uint64(1)).Return(nil).Times(1)
+
+		req := httptest.NewRequest(http.MethodDelete, "/api/genres/1", nil)
+		req = req.WithContext(ctx)
+
+		w := httptest.NewRecorder()
+		hdl.routes().ServeHTTP(w, req)
+		assert.Equal(t, http.StatusNoContent, w.Result().StatusCode)
+	})
+
+	t.Run("ErrNotFound", func(t *testing.T) {
+		ctrlRepo.EXPECT().DeleteGenre(ctx, uint64(2)).Return(ctrl.ErrNotFound).Times(1)
+
+		req := httptest.NewRequest(http.MethodDelete, "/api/genres/2", nil)
+		req = req.WithContext(ctx)
+
+		w := httptest.NewRecorder()
+		hdl.routes().ServeHTTP(w, req)
+		assert.Equal(t, http.StatusNotFound, w.Result().StatusCode)
+	})
+
+	t.Run("InvalidID", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodDelete, "/api/genres/rock", nil)
+		req = req.WithContext(ctx)
+
+		w := httptest.NewRecorder()
+		hdl.routes().ServeHTTP(w, req)
+		assert.Equal(t, http.StatusBadRequest, w.Result().StatusCode)
+	})
+}
+
+func TestHandler_ListTags(t *testing.T) {
+	ctrlMock := gomock.NewController(t)
+	defer ctrlMock.Finish()
+
+	ctrlRepo := mocks.NewMockCtrl(ctrlMock)
+	hdl := New(ctrlRepo)
+
+	ctx := context.Background()
+	ctrlRepo.EXPECT().ListTags(ctx, "work").Return([]*model.Tag{{ID: 3, Name: "workout"}}, nil).Times(1)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/tags?name=work", nil)
+	req = req.WithContext(ctx)
+
+	w := httptest.NewRecorder()
+	hdl.routes().ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Result().StatusCode)
+}
+
+func TestHandler_DeleteTag(t *testing.T) {
+	ctrlMock := gomock.NewController(t)
+	defer ctrlMock.Finish()
+
+	ctrlRepo := mocks.NewMockCtrl(ctrlMock)
+	hdl := New(ctrlRepo)
+
+	ctx := context.Background()
+
+	t.Run("Success", func(t *testing.T) {
+		ctrlRepo.EXPECT().DeleteTag(ctx, uint64(1)).Return(nil).Times(1)
+
+		req := httptest.NewRequest(http.MethodDelete, "/api/tags/1", nil)
+		req = req.WithContext(ctx)
+
+		w := httptest.NewRecorder()
+		hdl.routes().ServeHTTP(w, req)
+		assert.Equal(t, http.StatusNoContent, w.Result().StatusCode)
+	})
+
+	t.Run("ErrNotFound", func(t *testing.T) {
+		ctrlRepo.EXPECT().DeleteTag(ctx, uint64(2)).Return(ctrl.ErrNotFound).Times(1)
+
+		req := httptest.NewRequest(http.MethodDelete, "/api/tags/2", nil)
+		req = req.WithContext(ctx)
+
+		w := httptest.NewRecorder()
+		hdl.routes().ServeHTTP(w, req)
+		assert.Equal(t, http.StatusNotFound, w.Result().StatusCode)
+	})
+}
+
+func TestHandler_SetSongGenres(t *testing.T) {
+	ctrlMock := gomock.NewController(t)
+	defer ctrlMock.Finish()
+
+	ctrlRepo := mocks.NewMockCtrl(ctrlMock)
+	hdl := New(ctrlRepo)
+
+	ctx := context.Background()
+
+	t.Run("Success", func(t *testing.T) {
+		ctrlRepo.EXPECT().SetSongGenres(ctx, uint64(1), []string{"Hard Rock", "Metal"}).Return(nil).Times(1)
+
+		req := httptest.NewRequest(http.MethodPut, "/api/songs/1/genres", bytes.NewBufferString(`{"genres": [" Hard  Rock", "Metal"]}`))
+		req = req.WithContext(ctx)
+
+		w := httptest.NewRecorder()
+		hdl.routes().ServeHTTP(w, req)
+		assert.Equal(t, http.StatusOK, w.Result().StatusCode)
+	})
+
+	t.Run("ErrValidation", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodPut, "/api/songs/1/genres", bytes.NewBufferString(`{"genres": ["Rock", ""]}`))
+		req = req.WithContext(ctx)
+
+		w := httptest.NewRecorder()
+		hdl.routes().ServeHTTP(w, req)
+		assert.Equal(t, http.StatusBadRequest, w.Result().StatusCode)
+
+		res := &utils.Problem{}
+		assert.Nil(t, json.NewDecoder(w.Body).Decode(res))
+		assert.Equal(t, "/genres/1", res.Errors[0].Pointer)
+	})
+
+	t.Run("ErrUnknownGenre", func(t *testing.T) {
+		ctrlRepo.EXPECT().SetSongGenres(ctx, uint64(1), []string{"Polka"}).Return(ctrl.ErrUnknownGenre).Times(1)
+
+		req := httptest.NewRequest(http.MethodPut, "/api/songs/1/genres", bytes.NewBufferString(`{"genres": ["Polka"]}`))
+		req = req.WithContext(ctx)
+
+		w := httptest.NewRecorder()
+		hdl.routes().ServeHTTP(w, req)
+		assert.Equal(t, http.StatusBadRequest, w.Result().StatusCode)
+	})
+
+	t.Run("ErrNotFound", func(t *testing.T) {
+		ctrlRepo.EXPECT().SetSongGenres(ctx, uint64(2), []string{}).Return(ctrl.ErrNotFound).Times(1)
+
+		req := httptest.NewRequest(http.MethodPut, "/api/songs/2/genres", bytes.NewBufferString(`{"genres": []}`))
+		req = req.WithContext(ctx)
+
+		w := httptest.NewRecorder()
+		hdl.routes().ServeHTTP(w, req)
+		assert.Equal(t, http.StatusNotFound, w.Result().StatusCode)
+	})
+
+	t.Run("ErrDecodeRequest", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodPut, "/api/songs/1/genres", bytes.NewBufferString(`{"genres": "Rock"}`))
+		req = req.WithContext(ctx)
+
+		w := httptest.NewRecorder()
+		hdl.routes().ServeHTTP(w, req)
+		assert.Equal(t, http.StatusBadRequest, w.Result().StatusCode)
+	})
+}
+
+func TestHandler_SetSongTags(t *testing.T) {
+	ctrlMock := gomock.NewController(t)
+	defer ctrlMock.Finish()
+
+	ctrlRepo := mocks.NewMockCtrl(ctrlMock)
+	hdl := New(ctrlRepo)
+
+	ctx := context.Background()
+
+	t.Run("Success", func(t *testing.T) {
+		ctrlRepo.EXPECT().SetSongTags(ctx, uint64(1), []string{"90s", "workout"}).Return(nil).Times(1)
+
+		req := httptest.NewRequest(http.MethodPut, "/api/songs/1/tags", bytes.NewBufferString(`{"tags": ["90s", " workout "]}`))
+		req = req.WithContext(ctx)
+
+		w := httptest.NewRecorder()
+		hdl.routes().ServeHTTP(w, req)
+		assert.Equal(t, http.StatusOK, w.Result().StatusCode)
+	})
+
+	t.Run("ErrInternalError", func(t *testing.T) {
+		ctrlRepo.EXPECT().SetSongTags(ctx, uint64(1), []string{"90s"}).Return(errors.New("other error")).Times(1)
+
+		req := httptest.NewRequest(http.MethodPut, "/api/songs/1/tags", bytes.NewBufferString(`{"tags": ["90s"]}`))
+		req = req.WithContext(ctx)
+
+		w := httptest.NewRecorder()
+		hdl.routes().ServeHTTP(w, req)
+		assert.Equal(t, http.StatusInternalServerError, w.Result().StatusCode)
+	})
+}
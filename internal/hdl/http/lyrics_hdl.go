@@ -0,0 +1,203 @@
+package http
+
+import (
+	"bytes"
+	"errors"
+	"github.com/JMURv/effectiveMobile/internal/ctrl"
+	"github.com/JMURv/effectiveMobile/internal/hdl"
+	"github.com/JMURv/effectiveMobile/internal/validation"
+	"github.com/JMURv/effectiveMobile/pkg/lrc"
+	"github.com/JMURv/effectiveMobile/pkg/model"
+	utils "github.com/JMURv/effectiveMobile/pkg/utils/http"
+	"github.com/JMURv/effectiveMobile/pkg/utils/logger"
+	"go.uber.org/zap"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// maxLRCSize is well above the size of real LRC files, which rarely exceed a few KB
+const maxLRCSize = 1 << 20
+
+// ImportLRC
+// @Summary Загрузить синхронизированный текст
+// @Description Заменяет синхронизированный текст песни строками LRC файла. Поддерживаются тег offset и строки с несколькими метками времени.
+// @Description Куплеты текста песни не меняются
+// @Tags lyrics
+// @Accept plain
+// @Param id path int true "ID песни"
+// @Param lrc body string true "LRC файл"
+// @Success 200 {object} string "Синхронизированный текст загружен"
+// @Failure 400 {object} utils.Problem "Некорректный LRC файл или ошибка валидации"
+// @Failure 404 {object} utils.Problem "Песня не найдена"
+// @Failure 413 {object} utils.Problem "Файл слишком большой"
+// @Security ApiKeyAuth
+// @Security BearerAuth
+// @Failure 401 {object} utils.Problem "Требуется аутентификация"
+// @Failure 403 {object} utils.Problem "Недостаточно прав, требуется роль editor"
+// @Failure 500 {object} utils.Problem "Внутренняя ошибка сервера"
+// @Router /api/songs/{id}/lyrics.lrc [put]
+func (h *Handler) ImportLRC(w http.ResponseWriter, r *http.Request) {
+	const op = "songs.ImportLRC.hdl"
+
+	id, err := strconv.ParseUint(r.PathValue("id"), 10, 64)
+	if err != nil {
+		logger.FromContext(r.Context()).Debug(
+			"failed to extract param",
+			zap.Error(err), zap.String("op", op),
+		)
+		utils.ErrResponse(w, r, http.StatusBadRequest, hdl.ErrMissingSongID)
+		return
+	}
+
+	parsed, err := lrc.Parse(http.MaxBytesReader(w, r.Body, maxLRCSize))
+	var tooLarge *http.MaxBytesError
+	if err != nil && errors.As(err, &tooLarge) {
+		utils.ErrResponse(w, r, http.StatusRequestEntityTooLarge, hdl.ErrRequestTooLarge)
+		return
+	} else if err != nil {
+		logger.FromContext(r.Context()).Debug(
+			"failed to parse lrc",
+			zap.Error(err), zap.String("op", op),
+		)
+		utils.ErrResponse(w, r, http.StatusBadRequest, err)
+		return
+	}
+
+	lines := make([]*model.SyncedLine, 0, len(parsed.Lines))
+	for _, line := range parsed.Lines {
+		lines = append(lines, &model.SyncedLine{TimeMs: line.Time.Milliseconds(), Text: line.Text})
+	}
+
+	validation.NormalizeSyncedLyrics(lines)
+	if err := validation.ValidateSyncedLyrics(lines); err != nil {
+		logger.FromContext(r.Context()).Debug(
+			"failed to validate request",
+			zap.Error(err), zap.String("op", op),
+			zap.Int("lines", len(lines)),
+		)
+		utils.ErrResponse(w, r, http.StatusBadRequest, err)
+		return
+	}
+
+	err = h.ctrl.SetSyncedLyrics(r.Context(), id, lines)
+	if err != nil && errors.Is(err, ctrl.ErrNotFound) {
+		utils.ErrResponse(w, r, http.StatusNotFound, err)
+		return
+	} else if err != nil {
+		utils.ErrResponse(w, r, http.StatusInternalServerError, hdl.ErrInternal)
+		return
+	}
+
+	utils.SuccessResponse(w, http.StatusOK, "OK")
+}
+
+// ExportLRC
+// @Summary Скачать синхронизированный текст
+// @Description Возвращает синхронизированный текст песни в формате LRC с тегами ar и ti
+// @Tags lyrics
+// @Produce plain
+// @Param id path int true "ID песни"
+// @Success 200 {string} string "LRC файл"
+// @Failure 400 {object} utils.Problem "Некорректный запрос"
+// @Failure 404 {object} utils.Problem "Песня не найдена или у неё нет синхронизированного текста"
+// @Security ApiKeyAuth
+// @Security BearerAuth
+// @Failure 401 {object} utils.Problem "Требуется аутентификация"
+// @Failure 403 {object} utils.Problem "Недостаточно прав, требуется роль reader"
+// @Failure 500 {object} utils.Problem "Внутренняя ошибка сервера"
+// @Router /api/songs/{id}/lyrics.lrc [get]
+func (h *Handler) ExportLRC(w http.ResponseWriter, r *http.Request) {
+	const op = "songs.ExportLRC.hdl"
+
+	id, err := strconv.ParseUint(r.PathValue("id"), 10, 64)
+	if err != nil {
+		logger.FromContext(r.Context()).Debug(
+			"failed to extract param",
+			zap.Error(err), zap.String("op", op),
+		)
+		utils.ErrResponse(w, r, http.StatusBadRequest, hdl.ErrMissingSongID)
+		return
+	}
+
+	res, err := h.ctrl.GetSongLyrics(r.Context(), id)
+	if err != nil && errors.Is(err, ctrl.ErrNotFound) {
+		utils.ErrResponse(w, r, http.StatusNotFound, err)
+		return
+	} else if err != nil {
+		utils.ErrResponse(w, r, http.StatusInternalServerError, hdl.ErrInternal)
+		return
+	}
+
+	if len(res.Synced) == 0 {
+		utils.ErrResponse(w, r, http.StatusNotFound, hdl.ErrNoSyncedLyrics)
+		return
+	}
+
+	lyrics := &lrc.Lyrics{
+		Tags:  map[string]string{"ar": res.Group, "ti": res.Song},
+		Lines: make([]lrc.Line, 0, len(res.Synced)),
+	}
+	for _, line := range res.Synced {
+		lyrics.Lines = append(lyrics.Lines, lrc.Line{Time: time.Duration(line.TimeMs) * time.Millisecond, Text: line.Text})
+	}
+
+	buf := &bytes.Buffer{}
+	if err := lrc.Write(buf, lyrics); err != nil {
+		logger.FromContext(r.Context()).Debug(
+			"failed to write lrc",
+			zap.Error(err), zap.String("op", op),
+		)
+		utils.ErrResponse(w, r, http.StatusInternalServerError, hdl.ErrInternal)
+		return
+	}
+
+	utils.TextResponse(w, http.StatusOK, buf.Bytes())
+}
+
+// ExportText
+// @Summary Скачать текст песни
+// @Description Возвращает текст песни как обычный текст, куплеты разделены пустой строкой
+// @Tags lyrics
+// @Produce plain
+// @Param id path int true "ID песни"
+// @Success 200 {string} string "Текст песни"
+// @Failure 400 {object} utils.Problem "Некорректный запрос"
+// @Failure 404 {object} utils.Problem "Песня не найдена или у неё нет текста"
+// @Security ApiKeyAuth
+// @Security BearerAuth
+// @Failure 401 {object} utils.Problem "Требуется аутентификация"
+// @Failure 403 {object} utils.Problem "Недостаточно прав, требуется роль reader"
+// @Failure 500 {object} utils.Problem "Внутренняя ошибка сервера"
+// @Router /api/songs/{id}/lyrics.txt [get]
+func (h *Handler) ExportText(w http.ResponseWriter, r *http.Request) {
+	const op = "songs.ExportText.hdl"
+
+	id, err := strconv.ParseUint(r.PathValue("id"), 10, 64)
+	if err != nil {
+		logger.FromContext(r.Context()).Debug(
+			"failed to extract param",
+			zap.Error(err), zap.String("op", op),
+		)
+		utils.ErrResponse(w, r, http.StatusBadRequest, hdl.ErrMissingSongID)
+		return
+	}
+
+	res, err := h.ctrl.GetSongLyrics(r.Context(), id)
+	if err != nil && errors.Is(err, ctrl.ErrNotFound) {
+		utils.ErrResponse(w, r, http.StatusNotFound, err)
+		return
+	} else if err != nil {
+		utils.ErrResponse(w, r, http.StatusInternalServerError, hdl.ErrInternal)
+		return
+	}
+
+	if len(res.Verses) == 0 {
+		utils.ErrResponse(w, r, http.StatusNotFound, hdl.ErrNoLyrics)
+		return
+	}
+
+	// Verses are split on blank lines when the lyrics are fetched, joining them back keeps the structure
+	utils.TextResponse(w, http.StatusOK, []byte(strings.Join(res.Verses, "\n\n")+"\n"))
+}
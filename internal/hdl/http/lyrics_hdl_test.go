@@ -0,0 +1,144 @@
+package http
+
+import (
+	"bytes"
+	"context"
+	"github.com/JMURv/effectiveMobile/internal/ctrl"
+	"github.com/JMURv/effectiveMobile/mocks"
+	"github.com/JMURv/effectiveMobile/pkg/model"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestHandler_ImportLRC(t *testing.T) {
+	ctrlMock := gomock.NewController(t)
+	defer ctrlMock.Finish()
+
+	ctrlRepo := mocks.NewMockCtrl(ctrlMock)
+	hdl := New(ctrlRepo)
+
+	ctx := context.Background()
+
+	t.Run("Success", func(t *testing.T) {
+		ctrlRepo.EXPECT().SetSyncedLyrics(ctx, uint64(1), []*model.SyncedLine{
+			{TimeMs: 500, Text: "Chorus line"},
+			{TimeMs: 1000, Text: "Verse"},
+			{TimeMs: 4500, Text: "Chorus line"},
+		}).Return(nil).Times(1)
+
+		body := "[ar:Group]\n[offset:500]\n[00:01.00][00:05.00] Chorus   line\n[00:01.50]Verse\n"
+		req := httptest.NewRequest(http.MethodPut, "/api/songs/1/lyrics.lrc", bytes.NewBufferString(body))
+		req = req.WithContext(ctx)
+
+		w := httptest.NewRecorder()
+		hdl.routes().ServeHTTP(w, req)
+		assert.Equal(t, http.StatusOK, w.Result().StatusCode)
+	})
+
+	t.Run("ErrInvalidLRC", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodPut, "/api/songs/1/lyrics.lrc", bytes.NewBufferString("[00:99.00]bad\n"))
+		req = req.WithContext(ctx)
+
+		w := httptest.NewRecorder()
+		hdl.routes().ServeHTTP(w, req)
+		assert.Equal(t, http.StatusBadRequest, w.Result().StatusCode)
+	})
+
+	t.Run("ErrRequestTooLarge", func(t *testing.T) {
+		body := strings.Repeat("[00:01.00]"+strings.Repeat("a", 100)+"\n", maxLRCSize/100)
+		req := httptest.NewRequest(http.MethodPut, "/api/songs/1/lyrics.lrc", bytes.NewBufferString(body))
+		req = req.WithContext(ctx)
+
+		w := httptest.NewRecorder()
+		hdl.routes().ServeHTTP(w, req)
+		assert.Equal(t, http.StatusRequestEntityTooLarge, w.Result().StatusCode)
+	})
+
+	t.Run("ErrNotFound", func(t *testing.T) {
+		ctrlRepo.EXPECT().SetSyncedLyrics(ctx, uint64(2), []*model.SyncedLine{{TimeMs: 1000, Text: "line"}}).Return(ctrl.ErrNotFound).Times(1)
+
+		req := httptest.NewRequest(http.MethodPut, "/api/songs/2/lyrics.lrc", bytes.NewBufferString("[00:01.00]line\n"))
+		req = req.WithContext(ctx)
+
+		w := httptest.NewRecorder()
+		hdl.routes().ServeHTTP(w, req)
+		assert.Equal(t, http.StatusNotFound, w.Result().StatusCode)
+	})
+}
+
+func TestHandler_ExportLRC(t *testing.T) {
+	ctrlMock := gomock.NewController(t)
+	defer ctrlMock.Finish()
+
+	ctrlRepo := mocks.NewMockCtrl(ctrlMock)
+	hdl := New(ctrlRepo)
+
+	ctx := context.Background()
+
+	t.Run("Success", func(t *testing.T) {
+		ctrlRepo.EXPECT().GetSongLyrics(ctx, uint64(1)).Return(&model.SongLyrics{
+			Group:  "Group",
+			Song:   "Song",
+			Synced: []*model.SyncedLine{{TimeMs: 1500, Text: "first"}, {TimeMs: 62005, Text: "second"}},
+		}, nil).Times(1)
+
+		req := httptest.NewRequest(http.MethodGet, "/api/songs/1/lyrics.lrc", nil)
+		req = req.WithContext(ctx)
+
+		w := httptest.NewRecorder()
+		hdl.routes().ServeHTTP(w, req)
+		assert.Equal(t, http.StatusOK, w.Result().StatusCode)
+		assert.Equal(t, "text/plain; charset=utf-8", w.Header().Get("Content-Type"))
+		assert.Equal(t, "[ar:Group]\n[ti:Song]\n[00:01.50]first\n[01:02.005]second\n", w.Body.String())
+	})
+
+	t.Run("ErrNoSyncedLyrics", func(t *testing.T) {
+		ctrlRepo.EXPECT().GetSongLyrics(ctx, uint64(2)).Return(&model.SongLyrics{Verses: []string{"verse"}}, nil).Times(1)
+
+		req := httptest.NewRequest(http.MethodGet, "/api/songs/2/lyrics.lrc", nil)
+		req = req.WithContext(ctx)
+
+		w := httptest.NewRecorder()
+		hdl.routes().ServeHTTP(w, req)
+		assert.Equal(t, http.StatusNotFound, w.Result().StatusCode)
+	})
+}
+
+func TestHandler_ExportText(t *testing.T) {
+	ctrlMock := gomock.NewController(t)
+	defer ctrlMock.Finish()
+
+	ctrlRepo := mocks.NewMockCtrl(ctrlMock)
+	hdl := New(ctrlRepo)
+
+	ctx := context.Background()
+
+	t.Run("Success", func(t *testing.T) {
+		ctrlRepo.EXPECT().GetSongLyrics(ctx, uint64(1)).Return(&model.SongLyrics{
+			Verses: []string{"first line\nsecond line", "chorus"},
+		}, nil).Times(1)
+
+		req := httptest.NewRequest(http.MethodGet, "/api/songs/1/lyrics.txt", nil)
+		req = req.WithContext(ctx)
+
+		w := httptest.NewRecorder()
+		hdl.routes().ServeHTTP(w, req)
+		assert.Equal(t, http.StatusOK, w.Result().StatusCode)
+		assert.Equal(t, "first line\nsecond line\n\nchorus\n", w.Body.String())
+	})
+
+	t.Run("ErrNotFound", func(t *testing.T) {
+		ctrlRepo.EXPECT().GetSongLyrics(ctx, uint64(2)).Return(nil, ctrl.ErrNotFound).Times(1)
+
+		req := httptest.NewRequest(http.MethodGet, "/api/songs/2/lyrics.txt", nil)
+		req = req.WithContext(ctx)
+
+		w := httptest.NewRecorder()
+		hdl.routes().ServeHTTP(w, req)
+		assert.Equal(t, http.StatusNotFound, w.Result().StatusCode)
+	})
+}
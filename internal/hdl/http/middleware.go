@@ -0,0 +1,209 @@
+package http
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"github.com/JMURv/effectiveMobile/internal/hdl"
+	"github.com/JMURv/effectiveMobile/internal/metrics"
+	utils "github.com/JMURv/effectiveMobile/pkg/utils/http"
+	"github.com/JMURv/effectiveMobile/pkg/utils/logger"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const requestIDHeader = "X-Request-ID"
+
+var tracer = otel.Tracer("github.com/JMURv/effectiveMobile/internal/hdl/http")
+
+type middleware func(http.Handler) http.Handler
+
+// chain wraps h so that the first middleware is the outermost one
+func chain(h http.Handler, mws ...middleware) http.Handler {
+	for i := len(mws) - 1; i >= 0; i-- {
+		h = mws[i](h)
+	}
+	return h
+}
+
+// requestID takes the request ID from the client or generates a new one,
+// echoes it back and puts it with a request-scoped logger into the context
+func requestID(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		id := r.Header.Get(requestIDHeader)
+		if !validRequestID(id) {
+			id = newRequestID()
+		}
+		w.Header().Set(requestIDHeader, id)
+
+		ctx := logger.WithRequestID(r.Context(), id)
+		ctx = logger.WithLogger(ctx, logger.FromContext(ctx).With(zap.String("request_id", id)))
+		next.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
+
+// traced continues the trace from the incoming traceparent header and wraps
+// the request in a server span named after the matched route
+func traced(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := otel.GetTextMapPropagator().Extract(r.Context(), propagation.HeaderCarrier(r.Header))
+		ctx, span := tracer.Start(
+			ctx, r.Method,
+			trace.WithSpanKind(trace.SpanKindServer),
+			trace.WithAttributes(
+				semconv.HTTPRequestMethodKey.String(r.Method),
+				semconv.URLPath(r.URL.Path),
+			),
+		)
+		defer span.End()
+
+		if sc := span.SpanContext(); sc.IsValid() {
+			ctx = logger.WithLogger(ctx, logger.FromContext(ctx).With(zap.String("trace_id", sc.TraceID().String())))
+		}
+
+		rec := &statusRecorder{ResponseWriter: w}
+		r = r.WithContext(ctx)
+		next.ServeHTTP(rec, r)
+
+		route := routeLabel(r)
+		span.SetName(r.Method + " " + route)
+		span.SetAttributes(semconv.HTTPRoute(route), semconv.HTTPResponseStatusCode(rec.Status()))
+		if rec.Status() >= http.StatusInternalServerError {
+			span.SetStatus(codes.Error, http.StatusText(rec.Status()))
+		}
+	})
+}
+
+func accessLog(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		start := time.Now()
+		rec := &statusRecorder{ResponseWriter: w}
+		next.ServeHTTP(rec, r)
+
+		logger.FromContext(r.Context()).Info(
+			"request",
+			zap.String("method", r.Method),
+			zap.String("path", r.URL.Path),
+			zap.Int("status", rec.Status()),
+			zap.Int("bytes", rec.bytes),
+			zap.Duration("latency", time.Since(start)),
+			zap.String("remote_addr", r.RemoteAddr),
+			zap.String("user_agent", r.UserAgent()),
+		)
+	})
+}
+
+// instrument records request counters and latency by route pattern and status
+func instrument(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		start := time.Now()
+		rec := &statusRecorder{ResponseWriter: w}
+		next.ServeHTTP(rec, r)
+
+		route, status := routeLabel(r), strconv.Itoa(rec.Status())
+		metrics.HTTPRequests.WithLabelValues(route, r.Method, status).Inc()
+		metrics.HTTPRequestDuration.WithLabelValues(route, r.Method, status).Observe(time.Since(start).Seconds())
+	})
+}
+
+// routeLabel returns the matched pattern without its method, so that
+// path parameters do not blow up the label cardinality
+func routeLabel(r *http.Request) string {
+	if r.Pattern == "" {
+		return "unmatched"
+	}
+
+	if _, path, ok := strings.Cut(r.Pattern, " "); ok {
+		return path
+	}
+	return r.Pattern
+}
+
+// recoverer turns a panic in a handler into a JSON 500 instead of crashing the server
+func recoverer(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		rec := &statusRecorder{ResponseWriter: w}
+		defer func() {
+			err := recover()
+			if err == nil {
+				return
+			}
+			if err == http.ErrAbortHandler {
+				panic(err)
+			}
+
+			logger.FromContext(r.Context()).Error(
+				"panic occurred",
+				zap.Any("error", err),
+				zap.Stack("stack"),
+			)
+
+			if rec.status == 0 {
+				utils.ErrResponse(rec, r, http.StatusInternalServerError, hdl.ErrInternal)
+			}
+		}()
+
+		next.ServeHTTP(rec, r)
+	})
+}
+
+// statusRecorder remembers the status code and the number of bytes written
+type statusRecorder struct {
+	http.ResponseWriter
+	status int
+	bytes  int
+}
+
+func (r *statusRecorder) WriteHeader(status int) {
+	if r.status == 0 {
+		r.status = status
+	}
+	r.ResponseWriter.WriteHeader(status)
+}
+
+func (r *statusRecorder) Write(b []byte) (int, error) {
+	if r.status == 0 {
+		r.status = http.StatusOK
+	}
+
+	n, err := r.ResponseWriter.Write(b)
+	r.bytes += n
+	return n, err
+}
+
+func (r *statusRecorder) Status() int {
+	if r.status == 0 {
+		return http.StatusOK
+	}
+	return r.status
+}
+
+func (r *statusRecorder) Unwrap() http.ResponseWriter {
+	return r.ResponseWriter
+}
+
+func validRequestID(id string) bool {
+	if id == "" || len(id) > 128 {
+		return false
+	}
+
+	for _, c := range id {
+		if c < 0x21 || c > 0x7e {
+			return false
+		}
+	}
+	return true
+}
+
+func newRequestID() string {
+	b := make([]byte, 16)
+	rand.Read(b)
+	return hex.EncodeToString(b)
+}
@@ -0,0 +1,187 @@
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"github.com/JMURv/effectiveMobile/internal/hdl"
+	"github.com/JMURv/effectiveMobile/internal/metrics"
+	utils "github.com/JMURv/effectiveMobile/pkg/utils/http"
+	"github.com/JMURv/effectiveMobile/pkg/utils/logger"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zaptest/observer"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestMiddleware_RequestID(t *testing.T) {
+	var got string
+	h := chain(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got = logger.RequestID(r.Context())
+	}), requestID)
+
+	t.Run("Propagated", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		req.Header.Set(requestIDHeader, "abc-123")
+
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, req)
+		assert.Equal(t, "abc-123", got)
+		assert.Equal(t, "abc-123", w.Header().Get(requestIDHeader))
+	})
+
+	t.Run("Generated", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/", nil))
+		assert.Len(t, got, 32)
+		assert.Equal(t, got, w.Header().Get(requestIDHeader))
+	})
+
+	t.Run("InvalidReplaced", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		req.Header.Set(requestIDHeader, strings.Repeat("a", 200))
+
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, req)
+		assert.Len(t, got, 32)
+	})
+}
+
+func TestMiddleware_AccessLog(t *testing.T) {
+	core, logs := observer.New(zap.InfoLevel)
+	defer zap.ReplaceGlobals(zap.New(core))()
+
+	h := chain(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		logger.FromContext(r.Context()).Info("inside handler")
+		w.WriteHeader(http.StatusCreated)
+		w.Write([]byte("hello"))
+	}), requestID, accessLog)
+
+	req := httptest.NewRequest(http.MethodPost, "/api/songs", nil)
+	req.Header.Set(requestIDHeader, "abc-123")
+	h.ServeHTTP(httptest.NewRecorder(), req)
+
+	entries := logs.AllUntimed()
+	assert.Len(t, entries, 2)
+	assert.Equal(t, "abc-123", entries[0].ContextMap()["request_id"])
+
+	fields := entries[1].ContextMap()
+	assert.Equal(t, "request", entries[1].Message)
+	assert.Equal(t, "abc-123", fields["request_id"])
+	assert.Equal(t, http.MethodPost, fields["method"])
+	assert.Equal(t, "/api/songs", fields["path"])
+	assert.Equal(t, int64(http.StatusCreated), fields["status"])
+	assert.Equal(t, int64(5), fields["bytes"])
+	assert.Contains(t, fields, "latency")
+}
+
+func TestMiddleware_Instrument(t *testing.T) {
+	r := newRouter()
+	r.HandleFunc("GET /api/songs/{id}", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	})
+	h := chain(r, requestID, instrument)
+
+	found := metrics.HTTPRequests.WithLabelValues("/api/songs/{id}", http.MethodGet, "404")
+	unmatched := metrics.HTTPRequests.WithLabelValues("unmatched", http.MethodGet, "404")
+	before, beforeUnmatched := testutil.ToFloat64(found), testutil.ToFloat64(unmatched)
+
+	h.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/api/songs/1", nil))
+	h.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/api/songs/2", nil))
+	h.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/api/unknown", nil))
+
+	assert.Equal(t, before+2, testutil.ToFloat64(found))
+	assert.Equal(t, beforeUnmatched+1, testutil.ToFloat64(unmatched))
+}
+
+func TestMiddleware_Traced(t *testing.T) {
+	sr := tracetest.NewSpanRecorder()
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(sr))
+	defer tp.Shutdown(context.Background())
+
+	prevTP, prevProp := otel.GetTracerProvider(), otel.GetTextMapPropagator()
+	otel.SetTracerProvider(tp)
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+	defer func() {
+		otel.SetTracerProvider(prevTP)
+		otel.SetTextMapPropagator(prevProp)
+	}()
+
+	var inner trace.SpanContext
+	r := newRouter()
+	r.HandleFunc("GET /api/songs/{id}", func(w http.ResponseWriter, r *http.Request) {
+		inner = trace.SpanContextFromContext(r.Context())
+		w.WriteHeader(http.StatusInternalServerError)
+	})
+	h := chain(r, requestID, traced)
+
+	const traceID = "4bf92f3577b34da6a3ce929d0e0e4736"
+	req := httptest.NewRequest(http.MethodGet, "/api/songs/1", nil)
+	req.Header.Set("traceparent", "00-"+traceID+"-00f067aa0ba902b7-01")
+	h.ServeHTTP(httptest.NewRecorder(), req)
+
+	spans := sr.Ended()
+	assert.Len(t, spans, 1)
+	assert.Equal(t, "GET /api/songs/{id}", spans[0].Name())
+	assert.Equal(t, trace.SpanKindServer, spans[0].SpanKind())
+	assert.Equal(t, traceID, spans[0].SpanContext().TraceID().String())
+	assert.Equal(t, "00f067aa0ba902b7", spans[0].Parent().SpanID().String())
+	assert.Equal(t, codes.Error, spans[0].Status().Code)
+	assert.Equal(t, spans[0].SpanContext().SpanID(), inner.SpanID())
+}
+
+func TestMiddleware_Recoverer(t *testing.T) {
+	core, logs := observer.New(zap.InfoLevel)
+	defer zap.ReplaceGlobals(zap.New(core))()
+
+	t.Run("Panic", func(t *testing.T) {
+		h := chain(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			panic("boom")
+		}), requestID, accessLog, recoverer)
+
+		w := httptest.NewRecorder()
+		assert.NotPanics(t, func() {
+			h.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/", nil))
+		})
+		assert.Equal(t, http.StatusInternalServerError, w.Result().StatusCode)
+
+		res := &utils.Problem{}
+		assert.Nil(t, json.NewDecoder(w.Body).Decode(res))
+		assert.Equal(t, hdl.ErrInternal.Error(), res.Detail)
+
+		panics := logs.FilterMessage("panic occurred").AllUntimed()
+		assert.Len(t, panics, 1)
+		assert.Equal(t, int64(http.StatusInternalServerError), logs.FilterMessage("request").AllUntimed()[0].ContextMap()["status"])
+	})
+
+	t.Run("PanicAfterWrite", func(t *testing.T) {
+		h := chain(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusAccepted)
+			panic("boom")
+		}), recoverer)
+
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/", nil))
+		assert.Equal(t, http.StatusAccepted, w.Result().StatusCode)
+		assert.Empty(t, w.Body.String())
+	})
+
+	t.Run("AbortHandler", func(t *testing.T) {
+		h := chain(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			panic(http.ErrAbortHandler)
+		}), recoverer)
+
+		assert.PanicsWithValue(t, http.ErrAbortHandler, func() {
+			h.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/", nil))
+		})
+	})
+}
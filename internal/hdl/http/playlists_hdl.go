@@ -0,0 +1,582 @@
+package http
+
+import (
+	"encoding/json"
+	"errors"
+	"github.com/JMURv/effectiveMobile/internal/ctrl"
+	"github.com/JMURv/effectiveMobile/internal/hdl"
+	"github.com/JMURv/effectiveMobile/internal/validation"
+	"github.com/JMURv/effectiveMobile/pkg/model"
+	utils "github.com/JMURv/effectiveMobile/pkg/utils/http"
+	"github.com/JMURv/effectiveMobile/pkg/utils/logger"
+	"go.uber.org/zap"
+	"io"
+	"net/http"
+	"strconv"
+)
+
+type PlaylistRequest struct {
+	Name        string `json:"name"`
+	Description string `json:"description"`
+}
+
+type DuplicatePlaylistRequest struct {
+	Name string `json:"name"`
+}
+
+type PlaylistSongRequest struct {
+	SongID   uint64 `json:"song_id"`
+	Position int    `json:"position"`
+}
+
+type PlaylistPosition struct {
+	Position int `json:"position"`
+}
+
+// ListPlaylists
+// @Summary Список плейлистов
+// @Description Получить список плейлистов, недавно изменённые первыми
+// @Tags playlists
+// @Param page query int false "Номер страницы" default(1)
+// @Param size query int false "Размер страницы" default(40)
+// @Param name query string false "Фильтр по названию плейлиста"
+// @Success 200 {object} model.PaginatedPlaylists "Список плейлистов с пагинацией"
+// @Failure 400 {object} utils.Problem "Некорректный номер или размер страницы"
+// @Security ApiKeyAuth
+// @Security BearerAuth
+// @Failure 401 {object} utils.Problem "Требуется аутентификация"
+// @Failure 403 {object} utils.Problem "Недостаточно прав, требуется роль reader"
+// @Failure 500 {object} utils.Problem "Внутренняя ошибка сервера"
+// @Router /api/playlists [get]
+func (h *Handler) ListPlaylists(w http.ResponseWriter, r *http.Request) {
+	const op = "songs.ListPlaylists.hdl"
+
+	page, err := strconv.Atoi(r.URL.Query().Get("page"))
+	if err != nil {
+		page = 1
+	}
+
+	size, err := strconv.Atoi(r.URL.Query().Get("size"))
+	if err != nil {
+		size = 40
+	}
+
+	if err := validation.ValidatePage(page, size); err != nil {
+		logger.FromContext(r.Context()).Debug(
+			"failed to validate page",
+			zap.Error(err), zap.String("op", op),
+		)
+		utils.ErrResponse(w, r, http.StatusBadRequest, err)
+		return
+	}
+
+	res, err := h.ctrl.ListPlaylists(r.Context(), page, size, r.URL.Query().Get("name"))
+	if err != nil {
+		utils.ErrResponse(w, r, http.StatusInternalServerError, hdl.ErrInternal)
+		return
+	}
+
+	utils.SuccessPaginatedResponse(w, http.StatusOK, res)
+}
+
+// GetPlaylist
+// @Summary Получить плейлист по ID
+// @Tags playlists
+// @Param id path int true "ID плейлиста"
+// @Success 200 {object} model.Playlist "Плейлист"
+// @Failure 400 {object} utils.Problem "Некорректный запрос"
+// @Failure 404 {object} utils.Problem "Плейлист не найден"
+// @Security ApiKeyAuth
+// @Security BearerAuth
+// @Failure 401 {object} utils.Problem "Требуется аутентификация"
+// @Failure 403 {object} utils.Problem "Недостаточно прав, требуется роль reader"
+// @Failure 500 {object} utils.Problem "Внутренняя ошибка сервера"
+// @Router /api/playlists/{id} [get]
+func (h *Handler) GetPlaylist(w http.ResponseWriter, r *http.Request) {
+	const op = "songs.GetPlaylist.hdl"
+
+	id, err := strconv.ParseUint(r.PathValue("id"), 10, 64)
+	if err != nil {
+		logger.FromContext(r.Context()).Debug(
+			"failed to extract param",
+			zap.Error(err), zap.String("op", op),
+		)
+		utils.ErrResponse(w, r, http.StatusBadRequest, hdl.ErrMissingPlaylistID)
+		return
+	}
+
+	res, err := h.ctrl.GetPlaylist(r.Context(), id)
+	if err != nil && errors.Is(err, ctrl.ErrNotFound) {
+		utils.ErrResponse(w, r, http.StatusNotFound, err)
+		return
+	} else if err != nil {
+		utils.ErrResponse(w, r, http.StatusInternalServerError, hdl.ErrInternal)
+		return
+	}
+
+	utils.SuccessResponse(w, http.StatusOK, res)
+}
+
+// ListPlaylistSongs
+// @Summary Песни плейлиста
+// @Description Получить песни плейлиста в порядке их позиций, data содержит массив model.PlaylistEntry
+// @Tags playlists
+// @Param id path int true "ID плейлиста"
+// @Param page query int false "Номер страницы" default(1)
+// @Param size query int false "Размер страницы" default(40)
+// @Success 200 {object} model.PaginatedSongs "Песни плейлиста с пагинацией"
+// @Failure 400 {object} utils.Problem "Некорректный запрос"
+// @Failure 404 {object} utils.Problem "Плейлист не найден"
+// @Security ApiKeyAuth
+// @Security BearerAuth
+// @Failure 401 {object} utils.Problem "Требуется аутентификация"
+// @Failure 403 {object} utils.Problem "Недостаточно прав, требуется роль reader"
+// @Failure 500 {object} utils.Problem "Внутренняя ошибка сервера"
+// @Router /api/playlists/{id}/songs [get]
+func (h *Handler) ListPlaylistSongs(w http.ResponseWriter, r *http.Request) {
+	const op = "songs.ListPlaylistSongs.hdl"
+
+	id, err := strconv.ParseUint(r.PathValue("id"), 10, 64)
+	if err != nil {
+		logger.FromContext(r.Context()).Debug(
+			"failed to extract param",
+			zap.Error(err), zap.String("op", op),
+		)
+		utils.ErrResponse(w, r, http.StatusBadRequest, hdl.ErrMissingPlaylistID)
+		return
+	}
+
+	page, err := strconv.Atoi(r.URL.Query().Get("page"))
+	if err != nil {
+		page = 1
+	}
+
+	size, err := strconv.Atoi(r.URL.Query().Get("size"))
+	if err != nil {
+		size = 40
+	}
+
+	if err := validation.ValidatePage(page, size); err != nil {
+		logger.FromContext(r.Context()).Debug(
+			"failed to validate page",
+			zap.Error(err), zap.String("op", op),
+		)
+		utils.ErrResponse(w, r, http.StatusBadRequest, err)
+		return
+	}
+
+	res, err := h.ctrl.ListPlaylistSongs(r.Context(), id, page, size)
+	if err != nil && errors.Is(err, ctrl.ErrNotFound) {
+		utils.ErrResponse(w, r, http.StatusNotFound, err)
+		return
+	} else if err != nil {
+		utils.ErrResponse(w, r, http.StatusInternalServerError, hdl.ErrInternal)
+		return
+	}
+
+	utils.SuccessPaginatedResponse(w, http.StatusOK, res)
+}
+
+// CreatePlaylist
+// @Summary Создать плейлист
+// @Tags playlists
+// @Accept json
+// @Param playlist body PlaylistRequest true "Данные плейлиста"
+// @Success 201 {object} model.Playlist "Созданный плейлист"
+// @Failure 400 {object} utils.Problem "Ошибка валидации или декодирования запроса"
+// @Security ApiKeyAuth
+// @Security BearerAuth
+// @Failure 401 {object} utils.Problem "Требуется аутентификация"
+// @Failure 403 {object} utils.Problem "Недостаточно прав, требуется роль editor"
+// @Failure 500 {object} utils.Problem "Внутренняя ошибка сервера"
+// @Router /api/playlists [post]
+func (h *Handler) CreatePlaylist(w http.ResponseWriter, r *http.Request) {
+	const op = "songs.CreatePlaylist.hdl"
+
+	req := &model.Playlist{}
+	if err := json.NewDecoder(r.Body).Decode(req); err != nil {
+		logger.FromContext(r.Context()).Debug(
+			"failed to decode request",
+			zap.Error(err), zap.String("op", op),
+		)
+		utils.ErrResponse(w, r, http.StatusBadRequest, hdl.ErrDecodeRequest)
+		return
+	}
+
+	validation.NormalizePlaylist(req)
+	if err := validation.ValidatePlaylist(req); err != nil {
+		logger.FromContext(r.Context()).Debug(
+			"failed to validate request",
+			zap.Error(err), zap.String("op", op),
+			zap.Any("req", req),
+		)
+		utils.ErrResponse(w, r, http.StatusBadRequest, err)
+		return
+	}
+
+	res, err := h.ctrl.CreatePlaylist(r.Context(), req)
+	if err != nil {
+		utils.ErrResponse(w, r, http.StatusInternalServerError, hdl.ErrInternal)
+		return
+	}
+
+	utils.SuccessResponse(w, http.StatusCreated, res)
+}
+
+// UpdatePlaylist
+// @Summary Обновить плейлист
+// @Description Заменяет название и описание плейлиста, песни не меняются
+// @Tags playlists
+// @Accept json
+// @Param id path int true "ID плейлиста"
+// @Param playlist body PlaylistRequest true "Новые данные плейлиста"
+// @Success 200 {object} string "Плейлист успешно обновлён"
+// @Failure 400 {object} utils.Problem "Ошибка валидации или декодирования запроса"
+// @Failure 404 {object} utils.Problem "Плейлист не найден"
+// @Security ApiKeyAuth
+// @Security BearerAuth
+// @Failure 401 {object} utils.Problem "Требуется аутентификация"
+// @Failure 403 {object} utils.Problem "Недостаточно прав, требуется роль editor"
+// @Failure 500 {object} utils.Problem "Внутренняя ошибка сервера"
+// @Router /api/playlists/{id} [put]
+func (h *Handler) UpdatePlaylist(w http.ResponseWriter, r *http.Request) {
+	const op = "songs.UpdatePlaylist.hdl"
+
+	id, err := strconv.ParseUint(r.PathValue("id"), 10, 64)
+	if err != nil {
+		logger.FromContext(r.Context()).Debug(
+			"failed to extract param",
+			zap.Error(err), zap.String("op", op),
+		)
+		utils.ErrResponse(w, r, http.StatusBadRequest, hdl.ErrMissingPlaylistID)
+		return
+	}
+
+	req := &model.Playlist{}
+	if err := json.NewDecoder(r.Body).Decode(req); err != nil {
+		logger.FromContext(r.Context()).Debug(
+			"failed to decode request",
+			zap.Error(err), zap.String("op", op),
+		)
+		utils.ErrResponse(w, r, http.StatusBadRequest, hdl.ErrDecodeRequest)
+		return
+	}
+	req.ID = id
+
+	validation.NormalizePlaylist(req)
+	if err := validation.ValidatePlaylist(req); err != nil {
+		logger.FromContext(r.Context()).Debug(
+			"failed to validate request",
+			zap.Error(err), zap.String("op", op),
+			zap.Any("req", req),
+		)
+		utils.ErrResponse(w, r, http.StatusBadRequest, err)
+		return
+	}
+
+	err = h.ctrl.UpdatePlaylist(r.Context(), req)
+	if err != nil && errors.Is(err, ctrl.ErrNotFound) {
+		utils.ErrResponse(w, r, http.StatusNotFound, err)
+		return
+	} else if err != nil {
+		utils.ErrResponse(w, r, http.StatusInternalServerError, hdl.ErrInternal)
+		return
+	}
+
+	utils.SuccessResponse(w, http.StatusOK, "OK")
+}
+
+// DeletePlaylist
+// @Summary Удалить плейлист
+// @Tags playlists
+// @Param id path int true "ID плейлиста"
+// @Success 204 {object} string "Плейлист успешно удалён"
+// @Failure 400 {object} utils.Problem "Некорректный запрос"
+// @Failure 404 {object} utils.Problem "Плейлист не найден"
+// @Security ApiKeyAuth
+// @Security BearerAuth
+// @Failure 401 {object} utils.Problem "Требуется аутентификация"
+// @Failure 403 {object} utils.Problem "Недостаточно прав, требуется роль admin"
+// @Failure 500 {object} utils.Problem "Внутренняя ошибка сервера"
+// @Router /api/playlists/{id} [delete]
+func (h *Handler) DeletePlaylist(w http.ResponseWriter, r *http.Request) {
+	const op = "songs.DeletePlaylist.hdl"
+
+	id, err := strconv.ParseUint(r.PathValue("id"), 10, 64)
+	if err != nil {
+		logger.FromContext(r.Context()).Debug(
+			"failed to extract param",
+			zap.Error(err), zap.String("op", op),
+		)
+		utils.ErrResponse(w, r, http.StatusBadRequest, hdl.ErrMissingPlaylistID)
+		return
+	}
+
+	err = h.ctrl.DeletePlaylist(r.Context(), id)
+	if err != nil && errors.Is(err, ctrl.ErrNotFound) {
+		utils.ErrResponse(w, r, http.StatusNotFound, err)
+		return
+	} else if err != nil {
+		utils.ErrResponse(w, r, http.StatusInternalServerError, hdl.ErrInternal)
+		return
+	}
+
+	utils.SuccessResponse(w, http.StatusNoContent, "OK")
+}
+
+// DuplicatePlaylist
+// @Summary Дублировать плейлист
+// @Description Создаёт копию плейлиста с теми же песнями в том же порядке. Без названия копия получает название оригинала с пометкой "(copy)"
+// @Tags playlists
+// @Accept json
+// @Param id path int true "ID плейлиста"
+// @Param playlist body DuplicatePlaylistRequest false "Название копии"
+// @Success 201 {object} model.Playlist "Созданная копия"
+// @Failure 400 {object} utils.Problem "Ошибка валидации или декодирования запроса"
+// @Failure 404 {object} utils.Problem "Плейлист не найден"
+// @Security ApiKeyAuth
+// @Security BearerAuth
+// @Failure 401 {object} utils.Problem "Требуется аутентификация"
+// @Failure 403 {object} utils.Problem "Недостаточно прав, требуется роль editor"
+// @Failure 500 {object} utils.Problem "Внутренняя ошибка сервера"
+// @Router /api/playlists/{id}/duplicate [post]
+func (h *Handler) DuplicatePlaylist(w http.ResponseWriter, r *http.Request) {
+	const op = "songs.DuplicatePlaylist.hdl"
+
+	id, err := strconv.ParseUint(r.PathValue("id"), 10, 64)
+	if err != nil {
+		logger.FromContext(r.Context()).Debug(
+			"failed to extract param",
+			zap.Error(err), zap.String("op", op),
+		)
+		utils.ErrResponse(w, r, http.StatusBadRequest, hdl.ErrMissingPlaylistID)
+		return
+	}
+
+	// The body is optional
+	req := &DuplicatePlaylistRequest{}
+	if err := json.NewDecoder(r.Body).Decode(req); err != nil && !errors.Is(err, io.EOF) {
+		logger.FromContext(r.Context()).Debug(
+			"failed to decode request",
+			zap.Error(err), zap.String("op", op),
+		)
+		utils.ErrResponse(w, r, http.StatusBadRequest, hdl.ErrDecodeRequest)
+		return
+	}
+
+	req.Name = validation.NormalizeName(req.Name)
+	if err := validation.ValidatePlaylistCopy(req.Name); err != nil {
+		logger.FromContext(r.Context()).Debug(
+			"failed to validate request",
+			zap.Error(err), zap.String("op", op),
+			zap.Any("req", req),
+		)
+		utils.ErrResponse(w, r, http.StatusBadRequest, err)
+		return
+	}
+
+	res, err := h.ctrl.DuplicatePlaylist(r.Context(), id, req.Name)
+	if err != nil && errors.Is(err, ctrl.ErrNotFound) {
+		utils.ErrResponse(w, r, http.StatusNotFound, err)
+		return
+	} else if err != nil {
+		utils.ErrResponse(w, r, http.StatusInternalServerError, hdl.ErrInternal)
+		return
+	}
+
+	utils.SuccessResponse(w, http.StatusCreated, res)
+}
+
+// AddPlaylistSong
+// @Summary Добавить песню в плейлист
+// @Description Вставляет песню на позицию, сдвигая следующие песни. Без позиции или за концом списка песня добавляется в конец
+// @Tags playlists
+// @Accept json
+// @Param id path int true "ID плейлиста"
+// @Param song body PlaylistSongRequest true "ID песни и позиция"
+// @Success 201 {object} PlaylistPosition "Позиция добавленной песни"
+// @Failure 400 {object} utils.Problem "Ошибка валидации, декодирования запроса или песня не найдена"
+// @Failure 404 {object} utils.Problem "Плейлист не найден"
+// @Failure 409 {object} utils.Problem "Песня уже есть в плейлисте или плейлист изменён параллельным запросом"
+// @Security ApiKeyAuth
+// @Security BearerAuth
+// @Failure 401 {object} utils.Problem "Требуется аутентификация"
+// @Failure 403 {object} utils.Problem "Недостаточно прав, требуется роль editor"
+// @Failure 500 {object} utils.Problem "Внутренняя ошибка сервера"
+// @Router /api/playlists/{id}/songs [post]
+func (h *Handler) AddPlaylistSong(w http.ResponseWriter, r *http.Request) {
+	const op = "songs.AddPlaylistSong.hdl"
+
+	id, err := strconv.ParseUint(r.PathValue("id"), 10, 64)
+	if err != nil {
+		logger.FromContext(r.Context()).Debug(
+			"failed to extract param",
+			zap.Error(err), zap.String("op", op),
+		)
+		utils.ErrResponse(w, r, http.StatusBadRequest, hdl.ErrMissingPlaylistID)
+		return
+	}
+
+	req := &PlaylistSongRequest{}
+	if err := json.NewDecoder(r.Body).Decode(req); err != nil {
+		logger.FromContext(r.Context()).Debug(
+			"failed to decode request",
+			zap.Error(err), zap.String("op", op),
+		)
+		utils.ErrResponse(w, r, http.StatusBadRequest, hdl.ErrDecodeRequest)
+		return
+	}
+
+	if err := validation.ValidatePlaylistSong(req.SongID, req.Position); err != nil {
+		logger.FromContext(r.Context()).Debug(
+			"failed to validate request",
+			zap.Error(err), zap.String("op", op),
+			zap.Any("req", req),
+		)
+		utils.ErrResponse(w, r, http.StatusBadRequest, err)
+		return
+	}
+
+	res, err := h.ctrl.AddPlaylistSong(r.Context(), id, req.SongID, req.Position)
+	if err != nil && errors.Is(err, ctrl.ErrNotFound) {
+		utils.ErrResponse(w, r, http.StatusNotFound, err)
+		return
+	} else if err != nil && errors.Is(err, ctrl.ErrSongNotFound) {
+		utils.ErrResponse(w, r, http.StatusBadRequest, err)
+		return
+	} else if err != nil && (errors.Is(err, ctrl.ErrAlreadyInPlaylist) || errors.Is(err, ctrl.ErrPlaylistChanged)) {
+		utils.ErrResponse(w, r, http.StatusConflict, err)
+		return
+	} else if err != nil {
+		utils.ErrResponse(w, r, http.StatusInternalServerError, hdl.ErrInternal)
+		return
+	}
+
+	utils.SuccessResponse(w, http.StatusCreated, &PlaylistPosition{Position: res})
+}
+
+// MovePlaylistSong
+// @Summary Переместить песню в плейлисте
+// @Description Ставит песню на позицию, сдвигая песни между старой и новой позициями. Позиция за концом списка перемещает песню в конец
+// @Tags playlists
+// @Accept json
+// @Param id path int true "ID плейлиста"
+// @Param song_id path int true "ID песни"
+// @Param position body PlaylistPosition true "Новая позиция"
+// @Success 200 {object} PlaylistPosition "Итоговая позиция песни"
+// @Failure 400 {object} utils.Problem "Ошибка валидации или декодирования запроса"
+// @Failure 404 {object} utils.Problem "Плейлист не найден или песни в нём нет"
+// @Failure 409 {object} utils.Problem "Плейлист изменён параллельным запросом"
+// @Security ApiKeyAuth
+// @Security BearerAuth
+// @Failure 401 {object} utils.Problem "Требуется аутентификация"
+// @Failure 403 {object} utils.Problem "Недостаточно прав, требуется роль editor"
+// @Failure 500 {object} utils.Problem "Внутренняя ошибка сервера"
+// @Router /api/playlists/{id}/songs/{song_id} [put]
+func (h *Handler) MovePlaylistSong(w http.ResponseWriter, r *http.Request) {
+	const op = "songs.MovePlaylistSong.hdl"
+
+	id, err := strconv.ParseUint(r.PathValue("id"), 10, 64)
+	if err != nil {
+		logger.FromContext(r.Context()).Debug(
+			"failed to extract param",
+			zap.Error(err), zap.String("op", op),
+		)
+		utils.ErrResponse(w, r, http.StatusBadRequest, hdl.ErrMissingPlaylistID)
+		return
+	}
+
+	songID, err := strconv.ParseUint(r.PathValue("song_id"), 10, 64)
+	if err != nil {
+		logger.FromContext(r.Context()).Debug(
+			"failed to extract param",
+			zap.Error(err), zap.String("op", op),
+		)
+		utils.ErrResponse(w, r, http.StatusBadRequest, hdl.ErrMissingSongID)
+		return
+	}
+
+	req := &PlaylistPosition{}
+	if err := json.NewDecoder(r.Body).Decode(req); err != nil {
+		logger.FromContext(r.Context()).Debug(
+			"failed to decode request",
+			zap.Error(err), zap.String("op", op),
+		)
+		utils.ErrResponse(w, r, http.StatusBadRequest, hdl.ErrDecodeRequest)
+		return
+	}
+
+	if err := validation.ValidatePosition(req.Position); err != nil {
+		logger.FromContext(r.Context()).Debug(
+			"failed to validate request",
+			zap.Error(err), zap.String("op", op),
+			zap.Any("req", req),
+		)
+		utils.ErrResponse(w, r, http.StatusBadRequest, err)
+		return
+	}
+
+	res, err := h.ctrl.MovePlaylistSong(r.Context(), id, songID, req.Position)
+	if err != nil && errors.Is(err, ctrl.ErrNotFound) {
+		utils.ErrResponse(w, r, http.StatusNotFound, err)
+		return
+	} else if err != nil && errors.Is(err, ctrl.ErrPlaylistChanged) {
+		utils.ErrResponse(w, r, http.StatusConflict, err)
+		return
+	} else if err != nil {
+		utils.ErrResponse(w, r, http.StatusInternalServerError, hdl.ErrInternal)
+		return
+	}
+
+	utils.SuccessResponse(w, http.StatusOK, &PlaylistPosition{Position: res})
+}
+
+// RemovePlaylistSong
+// @Summary Убрать песню из плейлиста
+// @Description Следующие песни сдвигаются на освободившуюся позицию
+// @Tags playlists
+// @Param id path int true "ID плейлиста"
+// @Param song_id path int true "ID песни"
+// @Success 204 {object} string "Песня убрана из плейлиста"
+// @Failure 400 {object} utils.Problem "Некорректный запрос"
+// @Failure 404 {object} utils.Problem "Плейлист не найден или песни в нём нет"
+// @Security ApiKeyAuth
+// @Security BearerAuth
+// @Failure 401 {object} utils.Problem "Требуется аутентификация"
+// @Failure 403 {object} utils.Problem "Недостаточно прав, требуется роль editor"
+// @Failure 500 {object} utils.Problem "Внутренняя ошибка сервера"
+// @Router /api/playlists/{id}/songs/{song_id} [delete]
+func (h *Handler) RemovePlaylistSong(w http.ResponseWriter, r *http.Request) {
+	const op = "songs.RemovePlaylistSong.hdl"
+
+	id, err := strconv.ParseUint(r.PathValue("id"), 10, 64)
+	if err != nil {
+		logger.FromContext(r.Context()).Debug(
+			"failed to extract param",
+			zap.Error(err), zap.String("op", op),
+		)
+		utils.ErrResponse(w, r, http.StatusBadRequest, hdl.ErrMissingPlaylistID)
+		return
+	}
+
+	songID, err := strconv.ParseUint(r.PathValue("song_id"), 10, 64)
+	if err != nil {
+		logger.FromContext(r.Context()).Debug(
+			"failed to extract param",
+			zap.Error(err), zap.String("op", op),
+		)
+		utils.ErrResponse(w, r, http.StatusBadRequest, hdl.ErrMissingSongID)
+		return
+	}
+
+	err = h.ctrl.RemovePlaylistSong(r.Context(), id, songID)
+	if err != nil && errors.Is(err, ctrl.ErrNotFound) {
+		utils.ErrResponse(w, r, http.StatusNotFound, err)
+		return
+	} else if err != nil {
+		utils.ErrResponse(w, r, http.StatusInternalServerError, hdl.ErrInternal)
+		return
+	}
+
+	utils.SuccessResponse(w, http.StatusNoContent, "OK")
+}
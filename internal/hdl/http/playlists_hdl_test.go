@@ -0,0 +1,367 @@
+package http
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"github.com/JMURv/effectiveMobile/internal/ctrl"
+	"github.com/JMURv/effectiveMobile/mocks"
+	"github.com/JMURv/effectiveMobile/pkg/model"
+	utils "github.com/JMURv/effectiveMobile/pkg/utils/http"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestHandler_ListPlaylists(t *testing.T) {
+	ctrlMock := gomock.NewController(t)
+	defer ctrlMock.Finish()
+
+	ctrlRepo := mocks.NewMockCtrl(ctrlMock)
+	hdl := New(ctrlRepo)
+
+	ctx := context.Background()
+
+	t.Run("Success", func(t *testing.T) {
+		ctrlRepo.EXPECT().ListPlaylists(ctx, 1, 40, "road").Return(&model.PaginatedPlaylists{
+			Data:        []*model.Playlist{{ID: 1, Name: "Road trip"}},
+			Count:       1,
+			TotalPages:  1,
+			CurrentPage: 1,
+		}, nil).Times(1)
+
+		req := httptest.NewRequest(http.MethodGet, "/api/playlists?name=road", nil)
+		req = req.WithContext(ctx)
+
+		w := httptest.NewRecorder()
+		hdl.routes().ServeHTTP(w, req)
+		assert.Equal(t, http.StatusOK, w.Result().StatusCode)
+	})
+
+	t.Run("InvalidPage", func(t *testing.T) {
+		for _, query := range []string{"size=0", "size=-1", "page=0"} {
+			req := httptest.NewRequest(http.MethodGet, "/api/playlists?"+query, nil)
+			req = req.WithContext(ctx)
+
+			w := httptest.NewRecorder()
+			hdl.routes().ServeHTTP(w, req)
+			assert.Equal(t, http.StatusBadRequest, w.Result().StatusCode, query)
+		}
+	})
+}
+
+func TestHandler_ListPlaylistSongs(t *testing.T) {
+	ctrlMock := gomock.NewController(t)
+	defer ctrlMock.Finish()
+
+	ctrlRepo := mocks.NewMockCtrl(ctrlMock)
+	hdl := New(ctrlRepo)
+
+	ctx := context.Background()
+
+	t.Run("Success", func(t *testing.T) {
+		ctrlRepo.EXPECT().ListPlaylistSongs(ctx, uint64(1), 2, 10).Return(&model.PaginatedSongs{
+			Data:        []*model.PlaylistEntry{{Position: 11, Song: &model.Song{ID: 3}}},
+			Count:       11,
+			TotalPages:  2,
+			CurrentPage: 2,
+		}, nil).Times(1)
+
+		req := httptest.NewRequest(http.MethodGet, "/api/playlists/1/songs?page=2&size=10", nil)
+		req = req.WithContext(ctx)
+
+		w := httptest.NewRecorder()
+		hdl.routes().ServeHTTP(w, req)
+		assert.Equal(t, http.StatusOK, w.Result().StatusCode)
+	})
+
+	t.Run("ErrNotFound", func(t *testing.T) {
+		ctrlRepo.EXPECT().ListPlaylistSongs(ctx, uint64(2), 1, 40).Return(nil, ctrl.ErrNotFound).Times(1)
+
+		req := httptest.NewRequest(http.MethodGet, "/api/playlists/2/songs", nil)
+		req = req.WithContext(ctx)
+
+		w := httptest.NewRecorder()
+		hdl.routes().ServeHTTP(w, req)
+		assert.Equal(t, http.StatusNotFound, w.Result().StatusCode)
+	})
+
+	t.Run("ErrMissingPlaylistID", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/api/playlists/abc/songs", nil)
+		req = req.WithContext(ctx)
+
+		w := httptest.NewRecorder()
+		hdl.routes().ServeHTTP(w, req)
+		assert.Equal(t, http.StatusBadRequest, w.Result().StatusCode)
+	})
+
+	t.Run("InvalidPage", func(t *testing.T) {
+		for _, query := range []string{"size=0", "size=-1", "page=0"} {
+			req := httptest.NewRequest(http.MethodGet, "/api/playlists/1/songs?"+query, nil)
+			req = req.WithContext(ctx)
+
+			w := httptest.NewRecorder()
+			hdl.routes().ServeHTTP(w, req)
+			assert.Equal(t, http.StatusBadRequest, w.Result().StatusCode, query)
+		}
+	})
+}
+
+func TestHandler_CreatePlaylist(t *testing.T) {
+	ctrlMock := gomock.NewController(t)
+	defer ctrlMock.Finish()
+
+	ctrlRepo := mocks.NewMockCtrl(ctrlMock)
+	hdl := New(ctrlRepo)
+
+	ctx := context.Background()
+
+	t.Run("Success", func(t *testing.T) {
+		ctrlRepo.EXPECT().CreatePlaylist(ctx, &model.Playlist{Name: "Road trip", Description: "Long drives"}).
+			Return(&model.Playlist{ID: 1, Name: "Road trip", Description: "Long drives"}, nil).
+			Times(1)
+
+		req := httptest.NewRequest(http.MethodPost, "/api/playlists", bytes.NewBufferString(`{"name": " Road  trip ", "description": " Long drives "}`))
+		req = req.WithContext(ctx)
+
+		w := httptest.NewRecorder()
+		hdl.routes().ServeHTTP(w, req)
+		assert.Equal(t, http.StatusCreated, w.Result().StatusCode)
+	})
+
+	t.Run("ErrValidation", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodPost, "/api/playlists", bytes.NewBufferString(`{"description": "No name"}`))
+		req = req.WithContext(ctx)
+
+		w := httptest.NewRecorder()
+		hdl.routes().ServeHTTP(w, req)
+		assert.Equal(t, http.StatusBadRequest, w.Result().StatusCode)
+
+		res := &utils.Problem{}
+		assert.Nil(t, json.NewDecoder(w.Body).Decode(res))
+		assert.Equal(t, "/name", res.Errors[0].Pointer)
+	})
+}
+
+func TestHandler_DuplicatePlaylist(t *testing.T) {
+	ctrlMock := gomock.NewController(t)
+	defer ctrlMock.Finish()
+
+	ctrlRepo := mocks.NewMockCtrl(ctrlMock)
+	hdl := New(ctrlRepo)
+
+	ctx := context.Background()
+
+	t.Run("SuccessWithoutBody", func(t *testing.T) {
+		ctrlRepo.EXPECT().DuplicatePlaylist(ctx, uint64(1), "").Return(&model.Playlist{ID: 2, Name: "Road trip (copy)"}, nil).Times(1)
+
+		req := httptest.NewRequest(http.MethodPost, "/api/playlists/1/duplicate", nil)
+		req = req.WithContext(ctx)
+
+		w := httptest.NewRecorder()
+		hdl.routes().ServeHTTP(w, req)
+		assert.Equal(t, http.StatusCreated, w.Result().StatusCode)
+	})
+
+	t.Run("SuccessWithName", func(t *testing.T) {
+		ctrlRepo.EXPECT().DuplicatePlaylist(ctx, uint64(1), "Summer").Return(&model.Playlist{ID: 3, Name: "Summer"}, nil).Times(1)
+
+		req := httptest.NewRequest(http.MethodPost, "/api/playlists/1/duplicate", bytes.NewBufferString(`{"name": " Summer "}`))
+		req = req.WithContext(ctx)
+
+		w := httptest.NewRecorder()
+		hdl.routes().ServeHTTP(w, req)
+		assert.Equal(t, http.StatusCreated, w.Result().StatusCode)
+	})
+
+	t.Run("ErrNotFound", func(t *testing.T) {
+		ctrlRepo.EXPECT().DuplicatePlaylist(ctx, uint64(4), "").Return(nil, ctrl.ErrNotFound).Times(1)
+
+		req := httptest.NewRequest(http.MethodPost, "/api/playlists/4/duplicate", nil)
+		req = req.WithContext(ctx)
+
+		w := httptest.NewRecorder()
+		hdl.routes().ServeHTTP(w, req)
+		assert.Equal(t, http.StatusNotFound, w.Result().StatusCode)
+	})
+}
+
+func TestHandler_AddPlaylistSong(t *testing.T) {
+	ctrlMock := gomock.NewController(t)
+	defer ctrlMock.Finish()
+
+	ctrlRepo := mocks.NewMockCtrl(ctrlMock)
+	hdl := New(ctrlRepo)
+
+	ctx := context.Background()
+
+	t.Run("Success", func(t *testing.T) {
+		ctrlRepo.EXPECT().AddPlaylistSong(ctx, uint64(1), uint64(2), 0).Return(5, nil).Times(1)
+
+		req := httptest.NewRequest(http.MethodPost, "/api/playlists/1/songs", bytes.NewBufferString(`{"song_id": 2}`))
+		req = req.WithContext(ctx)
+
+		w := httptest.NewRecorder()
+		hdl.routes().ServeHTTP(w, req)
+		assert.Equal(t, http.StatusCreated, w.Result().StatusCode)
+
+		res := &struct {
+			Data PlaylistPosition `json:"data"`
+		}{}
+		assert.Nil(t, json.NewDecoder(w.Body).Decode(res))
+		assert.Equal(t, 5, res.Data.Position)
+	})
+
+	t.Run("ErrValidation", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodPost, "/api/playlists/1/songs", bytes.NewBufferString(`{"position": -1}`))
+		req = req.WithContext(ctx)
+
+		w := httptest.NewRecorder()
+		hdl.routes().ServeHTTP(w, req)
+		assert.Equal(t, http.StatusBadRequest, w.Result().StatusCode)
+
+		res := &utils.Problem{}
+		assert.Nil(t, json.NewDecoder(w.Body).Decode(res))
+		assert.Len(t, res.Errors, 2)
+	})
+
+	t.Run("ErrSongNotFound", func(t *testing.T) {
+		ctrlRepo.EXPECT().AddPlaylistSong(ctx, uint64(1), uint64(9), 1).Return(0, ctrl.ErrSongNotFound).Times(1)
+
+		req := httptest.NewRequest(http.MethodPost, "/api/playlists/1/songs", bytes.NewBufferString(`{"song_id": 9, "position": 1}`))
+		req = req.WithContext(ctx)
+
+		w := httptest.NewRecorder()
+		hdl.routes().ServeHTTP(w, req)
+		assert.Equal(t, http.StatusBadRequest, w.Result().StatusCode)
+	})
+
+	t.Run("ErrAlreadyInPlaylist", func(t *testing.T) {
+		ctrlRepo.EXPECT().AddPlaylistSong(ctx, uint64(1), uint64(2), 0).Return(0, ctrl.ErrAlreadyInPlaylist).Times(1)
+
+		req := httptest.NewRequest(http.MethodPost, "/api/playlists/1/songs", bytes.NewBufferString(`{"song_id": 2}`))
+		req = req.WithContext(ctx)
+
+		w := httptest.NewRecorder()
+		hdl.routes().ServeHTTP(w, req)
+		assert.Equal(t, http.StatusConflict, w.Result().StatusCode)
+	})
+
+	t.Run("ErrPlaylistChanged", func(t *testing.T) {
+		ctrlRepo.EXPECT().AddPlaylistSong(ctx, uint64(1), uint64(4), 0).Return(0, ctrl.ErrPlaylistChanged).Times(1)
+
+		req := httptest.NewRequest(http.MethodPost, "/api/playlists/1/songs", bytes.NewBufferString(`{"song_id": 4}`))
+		req = req.WithContext(ctx)
+
+		w := httptest.NewRecorder()
+		hdl.routes().ServeHTTP(w, req)
+		assert.Equal(t, http.StatusConflict, w.Result().StatusCode)
+	})
+
+	t.Run("ErrNotFound", func(t *testing.T) {
+		ctrlRepo.EXPECT().AddPlaylistSong(ctx, uint64(3), uint64(2), 0).Return(0, ctrl.ErrNotFound).Times(1)
+
+		req := httptest.NewRequest(http.MethodPost, "/api/playlists/3/songs", bytes.NewBufferString(`{"song_id": 2}`))
+		req = req.WithContext(ctx)
+
+		w := httptest.NewRecorder()
+		hdl.routes().ServeHTTP(w, req)
+		assert.Equal(t, http.StatusNotFound, w.Result().StatusCode)
+	})
+}
+
+func TestHandler_MovePlaylistSong(t *testing.T) {
+	ctrlMock := gomock.NewController(t)
+	defer ctrlMock.Finish()
+
+	ctrlRepo := mocks.NewMockCtrl(ctrlMock)
+	hdl := New(ctrlRepo)
+
+	ctx := context.Background()
+
+	t.Run("Success", func(t *testing.T) {
+		ctrlRepo.EXPECT().MovePlaylistSong(ctx, uint64(1), uint64(2), 1).Return(1, nil).Times(1)
+
+		req := httptest.NewRequest(http.MethodPut, "/api/playlists/1/songs/2", bytes.NewBufferString(`{"position": 1}`))
+		req = req.WithContext(ctx)
+
+		w := httptest.NewRecorder()
+		hdl.routes().ServeHTTP(w, req)
+		assert.Equal(t, http.StatusOK, w.Result().StatusCode)
+	})
+
+	t.Run("ErrValidation", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodPut, "/api/playlists/1/songs/2", bytes.NewBufferString(`{"position": 0}`))
+		req = req.WithContext(ctx)
+
+		w := httptest.NewRecorder()
+		hdl.routes().ServeHTTP(w, req)
+		assert.Equal(t, http.StatusBadRequest, w.Result().StatusCode)
+	})
+
+	t.Run("ErrMissingSongID", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodPut, "/api/playlists/1/songs/abc", bytes.NewBufferString(`{"position": 1}`))
+		req = req.WithContext(ctx)
+
+		w := httptest.NewRecorder()
+		hdl.routes().ServeHTTP(w, req)
+		assert.Equal(t, http.StatusBadRequest, w.Result().StatusCode)
+	})
+
+	t.Run("ErrNotFound", func(t *testing.T) {
+		ctrlRepo.EXPECT().MovePlaylistSong(ctx, uint64(1), uint64(7), 2).Return(0, ctrl.ErrNotFound).Times(1)
+
+		req := httptest.NewRequest(http.MethodPut, "/api/playlists/1/songs/7", bytes.NewBufferString(`{"position": 2}`))
+		req = req.WithContext(ctx)
+
+		w := httptest.NewRecorder()
+		hdl.routes().ServeHTTP(w, req)
+		assert.Equal(t, http.StatusNotFound, w.Result().StatusCode)
+	})
+
+	t.Run("ErrPlaylistChanged", func(t *testing.T) {
+		ctrlRepo.EXPECT().MovePlaylistSong(ctx, uint64(1), uint64(2), 3).Return(0, ctrl.ErrPlaylistChanged).Times(1)
+
+		req := httptest.NewRequest(http.MethodPut, "/api/playlists/1/songs/2", bytes.NewBufferString(`{"position": 3}`))
+		req = req.WithContext(ctx)
+
+		w := httptest.NewRecorder()
+		hdl.routes().ServeHTTP(w, req)
+		assert.Equal(t, http.StatusConflict, w.Result().StatusCode)
+	})
+}
+
+func TestHandler_RemovePlaylistSong(t *testing.T) {
+	ctrlMock := gomock.NewController(t)
+	defer ctrlMock.Finish()
+
+	ctrlRepo := mocks.NewMockCtrl(ctrlMock)
+	hdl := New(ctrlRepo)
+
+	ctx := context.Background()
+
+	t.Run("Success", func(t *testing.T) {
+		ctrlRepo.EXPECT().RemovePlaylistSong(ctx, uint64(1), uint64(2)).Return(nil).Times(1)
+
+		req := httptest.NewRequest(http.MethodDelete, "/api/playlists/1/songs/2", nil)
+		req = req.WithContext(ctx)
+
+		w := httptest.NewRecorder()
+		hdl.routes().ServeHTTP(w, req)
+		assert.Equal(t, http.StatusNoContent, w.Result().StatusCode)
+	})
+
+	t.Run("ErrInternalError", func(t *testing.T) {
+		ctrlRepo.EXPECT().RemovePlaylistSong(ctx, uint64(1), uint64(3)).Return(errors.New("other error")).Times(1)
+
+		req := httptest.NewRequest(http.MethodDelete, "/api/playlists/1/songs/3", nil)
+		req = req.WithContext(ctx)
+
+		w := httptest.NewRecorder()
+		hdl.routes().ServeHTTP(w, req)
+		assert.Equal(t, http.StatusInternalServerError, w.Result().StatusCode)
+	})
+}
@@ -0,0 +1,63 @@
+package http
+
+import (
+	"github.com/JMURv/effectiveMobile/internal/hdl"
+	utils "github.com/JMURv/effectiveMobile/pkg/utils/http"
+	"net/http"
+	"strings"
+)
+
+var routerMethods = []string{
+	http.MethodGet,
+	http.MethodHead,
+	http.MethodPost,
+	http.MethodPut,
+	http.MethodPatch,
+	http.MethodDelete,
+}
+
+// router wraps the pattern mux so that requests matching no route
+// get JSON 404 and 405 responses instead of the plain text ones
+type router struct {
+	mux *http.ServeMux
+}
+
+func newRouter() *router {
+	return &router{mux: http.NewServeMux()}
+}
+
+func (rt *router) Handle(pattern string, handler http.Handler) {
+	rt.mux.Handle(pattern, handler)
+}
+
+func (rt *router) HandleFunc(pattern string, handler http.HandlerFunc) {
+	rt.mux.HandleFunc(pattern, handler)
+}
+
+func (rt *router) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if _, pattern := rt.mux.Handler(r); pattern != "" {
+		rt.mux.ServeHTTP(w, r)
+		return
+	}
+
+	if allowed := rt.allowedMethods(r); len(allowed) > 0 {
+		w.Header().Set("Allow", strings.Join(allowed, ", "))
+		utils.ErrResponse(w, r, http.StatusMethodNotAllowed, hdl.ErrMethodNotAllowed)
+		return
+	}
+
+	utils.ErrResponse(w, r, http.StatusNotFound, hdl.ErrRouteNotFound)
+}
+
+// allowedMethods lists the methods some route accepts for the request path
+func (rt *router) allowedMethods(r *http.Request) []string {
+	res := make([]string, 0, len(routerMethods))
+	for _, method := range routerMethods {
+		probe := r.Clone(r.Context())
+		probe.Method = method
+		if _, pattern := rt.mux.Handler(probe); pattern != "" {
+			res = append(res, method)
+		}
+	}
+	return res
+}
@@ -0,0 +1,65 @@
+package http
+
+import (
+	"encoding/json"
+	"github.com/JMURv/effectiveMobile/internal/hdl"
+	"github.com/JMURv/effectiveMobile/mocks"
+	utils "github.com/JMURv/effectiveMobile/pkg/utils/http"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestHandler_Routes(t *testing.T) {
+	ctrlMock := gomock.NewController(t)
+	defer ctrlMock.Finish()
+
+	routes := New(mocks.NewMockCtrl(ctrlMock)).routes()
+
+	t.Run("HealthCheck", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		routes.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/health-check", nil))
+		assert.Equal(t, http.StatusOK, w.Result().StatusCode)
+	})
+
+	t.Run("UnknownRoute", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		routes.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/unknown", nil))
+		assert.Equal(t, http.StatusNotFound, w.Result().StatusCode)
+		assert.Equal(t, utils.ProblemContentType, w.Header().Get("Content-Type"))
+
+		res := &utils.Problem{}
+		assert.Nil(t, json.NewDecoder(w.Body).Decode(res))
+		assert.Equal(t, "about:blank", res.Type)
+		assert.Equal(t, http.StatusText(http.StatusNotFound), res.Title)
+		assert.Equal(t, http.StatusNotFound, res.Status)
+		assert.Equal(t, hdl.ErrRouteNotFound.Error(), res.Detail)
+		assert.Equal(t, "/api/unknown", res.Instance)
+	})
+
+	t.Run("UnknownNestedRoute", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		routes.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/songs/5/extra", nil))
+		assert.Equal(t, http.StatusNotFound, w.Result().StatusCode)
+	})
+
+	t.Run("MethodNotAllowed", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		routes.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/api/songs/5", nil))
+		assert.Equal(t, http.StatusMethodNotAllowed, w.Result().StatusCode)
+		assert.Equal(t, "GET, HEAD, PUT, PATCH, DELETE", w.Header().Get("Allow"))
+
+		res := &utils.Problem{}
+		assert.Nil(t, json.NewDecoder(w.Body).Decode(res))
+		assert.Equal(t, hdl.ErrMethodNotAllowed.Error(), res.Detail)
+	})
+
+	t.Run("MethodNotAllowedNested", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		routes.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/songs/5/refresh", nil))
+		assert.Equal(t, http.StatusMethodNotAllowed, w.Result().StatusCode)
+		assert.Equal(t, "POST", w.Header().Get("Allow"))
+	})
+}
@@ -1,6 +1,7 @@
 package http
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
 	"github.com/JMURv/effectiveMobile/internal/ctrl"
@@ -8,6 +9,7 @@ import (
 	"github.com/JMURv/effectiveMobile/internal/validation"
 	"github.com/JMURv/effectiveMobile/pkg/model"
 	utils "github.com/JMURv/effectiveMobile/pkg/utils/http"
+	"github.com/JMURv/effectiveMobile/pkg/utils/logger"
 	"go.uber.org/zap"
 	"net/http"
 	"strconv"
@@ -16,7 +18,8 @@ import (
 
 // ListSongs
 // @Summary Список песен
-// @Description Получить список песен с возможностью фильтрации и пагинации
+// @Description Получить список песен с возможностью фильтрации и пагинации.
+// @Description При передаче cursor или limit используется курсорная пагинация: ответ содержит next_cursor/prev_cursor вместо номеров страниц, count - только при with_count=true
 // @Tags songs
 // @Param page query int false "Номер страницы" default(1)
 // @Param size query int false "Размер страницы" default(40)
@@ -25,8 +28,23 @@ import (
 // @Param release_date query string false "Фильтр по дате релиза"
 // @Param min_release_date query string false "Фильтр по дате релиза (минимальная)"
 // @Param release_date query string false "Фильтр по дате релиза (максимальная)"
+// @Param q query string false "Полнотекстовый поиск по тексту песни, результаты ранжируются по релевантности"
+// @Param genre query string false "Фильтр по жанрам через запятую" example(rock,metal)
+// @Param genre_match query string false "Песня должна иметь любой (any) или все (all) из жанров" Enums(any, all) default(any)
+// @Param tag query string false "Фильтр по тегам через запятую" example(90s,workout)
+// @Param tag_match query string false "Песня должна иметь любой (any) или все (all) из тегов" Enums(any, all) default(any)
+// @Param facets query bool false "Добавить в ответ количество песен по жанрам и тегам для текущего фильтра" default(false)
+// @Param sort query string false "Сортировка через запятую, '-' перед полем - по убыванию. Доступные поля: id, group, song, release_date, created_at, updated_at" example(-release_date,group)
+// @Param cursor query string false "Курсор из next_cursor/prev_cursor, включает курсорную пагинацию"
+// @Param limit query int false "Размер страницы в курсорном режиме, включает курсорную пагинацию" default(40)
+// @Param with_count query bool false "Подсчитать общее количество песен в курсорном режиме" default(false)
 // @Success 200 {object} model.PaginatedSongs "Список песен с пагинацией"
-// @Failure 500 {object} utils.ErrorResponse "Внутренняя ошибка сервера"
+// @Failure 400 {object} utils.Problem "Некорректная сортировка, курсор или режим фильтра по жанрам и тегам"
+// @Security ApiKeyAuth
+// @Security BearerAuth
+// @Failure 401 {object} utils.Problem "Требуется аутентификация"
+// @Failure 403 {object} utils.Problem "Недостаточно прав, требуется роль reader"
+// @Failure 500 {object} utils.Problem "Внутренняя ошибка сервера"
 // @Router /api/songs [get]
 func (h *Handler) ListSongs(w http.ResponseWriter, r *http.Request) {
 	const op = "songs.ListSongs.hdl"
@@ -41,10 +59,65 @@ func (h *Handler) ListSongs(w http.ResponseWriter, r *http.Request) {
 		size = 40
 	}
 
+	if err := validation.ValidateSort(r.URL.Query().Get("sort")); err != nil {
+		logger.FromContext(r.Context()).Debug(
+			"failed to validate sort",
+			zap.Error(err), zap.String("op", op),
+		)
+		utils.ErrResponse(w, r, http.StatusBadRequest, err)
+		return
+	}
+
+	for _, key := range []string{"genre_match", "tag_match"} {
+		if err := validation.ValidateMatch(r.URL.Query().Get(key)); err != nil {
+			logger.FromContext(r.Context()).Debug(
+				"failed to validate match",
+				zap.Error(err), zap.String("op", op),
+				zap.String("key", key),
+			)
+			utils.ErrResponse(w, r, http.StatusBadRequest, err)
+			return
+		}
+	}
+
 	filters := utils.ParseFiltersByURL(r)
+	if facets, _ := strconv.ParseBool(r.URL.Query().Get("facets")); facets {
+		filters["facets"] = true
+	}
+
+	if r.URL.Query().Has("cursor") || r.URL.Query().Has("limit") {
+		limit, err := strconv.Atoi(r.URL.Query().Get("limit"))
+		if err != nil || limit < 1 {
+			limit = 40
+		}
+
+		cursor := r.URL.Query().Get("cursor")
+		if err := validation.ValidateCursor(cursor); err != nil {
+			logger.FromContext(r.Context()).Debug(
+				"failed to validate cursor",
+				zap.Error(err), zap.String("op", op),
+			)
+			utils.ErrResponse(w, r, http.StatusBadRequest, err)
+			return
+		}
+
+		withCount, _ := strconv.ParseBool(r.URL.Query().Get("with_count"))
+		res, err := h.ctrl.ListSongsCursor(r.Context(), cursor, limit, withCount, filters)
+		if err != nil && errors.Is(err, ctrl.ErrInvalidCursor) {
+			utils.ErrResponse(w, r, http.StatusBadRequest, err)
+			return
+		} else if err != nil {
+			utils.ErrResponse(w, r, http.StatusInternalServerError, hdl.ErrInternal)
+			return
+		}
+
+		utils.SuccessPaginatedResponse(w, http.StatusOK, res)
+		return
+	}
+
 	res, err := h.ctrl.ListSongs(r.Context(), page, size, filters)
 	if err != nil {
-		utils.ErrResponse(w, http.StatusInternalServerError, err)
+		utils.ErrResponse(w, r, http.StatusInternalServerError, hdl.ErrInternal)
 		return
 	}
 
@@ -53,28 +126,33 @@ func (h *Handler) ListSongs(w http.ResponseWriter, r *http.Request) {
 
 // GetSong
 // @Summary Получить песню по ID
-// @Description Получить информацию о песне и её тексте(пагинация)
+// @Description Получить информацию о песне и её тексте(пагинация).
+// @Description Если есть перевод на один из предпочитаемых языков, те же куплеты перевода возвращаются в translated_lyrics
 // @Tags songs
 // @Param id path int true "ID песни"
 // @Param page query int false "Номер куплета для пагинации текста песни" default(1)
 // @Param size query int false "Размер куплета для пагинации текста песни" default(40)
+// @Param lang query string false "Язык перевода (BCP 47), важнее Accept-Language"
+// @Param Accept-Language header string false "Предпочитаемые языки перевода"
 // @Success 200 {object} model.PaginatedSongs "Детали песни с пагинированным текстом"
-// @Failure 400 {object} utils.ErrorResponse "Некорректный запрос"
-// @Failure 404 {object} utils.ErrorResponse "Песня не найдена"
-// @Failure 500 {object} utils.ErrorResponse "Внутренняя ошибка сервера"
+// @Failure 400 {object} utils.Problem "Некорректный запрос или язык"
+// @Failure 404 {object} utils.Problem "Песня не найдена"
+// @Security ApiKeyAuth
+// @Security BearerAuth
+// @Failure 401 {object} utils.Problem "Требуется аутентификация"
+// @Failure 403 {object} utils.Problem "Недостаточно прав, требуется роль reader"
+// @Failure 500 {object} utils.Problem "Внутренняя ошибка сервера"
 // @Router /api/songs/{id} [get]
 func (h *Handler) GetSong(w http.ResponseWriter, r *http.Request) {
 	const op = "songs.GetSong.hdl"
 
-	id, err := strconv.ParseUint(
-		strings.TrimPrefix(r.URL.Path, "/api/songs/"), 10, 64,
-	)
+	id, err := strconv.ParseUint(r.PathValue("id"), 10, 64)
 	if err != nil {
-		zap.L().Debug(
+		logger.FromContext(r.Context()).Debug(
 			"failed to extract param",
 			zap.Error(err), zap.String("op", op),
 		)
-		utils.ErrResponse(w, http.StatusBadRequest, hdl.ErrMissingSongID)
+		utils.ErrResponse(w, r, http.StatusBadRequest, hdl.ErrMissingSongID)
 		return
 	}
 
@@ -88,63 +166,85 @@ func (h *Handler) GetSong(w http.ResponseWriter, r *http.Request) {
 		size = 40
 	}
 
-	res, err := h.ctrl.GetSong(r.Context(), id, page, size)
+	langs, err := preferredLanguages(r)
+	if err != nil {
+		logger.FromContext(r.Context()).Debug(
+			"failed to parse language",
+			zap.Error(err), zap.String("op", op),
+		)
+		utils.ErrResponse(w, r, http.StatusBadRequest, hdl.ErrInvalidLanguage)
+		return
+	}
+
+	res, err := h.ctrl.GetSong(r.Context(), id, page, size, langs)
 	if err != nil && errors.Is(err, ctrl.ErrNotFound) {
-		utils.ErrResponse(w, http.StatusNotFound, err)
+		utils.ErrResponse(w, r, http.StatusNotFound, err)
 		return
 	} else if err != nil {
-		utils.ErrResponse(w, http.StatusInternalServerError, hdl.ErrInternal)
+		utils.ErrResponse(w, r, http.StatusInternalServerError, hdl.ErrInternal)
 		return
 	}
 
+	w.Header().Set("Vary", "Accept-Language")
 	utils.SuccessPaginatedResponse(w, http.StatusOK, res)
 }
 
 type CreateSongRequest struct {
-	Group string `json:"group"`
-	Song  string `json:"song"`
+	Group string            `json:"group"`
+	Song  string            `json:"song"`
+	Album *model.AlbumTrack `json:"album,omitempty"`
 }
 
 // CreateSong
 // @Summary Добавить новую песню
-// @Description Добавить новую песню
+// @Description Добавить новую песню. Дата релиза, текст и ссылка загружаются из внешнего API в фоне, прогресс виден в enrichment_status.
+// @Description Песню можно сразу добавить в альбом, тогда дата релиза по умолчанию берётся из альбома
 // @Tags songs
 // @Accept json
 // @Param song body CreateSongRequest true "Данные новой песни"
+// @Param Cache-Control header string false "no-cache — не использовать кэш внешнего API"
 // @Success 200 {object} int "ID добавленной песни"
-// @Failure 400 {object} utils.ErrorResponse "Ошибка валидации или декодирования запроса"
-// @Failure 409 {object} utils.ErrorResponse "Песня уже существует"
-// @Failure 500 {object} utils.ErrorResponse "Внутренняя ошибка сервера"
+// @Failure 400 {object} utils.Problem "Ошибка валидации, декодирования запроса или альбом не найден"
+// @Failure 409 {object} utils.Problem "Песня уже существует или место в альбоме занято"
+// @Security ApiKeyAuth
+// @Security BearerAuth
+// @Failure 401 {object} utils.Problem "Требуется аутентификация"
+// @Failure 403 {object} utils.Problem "Недостаточно прав, требуется роль editor"
+// @Failure 500 {object} utils.Problem "Внутренняя ошибка сервера"
 // @Router /api/songs [post]
 func (h *Handler) CreateSong(w http.ResponseWriter, r *http.Request) {
 	const op = "songs.CreateSong.hdl"
 
 	req := &model.Song{}
 	if err := json.NewDecoder(r.Body).Decode(req); err != nil {
-		zap.L().Debug(
+		logger.FromContext(r.Context()).Debug(
 			"failed to decode request",
 			zap.Error(err), zap.String("op", op),
 		)
-		utils.ErrResponse(w, http.StatusBadRequest, hdl.ErrDecodeRequest)
+		utils.ErrResponse(w, r, http.StatusBadRequest, hdl.ErrDecodeRequest)
 		return
 	}
 
+	validation.NormalizeSong(req)
 	if err := validation.ValidateSong(req); err != nil {
-		zap.L().Debug(
+		logger.FromContext(r.Context()).Debug(
 			"failed to validate request",
 			zap.Error(err), zap.String("op", op),
 			zap.Any("req", req),
 		)
-		utils.ErrResponse(w, http.StatusBadRequest, err)
+		utils.ErrResponse(w, r, http.StatusBadRequest, err)
 		return
 	}
 
-	res, err := h.ctrl.CreateSong(r.Context(), req)
-	if err != nil && errors.Is(err, ctrl.ErrAlreadyExists) {
-		utils.ErrResponse(w, http.StatusConflict, err)
+	res, err := h.ctrl.CreateSong(cacheCtx(r), req)
+	if err != nil && (errors.Is(err, ctrl.ErrAlreadyExists) || errors.Is(err, ctrl.ErrTrackTaken)) {
+		utils.ErrResponse(w, r, http.StatusConflict, err)
+		return
+	} else if err != nil && errors.Is(err, ctrl.ErrAlbumNotFound) {
+		utils.ErrResponse(w, r, http.StatusBadRequest, err)
 		return
 	} else if err != nil {
-		utils.ErrResponse(w, http.StatusInternalServerError, hdl.ErrInternal)
+		utils.ErrResponse(w, r, http.StatusInternalServerError, hdl.ErrInternal)
 		return
 	}
 
@@ -159,51 +259,130 @@ func (h *Handler) CreateSong(w http.ResponseWriter, r *http.Request) {
 // @Param id path int true "ID песни"
 // @Param song body model.Song true "Обновленные данные песни"
 // @Success 200 {object} string "OK"
-// @Failure 400 {object} utils.ErrorResponse "Ошибка валидации или декодирования запроса"
-// @Failure 404 {object} utils.ErrorResponse "Песня не найдена"
-// @Failure 500 {object} utils.ErrorResponse "Внутренняя ошибка сервера"
+// @Failure 400 {object} utils.Problem "Ошибка валидации или декодирования запроса"
+// @Failure 404 {object} utils.Problem "Песня не найдена"
+// @Failure 409 {object} utils.Problem "Песня с такими группой и названием уже существует или число куплетов не совпадает с переводами"
+// @Security ApiKeyAuth
+// @Security BearerAuth
+// @Failure 401 {object} utils.Problem "Требуется аутентификация"
+// @Failure 403 {object} utils.Problem "Недостаточно прав, требуется роль editor"
+// @Failure 500 {object} utils.Problem "Внутренняя ошибка сервера"
 // @Router /api/songs/{id} [put]
 func (h *Handler) UpdateSong(w http.ResponseWriter, r *http.Request) {
 	const op = "songs.UpdateSong.hdl"
 
-	songID, err := strconv.ParseUint(
-		strings.TrimPrefix(r.URL.Path, "/api/songs/"), 10, 64,
-	)
+	songID, err := strconv.ParseUint(r.PathValue("id"), 10, 64)
 	if err != nil {
-		zap.L().Debug(
+		logger.FromContext(r.Context()).Debug(
 			"failed to extract param",
 			zap.Error(err), zap.String("op", op),
 		)
-		utils.ErrResponse(w, http.StatusBadRequest, hdl.ErrMissingSongID)
+		utils.ErrResponse(w, r, http.StatusBadRequest, hdl.ErrMissingSongID)
 		return
 	}
 
 	req := &model.Song{ID: songID}
 	if err := json.NewDecoder(r.Body).Decode(req); err != nil {
-		zap.L().Debug(
+		logger.FromContext(r.Context()).Debug(
 			"failed to decode request",
 			zap.Error(err), zap.String("op", op),
 		)
-		utils.ErrResponse(w, http.StatusBadRequest, hdl.ErrDecodeRequest)
+		utils.ErrResponse(w, r, http.StatusBadRequest, hdl.ErrDecodeRequest)
 		return
 	}
 
-	if err := validation.ValidateSong(req); err != nil {
-		zap.L().Debug(
+	validation.NormalizeSong(req)
+	if err := validation.ValidateSongUpdate(req); err != nil {
+		logger.FromContext(r.Context()).Debug(
 			"failed to validate request",
 			zap.Error(err), zap.String("op", op),
 			zap.Any("req", req),
 		)
-		utils.ErrResponse(w, http.StatusBadRequest, err)
+		utils.ErrResponse(w, r, http.StatusBadRequest, err)
 		return
 	}
 
 	err = h.ctrl.UpdateSong(r.Context(), req)
 	if err != nil && errors.Is(err, ctrl.ErrNotFound) {
-		utils.ErrResponse(w, http.StatusNotFound, err)
+		utils.ErrResponse(w, r, http.StatusNotFound, err)
+		return
+	} else if err != nil && errors.Is(err, ctrl.ErrAlreadyExists) {
+		utils.ErrResponse(w, r, http.StatusConflict, err)
+		return
+	} else if err != nil && errors.Is(err, ctrl.ErrTranslationsMisaligned) {
+		utils.ErrResponse(w, r, http.StatusConflict, err)
+		return
+	} else if err != nil {
+		utils.ErrResponse(w, r, http.StatusInternalServerError, hdl.ErrInternal)
+		return
+	}
+
+	utils.SuccessResponse(w, http.StatusOK, "OK")
+}
+
+// PatchSong
+// @Summary Частично обновить информацию о песне
+// @Description Обновить только переданные поля существующей песни по ID
+// @Tags songs
+// @Accept json
+// @Param id path int true "ID песни"
+// @Param song body model.SongPatch true "Изменяемые поля песни"
+// @Success 200 {object} string "OK"
+// @Failure 400 {object} utils.Problem "Ошибка валидации или декодирования запроса"
+// @Failure 404 {object} utils.Problem "Песня не найдена"
+// @Failure 409 {object} utils.Problem "Песня с такими группой и названием уже существует или число куплетов не совпадает с переводами"
+// @Security ApiKeyAuth
+// @Security BearerAuth
+// @Failure 401 {object} utils.Problem "Требуется аутентификация"
+// @Failure 403 {object} utils.Problem "Недостаточно прав, требуется роль editor"
+// @Failure 500 {object} utils.Problem "Внутренняя ошибка сервера"
+// @Router /api/songs/{id} [patch]
+func (h *Handler) PatchSong(w http.ResponseWriter, r *http.Request) {
+	const op = "songs.PatchSong.hdl"
+
+	songID, err := strconv.ParseUint(r.PathValue("id"), 10, 64)
+	if err != nil {
+		logger.FromContext(r.Context()).Debug(
+			"failed to extract param",
+			zap.Error(err), zap.String("op", op),
+		)
+		utils.ErrResponse(w, r, http.StatusBadRequest, hdl.ErrMissingSongID)
+		return
+	}
+
+	req := &model.SongPatch{}
+	if err := json.NewDecoder(r.Body).Decode(req); err != nil {
+		logger.FromContext(r.Context()).Debug(
+			"failed to decode request",
+			zap.Error(err), zap.String("op", op),
+		)
+		utils.ErrResponse(w, r, http.StatusBadRequest, hdl.ErrDecodeRequest)
+		return
+	}
+
+	validation.NormalizeSongPatch(req)
+	if err := validation.ValidateSongPatch(req); err != nil {
+		logger.FromContext(r.Context()).Debug(
+			"failed to validate request",
+			zap.Error(err), zap.String("op", op),
+			zap.Any("req", req),
+		)
+		utils.ErrResponse(w, r, http.StatusBadRequest, err)
+		return
+	}
+
+	err = h.ctrl.PatchSong(r.Context(), songID, req)
+	if err != nil && errors.Is(err, ctrl.ErrNotFound) {
+		utils.ErrResponse(w, r, http.StatusNotFound, err)
+		return
+	} else if err != nil && errors.Is(err, ctrl.ErrAlreadyExists) {
+		utils.ErrResponse(w, r, http.StatusConflict, err)
+		return
+	} else if err != nil && errors.Is(err, ctrl.ErrTranslationsMisaligned) {
+		utils.ErrResponse(w, r, http.StatusConflict, err)
 		return
 	} else if err != nil {
-		utils.ErrResponse(w, http.StatusInternalServerError, hdl.ErrInternal)
+		utils.ErrResponse(w, r, http.StatusInternalServerError, hdl.ErrInternal)
 		return
 	}
 
@@ -216,33 +395,89 @@ func (h *Handler) UpdateSong(w http.ResponseWriter, r *http.Request) {
 // @Tags songs
 // @Param id path int true "ID песни"
 // @Success 204 {object} string "Песня успешно удалена"
-// @Failure 400 {object} map[string]string "Некорректный запрос"
-// @Failure 404 {object} map[string]string "Песня не найдена"
-// @Failure 500 {object} map[string]string "Внутренняя ошибка сервера"
+// @Failure 400 {object} utils.Problem "Некорректный запрос"
+// @Failure 404 {object} utils.Problem "Песня не найдена"
+// @Security ApiKeyAuth
+// @Security BearerAuth
+// @Failure 401 {object} utils.Problem "Требуется аутентификация"
+// @Failure 403 {object} utils.Problem "Недостаточно прав, требуется роль admin"
+// @Failure 500 {object} utils.Problem "Внутренняя ошибка сервера"
 // @Router /api/songs/{id} [delete]
 func (h *Handler) DeleteSong(w http.ResponseWriter, r *http.Request) {
 	const op = "songs.DeleteSong.hdl"
 
-	songID, err := strconv.ParseUint(
-		strings.TrimPrefix(r.URL.Path, "/api/songs/"), 10, 64,
-	)
+	songID, err := strconv.ParseUint(r.PathValue("id"), 10, 64)
 	if err != nil {
-		zap.L().Debug(
+		logger.FromContext(r.Context()).Debug(
 			"failed to extract param",
 			zap.Error(err), zap.String("op", op),
 		)
-		utils.ErrResponse(w, http.StatusBadRequest, hdl.ErrMissingSongID)
+		utils.ErrResponse(w, r, http.StatusBadRequest, hdl.ErrMissingSongID)
 		return
 	}
 
 	err = h.ctrl.DeleteSong(r.Context(), songID)
 	if err != nil && errors.Is(err, ctrl.ErrNotFound) {
-		utils.ErrResponse(w, http.StatusNotFound, err)
+		utils.ErrResponse(w, r, http.StatusNotFound, err)
 		return
 	} else if err != nil {
-		utils.ErrResponse(w, http.StatusInternalServerError, hdl.ErrInternal)
+		utils.ErrResponse(w, r, http.StatusInternalServerError, hdl.ErrInternal)
 		return
 	}
 
 	utils.SuccessResponse(w, http.StatusNoContent, "OK")
 }
+
+// RefreshSong
+// @Summary Повторно обогатить песню
+// @Description Сбросить статус обогащения и поставить песню в очередь на загрузку данных из внешнего API
+// @Tags songs
+// @Param id path int true "ID песни"
+// @Param Cache-Control header string false "no-cache — не использовать кэш внешнего API"
+// @Success 202 {object} string "Песня поставлена в очередь"
+// @Failure 400 {object} utils.Problem "Некорректный запрос"
+// @Failure 404 {object} utils.Problem "Песня не найдена"
+// @Failure 409 {object} utils.Problem "Песня уже обогащается"
+// @Security ApiKeyAuth
+// @Security BearerAuth
+// @Failure 401 {object} utils.Problem "Требуется аутентификация"
+// @Failure 403 {object} utils.Problem "Недостаточно прав, требуется роль editor"
+// @Failure 500 {object} utils.Problem "Внутренняя ошибка сервера"
+// @Router /api/songs/{id}/refresh [post]
+func (h *Handler) RefreshSong(w http.ResponseWriter, r *http.Request) {
+	const op = "songs.RefreshSong.hdl"
+
+	songID, err := strconv.ParseUint(r.PathValue("id"), 10, 64)
+	if err != nil {
+		logger.FromContext(r.Context()).Debug(
+			"failed to extract param",
+			zap.Error(err), zap.String("op", op),
+		)
+		utils.ErrResponse(w, r, http.StatusBadRequest, hdl.ErrMissingSongID)
+		return
+	}
+
+	err = h.ctrl.RefreshSong(cacheCtx(r), songID)
+	if err != nil && errors.Is(err, ctrl.ErrNotFound) {
+		utils.ErrResponse(w, r, http.StatusNotFound, err)
+		return
+	} else if err != nil && errors.Is(err, ctrl.ErrEnrichmentInProgress) {
+		utils.ErrResponse(w, r, http.StatusConflict, err)
+		return
+	} else if err != nil {
+		utils.ErrResponse(w, r, http.StatusInternalServerError, hdl.ErrInternal)
+		return
+	}
+
+	utils.SuccessResponse(w, http.StatusAccepted, "OK")
+}
+
+// cacheCtx marks the request context for a cache bypass when the client sends Cache-Control: no-cache
+func cacheCtx(r *http.Request) context.Context {
+	for _, directive := range strings.Split(r.Header.Get("Cache-Control"), ",") {
+		if strings.EqualFold(strings.TrimSpace(directive), "no-cache") {
+			return ctrl.WithCacheBypass(r.Context())
+		}
+	}
+	return r.Context()
+}
@@ -8,10 +8,13 @@ import (
 	"github.com/JMURv/effectiveMobile/internal/ctrl"
 	"github.com/JMURv/effectiveMobile/mocks"
 	"github.com/JMURv/effectiveMobile/pkg/model"
+	utils "github.com/JMURv/effectiveMobile/pkg/utils/http"
+	"github.com/JMURv/effectiveMobile/pkg/utils/logger"
 	"github.com/stretchr/testify/assert"
 	"go.uber.org/mock/gomock"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 	"time"
 )
@@ -92,6 +95,84 @@ func TestHandler_ListSongs(t *testing.T) {
 		hdl.ListSongs(w, req)
 		assert.Equal(t, http.StatusOK, w.Result().StatusCode)
 	})
+
+	t.Run("WithSort", func(t *testing.T) {
+		sortFilters := map[string]any{"group": "rock", "sort": "-release_date,group"}
+		ctrlRepo.EXPECT().ListSongs(ctx, 1, 40, sortFilters).Return(success, nil).Times(1)
+		req := httptest.NewRequest(http.MethodGet, "/api/songs?group=rock&sort=-release_date,group", nil)
+		req = req.WithContext(ctx)
+
+		w := httptest.NewRecorder()
+		hdl.ListSongs(w, req)
+		assert.Equal(t, http.StatusOK, w.Result().StatusCode)
+	})
+
+	t.Run("CursorMode", func(t *testing.T) {
+		cursorFilters := map[string]any{"group": "rock"}
+		ctrlRepo.EXPECT().ListSongsCursor(ctx, "", 20, true, cursorFilters).Return(&model.CursorPaginatedSongs{}, nil).Times(1)
+		req := httptest.NewRequest(http.MethodGet, "/api/songs?group=rock&limit=20&with_count=true", nil)
+		req = req.WithContext(ctx)
+
+		w := httptest.NewRecorder()
+		hdl.ListSongs(w, req)
+		assert.Equal(t, http.StatusOK, w.Result().StatusCode)
+	})
+
+	t.Run("CursorModeErrInvalidCursor", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/api/songs?cursor=invalid!", nil)
+		req = req.WithContext(ctx)
+
+		w := httptest.NewRecorder()
+		hdl.ListSongs(w, req)
+		assert.Equal(t, http.StatusBadRequest, w.Result().StatusCode)
+	})
+
+	t.Run("CursorModeErrFromCtrl", func(t *testing.T) {
+		ctrlRepo.EXPECT().ListSongsCursor(ctx, "", 40, false, map[string]any{}).Return(nil, ctrl.ErrInvalidCursor).Times(1)
+		req := httptest.NewRequest(http.MethodGet, "/api/songs?limit=invalid", nil)
+		req = req.WithContext(ctx)
+
+		w := httptest.NewRecorder()
+		hdl.ListSongs(w, req)
+		assert.Equal(t, http.StatusBadRequest, w.Result().StatusCode)
+	})
+
+	t.Run("InvalidSort", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/api/songs?group=rock&sort=lyrics", nil)
+		req = req.WithContext(ctx)
+
+		w := httptest.NewRecorder()
+		hdl.ListSongs(w, req)
+		assert.Equal(t, http.StatusBadRequest, w.Result().StatusCode)
+	})
+
+	t.Run("WithLabelsAndFacets", func(t *testing.T) {
+		labelFilters := map[string]any{"genre": "rock,metal", "tag": "90s", "tag_match": "all", "facets": true}
+		faceted := &model.PaginatedSongs{
+			Data:   []*model.Song{},
+			Facets: &model.Facets{Genres: []model.Facet{{Name: "rock", Count: 3}}, Tags: []model.Facet{}},
+		}
+		ctrlRepo.EXPECT().ListSongs(ctx, 1, 40, labelFilters).Return(faceted, nil).Times(1)
+		req := httptest.NewRequest(http.MethodGet, "/api/songs?genre=rock,metal&tag=90s&tag_match=all&facets=true", nil)
+		req = req.WithContext(ctx)
+
+		w := httptest.NewRecorder()
+		hdl.ListSongs(w, req)
+		assert.Equal(t, http.StatusOK, w.Result().StatusCode)
+
+		res := &model.PaginatedSongs{}
+		assert.Nil(t, json.NewDecoder(w.Body).Decode(res))
+		assert.Equal(t, faceted.Facets, res.Facets)
+	})
+
+	t.Run("InvalidMatch", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/api/songs?genre=rock&genre_match=some", nil)
+		req = req.WithContext(ctx)
+
+		w := httptest.NewRecorder()
+		hdl.ListSongs(w, req)
+		assert.Equal(t, http.StatusBadRequest, w.Result().StatusCode)
+	})
 }
 
 func TestHandler_GetSong(t *testing.T) {
@@ -108,36 +189,36 @@ func TestHandler_GetSong(t *testing.T) {
 	songDetails := &model.PaginatedSongs{}
 
 	t.Run("Success", func(t *testing.T) {
-		ctrlRepo.EXPECT().GetSong(ctx, songID, page, size).Return(songDetails, nil).Times(1)
+		ctrlRepo.EXPECT().GetSong(ctx, songID, page, size, nil).Return(songDetails, nil).Times(1)
 
 		req := httptest.NewRequest(http.MethodGet, "/api/songs/1?page=1&size=40", nil)
 		req = req.WithContext(ctx)
 
 		w := httptest.NewRecorder()
-		hdl.GetSong(w, req)
+		hdl.routes().ServeHTTP(w, req)
 		assert.Equal(t, http.StatusOK, w.Result().StatusCode)
 	})
 
 	t.Run("ErrNotFound", func(t *testing.T) {
-		ctrlRepo.EXPECT().GetSong(ctx, songID, page, size).Return(nil, ctrl.ErrNotFound).Times(1)
+		ctrlRepo.EXPECT().GetSong(ctx, songID, page, size, nil).Return(nil, ctrl.ErrNotFound).Times(1)
 
 		req := httptest.NewRequest(http.MethodGet, "/api/songs/1?page=1&size=40", nil)
 		req = req.WithContext(ctx)
 
 		w := httptest.NewRecorder()
-		hdl.GetSong(w, req)
+		hdl.routes().ServeHTTP(w, req)
 		assert.Equal(t, http.StatusNotFound, w.Result().StatusCode)
 	})
 
 	t.Run("ErrInternalError", func(t *testing.T) {
 		var ErrOther = errors.New("other error")
-		ctrlRepo.EXPECT().GetSong(ctx, songID, page, size).Return(nil, ErrOther).Times(1)
+		ctrlRepo.EXPECT().GetSong(ctx, songID, page, size, nil).Return(nil, ErrOther).Times(1)
 
 		req := httptest.NewRequest(http.MethodGet, "/api/songs/1?page=1&size=40", nil)
 		req = req.WithContext(ctx)
 
 		w := httptest.NewRecorder()
-		hdl.GetSong(w, req)
+		hdl.routes().ServeHTTP(w, req)
 		assert.Equal(t, http.StatusInternalServerError, w.Result().StatusCode)
 	})
 
@@ -146,18 +227,18 @@ func TestHandler_GetSong(t *testing.T) {
 		req = req.WithContext(ctx)
 
 		w := httptest.NewRecorder()
-		hdl.GetSong(w, req)
+		hdl.routes().ServeHTTP(w, req)
 		assert.Equal(t, http.StatusBadRequest, w.Result().StatusCode)
 	})
 
 	t.Run("MissingPageAndSize", func(t *testing.T) {
-		ctrlRepo.EXPECT().GetSong(ctx, songID, 1, 40).Return(songDetails, nil).Times(1)
+		ctrlRepo.EXPECT().GetSong(ctx, songID, 1, 40, nil).Return(songDetails, nil).Times(1)
 
 		req := httptest.NewRequest(http.MethodGet, "/api/songs/1", nil)
 		req = req.WithContext(ctx)
 
 		w := httptest.NewRecorder()
-		hdl.GetSong(w, req)
+		hdl.routes().ServeHTTP(w, req)
 		assert.Equal(t, http.StatusOK, w.Result().StatusCode)
 	})
 }
@@ -236,6 +317,76 @@ func TestHandler_CreateSong(t *testing.T) {
 		hdl.CreateSong(w, req)
 		assert.Equal(t, http.StatusBadRequest, w.Result().StatusCode)
 	})
+
+	t.Run("SuccessWithAlbum", func(t *testing.T) {
+		withAlbum := &model.Song{
+			Group: "group",
+			Song:  "song",
+			Album: &model.AlbumTrack{AlbumID: 2, Disc: 1, TrackNumber: 3},
+		}
+		ctrlRepo.EXPECT().CreateSong(ctx, withAlbum).Return(uint64(1), nil).Times(1)
+
+		req := httptest.NewRequest(http.MethodPost, "/api/songs", strings.NewReader(`{"group":"group","song":"song","album":{"album_id":2,"track_number":3}}`))
+		req.Header.Set("Content-Type", "application/json")
+		req = req.WithContext(ctx)
+
+		w := httptest.NewRecorder()
+		hdl.CreateSong(w, req)
+		assert.Equal(t, http.StatusCreated, w.Result().StatusCode)
+	})
+
+	t.Run("ErrAlbumNotFound", func(t *testing.T) {
+		ctrlRepo.EXPECT().CreateSong(ctx, gomock.Any()).Return(uint64(0), ctrl.ErrAlbumNotFound).Times(1)
+
+		req := httptest.NewRequest(http.MethodPost, "/api/songs", strings.NewReader(`{"group":"group","song":"song","album":{"album_id":2,"track_number":3}}`))
+		req.Header.Set("Content-Type", "application/json")
+		req = req.WithContext(ctx)
+
+		w := httptest.NewRecorder()
+		hdl.CreateSong(w, req)
+		assert.Equal(t, http.StatusBadRequest, w.Result().StatusCode)
+	})
+
+	t.Run("ErrTrackTaken", func(t *testing.T) {
+		ctrlRepo.EXPECT().CreateSong(ctx, gomock.Any()).Return(uint64(0), ctrl.ErrTrackTaken).Times(1)
+
+		req := httptest.NewRequest(http.MethodPost, "/api/songs", strings.NewReader(`{"group":"group","song":"song","album":{"album_id":2,"track_number":3}}`))
+		req.Header.Set("Content-Type", "application/json")
+		req = req.WithContext(ctx)
+
+		w := httptest.NewRecorder()
+		hdl.CreateSong(w, req)
+		assert.Equal(t, http.StatusConflict, w.Result().StatusCode)
+	})
+
+	t.Run("ValidationProblem", func(t *testing.T) {
+		payload, _ := json.Marshal(map[string]any{
+			"song":         strings.Repeat("a", 256),
+			"release_date": time.Now().Add(48 * time.Hour),
+			"link":         "ftp://example.com",
+		})
+		req := httptest.NewRequest(http.MethodPost, "/api/songs", bytes.NewBuffer(payload))
+		req.Header.Set("Content-Type", "application/json")
+		req = req.WithContext(logger.WithRequestID(ctx, "abc-123"))
+
+		w := httptest.NewRecorder()
+		hdl.CreateSong(w, req)
+		assert.Equal(t, http.StatusBadRequest, w.Result().StatusCode)
+		assert.Equal(t, utils.ProblemContentType, w.Header().Get("Content-Type"))
+
+		res := &utils.Problem{}
+		assert.Nil(t, json.NewDecoder(w.Body).Decode(res))
+		assert.Equal(t, utils.ValidationProblemType, res.Type)
+		assert.Equal(t, http.StatusBadRequest, res.Status)
+		assert.Equal(t, "/api/songs", res.Instance)
+		assert.Equal(t, "abc-123", res.RequestID)
+
+		pointers := make([]string, 0, len(res.Errors))
+		for _, e := range res.Errors {
+			pointers = append(pointers, e.Pointer)
+		}
+		assert.Equal(t, []string{"/group", "/song", "/release_date", "/link"}, pointers)
+	})
 }
 
 func TestHandler_UpdateSong(t *testing.T) {
@@ -247,9 +398,12 @@ func TestHandler_UpdateSong(t *testing.T) {
 
 	ctx := context.Background()
 	success := &model.Song{
-		ID:    1,
-		Group: "group",
-		Song:  "song",
+		ID:          1,
+		Group:       "group",
+		Song:        "song",
+		ReleaseDate: time.Date(2006, time.July, 16, 0, 0, 0, 0, time.UTC),
+		Lyrics:      []string{"verse"},
+		Link:        "https://example.com/song",
 	}
 
 	t.Run("Success", func(t *testing.T) {
@@ -261,7 +415,7 @@ func TestHandler_UpdateSong(t *testing.T) {
 		req = req.WithContext(ctx)
 
 		w := httptest.NewRecorder()
-		hdl.UpdateSong(w, req)
+		hdl.routes().ServeHTTP(w, req)
 		assert.Equal(t, http.StatusOK, w.Result().StatusCode)
 	})
 
@@ -274,10 +428,36 @@ func TestHandler_UpdateSong(t *testing.T) {
 		req = req.WithContext(ctx)
 
 		w := httptest.NewRecorder()
-		hdl.UpdateSong(w, req)
+		hdl.routes().ServeHTTP(w, req)
 		assert.Equal(t, http.StatusNotFound, w.Result().StatusCode)
 	})
 
+	t.Run("ErrAlreadyExists", func(t *testing.T) {
+		ctrlRepo.EXPECT().UpdateSong(ctx, success).Return(ctrl.ErrAlreadyExists).Times(1)
+
+		payload, _ := json.Marshal(success)
+		req := httptest.NewRequest(http.MethodPut, "/api/songs/1", bytes.NewBuffer(payload))
+		req.Header.Set("Content-Type", "application/json")
+		req = req.WithContext(ctx)
+
+		w := httptest.NewRecorder()
+		hdl.routes().ServeHTTP(w, req)
+		assert.Equal(t, http.StatusConflict, w.Result().StatusCode)
+	})
+
+	t.Run("ErrTranslationsMisaligned", func(t *testing.T) {
+		ctrlRepo.EXPECT().UpdateSong(ctx, success).Return(ctrl.ErrTranslationsMisaligned).Times(1)
+
+		payload, _ := json.Marshal(success)
+		req := httptest.NewRequest(http.MethodPut, "/api/songs/1", bytes.NewBuffer(payload))
+		req.Header.Set("Content-Type", "application/json")
+		req = req.WithContext(ctx)
+
+		w := httptest.NewRecorder()
+		hdl.routes().ServeHTTP(w, req)
+		assert.Equal(t, http.StatusConflict, w.Result().StatusCode)
+	})
+
 	t.Run("ErrInternalError", func(t *testing.T) {
 		var ErrOther = errors.New("other error")
 		ctrlRepo.EXPECT().UpdateSong(ctx, success).Return(ErrOther).Times(1)
@@ -288,7 +468,7 @@ func TestHandler_UpdateSong(t *testing.T) {
 		req = req.WithContext(ctx)
 
 		w := httptest.NewRecorder()
-		hdl.UpdateSong(w, req)
+		hdl.routes().ServeHTTP(w, req)
 		assert.Equal(t, http.StatusInternalServerError, w.Result().StatusCode)
 	})
 
@@ -299,7 +479,7 @@ func TestHandler_UpdateSong(t *testing.T) {
 		req = req.WithContext(ctx)
 
 		w := httptest.NewRecorder()
-		hdl.UpdateSong(w, req)
+		hdl.routes().ServeHTTP(w, req)
 		assert.Equal(t, http.StatusBadRequest, w.Result().StatusCode)
 	})
 
@@ -310,7 +490,7 @@ func TestHandler_UpdateSong(t *testing.T) {
 		req = req.WithContext(ctx)
 
 		w := httptest.NewRecorder()
-		hdl.UpdateSong(w, req)
+		hdl.routes().ServeHTTP(w, req)
 		assert.Equal(t, http.StatusBadRequest, w.Result().StatusCode)
 	})
 
@@ -322,7 +502,153 @@ func TestHandler_UpdateSong(t *testing.T) {
 		req = req.WithContext(ctx)
 
 		w := httptest.NewRecorder()
-		hdl.UpdateSong(w, req)
+		hdl.routes().ServeHTTP(w, req)
+		assert.Equal(t, http.StatusBadRequest, w.Result().StatusCode)
+	})
+
+	t.Run("Normalized", func(t *testing.T) {
+		ctrlRepo.EXPECT().UpdateSong(gomock.Any(), success).Return(nil).Times(1)
+
+		payload, _ := json.Marshal(map[string]any{
+			"group":        "  group ",
+			"song":         "song",
+			"release_date": success.ReleaseDate,
+			"lyrics":       []string{" verse  "},
+			"link":         " https://example.com/song ",
+		})
+		req := httptest.NewRequest(http.MethodPut, "/api/songs/1", bytes.NewBuffer(payload))
+		req.Header.Set("Content-Type", "application/json")
+
+		w := httptest.NewRecorder()
+		hdl.routes().ServeHTTP(w, req)
+		assert.Equal(t, http.StatusOK, w.Result().StatusCode)
+	})
+
+	t.Run("IncompletePayload", func(t *testing.T) {
+		payload, _ := json.Marshal(map[string]any{"group": "group", "song": "song", "lyrics": []string{}})
+		req := httptest.NewRequest(http.MethodPut, "/api/songs/1", bytes.NewBuffer(payload))
+		req.Header.Set("Content-Type", "application/json")
+
+		w := httptest.NewRecorder()
+		hdl.routes().ServeHTTP(w, req)
+		assert.Equal(t, http.StatusBadRequest, w.Result().StatusCode)
+
+		res := &utils.Problem{}
+		assert.Nil(t, json.NewDecoder(w.Body).Decode(res))
+		assert.Len(t, res.Errors, 3)
+	})
+}
+
+func TestHandler_PatchSong(t *testing.T) {
+	ctrlMock := gomock.NewController(t)
+	defer ctrlMock.Finish()
+
+	ctrlRepo := mocks.NewMockCtrl(ctrlMock)
+	hdl := New(ctrlRepo)
+
+	ctx := context.Background()
+	songID := uint64(1)
+	link := "https://example.com/patched"
+	success := &model.SongPatch{Link: &link}
+
+	t.Run("Success", func(t *testing.T) {
+		ctrlRepo.EXPECT().PatchSong(ctx, songID, success).Return(nil).Times(1)
+
+		req := httptest.NewRequest(http.MethodPatch, "/api/songs/1", bytes.NewBufferString(`{"link":"https://example.com/patched"}`))
+		req.Header.Set("Content-Type", "application/json")
+		req = req.WithContext(ctx)
+
+		w := httptest.NewRecorder()
+		hdl.routes().ServeHTTP(w, req)
+		assert.Equal(t, http.StatusOK, w.Result().StatusCode)
+	})
+
+	t.Run("ErrNotFound", func(t *testing.T) {
+		ctrlRepo.EXPECT().PatchSong(ctx, songID, success).Return(ctrl.ErrNotFound).Times(1)
+
+		req := httptest.NewRequest(http.MethodPatch, "/api/songs/1", bytes.NewBufferString(`{"link":"https://example.com/patched"}`))
+		req.Header.Set("Content-Type", "application/json")
+		req = req.WithContext(ctx)
+
+		w := httptest.NewRecorder()
+		hdl.routes().ServeHTTP(w, req)
+		assert.Equal(t, http.StatusNotFound, w.Result().StatusCode)
+	})
+
+	t.Run("ErrAlreadyExists", func(t *testing.T) {
+		ctrlRepo.EXPECT().PatchSong(ctx, songID, success).Return(ctrl.ErrAlreadyExists).Times(1)
+
+		req := httptest.NewRequest(http.MethodPatch, "/api/songs/1", bytes.NewBufferString(`{"link":"https://example.com/patched"}`))
+		req.Header.Set("Content-Type", "application/json")
+		req = req.WithContext(ctx)
+
+		w := httptest.NewRecorder()
+		hdl.routes().ServeHTTP(w, req)
+		assert.Equal(t, http.StatusConflict, w.Result().StatusCode)
+	})
+
+	t.Run("ErrTranslationsMisaligned", func(t *testing.T) {
+		ctrlRepo.EXPECT().PatchSong(ctx, songID, success).Return(ctrl.ErrTranslationsMisaligned).Times(1)
+
+		req := httptest.NewRequest(http.MethodPatch, "/api/songs/1", bytes.NewBufferString(`{"link":"https://example.com/patched"}`))
+		req.Header.Set("Content-Type", "application/json")
+		req = req.WithContext(ctx)
+
+		w := httptest.NewRecorder()
+		hdl.routes().ServeHTTP(w, req)
+		assert.Equal(t, http.StatusConflict, w.Result().StatusCode)
+	})
+
+	t.Run("ErrInternalError", func(t *testing.T) {
+		var ErrOther = errors.New("other error")
+		ctrlRepo.EXPECT().PatchSong(ctx, songID, success).Return(ErrOther).Times(1)
+
+		req := httptest.NewRequest(http.MethodPatch, "/api/songs/1", bytes.NewBufferString(`{"link":"https://example.com/patched"}`))
+		req.Header.Set("Content-Type", "application/json")
+		req = req.WithContext(ctx)
+
+		w := httptest.NewRecorder()
+		hdl.routes().ServeHTTP(w, req)
+		assert.Equal(t, http.StatusInternalServerError, w.Result().StatusCode)
+	})
+
+	t.Run("ErrEmptyPatch", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodPatch, "/api/songs/1", bytes.NewBufferString(`{}`))
+		req.Header.Set("Content-Type", "application/json")
+		req = req.WithContext(ctx)
+
+		w := httptest.NewRecorder()
+		hdl.routes().ServeHTTP(w, req)
+		assert.Equal(t, http.StatusBadRequest, w.Result().StatusCode)
+	})
+
+	t.Run("ErrEmptyGroup", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodPatch, "/api/songs/1", bytes.NewBufferString(`{"group":""}`))
+		req.Header.Set("Content-Type", "application/json")
+		req = req.WithContext(ctx)
+
+		w := httptest.NewRecorder()
+		hdl.routes().ServeHTTP(w, req)
+		assert.Equal(t, http.StatusBadRequest, w.Result().StatusCode)
+	})
+
+	t.Run("InvalidJSON", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodPatch, "/api/songs/1", bytes.NewBufferString(`{"link":123}`))
+		req.Header.Set("Content-Type", "application/json")
+		req = req.WithContext(ctx)
+
+		w := httptest.NewRecorder()
+		hdl.routes().ServeHTTP(w, req)
+		assert.Equal(t, http.StatusBadRequest, w.Result().StatusCode)
+	})
+
+	t.Run("InvalidSongID", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodPatch, "/api/songs/invalid", bytes.NewBufferString(`{"link":"https://example.com"}`))
+		req.Header.Set("Content-Type", "application/json")
+		req = req.WithContext(ctx)
+
+		w := httptest.NewRecorder()
+		hdl.routes().ServeHTTP(w, req)
 		assert.Equal(t, http.StatusBadRequest, w.Result().StatusCode)
 	})
 }
@@ -344,7 +670,7 @@ func TestHandler_DeleteSong(t *testing.T) {
 		req = req.WithContext(ctx)
 
 		w := httptest.NewRecorder()
-		hdl.DeleteSong(w, req)
+		hdl.routes().ServeHTTP(w, req)
 		assert.Equal(t, http.StatusNoContent, w.Result().StatusCode)
 	})
 
@@ -355,7 +681,7 @@ func TestHandler_DeleteSong(t *testing.T) {
 		req = req.WithContext(ctx)
 
 		w := httptest.NewRecorder()
-		hdl.DeleteSong(w, req)
+		hdl.routes().ServeHTTP(w, req)
 		assert.Equal(t, http.StatusNotFound, w.Result().StatusCode)
 	})
 
@@ -367,7 +693,7 @@ func TestHandler_DeleteSong(t *testing.T) {
 		req = req.WithContext(ctx)
 
 		w := httptest.NewRecorder()
-		hdl.DeleteSong(w, req)
+		hdl.routes().ServeHTTP(w, req)
 		assert.Equal(t, http.StatusInternalServerError, w.Result().StatusCode)
 	})
 
@@ -376,7 +702,89 @@ func TestHandler_DeleteSong(t *testing.T) {
 		req = req.WithContext(ctx)
 
 		w := httptest.NewRecorder()
-		hdl.DeleteSong(w, req)
+		hdl.routes().ServeHTTP(w, req)
+		assert.Equal(t, http.StatusBadRequest, w.Result().StatusCode)
+	})
+}
+
+func TestHandler_RefreshSong(t *testing.T) {
+	ctrlMock := gomock.NewController(t)
+	defer ctrlMock.Finish()
+
+	ctrlRepo := mocks.NewMockCtrl(ctrlMock)
+	hdl := New(ctrlRepo)
+
+	ctx := context.Background()
+	songID := uint64(1)
+
+	t.Run("Success", func(t *testing.T) {
+		ctrlRepo.EXPECT().RefreshSong(ctx, songID).Return(nil).Times(1)
+
+		req := httptest.NewRequest(http.MethodPost, "/api/songs/1/refresh", nil)
+		req = req.WithContext(ctx)
+
+		w := httptest.NewRecorder()
+		hdl.routes().ServeHTTP(w, req)
+		assert.Equal(t, http.StatusAccepted, w.Result().StatusCode)
+	})
+
+	t.Run("CacheBypass", func(t *testing.T) {
+		ctrlRepo.EXPECT().RefreshSong(gomock.Any(), songID).DoAndReturn(
+			func(ctx context.Context, id uint64) error {
+				assert.True(t, ctrl.IsCacheBypass(ctx))
+				return nil
+			},
+		).Times(1)
+
+		req := httptest.NewRequest(http.MethodPost, "/api/songs/1/refresh", nil)
+		req.Header.Set("Cache-Control", "max-age=0, no-cache")
+		req = req.WithContext(ctx)
+
+		w := httptest.NewRecorder()
+		hdl.routes().ServeHTTP(w, req)
+		assert.Equal(t, http.StatusAccepted, w.Result().StatusCode)
+	})
+
+	t.Run("ErrNotFound", func(t *testing.T) {
+		ctrlRepo.EXPECT().RefreshSong(ctx, songID).Return(ctrl.ErrNotFound).Times(1)
+
+		req := httptest.NewRequest(http.MethodPost, "/api/songs/1/refresh", nil)
+		req = req.WithContext(ctx)
+
+		w := httptest.NewRecorder()
+		hdl.routes().ServeHTTP(w, req)
+		assert.Equal(t, http.StatusNotFound, w.Result().StatusCode)
+	})
+
+	t.Run("ErrEnrichmentInProgress", func(t *testing.T) {
+		ctrlRepo.EXPECT().RefreshSong(ctx, songID).Return(ctrl.ErrEnrichmentInProgress).Times(1)
+
+		req := httptest.NewRequest(http.MethodPost, "/api/songs/1/refresh", nil)
+		req = req.WithContext(ctx)
+
+		w := httptest.NewRecorder()
+		hdl.routes().ServeHTTP(w, req)
+		assert.Equal(t, http.StatusConflict, w.Result().StatusCode)
+	})
+
+	t.Run("ErrInternalError", func(t *testing.T) {
+		var ErrOther = errors.New("other error")
+		ctrlRepo.EXPECT().RefreshSong(ctx, songID).Return(ErrOther).Times(1)
+
+		req := httptest.NewRequest(http.MethodPost, "/api/songs/1/refresh", nil)
+		req = req.WithContext(ctx)
+
+		w := httptest.NewRecorder()
+		hdl.routes().ServeHTTP(w, req)
+		assert.Equal(t, http.StatusInternalServerError, w.Result().StatusCode)
+	})
+
+	t.Run("InvalidSongID", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodPost, "/api/songs/invalid/refresh", nil)
+		req = req.WithContext(ctx)
+
+		w := httptest.NewRecorder()
+		hdl.routes().ServeHTTP(w, req)
 		assert.Equal(t, http.StatusBadRequest, w.Result().StatusCode)
 	})
 }
@@ -0,0 +1,234 @@
+package http
+
+import (
+	"encoding/json"
+	"errors"
+	"github.com/JMURv/effectiveMobile/internal/ctrl"
+	"github.com/JMURv/effectiveMobile/internal/hdl"
+	"github.com/JMURv/effectiveMobile/internal/validation"
+	"github.com/JMURv/effectiveMobile/pkg/model"
+	utils "github.com/JMURv/effectiveMobile/pkg/utils/http"
+	"github.com/JMURv/effectiveMobile/pkg/utils/logger"
+	"go.uber.org/zap"
+	"golang.org/x/text/language"
+	"net/http"
+	"strconv"
+)
+
+type TranslationLyricsRequest struct {
+	Lyrics []string `json:"lyrics"`
+}
+
+// preferredLanguages returns the languages the client wants the lyrics translated to.
+// The lang query parameter wins over Accept-Language, which is only a hint and is ignored when malformed
+func preferredLanguages(r *http.Request) ([]language.Tag, error) {
+	if lang := r.URL.Query().Get("lang"); lang != "" {
+		tag, err := language.Parse(lang)
+		if err != nil {
+			return nil, err
+		}
+		return []language.Tag{tag}, nil
+	}
+
+	tags, _, err := language.ParseAcceptLanguage(r.Header.Get("Accept-Language"))
+	if err != nil {
+		return nil, nil
+	}
+	return tags, nil
+}
+
+// CreateTranslation
+// @Summary Добавить перевод текста
+// @Description Добавляет перевод текста песни на язык с тегом BCP 47. Куплеты перевода соответствуют куплетам оригинала,
+// @Description поэтому их должно быть столько же. Пустой куплет означает, что он ещё не переведён
+// @Tags translations
+// @Accept json
+// @Param id path int true "ID песни"
+// @Param translation body model.Translation true "Язык и куплеты перевода"
+// @Success 201 {object} model.Translation "Добавленный перевод"
+// @Failure 400 {object} utils.Problem "Ошибка валидации, декодирования запроса или число куплетов не совпадает с оригиналом"
+// @Failure 404 {object} utils.Problem "Песня не найдена"
+// @Failure 409 {object} utils.Problem "Перевод на этот язык уже существует"
+// @Security ApiKeyAuth
+// @Security BearerAuth
+// @Failure 401 {object} utils.Problem "Требуется аутентификация"
+// @Failure 403 {object} utils.Problem "Недостаточно прав, требуется роль editor"
+// @Failure 500 {object} utils.Problem "Внутренняя ошибка сервера"
+// @Router /api/songs/{id}/translations [post]
+func (h *Handler) CreateTranslation(w http.ResponseWriter, r *http.Request) {
+	const op = "songs.CreateTranslation.hdl"
+
+	id, err := strconv.ParseUint(r.PathValue("id"), 10, 64)
+	if err != nil {
+		logger.FromContext(r.Context()).Debug(
+			"failed to extract param",
+			zap.Error(err), zap.String("op", op),
+		)
+		utils.ErrResponse(w, r, http.StatusBadRequest, hdl.ErrMissingSongID)
+		return
+	}
+
+	req := &model.Translation{}
+	if err := json.NewDecoder(r.Body).Decode(req); err != nil {
+		logger.FromContext(r.Context()).Debug(
+			"failed to decode request",
+			zap.Error(err), zap.String("op", op),
+		)
+		utils.ErrResponse(w, r, http.StatusBadRequest, hdl.ErrDecodeRequest)
+		return
+	}
+
+	validation.NormalizeTranslation(req)
+	if err := validation.ValidateTranslation(req); err != nil {
+		logger.FromContext(r.Context()).Debug(
+			"failed to validate request",
+			zap.Error(err), zap.String("op", op),
+			zap.String("lang", req.Lang), zap.Int("verses", len(req.Lyrics)),
+		)
+		utils.ErrResponse(w, r, http.StatusBadRequest, err)
+		return
+	}
+
+	err = h.ctrl.CreateTranslation(r.Context(), id, req)
+	if err != nil && errors.Is(err, ctrl.ErrNotFound) {
+		utils.ErrResponse(w, r, http.StatusNotFound, err)
+		return
+	} else if err != nil && errors.Is(err, ctrl.ErrAlreadyExists) {
+		utils.ErrResponse(w, r, http.StatusConflict, err)
+		return
+	} else if err != nil && errors.Is(err, ctrl.ErrVerseCountMismatch) {
+		utils.ErrResponse(w, r, http.StatusBadRequest, err)
+		return
+	} else if err != nil {
+		utils.ErrResponse(w, r, http.StatusInternalServerError, hdl.ErrInternal)
+		return
+	}
+
+	utils.SuccessResponse(w, http.StatusCreated, req)
+}
+
+// UpdateTranslation
+// @Summary Изменить перевод текста
+// @Description Заменяет куплеты перевода, их должно быть столько же, сколько куплетов в оригинале
+// @Tags translations
+// @Accept json
+// @Param id path int true "ID песни"
+// @Param lang path string true "Язык перевода (BCP 47)"
+// @Param translation body TranslationLyricsRequest true "Куплеты перевода"
+// @Success 200 {object} string "Перевод изменён"
+// @Failure 400 {object} utils.Problem "Ошибка валидации, декодирования запроса или число куплетов не совпадает с оригиналом"
+// @Failure 404 {object} utils.Problem "Песня или перевод не найдены"
+// @Security ApiKeyAuth
+// @Security BearerAuth
+// @Failure 401 {object} utils.Problem "Требуется аутентификация"
+// @Failure 403 {object} utils.Problem "Недостаточно прав, требуется роль editor"
+// @Failure 500 {object} utils.Problem "Внутренняя ошибка сервера"
+// @Router /api/songs/{id}/translations/{lang} [put]
+func (h *Handler) UpdateTranslation(w http.ResponseWriter, r *http.Request) {
+	const op = "songs.UpdateTranslation.hdl"
+
+	id, err := strconv.ParseUint(r.PathValue("id"), 10, 64)
+	if err != nil {
+		logger.FromContext(r.Context()).Debug(
+			"failed to extract param",
+			zap.Error(err), zap.String("op", op),
+		)
+		utils.ErrResponse(w, r, http.StatusBadRequest, hdl.ErrMissingSongID)
+		return
+	}
+
+	lang, err := language.Parse(r.PathValue("lang"))
+	if err != nil {
+		logger.FromContext(r.Context()).Debug(
+			"failed to extract param",
+			zap.Error(err), zap.String("op", op),
+		)
+		utils.ErrResponse(w, r, http.StatusBadRequest, hdl.ErrInvalidLanguage)
+		return
+	}
+
+	req := &model.Translation{}
+	if err := json.NewDecoder(r.Body).Decode(req); err != nil {
+		logger.FromContext(r.Context()).Debug(
+			"failed to decode request",
+			zap.Error(err), zap.String("op", op),
+		)
+		utils.ErrResponse(w, r, http.StatusBadRequest, hdl.ErrDecodeRequest)
+		return
+	}
+
+	req.Lang = lang.String()
+	validation.NormalizeTranslation(req)
+	if err := validation.ValidateTranslation(req); err != nil {
+		logger.FromContext(r.Context()).Debug(
+			"failed to validate request",
+			zap.Error(err), zap.String("op", op),
+			zap.String("lang", req.Lang), zap.Int("verses", len(req.Lyrics)),
+		)
+		utils.ErrResponse(w, r, http.StatusBadRequest, err)
+		return
+	}
+
+	err = h.ctrl.UpdateTranslation(r.Context(), id, req)
+	if err != nil && errors.Is(err, ctrl.ErrNotFound) {
+		utils.ErrResponse(w, r, http.StatusNotFound, err)
+		return
+	} else if err != nil && errors.Is(err, ctrl.ErrVerseCountMismatch) {
+		utils.ErrResponse(w, r, http.StatusBadRequest, err)
+		return
+	} else if err != nil {
+		utils.ErrResponse(w, r, http.StatusInternalServerError, hdl.ErrInternal)
+		return
+	}
+
+	utils.SuccessResponse(w, http.StatusOK, "OK")
+}
+
+// DeleteTranslation
+// @Summary Удалить перевод текста
+// @Tags translations
+// @Param id path int true "ID песни"
+// @Param lang path string true "Язык перевода (BCP 47)"
+// @Success 204 {object} string "Перевод удалён"
+// @Failure 400 {object} utils.Problem "Некорректный запрос или язык"
+// @Failure 404 {object} utils.Problem "Песня или перевод не найдены"
+// @Security ApiKeyAuth
+// @Security BearerAuth
+// @Failure 401 {object} utils.Problem "Требуется аутентификация"
+// @Failure 403 {object} utils.Problem "Недостаточно прав, требуется роль editor"
+// @Failure 500 {object} utils.Problem "Внутренняя ошибка сервера"
+// @Router /api/songs/{id}/translations/{lang} [delete]
+func (h *Handler) DeleteTranslation(w http.ResponseWriter, r *http.Request) {
+	const op = "songs.DeleteTranslation.hdl"
+
+	id, err := strconv.ParseUint(r.PathValue("id"), 10, 64)
+	if err != nil {
+		logger.FromContext(r.Context()).Debug(
+			"failed to extract param",
+			zap.Error(err), zap.String("op", op),
+		)
+		utils.ErrResponse(w, r, http.StatusBadRequest, hdl.ErrMissingSongID)
+		return
+	}
+
+	lang, err := language.Parse(r.PathValue("lang"))
+	if err != nil {
+		logger.FromContext(r.Context()).Debug(
+			"failed to extract param",
+			zap.Error(err), zap.String("op", op),
+		)
+		utils.ErrResponse(w, r, http.StatusBadRequest, hdl.ErrInvalidLanguage)
+		return
+	}
+
+	err = h.ctrl.DeleteTranslation(r.Context(), id, lang.String())
+	if err != nil && errors.Is(err, ctrl.ErrNotFound) {
+		utils.ErrResponse(w, r, http.StatusNotFound, err)
+		return
+	} else if err != nil {
+		utils.ErrResponse(w, r, http.StatusInternalServerError, hdl.ErrInternal)
+		return
+	}
+
+	utils.SuccessResponse(w, http.StatusNoContent, "OK")
+}
@@ -0,0 +1,220 @@
+package http
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"github.com/JMURv/effectiveMobile/internal/ctrl"
+	"github.com/JMURv/effectiveMobile/mocks"
+	"github.com/JMURv/effectiveMobile/pkg/model"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+	"golang.org/x/text/language"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestHandler_GetSong_Language(t *testing.T) {
+	ctrlMock := gomock.NewController(t)
+	defer ctrlMock.Finish()
+
+	ctrlRepo := mocks.NewMockCtrl(ctrlMock)
+	hdl := New(ctrlRepo)
+
+	ctx := context.Background()
+
+	t.Run("AcceptLanguage", func(t *testing.T) {
+		langs := []language.Tag{language.MustParse("de-AT"), language.English}
+		ctrlRepo.EXPECT().GetSong(ctx, uint64(1), 1, 40, langs).Return(&model.PaginatedSongs{
+			Data: &model.Song{ID: 1, Lyrics: []string{"a"}, Lang: "de", TranslatedLyrics: []string{"A"}},
+		}, nil).Times(1)
+
+		req := httptest.NewRequest(http.MethodGet, "/api/songs/1", nil)
+		req.Header.Set("Accept-Language", "en;q=0.5, de-AT")
+		req = req.WithContext(ctx)
+
+		w := httptest.NewRecorder()
+		hdl.routes().ServeHTTP(w, req)
+		assert.Equal(t, http.StatusOK, w.Result().StatusCode)
+		assert.Equal(t, "Accept-Language", w.Header().Get("Vary"))
+	})
+
+	t.Run("LangWinsOverHeader", func(t *testing.T) {
+		ctrlRepo.EXPECT().GetSong(ctx, uint64(1), 1, 40, []language.Tag{language.French}).
+			Return(&model.PaginatedSongs{Data: &model.Song{ID: 1}}, nil).Times(1)
+
+		req := httptest.NewRequest(http.MethodGet, "/api/songs/1?lang=fr", nil)
+		req.Header.Set("Accept-Language", "de")
+		req = req.WithContext(ctx)
+
+		w := httptest.NewRecorder()
+		hdl.routes().ServeHTTP(w, req)
+		assert.Equal(t, http.StatusOK, w.Result().StatusCode)
+	})
+
+	t.Run("MalformedHeader", func(t *testing.T) {
+		ctrlRepo.EXPECT().GetSong(ctx, uint64(1), 1, 40, nil).
+			Return(&model.PaginatedSongs{Data: &model.Song{ID: 1}}, nil).Times(1)
+
+		req := httptest.NewRequest(http.MethodGet, "/api/songs/1", nil)
+		req.Header.Set("Accept-Language", "de;q=bad")
+		req = req.WithContext(ctx)
+
+		w := httptest.NewRecorder()
+		hdl.routes().ServeHTTP(w, req)
+		assert.Equal(t, http.StatusOK, w.Result().StatusCode)
+	})
+
+	t.Run("InvalidLang", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/api/songs/1?lang=english", nil)
+		req = req.WithContext(ctx)
+
+		w := httptest.NewRecorder()
+		hdl.routes().ServeHTTP(w, req)
+		assert.Equal(t, http.StatusBadRequest, w.Result().StatusCode)
+	})
+}
+
+func TestHandler_CreateTranslation(t *testing.T) {
+	ctrlMock := gomock.NewController(t)
+	defer ctrlMock.Finish()
+
+	ctrlRepo := mocks.NewMockCtrl(ctrlMock)
+	hdl := New(ctrlRepo)
+
+	ctx := context.Background()
+
+	t.Run("Success", func(t *testing.T) {
+		expected := &model.Translation{Lang: "pt-BR", Lyrics: []string{"primeiro", ""}}
+		ctrlRepo.EXPECT().CreateTranslation(ctx, uint64(1), expected).Return(nil).Times(1)
+
+		req := httptest.NewRequest(http.MethodPost, "/api/songs/1/translations", bytes.NewBufferString(`{"lang": "PT-br", "lyrics": [" primeiro ", ""]}`))
+		req = req.WithContext(ctx)
+
+		w := httptest.NewRecorder()
+		hdl.routes().ServeHTTP(w, req)
+		assert.Equal(t, http.StatusCreated, w.Result().StatusCode)
+
+		res := &struct {
+			Data model.Translation `json:"data"`
+		}{}
+		assert.Nil(t, json.NewDecoder(w.Body).Decode(res))
+		assert.Equal(t, *expected, res.Data)
+	})
+
+	t.Run("ErrValidation", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodPost, "/api/songs/1/translations", bytes.NewBufferString(`{"lang": "???", "lyrics": []}`))
+		req = req.WithContext(ctx)
+
+		w := httptest.NewRecorder()
+		hdl.routes().ServeHTTP(w, req)
+		assert.Equal(t, http.StatusBadRequest, w.Result().StatusCode)
+	})
+
+	t.Run("ErrAlreadyExists", func(t *testing.T) {
+		ctrlRepo.EXPECT().CreateTranslation(ctx, uint64(1), gomock.Any()).Return(ctrl.ErrAlreadyExists).Times(1)
+
+		req := httptest.NewRequest(http.MethodPost, "/api/songs/1/translations", bytes.NewBufferString(`{"lang": "de", "lyrics": ["eins"]}`))
+		req = req.WithContext(ctx)
+
+		w := httptest.NewRecorder()
+		hdl.routes().ServeHTTP(w, req)
+		assert.Equal(t, http.StatusConflict, w.Result().StatusCode)
+	})
+
+	t.Run("ErrVerseCountMismatch", func(t *testing.T) {
+		ctrlRepo.EXPECT().CreateTranslation(ctx, uint64(1), gomock.Any()).Return(ctrl.ErrVerseCountMismatch).Times(1)
+
+		req := httptest.NewRequest(http.MethodPost, "/api/songs/1/translations", bytes.NewBufferString(`{"lang": "de", "lyrics": ["eins"]}`))
+		req = req.WithContext(ctx)
+
+		w := httptest.NewRecorder()
+		hdl.routes().ServeHTTP(w, req)
+		assert.Equal(t, http.StatusBadRequest, w.Result().StatusCode)
+	})
+
+	t.Run("ErrNotFound", func(t *testing.T) {
+		ctrlRepo.EXPECT().CreateTranslation(ctx, uint64(2), gomock.Any()).Return(ctrl.ErrNotFound).Times(1)
+
+		req := httptest.NewRequest(http.MethodPost, "/api/songs/2/translations", bytes.NewBufferString(`{"lang": "de", "lyrics": ["eins"]}`))
+		req = req.WithContext(ctx)
+
+		w := httptest.NewRecorder()
+		hdl.routes().ServeHTTP(w, req)
+		assert.Equal(t, http.StatusNotFound, w.Result().StatusCode)
+	})
+}
+
+func TestHandler_UpdateTranslation(t *testing.T) {
+	ctrlMock := gomock.NewController(t)
+	defer ctrlMock.Finish()
+
+	ctrlRepo := mocks.NewMockCtrl(ctrlMock)
+	hdl := New(ctrlRepo)
+
+	ctx := context.Background()
+
+	t.Run("Success", func(t *testing.T) {
+		ctrlRepo.EXPECT().UpdateTranslation(ctx, uint64(1), &model.Translation{Lang: "en-GB", Lyrics: []string{"one"}}).Return(nil).Times(1)
+
+		req := httptest.NewRequest(http.MethodPut, "/api/songs/1/translations/en-gb", bytes.NewBufferString(`{"lang": "ignored", "lyrics": ["one"]}`))
+		req = req.WithContext(ctx)
+
+		w := httptest.NewRecorder()
+		hdl.routes().ServeHTTP(w, req)
+		assert.Equal(t, http.StatusOK, w.Result().StatusCode)
+	})
+
+	t.Run("InvalidLang", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodPut, "/api/songs/1/translations/english", bytes.NewBufferString(`{"lyrics": ["one"]}`))
+		req = req.WithContext(ctx)
+
+		w := httptest.NewRecorder()
+		hdl.routes().ServeHTTP(w, req)
+		assert.Equal(t, http.StatusBadRequest, w.Result().StatusCode)
+	})
+
+	t.Run("ErrNotFound", func(t *testing.T) {
+		ctrlRepo.EXPECT().UpdateTranslation(ctx, uint64(1), gomock.Any()).Return(ctrl.ErrNotFound).Times(1)
+
+		req := httptest.NewRequest(http.MethodPut, "/api/songs/1/translations/fr", bytes.NewBufferString(`{"lyrics": ["un"]}`))
+		req = req.WithContext(ctx)
+
+		w := httptest.NewRecorder()
+		hdl.routes().ServeHTTP(w, req)
+		assert.Equal(t, http.StatusNotFound, w.Result().StatusCode)
+	})
+}
+
+func TestHandler_DeleteTranslation(t *testing.T) {
+	ctrlMock := gomock.NewController(t)
+	defer ctrlMock.Finish()
+
+	ctrlRepo := mocks.NewMockCtrl(ctrlMock)
+	hdl := New(ctrlRepo)
+
+	ctx := context.Background()
+
+	t.Run("Success", func(t *testing.T) {
+		ctrlRepo.EXPECT().DeleteTranslation(ctx, uint64(1), "de").Return(nil).Times(1)
+
+		req := httptest.NewRequest(http.MethodDelete, "/api/songs/1/translations/DE", nil)
+		req = req.WithContext(ctx)
+
+		w := httptest.NewRecorder()
+		hdl.routes().ServeHTTP(w, req)
+		assert.Equal(t, http.StatusNoContent, w.Result().StatusCode)
+	})
+
+	t.Run("ErrNotFound", func(t *testing.T) {
+		ctrlRepo.EXPECT().DeleteTranslation(ctx, uint64(1), "fr").Return(ctrl.ErrNotFound).Times(1)
+
+		req := httptest.NewRequest(http.MethodDelete, "/api/songs/1/translations/fr", nil)
+		req = req.WithContext(ctx)
+
+		w := httptest.NewRecorder()
+		hdl.routes().ServeHTTP(w, req)
+		assert.Equal(t, http.StatusNotFound, w.Result().StatusCode)
+	})
+}
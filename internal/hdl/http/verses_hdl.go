@@ -0,0 +1,340 @@
+package http
+
+import (
+	"encoding/json"
+	"errors"
+	"github.com/JMURv/effectiveMobile/internal/ctrl"
+	"github.com/JMURv/effectiveMobile/internal/hdl"
+	"github.com/JMURv/effectiveMobile/internal/validation"
+	"github.com/JMURv/effectiveMobile/pkg/model"
+	utils "github.com/JMURv/effectiveMobile/pkg/utils/http"
+	"github.com/JMURv/effectiveMobile/pkg/utils/logger"
+	"go.uber.org/zap"
+	"net/http"
+	"strconv"
+)
+
+type VerseTextRequest struct {
+	Text string `json:"text"`
+}
+
+type VersePosition struct {
+	Position int `json:"position"`
+}
+
+// ListVerses
+// @Summary Куплеты песни
+// @Description Получить куплеты текста песни с их позициями, позиции начинаются с 1
+// @Tags verses
+// @Param id path int true "ID песни"
+// @Param page query int false "Номер страницы" default(1)
+// @Param size query int false "Размер страницы" default(40)
+// @Success 200 {object} model.PaginatedVerses "Куплеты с пагинацией"
+// @Failure 400 {object} utils.Problem "Некорректный запрос"
+// @Failure 404 {object} utils.Problem "Песня не найдена"
+// @Security ApiKeyAuth
+// @Security BearerAuth
+// @Failure 401 {object} utils.Problem "Требуется аутентификация"
+// @Failure 403 {object} utils.Problem "Недостаточно прав, требуется роль reader"
+// @Failure 500 {object} utils.Problem "Внутренняя ошибка сервера"
+// @Router /api/songs/{id}/verses [get]
+func (h *Handler) ListVerses(w http.ResponseWriter, r *http.Request) {
+	const op = "songs.ListVerses.hdl"
+
+	id, err := strconv.ParseUint(r.PathValue("id"), 10, 64)
+	if err != nil {
+		logger.FromContext(r.Context()).Debug(
+			"failed to extract param",
+			zap.Error(err), zap.String("op", op),
+		)
+		utils.ErrResponse(w, r, http.StatusBadRequest, hdl.ErrMissingSongID)
+		return
+	}
+
+	page, err := strconv.Atoi(r.URL.Query().Get("page"))
+	if err != nil {
+		page = 1
+	}
+
+	size, err := strconv.Atoi(r.URL.Query().Get("size"))
+	if err != nil {
+		size = 40
+	}
+
+	if err := validation.ValidatePage(page, size); err != nil {
+		logger.FromContext(r.Context()).Debug(
+			"failed to validate page",
+			zap.Error(err), zap.String("op", op),
+		)
+		utils.ErrResponse(w, r, http.StatusBadRequest, err)
+		return
+	}
+
+	res, err := h.ctrl.ListVerses(r.Context(), id, page, size)
+	if err != nil && errors.Is(err, ctrl.ErrNotFound) {
+		utils.ErrResponse(w, r, http.StatusNotFound, err)
+		return
+	} else if err != nil {
+		utils.ErrResponse(w, r, http.StatusInternalServerError, hdl.ErrInternal)
+		return
+	}
+
+	utils.SuccessPaginatedResponse(w, http.StatusOK, res)
+}
+
+// InsertVerse
+// @Summary Добавить куплет
+// @Description Вставляет куплет на позицию, сдвигая следующие куплеты. Без позиции или за концом текста куплет добавляется в конец
+// @Tags verses
+// @Accept json
+// @Param id path int true "ID песни"
+// @Param verse body model.Verse true "Текст и позиция куплета"
+// @Success 201 {object} model.Verse "Добавленный куплет"
+// @Failure 400 {object} utils.Problem "Ошибка валидации или декодирования запроса"
+// @Failure 404 {object} utils.Problem "Песня не найдена"
+// @Security ApiKeyAuth
+// @Security BearerAuth
+// @Failure 401 {object} utils.Problem "Требуется аутентификация"
+// @Failure 403 {object} utils.Problem "Недостаточно прав, требуется роль editor"
+// @Failure 500 {object} utils.Problem "Внутренняя ошибка сервера"
+// @Router /api/songs/{id}/verses [post]
+func (h *Handler) InsertVerse(w http.ResponseWriter, r *http.Request) {
+	const op = "songs.InsertVerse.hdl"
+
+	id, err := strconv.ParseUint(r.PathValue("id"), 10, 64)
+	if err != nil {
+		logger.FromContext(r.Context()).Debug(
+			"failed to extract param",
+			zap.Error(err), zap.String("op", op),
+		)
+		utils.ErrResponse(w, r, http.StatusBadRequest, hdl.ErrMissingSongID)
+		return
+	}
+
+	req := &model.Verse{}
+	if err := json.NewDecoder(r.Body).Decode(req); err != nil {
+		logger.FromContext(r.Context()).Debug(
+			"failed to decode request",
+			zap.Error(err), zap.String("op", op),
+		)
+		utils.ErrResponse(w, r, http.StatusBadRequest, hdl.ErrDecodeRequest)
+		return
+	}
+
+	req.Text = validation.NormalizeVerse(req.Text)
+	if err := validation.ValidateVerse(req); err != nil {
+		logger.FromContext(r.Context()).Debug(
+			"failed to validate request",
+			zap.Error(err), zap.String("op", op),
+			zap.Any("req", req),
+		)
+		utils.ErrResponse(w, r, http.StatusBadRequest, err)
+		return
+	}
+
+	req.Position, err = h.ctrl.InsertVerse(r.Context(), id, req.Position, req.Text)
+	if err != nil && errors.Is(err, ctrl.ErrNotFound) {
+		utils.ErrResponse(w, r, http.StatusNotFound, err)
+		return
+	} else if err != nil {
+		utils.ErrResponse(w, r, http.StatusInternalServerError, hdl.ErrInternal)
+		return
+	}
+
+	utils.SuccessResponse(w, http.StatusCreated, req)
+}
+
+// UpdateVerse
+// @Summary Изменить куплет
+// @Tags verses
+// @Accept json
+// @Param id path int true "ID песни"
+// @Param n path int true "Позиция куплета"
+// @Param verse body VerseTextRequest true "Новый текст куплета"
+// @Success 200 {object} string "Куплет успешно изменён"
+// @Failure 400 {object} utils.Problem "Ошибка валидации или декодирования запроса"
+// @Failure 404 {object} utils.Problem "Песня или куплет не найдены"
+// @Security ApiKeyAuth
+// @Security BearerAuth
+// @Failure 401 {object} utils.Problem "Требуется аутентификация"
+// @Failure 403 {object} utils.Problem "Недостаточно прав, требуется роль editor"
+// @Failure 500 {object} utils.Problem "Внутренняя ошибка сервера"
+// @Router /api/songs/{id}/verses/{n} [put]
+func (h *Handler) UpdateVerse(w http.ResponseWriter, r *http.Request) {
+	const op = "songs.UpdateVerse.hdl"
+
+	id, err := strconv.ParseUint(r.PathValue("id"), 10, 64)
+	if err != nil {
+		logger.FromContext(r.Context()).Debug(
+			"failed to extract param",
+			zap.Error(err), zap.String("op", op),
+		)
+		utils.ErrResponse(w, r, http.StatusBadRequest, hdl.ErrMissingSongID)
+		return
+	}
+
+	n, err := strconv.Atoi(r.PathValue("n"))
+	if err != nil {
+		logger.FromContext(r.Context()).Debug(
+			"failed to extract param",
+			zap.Error(err), zap.String("op", op),
+		)
+		utils.ErrResponse(w, r, http.StatusBadRequest, hdl.ErrMissingVerse)
+		return
+	}
+
+	req := &model.Verse{}
+	if err := json.NewDecoder(r.Body).Decode(req); err != nil {
+		logger.FromContext(r.Context()).Debug(
+			"failed to decode request",
+			zap.Error(err), zap.String("op", op),
+		)
+		utils.ErrResponse(w, r, http.StatusBadRequest, hdl.ErrDecodeRequest)
+		return
+	}
+	req.Position = n
+
+	req.Text = validation.NormalizeVerse(req.Text)
+	if err := validation.ValidateVerse(req); err != nil {
+		logger.FromContext(r.Context()).Debug(
+			"failed to validate request",
+			zap.Error(err), zap.String("op", op),
+			zap.Any("req", req),
+		)
+		utils.ErrResponse(w, r, http.StatusBadRequest, err)
+		return
+	}
+
+	err = h.ctrl.UpdateVerse(r.Context(), id, req.Position, req.Text)
+	if err != nil && (errors.Is(err, ctrl.ErrNotFound) || errors.Is(err, ctrl.ErrVerseNotFound)) {
+		utils.ErrResponse(w, r, http.StatusNotFound, err)
+		return
+	} else if err != nil {
+		utils.ErrResponse(w, r, http.StatusInternalServerError, hdl.ErrInternal)
+		return
+	}
+
+	utils.SuccessResponse(w, http.StatusOK, "OK")
+}
+
+// DeleteVerse
+// @Summary Удалить куплет
+// @Description Следующие куплеты сдвигаются на освободившуюся позицию
+// @Tags verses
+// @Param id path int true "ID песни"
+// @Param n path int true "Позиция куплета"
+// @Success 204 {object} string "Куплет успешно удалён"
+// @Failure 400 {object} utils.Problem "Некорректный запрос"
+// @Failure 404 {object} utils.Problem "Песня или куплет не найдены"
+// @Security ApiKeyAuth
+// @Security BearerAuth
+// @Failure 401 {object} utils.Problem "Требуется аутентификация"
+// @Failure 403 {object} utils.Problem "Недостаточно прав, требуется роль editor"
+// @Failure 500 {object} utils.Problem "Внутренняя ошибка сервера"
+// @Router /api/songs/{id}/verses/{n} [delete]
+func (h *Handler) DeleteVerse(w http.ResponseWriter, r *http.Request) {
+	const op = "songs.DeleteVerse.hdl"
+
+	id, err := strconv.ParseUint(r.PathValue("id"), 10, 64)
+	if err != nil {
+		logger.FromContext(r.Context()).Debug(
+			"failed to extract param",
+			zap.Error(err), zap.String("op", op),
+		)
+		utils.ErrResponse(w, r, http.StatusBadRequest, hdl.ErrMissingSongID)
+		return
+	}
+
+	n, err := strconv.Atoi(r.PathValue("n"))
+	if err != nil {
+		logger.FromContext(r.Context()).Debug(
+			"failed to extract param",
+			zap.Error(err), zap.String("op", op),
+		)
+		utils.ErrResponse(w, r, http.StatusBadRequest, hdl.ErrMissingVerse)
+		return
+	}
+
+	err = h.ctrl.DeleteVerse(r.Context(), id, n)
+	if err != nil && (errors.Is(err, ctrl.ErrNotFound) || errors.Is(err, ctrl.ErrVerseNotFound)) {
+		utils.ErrResponse(w, r, http.StatusNotFound, err)
+		return
+	} else if err != nil {
+		utils.ErrResponse(w, r, http.StatusInternalServerError, hdl.ErrInternal)
+		return
+	}
+
+	utils.SuccessResponse(w, http.StatusNoContent, "OK")
+}
+
+// MoveVerse
+// @Summary Переместить куплет
+// @Description Ставит куплет на позицию, сдвигая куплеты между старой и новой позициями. Позиция за концом текста перемещает куплет в конец
+// @Tags verses
+// @Accept json
+// @Param id path int true "ID песни"
+// @Param n path int true "Текущая позиция куплета"
+// @Param position body VersePosition true "Новая позиция"
+// @Success 200 {object} VersePosition "Итоговая позиция куплета"
+// @Failure 400 {object} utils.Problem "Ошибка валидации или декодирования запроса"
+// @Failure 404 {object} utils.Problem "Песня или куплет не найдены"
+// @Security ApiKeyAuth
+// @Security BearerAuth
+// @Failure 401 {object} utils.Problem "Требуется аутентификация"
+// @Failure 403 {object} utils.Problem "Недостаточно прав, требуется роль editor"
+// @Failure 500 {object} utils.Problem "Внутренняя ошибка сервера"
+// @Router /api/songs/{id}/verses/{n}/move [post]
+func (h *Handler) MoveVerse(w http.ResponseWriter, r *http.Request) {
+	const op = "songs.MoveVerse.hdl"
+
+	id, err := strconv.ParseUint(r.PathValue("id"), 10, 64)
+	if err != nil {
+		logger.FromContext(r.Context()).Debug(
+			"failed to extract param",
+			zap.Error(err), zap.String("op", op),
+		)
+		utils.ErrResponse(w, r, http.StatusBadRequest, hdl.ErrMissingSongID)
+		return
+	}
+
+	n, err := strconv.Atoi(r.PathValue("n"))
+	if err != nil {
+		logger.FromContext(r.Context()).Debug(
+			"failed to extract param",
+			zap.Error(err), zap.String("op", op),
+		)
+		utils.ErrResponse(w, r, http.StatusBadRequest, hdl.ErrMissingVerse)
+		return
+	}
+
+	req := &VersePosition{}
+	if err := json.NewDecoder(r.Body).Decode(req); err != nil {
+		logger.FromContext(r.Context()).Debug(
+			"failed to decode request",
+			zap.Error(err), zap.String("op", op),
+		)
+		utils.ErrResponse(w, r, http.StatusBadRequest, hdl.ErrDecodeRequest)
+		return
+	}
+
+	if err := validation.ValidatePosition(req.Position); err != nil {
+		logger.FromContext(r.Context()).Debug(
+			"failed to validate request",
+			zap.Error(err), zap.String("op", op),
+			zap.Any("req", req),
+		)
+		utils.ErrResponse(w, r, http.StatusBadRequest, err)
+		return
+	}
+
+	res, err := h.ctrl.MoveVerse(r.Context(), id, n, req.Position)
+	if err != nil && (errors.Is(err, ctrl.ErrNotFound) || errors.Is(err, ctrl.ErrVerseNotFound)) {
+		utils.ErrResponse(w, r, http.StatusNotFound, err)
+		return
+	} else if err != nil {
+		utils.ErrResponse(w, r, http.StatusInternalServerError, hdl.ErrInternal)
+		return
+	}
+
+	utils.SuccessResponse(w, http.StatusOK, &VersePosition{Position: res})
+}
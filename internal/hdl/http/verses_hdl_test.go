@@ -0,0 +1,224 @@
+package http
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"github.com/JMURv/effectiveMobile/internal/ctrl"
+	"github.com/JMURv/effectiveMobile/mocks"
+	"github.com/JMURv/effectiveMobile/pkg/model"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestHandler_ListVerses(t *testing.T) {
+	ctrlMock := gomock.NewController(t)
+	defer ctrlMock.Finish()
+
+	ctrlRepo := mocks.NewMockCtrl(ctrlMock)
+	hdl := New(ctrlRepo)
+
+	ctx := context.Background()
+
+	t.Run("Success", func(t *testing.T) {
+		ctrlRepo.EXPECT().ListVerses(ctx, uint64(1), 2, 5).Return(&model.PaginatedVerses{
+			Data:        []*model.Verse{{Position: 6, Text: "chorus"}},
+			Count:       6,
+			TotalPages:  2,
+			CurrentPage: 2,
+		}, nil).Times(1)
+
+		req := httptest.NewRequest(http.MethodGet, "/api/songs/1/verses?page=2&size=5", nil)
+		req = req.WithContext(ctx)
+
+		w := httptest.NewRecorder()
+		hdl.routes().ServeHTTP(w, req)
+		assert.Equal(t, http.StatusOK, w.Result().StatusCode)
+	})
+
+	t.Run("ErrNotFound", func(t *testing.T) {
+		ctrlRepo.EXPECT().ListVerses(ctx, uint64(2), 1, 40).Return(nil, ctrl.ErrNotFound).Times(1)
+
+		req := httptest.NewRequest(http.MethodGet, "/api/songs/2/verses", nil)
+		req = req.WithContext(ctx)
+
+		w := httptest.NewRecorder()
+		hdl.routes().ServeHTTP(w, req)
+		assert.Equal(t, http.StatusNotFound, w.Result().StatusCode)
+	})
+
+	t.Run("InvalidPage", func(t *testing.T) {
+		for _, query := range []string{"size=0", "size=-1", "page=0"} {
+			req := httptest.NewRequest(http.MethodGet, "/api/songs/1/verses?"+query, nil)
+			req = req.WithContext(ctx)
+
+			w := httptest.NewRecorder()
+			hdl.routes().ServeHTTP(w, req)
+			assert.Equal(t, http.StatusBadRequest, w.Result().StatusCode, query)
+		}
+	})
+}
+
+func TestHandler_InsertVerse(t *testing.T) {
+	ctrlMock := gomock.NewController(t)
+	defer ctrlMock.Finish()
+
+	ctrlRepo := mocks.NewMockCtrl(ctrlMock)
+	hdl := New(ctrlRepo)
+
+	ctx := context.Background()
+
+	t.Run("Success", func(t *testing.T) {
+		ctrlRepo.EXPECT().InsertVerse(ctx, uint64(1), 2, "first line\nsecond line").Return(2, nil).Times(1)
+
+		req := httptest.NewRequest(http.MethodPost, "/api/songs/1/verses", bytes.NewBufferString(`{"position": 2, "text": " first  line \n second line "}`))
+		req = req.WithContext(ctx)
+
+		w := httptest.NewRecorder()
+		hdl.routes().ServeHTTP(w, req)
+		assert.Equal(t, http.StatusCreated, w.Result().StatusCode)
+
+		res := &struct {
+			Data model.Verse `json:"data"`
+		}{}
+		assert.Nil(t, json.NewDecoder(w.Body).Decode(res))
+		assert.Equal(t, model.Verse{Position: 2, Text: "first line\nsecond line"}, res.Data)
+	})
+
+	t.Run("ErrValidation", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodPost, "/api/songs/1/verses", bytes.NewBufferString(`{"text": "  "}`))
+		req = req.WithContext(ctx)
+
+		w := httptest.NewRecorder()
+		hdl.routes().ServeHTTP(w, req)
+		assert.Equal(t, http.StatusBadRequest, w.Result().StatusCode)
+	})
+
+	t.Run("ErrNotFound", func(t *testing.T) {
+		ctrlRepo.EXPECT().InsertVerse(ctx, uint64(2), 0, "chorus").Return(0, ctrl.ErrNotFound).Times(1)
+
+		req := httptest.NewRequest(http.MethodPost, "/api/songs/2/verses", bytes.NewBufferString(`{"text": "chorus"}`))
+		req = req.WithContext(ctx)
+
+		w := httptest.NewRecorder()
+		hdl.routes().ServeHTTP(w, req)
+		assert.Equal(t, http.StatusNotFound, w.Result().StatusCode)
+	})
+}
+
+func TestHandler_UpdateVerse(t *testing.T) {
+	ctrlMock := gomock.NewController(t)
+	defer ctrlMock.Finish()
+
+	ctrlRepo := mocks.NewMockCtrl(ctrlMock)
+	hdl := New(ctrlRepo)
+
+	ctx := context.Background()
+
+	t.Run("Success", func(t *testing.T) {
+		ctrlRepo.EXPECT().UpdateVerse(ctx, uint64(1), 3, "bridge").Return(nil).Times(1)
+
+		req := httptest.NewRequest(http.MethodPut, "/api/songs/1/verses/3", bytes.NewBufferString(`{"text": "bridge"}`))
+		req = req.WithContext(ctx)
+
+		w := httptest.NewRecorder()
+		hdl.routes().ServeHTTP(w, req)
+		assert.Equal(t, http.StatusOK, w.Result().StatusCode)
+	})
+
+	t.Run("ErrMissingVerse", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodPut, "/api/songs/1/verses/abc", bytes.NewBufferString(`{"text": "bridge"}`))
+		req = req.WithContext(ctx)
+
+		w := httptest.NewRecorder()
+		hdl.routes().ServeHTTP(w, req)
+		assert.Equal(t, http.StatusBadRequest, w.Result().StatusCode)
+	})
+
+	t.Run("ErrVerseNotFound", func(t *testing.T) {
+		ctrlRepo.EXPECT().UpdateVerse(ctx, uint64(1), 9, "bridge").Return(ctrl.ErrVerseNotFound).Times(1)
+
+		req := httptest.NewRequest(http.MethodPut, "/api/songs/1/verses/9", bytes.NewBufferString(`{"text": "bridge"}`))
+		req = req.WithContext(ctx)
+
+		w := httptest.NewRecorder()
+		hdl.routes().ServeHTTP(w, req)
+		assert.Equal(t, http.StatusNotFound, w.Result().StatusCode)
+	})
+}
+
+func TestHandler_DeleteVerse(t *testing.T) {
+	ctrlMock := gomock.NewController(t)
+	defer ctrlMock.Finish()
+
+	ctrlRepo := mocks.NewMockCtrl(ctrlMock)
+	hdl := New(ctrlRepo)
+
+	ctx := context.Background()
+
+	t.Run("Success", func(t *testing.T) {
+		ctrlRepo.EXPECT().DeleteVerse(ctx, uint64(1), 2).Return(nil).Times(1)
+
+		req := httptest.NewRequest(http.MethodDelete, "/api/songs/1/verses/2", nil)
+		req = req.WithContext(ctx)
+
+		w := httptest.NewRecorder()
+		hdl.routes().ServeHTTP(w, req)
+		assert.Equal(t, http.StatusNoContent, w.Result().StatusCode)
+	})
+
+	t.Run("ErrVerseNotFound", func(t *testing.T) {
+		ctrlRepo.EXPECT().DeleteVerse(ctx, uint64(1), 9).Return(ctrl.ErrVerseNotFound).Times(1)
+
+		req := httptest.NewRequest(http.MethodDelete, "/api/songs/1/verses/9", nil)
+		req = req.WithContext(ctx)
+
+		w := httptest.NewRecorder()
+		hdl.routes().ServeHTTP(w, req)
+		assert.Equal(t, http.StatusNotFound, w.Result().StatusCode)
+	})
+}
+
+func TestHandler_MoveVerse(t *testing.T) {
+	ctrlMock := gomock.NewController(t)
+	defer ctrlMock.Finish()
+
+	ctrlRepo := mocks.NewMockCtrl(ctrlMock)
+	hdl := New(ctrlRepo)
+
+	ctx := context.Background()
+
+	t.Run("Success", func(t *testing.T) {
+		ctrlRepo.EXPECT().MoveVerse(ctx, uint64(1), 4, 1).Return(1, nil).Times(1)
+
+		req := httptest.NewRequest(http.MethodPost, "/api/songs/1/verses/4/move", bytes.NewBufferString(`{"position": 1}`))
+		req = req.WithContext(ctx)
+
+		w := httptest.NewRecorder()
+		hdl.routes().ServeHTTP(w, req)
+		assert.Equal(t, http.StatusOK, w.Result().StatusCode)
+	})
+
+	t.Run("ErrValidation", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodPost, "/api/songs/1/verses/4/move", bytes.NewBufferString(`{"position": 0}`))
+		req = req.WithContext(ctx)
+
+		w := httptest.NewRecorder()
+		hdl.routes().ServeHTTP(w, req)
+		assert.Equal(t, http.StatusBadRequest, w.Result().StatusCode)
+	})
+
+	t.Run("ErrNotFound", func(t *testing.T) {
+		ctrlRepo.EXPECT().MoveVerse(ctx, uint64(2), 1, 2).Return(0, ctrl.ErrNotFound).Times(1)
+
+		req := httptest.NewRequest(http.MethodPost, "/api/songs/2/verses/1/move", bytes.NewBufferString(`{"position": 2}`))
+		req = req.WithContext(ctx)
+
+		w := httptest.NewRecorder()
+		hdl.routes().ServeHTTP(w, req)
+		assert.Equal(t, http.StatusNotFound, w.Result().StatusCode)
+	})
+}
@@ -0,0 +1,112 @@
+package metrics
+
+import (
+	"database/sql"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+const namespace = "songs"
+
+var (
+	HTTPRequests = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "http_requests_total",
+		Help:      "HTTP requests by route, method and status.",
+	}, []string{"route", "method", "status"})
+
+	HTTPRequestDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "http_request_duration_seconds",
+		Help:      "HTTP request latency by route, method and status.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"route", "method", "status"})
+
+	ExternalRequests = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "external_requests_total",
+		Help:      "Metadata provider calls by provider and outcome.",
+	}, []string{"provider", "outcome"})
+
+	ExternalRequestDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "external_request_duration_seconds",
+		Help:      "Metadata provider call latency by provider and outcome.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"provider", "outcome"})
+
+	ExternalRetries = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "external_retries_total",
+		Help:      "Retried metadata provider calls.",
+	}, []string{"provider"})
+
+	ExternalBreakerState = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      "external_circuit_breaker_state",
+		Help:      "Circuit breaker state: 0 closed, 1 half-open, 2 open.",
+	}, []string{"provider"})
+
+	ExternalBreakerOpens = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "external_circuit_breaker_opens_total",
+		Help:      "Times the circuit breaker has opened.",
+	}, []string{"provider"})
+
+	ExternalCacheLookups = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "external_cache_lookups_total",
+		Help:      "Song detail cache lookups by result.",
+	}, []string{"result"})
+
+	ExternalCacheEvictions = promauto.NewCounter(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "external_cache_evictions_total",
+		Help:      "Song details evicted from the full in-memory cache.",
+	})
+
+	SongsChanged = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "songs_changed_total",
+		Help:      "Songs created, updated and deleted.",
+	}, []string{"action"})
+)
+
+// RegisterDBStats exposes the connection pool statistics of a database handle
+func RegisterDBStats(stats func() sql.DBStats) {
+	gauge := func(name, help string, value func(sql.DBStats) float64) prometheus.Collector {
+		return prometheus.NewGaugeFunc(prometheus.GaugeOpts{
+			Namespace: namespace,
+			Subsystem: "db",
+			Name:      name,
+			Help:      help,
+		}, func() float64 { return value(stats()) })
+	}
+	counter := func(name, help string, value func(sql.DBStats) float64) prometheus.Collector {
+		return prometheus.NewCounterFunc(prometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: "db",
+			Name:      name,
+			Help:      help,
+		}, func() float64 { return value(stats()) })
+	}
+
+	prometheus.MustRegister(
+		gauge("max_open_connections", "Maximum number of open connections.",
+			func(s sql.DBStats) float64 { return float64(s.MaxOpenConnections) }),
+		gauge("open_connections", "Established connections, in use and idle.",
+			func(s sql.DBStats) float64 { return float64(s.OpenConnections) }),
+		gauge("in_use_connections", "Connections currently in use.",
+			func(s sql.DBStats) float64 { return float64(s.InUse) }),
+		gauge("idle_connections", "Idle connections.",
+			func(s sql.DBStats) float64 { return float64(s.Idle) }),
+		counter("wait_count_total", "Connections waited for.",
+			func(s sql.DBStats) float64 { return float64(s.WaitCount) }),
+		counter("wait_duration_seconds_total", "Time spent waiting for a connection.",
+			func(s sql.DBStats) float64 { return s.WaitDuration.Seconds() }),
+		counter("max_idle_closed_total", "Connections closed due to the idle limit.",
+			func(s sql.DBStats) float64 { return float64(s.MaxIdleClosed) }),
+		counter("max_lifetime_closed_total", "Connections closed due to the lifetime limit.",
+			func(s sql.DBStats) float64 { return float64(s.MaxLifetimeClosed) }),
+	)
+}
@@ -0,0 +1,271 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"github.com/JMURv/effectiveMobile/internal/repo"
+	"github.com/JMURv/effectiveMobile/pkg/model"
+)
+
+const albumColumns = "albums.id, albums.artist_id, artists.name, albums.title, albums.release_date, albums.cover_link, albums.created_at, albums.updated_at"
+
+const albumFrom = "albums JOIN artists ON artists.id = albums.artist_id"
+
+func albumDest(album *model.Album) []any {
+	return []any{
+		&album.ID,
+		&album.ArtistID,
+		&album.Artist,
+		&album.Title,
+		nullTime{&album.ReleaseDate},
+		&album.CoverLink,
+		&album.CreatedAt,
+		&album.UpdatedAt,
+	}
+}
+
+func (r *Repository) ListAlbums(ctx context.Context, page, size int, title string) (_ *model.PaginatedAlbums, err error) {
+	ctx, span := startSpan(ctx, "songs.ListAlbums.repo")
+	defer endSpan(span, &err)
+
+	filterQ, args := "", []any{}
+	if title != "" {
+		filterQ = " WHERE albums.title ILIKE $1"
+		args = append(args, "%"+title+"%")
+	}
+
+	rows, err := r.conn.QueryContext(
+		ctx,
+		fmt.Sprintf(
+			"SELECT %s FROM %s%s ORDER BY albums.release_date DESC NULLS LAST, albums.id ASC LIMIT %d OFFSET %d",
+			albumColumns, albumFrom, filterQ, size, (page-1)*size,
+		),
+		args...,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	res := make([]*model.Album, 0, size)
+	for rows.Next() {
+		album := &model.Album{}
+		if err := rows.Scan(albumDest(album)...); err != nil {
+			return nil, err
+		}
+		res = append(res, album)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	var count int64
+	if err := r.conn.QueryRowContext(ctx, "SELECT COUNT(*) FROM albums"+filterQ, args...).Scan(&count); err != nil {
+		return nil, err
+	}
+
+	totalPages := int((count + int64(size) - 1) / int64(size))
+	return &model.PaginatedAlbums{
+		Data:        res,
+		Count:       count,
+		TotalPages:  totalPages,
+		CurrentPage: page,
+		HasNextPage: page < totalPages,
+	}, nil
+}
+
+func (r *Repository) GetAlbum(ctx context.Context, id uint64) (_ *model.Album, err error) {
+	ctx, span := startSpan(ctx, "songs.GetAlbum.repo")
+	defer endSpan(span, &err)
+
+	res := &model.Album{}
+	err = r.conn.QueryRowContext(ctx, `SELECT `+albumColumns+` FROM `+albumFrom+` WHERE albums.id = $1`, id).
+		Scan(albumDest(res)...)
+	if err == sql.ErrNoRows {
+		return nil, repo.ErrNotFound
+	} else if err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+func (r *Repository) CreateAlbum(ctx context.Context, req *model.Album) (_ *model.Album, err error) {
+	ctx, span := startSpan(ctx, "songs.CreateAlbum.repo")
+	defer endSpan(span, &err)
+
+	tx, err := r.conn.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	artist, err := resolveArtist(ctx, tx, req.Artist)
+	if err != nil {
+		return nil, err
+	}
+
+	res := *req
+	res.ArtistID, res.Artist = artist.ID, artist.Name
+	err = tx.QueryRowContext(
+		ctx,
+		`INSERT INTO albums (artist_id, title, release_date, cover_link) VALUES ($1, $2, $3, $4)
+		 ON CONFLICT (artist_id, (lower(btrim(title)))) DO NOTHING
+		 RETURNING id, created_at, updated_at`,
+		res.ArtistID, res.Title, toNullTime(res.ReleaseDate), res.CoverLink,
+	).Scan(&res.ID, &res.CreatedAt, &res.UpdatedAt)
+	if err == sql.ErrNoRows || isUniqueViolation(err) {
+		return nil, repo.ErrAlreadyExists
+	} else if err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+	return &res, nil
+}
+
+func (r *Repository) UpdateAlbum(ctx context.Context, req *model.Album) (err error) {
+	ctx, span := startSpan(ctx, "songs.UpdateAlbum.repo")
+	defer endSpan(span, &err)
+
+	tx, err := r.conn.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	artist, err := resolveArtist(ctx, tx, req.Artist)
+	if err != nil {
+		return err
+	}
+	req.ArtistID, req.Artist = artist.ID, artist.Name
+
+	res, err := tx.ExecContext(
+		ctx,
+		`UPDATE albums SET artist_id = $1, title = $2, release_date = $3, cover_link = $4 WHERE id = $5`,
+		req.ArtistID, req.Title, toNullTime(req.ReleaseDate), req.CoverLink, req.ID,
+	)
+	if isUniqueViolation(err) {
+		return repo.ErrAlreadyExists
+	} else if err != nil {
+		return err
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if affected == 0 {
+		return repo.ErrNotFound
+	}
+	return tx.Commit()
+}
+
+// DeleteAlbum deletes the album with its track listing, the songs are kept
+func (r *Repository) DeleteAlbum(ctx context.Context, id uint64) (err error) {
+	ctx, span := startSpan(ctx, "songs.DeleteAlbum.repo")
+	defer endSpan(span, &err)
+
+	res, err := r.conn.ExecContext(ctx, `DELETE FROM albums WHERE id = $1`, id)
+	if err != nil {
+		return err
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if affected == 0 {
+		return repo.ErrNotFound
+	}
+	return nil
+}
+
+func (r *Repository) ListAlbumTracks(ctx context.Context, id uint64) (_ []*model.Track, err error) {
+	ctx, span := startSpan(ctx, "songs.ListAlbumTracks.repo")
+	defer endSpan(span, &err)
+
+	rows, err := r.conn.QueryContext(
+		ctx,
+		`SELECT disc, track_number, `+songColumns+` FROM album_tracks
+		 JOIN songs ON songs.id = album_tracks.song_id
+		 WHERE album_id = $1
+		 ORDER BY disc ASC, track_number ASC`,
+		id,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	res := make([]*model.Track, 0)
+	for rows.Next() {
+		track := &model.Track{Song: &model.Song{}}
+		if err := rows.Scan(append([]any{&track.Disc, &track.TrackNumber}, songDest(track.Song)...)...); err != nil {
+			return nil, err
+		}
+		res = append(res, track)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// SetAlbumTrack puts the song on the album at the disc and track number,
+// moving it there when it already is on the album
+func (r *Repository) SetAlbumTrack(ctx context.Context, id, songID uint64, disc, trackNumber int) (err error) {
+	ctx, span := startSpan(ctx, "songs.SetAlbumTrack.repo")
+	defer endSpan(span, &err)
+
+	res, err := r.conn.ExecContext(
+		ctx,
+		`INSERT INTO album_tracks (album_id, song_id, disc, track_number)
+		 SELECT id, $2, $3, $4 FROM albums WHERE id = $1
+		 ON CONFLICT (album_id, song_id) DO UPDATE SET disc = EXCLUDED.disc, track_number = EXCLUDED.track_number`,
+		id, songID, disc, trackNumber,
+	)
+	if isForeignKeyViolation(err) {
+		return repo.ErrSongNotFound
+	} else if isUniqueViolation(err) {
+		return repo.ErrTrackTaken
+	} else if err != nil {
+		return err
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if affected == 0 {
+		return repo.ErrNotFound
+	}
+	return nil
+}
+
+func (r *Repository) RemoveAlbumTrack(ctx context.Context, id, songID uint64) (err error) {
+	ctx, span := startSpan(ctx, "songs.RemoveAlbumTrack.repo")
+	defer endSpan(span, &err)
+
+	res, err := r.conn.ExecContext(ctx, `DELETE FROM album_tracks WHERE album_id = $1 AND song_id = $2`, id, songID)
+	if err != nil {
+		return err
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if affected == 0 {
+		return repo.ErrNotFound
+	}
+	return nil
+}
@@ -0,0 +1,189 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"github.com/JMURv/effectiveMobile/internal/repo"
+	"github.com/JMURv/effectiveMobile/pkg/model"
+)
+
+const artistColumns = "id, name, created_at, updated_at"
+
+func artistDest(artist *model.Artist) []any {
+	return []any{&artist.ID, &artist.Name, &artist.CreatedAt, &artist.UpdatedAt}
+}
+
+func (r *Repository) ListArtists(ctx context.Context, page, size int, name string) (_ *model.PaginatedArtists, err error) {
+	ctx, span := startSpan(ctx, "songs.ListArtists.repo")
+	defer endSpan(span, &err)
+
+	filterQ, args := "", []any{}
+	if name != "" {
+		filterQ = " WHERE name ILIKE $1"
+		args = append(args, "%"+name+"%")
+	}
+
+	rows, err := r.conn.QueryContext(
+		ctx,
+		fmt.Sprintf("SELECT %s FROM artists%s ORDER BY name ASC, id ASC LIMIT %d OFFSET %d", artistColumns, filterQ, size, (page-1)*size),
+		args...,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	res := make([]*model.Artist, 0, size)
+	for rows.Next() {
+		artist := &model.Artist{}
+		if err := rows.Scan(artistDest(artist)...); err != nil {
+			return nil, err
+		}
+		res = append(res, artist)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	var count int64
+	if err := r.conn.QueryRowContext(ctx, "SELECT COUNT(*) FROM artists"+filterQ, args...).Scan(&count); err != nil {
+		return nil, err
+	}
+
+	totalPages := int((count + int64(size) - 1) / int64(size))
+	return &model.PaginatedArtists{
+		Data:        res,
+		Count:       count,
+		TotalPages:  totalPages,
+		CurrentPage: page,
+		HasNextPage: page < totalPages,
+	}, nil
+}
+
+func (r *Repository) GetArtist(ctx context.Context, id uint64) (_ *model.Artist, err error) {
+	ctx, span := startSpan(ctx, "songs.GetArtist.repo")
+	defer endSpan(span, &err)
+
+	res := &model.Artist{}
+	err = r.conn.QueryRowContext(ctx, `SELECT `+artistColumns+` FROM artists WHERE id = $1`, id).
+		Scan(artistDest(res)...)
+	if err == sql.ErrNoRows {
+		return nil, repo.ErrNotFound
+	} else if err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+func (r *Repository) CreateArtist(ctx context.Context, req *model.Artist) (_ *model.Artist, err error) {
+	ctx, span := startSpan(ctx, "songs.CreateArtist.repo")
+	defer endSpan(span, &err)
+
+	res := &model.Artist{}
+	err = r.conn.QueryRowContext(
+		ctx,
+		`INSERT INTO artists (name) VALUES ($1)
+		 ON CONFLICT ((lower(btrim(name)))) DO NOTHING
+		 RETURNING `+artistColumns,
+		req.Name,
+	).Scan(artistDest(res)...)
+	if err == sql.ErrNoRows || isUniqueViolation(err) {
+		return nil, repo.ErrAlreadyExists
+	} else if err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+// resolveArtist returns the artist with the given name, creating it when there is none.
+// Names are matched case-insensitively, the stored spelling wins. It runs in the transaction
+// of the song or album that refers to the artist, so a failed write leaves no artist behind
+func resolveArtist(ctx context.Context, tx *sql.Tx, name string) (*model.Artist, error) {
+	res := &model.Artist{}
+	err := tx.QueryRowContext(
+		ctx,
+		`INSERT INTO artists (name) VALUES ($1)
+		 ON CONFLICT ((lower(btrim(name)))) DO NOTHING
+		 RETURNING `+artistColumns,
+		name,
+	).Scan(artistDest(res)...)
+	if err == nil {
+		return res, nil
+	} else if err != sql.ErrNoRows {
+		return nil, err
+	}
+
+	// The artist exists. It is only read, so that its updated_at stays as it was
+	err = tx.QueryRowContext(
+		ctx,
+		`SELECT `+artistColumns+` FROM artists WHERE lower(btrim(name)) = lower(btrim($1))`,
+		name,
+	).Scan(artistDest(res)...)
+	if err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+// UpdateArtist renames the artist and the group of all its songs
+func (r *Repository) UpdateArtist(ctx context.Context, req *model.Artist) (err error) {
+	ctx, span := startSpan(ctx, "songs.UpdateArtist.repo")
+	defer endSpan(span, &err)
+
+	tx, err := r.conn.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	res, err := tx.ExecContext(ctx, `UPDATE artists SET name = $1 WHERE id = $2`, req.Name, req.ID)
+	if isUniqueViolation(err) {
+		return repo.ErrAlreadyExists
+	} else if err != nil {
+		return err
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if affected == 0 {
+		return repo.ErrNotFound
+	}
+
+	_, err = tx.ExecContext(ctx, `UPDATE songs SET group_name = $1 WHERE artist_id = $2`, req.Name, req.ID)
+	if isUniqueViolation(err) {
+		return repo.ErrAlreadyExists
+	} else if err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+func (r *Repository) DeleteArtist(ctx context.Context, id uint64) (err error) {
+	ctx, span := startSpan(ctx, "songs.DeleteArtist.repo")
+	defer endSpan(span, &err)
+
+	res, err := r.conn.ExecContext(ctx, `DELETE FROM artists WHERE id = $1`, id)
+	if isForeignKeyViolation(err) {
+		return repo.ErrInUse
+	} else if err != nil {
+		return err
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if affected == 0 {
+		return repo.ErrNotFound
+	}
+	return nil
+}
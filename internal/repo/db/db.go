@@ -1,15 +1,29 @@
 package db
 
 import (
+	"context"
 	"database/sql"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"github.com/JMURv/effectiveMobile/internal/repo"
+	"github.com/JMURv/effectiveMobile/internal/tracing"
 	conf "github.com/JMURv/effectiveMobile/pkg/config"
 	utils "github.com/JMURv/effectiveMobile/pkg/utils/db"
 	_ "github.com/golang-migrate/migrate/v4/source/file"
-	_ "github.com/lib/pq"
+	"github.com/lib/pq"
+	"go.opentelemetry.io/otel"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"go.opentelemetry.io/otel/trace"
 	"go.uber.org/zap"
+	"time"
 )
 
+const uniqueViolation = pq.ErrorCode("23505")
+const foreignKeyViolation = pq.ErrorCode("23503")
+
+var tracer = otel.Tracer("github.com/JMURv/effectiveMobile/internal/repo/db")
+
 type Repository struct {
 	conn *sql.DB
 }
@@ -40,3 +54,110 @@ func New(conf *conf.DBConfig) *Repository {
 func (r *Repository) Close() error {
 	return r.conn.Close()
 }
+
+// Stats reports the connection pool statistics
+func (r *Repository) Stats() sql.DBStats {
+	return r.conn.Stats()
+}
+
+func isForeignKeyViolation(err error) bool {
+	var pqErr *pq.Error
+	return errors.As(err, &pqErr) && pqErr.Code == foreignKeyViolation
+}
+
+// startSpan starts a client span for a database call
+func startSpan(ctx context.Context, name string) (context.Context, trace.Span) {
+	return tracer.Start(
+		ctx, name,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(semconv.DBSystemPostgreSQL),
+	)
+}
+
+// endSpan ends the span, marking it as failed when the call returned an unexpected error.
+// The repo errors are outcomes the callers handle, not failures of the database
+func endSpan(span trace.Span, err *error) {
+	if *err != nil && !isRepoErr(*err) {
+		tracing.RecordError(span, *err)
+	}
+	span.End()
+}
+
+func isRepoErr(err error) bool {
+	for _, target := range []error{
+		repo.ErrNotFound, repo.ErrAlreadyExists, repo.ErrInUse, repo.ErrTrackTaken,
+		repo.ErrUnknownGenre, repo.ErrSongNotFound, repo.ErrPlaylistChanged, repo.ErrVerseNotFound,
+		repo.ErrVerseCountMismatch, repo.ErrTranslationsMisaligned, repo.ErrEnrichmentInProgress,
+	} {
+		if errors.Is(err, target) {
+			return true
+		}
+	}
+	return false
+}
+
+func isUniqueViolation(err error) bool {
+	var pqErr *pq.Error
+	return errors.As(err, &pqErr) && pqErr.Code == uniqueViolation
+}
+
+// nullTime scans a nullable timestamp, leaving the zero time for NULL
+type nullTime struct {
+	t *time.Time
+}
+
+func (n nullTime) Scan(src any) error {
+	if src == nil {
+		*n.t = time.Time{}
+		return nil
+	}
+
+	t, ok := src.(time.Time)
+	if !ok {
+		return fmt.Errorf("cannot scan %T into time.Time", src)
+	}
+	*n.t = t
+	return nil
+}
+
+func toNullTime(t time.Time) sql.NullTime {
+	return sql.NullTime{Time: t, Valid: !t.IsZero()}
+}
+
+// jsonMap scans a JSONB object into a string map, leaving nil for NULL or empty objects
+type jsonMap struct {
+	m *map[string]string
+}
+
+func (j jsonMap) Scan(src any) error {
+	var raw []byte
+	switch v := src.(type) {
+	case nil:
+		*j.m = nil
+		return nil
+	case []byte:
+		raw = v
+	case string:
+		raw = []byte(v)
+	default:
+		return fmt.Errorf("cannot scan %T into map[string]string", src)
+	}
+
+	res := map[string]string{}
+	if err := json.Unmarshal(raw, &res); err != nil {
+		return err
+	}
+
+	if len(res) == 0 {
+		res = nil
+	}
+	*j.m = res
+	return nil
+}
+
+func toJSONMap(m map[string]string) ([]byte, error) {
+	if m == nil {
+		return []byte("{}"), nil
+	}
+	return json.Marshal(m)
+}
@@ -6,9 +6,14 @@ import (
 	"errors"
 	"github.com/JMURv/effectiveMobile/internal/repo"
 	"github.com/JMURv/effectiveMobile/pkg/model"
+	utils "github.com/JMURv/effectiveMobile/pkg/utils/db"
+	"github.com/JMURv/effectiveMobile/pkg/utils/keyset"
 	"github.com/lib/pq"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
 	"gopkg.in/DATA-DOG/go-sqlmock.v1"
 	"regexp"
 	"testing"
@@ -54,12 +59,93 @@ func TestRepository_ListSongs(t *testing.T) {
 	//	require.NoError(t, mock.ExpectationsWereMet())
 	//})
 
+	t.Run("SuccessWithIDAndTimestamps", func(t *testing.T) {
+		page := 1
+		size := 2
+		filters := map[string]any{}
+		now := time.Now()
+
+		mock.ExpectQuery(regexp.QuoteMeta(`SELECT id, artist_id, group_name, song_name, release_date, link, lyrics, enrichment_status, enrichment_error, metadata_sources, created_at, updated_at FROM songs ORDER BY id ASC LIMIT 2 OFFSET 0`)).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "artist_id", "group_name", "song_name", "release_date", "link", "lyrics", "enrichment_status", "enrichment_error", "metadata_sources", "created_at", "updated_at"}).
+				AddRow(1, 1, "test-group", "test-song", now, "https://example.com", []byte(`{"Lyric 1","Lyric 2"}`), "done", "", []byte(`{}`), now, now))
+
+		mock.ExpectQuery(regexp.QuoteMeta(`SELECT COUNT(*) FROM songs`)).
+			WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+
+		result, err := repository.ListSongs(context.Background(), page, size, filters)
+		require.NoError(t, err)
+		require.NotNil(t, result)
+
+		songs := result.Data.([]*model.Song)
+		require.Len(t, songs, 1)
+		assert.Equal(t, uint64(1), songs[0].ID)
+		assert.Equal(t, uint64(1), songs[0].ArtistID)
+		assert.Equal(t, []string{"Lyric 1", "Lyric 2"}, songs[0].Lyrics)
+		assert.Equal(t, now, songs[0].CreatedAt)
+		assert.Equal(t, now, songs[0].UpdatedAt)
+
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("SuccessWithSearch", func(t *testing.T) {
+		page := 1
+		size := 2
+		filters := map[string]any{"q": "soul alight"}
+		now := time.Now()
+
+		mock.ExpectQuery(regexp.QuoteMeta(`SELECT id, artist_id, group_name, song_name, release_date, link, lyrics, enrichment_status, enrichment_error, metadata_sources, created_at, updated_at, ts_headline('simple', array_to_string(lyrics, E'\n'), websearch_to_tsquery('simple', $2)) AS headline FROM songs WHERE lyrics_tsv @@ websearch_to_tsquery('simple', $1) ORDER BY ts_rank(lyrics_tsv, websearch_to_tsquery('simple', $2)) DESC, id ASC LIMIT 2 OFFSET 0`)).
+			WithArgs("soul alight", "soul alight").
+			WillReturnRows(sqlmock.NewRows([]string{"id", "artist_id", "group_name", "song_name", "release_date", "link", "lyrics", "enrichment_status", "enrichment_error", "metadata_sources", "created_at", "updated_at", "headline"}).
+				AddRow(1, 1, "test-group", "test-song", now, "https://example.com", []byte(`{"You set my soul alight"}`), "done", "", []byte(`{}`), now, now, "You set my <b>soul</b> <b>alight</b>"))
+
+		mock.ExpectQuery(regexp.QuoteMeta(`SELECT COUNT(*) FROM songs WHERE lyrics_tsv @@ websearch_to_tsquery('simple', $1)`)).
+			WithArgs("soul alight").
+			WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+
+		result, err := repository.ListSongs(context.Background(), page, size, filters)
+		require.NoError(t, err)
+		require.NotNil(t, result)
+
+		songs := result.Data.([]*model.Song)
+		require.Len(t, songs, 1)
+		assert.Equal(t, "You set my <b>soul</b> <b>alight</b>", songs[0].Headline)
+
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("SuccessWithSort", func(t *testing.T) {
+		page := 2
+		size := 2
+		filters := map[string]any{"sort": "-release_date,group"}
+
+		mock.ExpectQuery(regexp.QuoteMeta(`SELECT id, artist_id, group_name, song_name, release_date, link, lyrics, enrichment_status, enrichment_error, metadata_sources, created_at, updated_at FROM songs ORDER BY release_date DESC NULLS LAST, group_name ASC, id ASC LIMIT 2 OFFSET 2`)).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "artist_id", "group_name", "song_name", "release_date", "link", "lyrics", "enrichment_status", "enrichment_error", "metadata_sources", "created_at", "updated_at"}))
+
+		mock.ExpectQuery(regexp.QuoteMeta(`SELECT COUNT(*) FROM songs`)).
+			WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(2))
+
+		result, err := repository.ListSongs(context.Background(), page, size, filters)
+		require.NoError(t, err)
+		require.NotNil(t, result)
+		assert.False(t, result.HasNextPage)
+
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("ErrInvalidSort", func(t *testing.T) {
+		filters := map[string]any{"sort": "lyrics"}
+
+		result, err := repository.ListSongs(context.Background(), 1, 2, filters)
+		assert.ErrorIs(t, err, utils.ErrInvalidSort)
+		assert.Nil(t, result)
+	})
+
 	t.Run("DBErrorOnQuery", func(t *testing.T) {
 		page := 1
 		size := 2
 		filters := map[string]any{"group": "test-group"}
 
-		mock.ExpectQuery(regexp.QuoteMeta(`SELECT group_name, song_name, release_date, link, lyrics FROM songs`)).
+		mock.ExpectQuery(regexp.QuoteMeta(`SELECT id, artist_id, group_name, song_name, release_date, link, lyrics, enrichment_status, enrichment_error, metadata_sources, created_at, updated_at FROM songs`)).
 			WillReturnError(errors.New("some database error"))
 
 		result, err := repository.ListSongs(context.Background(), page, size, filters)
@@ -92,6 +178,187 @@ func TestRepository_ListSongs(t *testing.T) {
 	//})
 }
 
+func TestRepository_ListSongsCursor(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	repository := Repository{conn: db}
+	columns := []string{"id", "artist_id", "group_name", "song_name", "release_date", "link", "lyrics", "enrichment_status", "enrichment_error", "metadata_sources", "created_at", "updated_at"}
+	releaseDate := time.Date(2006, 7, 16, 0, 0, 0, 0, time.UTC)
+	now := time.Now()
+
+	t.Run("FirstPage", func(t *testing.T) {
+		mock.ExpectQuery(regexp.QuoteMeta(`SELECT id, artist_id, group_name, song_name, release_date, link, lyrics, enrichment_status, enrichment_error, metadata_sources, created_at, updated_at FROM songs ORDER BY release_date DESC NULLS LAST, id ASC LIMIT 3`)).
+			WillReturnRows(sqlmock.NewRows(columns).
+				AddRow(1, 1, "group", "song 1", releaseDate, "https://example.com", []byte(`{}`), "done", "", []byte(`{}`), now, now).
+				AddRow(2, 1, "group", "song 2", releaseDate, "https://example.com", []byte(`{}`), "done", "", []byte(`{}`), now, now).
+				AddRow(3, 1, "group", "song 3", releaseDate, "https://example.com", []byte(`{}`), "done", "", []byte(`{}`), now, now))
+
+		result, err := repository.ListSongsCursor(context.Background(), "", 2, false, map[string]any{"sort": "-release_date"})
+		require.NoError(t, err)
+		assert.Len(t, result.Data.([]*model.Song), 2)
+		assert.Nil(t, result.Count)
+		assert.Empty(t, result.PrevCursor)
+
+		next, err := keyset.Decode(result.NextCursor)
+		require.NoError(t, err)
+		assert.Equal(t, "-release_date", next.Sort)
+		assert.Equal(t, cursorValues(releaseDate.Format(time.RFC3339Nano), "2"), next.Values)
+		assert.False(t, next.Backward)
+
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("NextPageWithCount", func(t *testing.T) {
+		cursor := keyset.Encode(&keyset.Cursor{
+			Sort:   "-release_date",
+			Values: cursorValues(releaseDate.Format(time.RFC3339Nano), "2"),
+		})
+
+		mock.ExpectQuery(regexp.QuoteMeta(`SELECT id, artist_id, group_name, song_name, release_date, link, lyrics, enrichment_status, enrichment_error, metadata_sources, created_at, updated_at FROM songs WHERE artist_id IN (SELECT id FROM artists WHERE name ILIKE $1) AND (((release_date < $2 OR release_date IS NULL)) OR (release_date = $2 AND id > $3)) ORDER BY release_date DESC NULLS LAST, id ASC LIMIT 3`)).
+			WithArgs("%group%", releaseDate, uint64(2)).
+			WillReturnRows(sqlmock.NewRows(columns).
+				AddRow(3, 1, "group", "song 3", releaseDate, "https://example.com", []byte(`{}`), "done", "", []byte(`{}`), now, now))
+
+		mock.ExpectQuery(regexp.QuoteMeta(`SELECT COUNT(*) FROM songs WHERE artist_id IN (SELECT id FROM artists WHERE name ILIKE $1)`)).
+			WithArgs("%group%").
+			WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(3))
+
+		result, err := repository.ListSongsCursor(context.Background(), cursor, 2, true, map[string]any{"group": "group"})
+		require.NoError(t, err)
+		assert.Len(t, result.Data.([]*model.Song), 1)
+		assert.Equal(t, int64(3), *result.Count)
+		assert.Empty(t, result.NextCursor)
+
+		prev, err := keyset.Decode(result.PrevCursor)
+		require.NoError(t, err)
+		assert.Equal(t, cursorValues(releaseDate.Format(time.RFC3339Nano), "3"), prev.Values)
+		assert.True(t, prev.Backward)
+
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("PrevPage", func(t *testing.T) {
+		cursor := keyset.Encode(&keyset.Cursor{Values: cursorValues("3"), Backward: true})
+
+		mock.ExpectQuery(regexp.QuoteMeta(`SELECT id, artist_id, group_name, song_name, release_date, link, lyrics, enrichment_status, enrichment_error, metadata_sources, created_at, updated_at FROM songs WHERE ((id < $1)) ORDER BY id DESC LIMIT 3`)).
+			WithArgs(uint64(3)).
+			WillReturnRows(sqlmock.NewRows(columns).
+				AddRow(2, 1, "group", "song 2", releaseDate, "https://example.com", []byte(`{}`), "done", "", []byte(`{}`), now, now).
+				AddRow(1, 1, "group", "song 1", releaseDate, "https://example.com", []byte(`{}`), "done", "", []byte(`{}`), now, now))
+
+		result, err := repository.ListSongsCursor(context.Background(), cursor, 2, false, map[string]any{})
+		require.NoError(t, err)
+
+		songs := result.Data.([]*model.Song)
+		require.Len(t, songs, 2)
+		assert.Equal(t, uint64(1), songs[0].ID)
+		assert.Equal(t, uint64(2), songs[1].ID)
+		assert.Empty(t, result.PrevCursor)
+
+		next, err := keyset.Decode(result.NextCursor)
+		require.NoError(t, err)
+		assert.Equal(t, cursorValues("2"), next.Values)
+
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("NullBoundaryRow", func(t *testing.T) {
+		// Songs without a release date go last, the page after one of them continues by id among the NULLs
+		cursor := keyset.Encode(&keyset.Cursor{Sort: "-release_date", Values: []*string{nil, ptr("5")}})
+
+		mock.ExpectQuery(regexp.QuoteMeta(`SELECT id, artist_id, group_name, song_name, release_date, link, lyrics, enrichment_status, enrichment_error, metadata_sources, created_at, updated_at FROM songs WHERE ((release_date IS NULL AND id > $1)) ORDER BY release_date DESC NULLS LAST, id ASC LIMIT 3`)).
+			WithArgs(uint64(5)).
+			WillReturnRows(sqlmock.NewRows(columns).
+				AddRow(6, 1, "group", "song 6", nil, "", []byte(`{}`), "pending", "", []byte(`{}`), now, now).
+				AddRow(7, 1, "group", "song 7", nil, "", []byte(`{}`), "failed", "", []byte(`{}`), now, now).
+				AddRow(8, 1, "group", "song 8", nil, "", []byte(`{}`), "pending", "", []byte(`{}`), now, now))
+
+		result, err := repository.ListSongsCursor(context.Background(), cursor, 2, false, map[string]any{})
+		require.NoError(t, err)
+		assert.Len(t, result.Data.([]*model.Song), 2)
+
+		next, err := keyset.Decode(result.NextCursor)
+		require.NoError(t, err)
+		assert.Equal(t, []*string{nil, ptr("7")}, next.Values)
+
+		prev, err := keyset.Decode(result.PrevCursor)
+		require.NoError(t, err)
+		assert.Equal(t, []*string{nil, ptr("6")}, prev.Values)
+
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("NullBoundaryRowBackward", func(t *testing.T) {
+		cursor := keyset.Encode(&keyset.Cursor{Sort: "-release_date", Values: []*string{nil, ptr("6")}, Backward: true})
+
+		mock.ExpectQuery(regexp.QuoteMeta(`SELECT id, artist_id, group_name, song_name, release_date, link, lyrics, enrichment_status, enrichment_error, metadata_sources, created_at, updated_at FROM songs WHERE ((release_date IS NOT NULL) OR (release_date IS NULL AND id < $1)) ORDER BY release_date ASC NULLS FIRST, id DESC LIMIT 3`)).
+			WithArgs(uint64(6)).
+			WillReturnRows(sqlmock.NewRows(columns).
+				AddRow(5, 1, "group", "song 5", nil, "", []byte(`{}`), "pending", "", []byte(`{}`), now, now).
+				AddRow(2, 1, "group", "song 2", releaseDate, "https://example.com", []byte(`{}`), "done", "", []byte(`{}`), now, now))
+
+		result, err := repository.ListSongsCursor(context.Background(), cursor, 2, false, map[string]any{})
+		require.NoError(t, err)
+
+		songs := result.Data.([]*model.Song)
+		require.Len(t, songs, 2)
+		assert.Equal(t, uint64(2), songs[0].ID)
+		assert.Equal(t, uint64(5), songs[1].ID)
+
+		next, err := keyset.Decode(result.NextCursor)
+		require.NoError(t, err)
+		assert.Equal(t, []*string{nil, ptr("5")}, next.Values)
+
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("ErrNullID", func(t *testing.T) {
+		cursor := keyset.Encode(&keyset.Cursor{Values: []*string{nil}})
+
+		result, err := repository.ListSongsCursor(context.Background(), cursor, 2, false, map[string]any{})
+		assert.ErrorIs(t, err, keyset.ErrInvalidCursor)
+		assert.Nil(t, result)
+	})
+
+	t.Run("ErrInvalidCursor", func(t *testing.T) {
+		result, err := repository.ListSongsCursor(context.Background(), "not-a-cursor", 2, false, map[string]any{})
+		assert.ErrorIs(t, err, keyset.ErrInvalidCursor)
+		assert.Nil(t, result)
+	})
+
+	t.Run("ErrSortMismatch", func(t *testing.T) {
+		cursor := keyset.Encode(&keyset.Cursor{Sort: "group", Values: cursorValues("group", "1")})
+
+		result, err := repository.ListSongsCursor(context.Background(), cursor, 2, false, map[string]any{"sort": "song"})
+		assert.ErrorIs(t, err, keyset.ErrInvalidCursor)
+		assert.Nil(t, result)
+	})
+
+	t.Run("DBErrorOnQuery", func(t *testing.T) {
+		mock.ExpectQuery(regexp.QuoteMeta(`SELECT id, artist_id, group_name, song_name, release_date, link, lyrics, enrichment_status, enrichment_error, metadata_sources, created_at, updated_at FROM songs`)).
+			WillReturnError(errors.New("some database error"))
+
+		result, err := repository.ListSongsCursor(context.Background(), "", 2, false, map[string]any{})
+		require.Error(t, err)
+		assert.Nil(t, result)
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func ptr[T any](v T) *T {
+	return &v
+}
+
+func cursorValues(values ...string) []*string {
+	res := make([]*string, 0, len(values))
+	for i := range values {
+		res = append(res, &values[i])
+	}
+	return res
+}
+
 // https://github.com/DATA-DOG/go-sqlmock/issues/201
 func TestRepository_GetSong(t *testing.T) {
 	db, mock, err := sqlmock.New()
@@ -126,12 +393,62 @@ func TestRepository_GetSong(t *testing.T) {
 	//	require.NoError(t, mock.ExpectationsWereMet())
 	//})
 
+	t.Run("SuccessWithIDAndTimestamps", func(t *testing.T) {
+		id := uint64(1)
+		page := 1
+		size := 2
+		now := time.Now()
+
+		mock.ExpectQuery(regexp.QuoteMeta(`SELECT id, artist_id, group_name, song_name, release_date, link, lyrics[$2:$3], enrichment_status, enrichment_error, metadata_sources, created_at, updated_at, ARRAY( SELECT genres.name FROM song_genres JOIN genres ON genres.id = song_genres.genre_id WHERE song_genres.song_id = songs.id ORDER BY genres.name ) AS genres, ARRAY( SELECT tags.name FROM song_tags JOIN tags ON tags.id = song_tags.tag_id WHERE song_tags.song_id = songs.id ORDER BY tags.name ) AS tags, ARRAY(SELECT lang FROM song_translations WHERE song_id = songs.id ORDER BY lang) AS translations, cardinality(lyrics) as count FROM songs WHERE id = $1`)).
+			WithArgs(id, 1, size).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "artist_id", "group_name", "song_name", "release_date", "link", "lyrics", "enrichment_status", "enrichment_error", "metadata_sources", "created_at", "updated_at", "genres", "tags", "translations", "count"}).
+				AddRow(id, 1, "test-group", "test-song", now, "https://example.com", []byte(`{"Lyric 1","Lyric 2"}`), "done", "", []byte(`{"link": "fixtures", "lyrics": "info"}`), now, now, []byte(`{rock}`), []byte(`{90s,workout}`), []byte(`{de,en}`), 2))
+
+		result, err := repository.GetSong(context.Background(), id, page, size)
+		require.NoError(t, err)
+		require.NotNil(t, result)
+
+		song := result.Data.(*model.Song)
+		assert.Equal(t, id, song.ID)
+		assert.Equal(t, map[string]string{model.FieldLink: "fixtures", model.FieldLyrics: "info"}, song.MetadataSources)
+		assert.Equal(t, []string{"rock"}, song.Genres)
+		assert.Equal(t, []string{"90s", "workout"}, song.Tags)
+		assert.Equal(t, []string{"de", "en"}, song.Translations)
+		assert.Equal(t, now, song.CreatedAt)
+		assert.Equal(t, now, song.UpdatedAt)
+		assert.Equal(t, int64(2), result.Count)
+
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("SuccessPendingEnrichment", func(t *testing.T) {
+		id := uint64(4)
+		page := 1
+		size := 2
+		now := time.Now()
+
+		mock.ExpectQuery(regexp.QuoteMeta(`SELECT id, artist_id, group_name, song_name, release_date, link, lyrics[$2:$3], enrichment_status, enrichment_error, metadata_sources, created_at, updated_at, ARRAY( SELECT genres.name FROM song_genres JOIN genres ON genres.id = song_genres.genre_id WHERE song_genres.song_id = songs.id ORDER BY genres.name ) AS genres, ARRAY( SELECT tags.name FROM song_tags JOIN tags ON tags.id = song_tags.tag_id WHERE song_tags.song_id = songs.id ORDER BY tags.name ) AS tags, ARRAY(SELECT lang FROM song_translations WHERE song_id = songs.id ORDER BY lang) AS translations, cardinality(lyrics) as count FROM songs WHERE id = $1`)).
+			WithArgs(id, 1, size).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "artist_id", "group_name", "song_name", "release_date", "link", "lyrics", "enrichment_status", "enrichment_error", "metadata_sources", "created_at", "updated_at", "genres", "tags", "translations", "count"}).
+				AddRow(id, 1, "test-group", "test-song", nil, "", []byte(`{}`), model.EnrichmentPending, "", []byte(`{}`), now, now, []byte(`{}`), []byte(`{}`), []byte(`{}`), 0))
+
+		result, err := repository.GetSong(context.Background(), id, page, size)
+		require.NoError(t, err)
+
+		song := result.Data.(*model.Song)
+		assert.True(t, song.ReleaseDate.IsZero())
+		assert.Equal(t, model.EnrichmentPending, song.EnrichmentStatus)
+		assert.Equal(t, int64(0), result.Count)
+
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+
 	t.Run("ErrNotFound", func(t *testing.T) {
 		id := uint64(2)
 		page := 1
 		size := 2
 
-		mock.ExpectQuery(regexp.QuoteMeta(`SELECT group_name, song_name, release_date, link, lyrics[$2:$3], array_length(lyrics, 1) as count FROM songs WHERE id = $1`)).
+		mock.ExpectQuery(regexp.QuoteMeta(`SELECT id, artist_id, group_name, song_name, release_date, link, lyrics[$2:$3], enrichment_status, enrichment_error, metadata_sources, created_at, updated_at, ARRAY( SELECT genres.name FROM song_genres JOIN genres ON genres.id = song_genres.genre_id WHERE song_genres.song_id = songs.id ORDER BY genres.name ) AS genres, ARRAY( SELECT tags.name FROM song_tags JOIN tags ON tags.id = song_tags.tag_id WHERE song_tags.song_id = songs.id ORDER BY tags.name ) AS tags, ARRAY(SELECT lang FROM song_translations WHERE song_id = songs.id ORDER BY lang) AS translations, cardinality(lyrics) as count FROM songs WHERE id = $1`)).
 			WithArgs(id, 1, size).
 			WillReturnError(sql.ErrNoRows)
 
@@ -147,7 +464,7 @@ func TestRepository_GetSong(t *testing.T) {
 		page := 1
 		size := 2
 
-		mock.ExpectQuery(regexp.QuoteMeta(`SELECT group_name, song_name, release_date, link, lyrics[$2:$3], array_length(lyrics, 1) as count FROM songs WHERE id = $1`)).
+		mock.ExpectQuery(regexp.QuoteMeta(`SELECT id, artist_id, group_name, song_name, release_date, link, lyrics[$2:$3], enrichment_status, enrichment_error, metadata_sources, created_at, updated_at, ARRAY( SELECT genres.name FROM song_genres JOIN genres ON genres.id = song_genres.genre_id WHERE song_genres.song_id = songs.id ORDER BY genres.name ) AS genres, ARRAY( SELECT tags.name FROM song_tags JOIN tags ON tags.id = song_tags.tag_id WHERE song_tags.song_id = songs.id ORDER BY tags.name ) AS tags, ARRAY(SELECT lang FROM song_translations WHERE song_id = songs.id ORDER BY lang) AS translations, cardinality(lyrics) as count FROM songs WHERE id = $1`)).
 			WithArgs(id, 1, size).
 			WillReturnError(errors.New("some database error"))
 
@@ -159,15 +476,27 @@ func TestRepository_GetSong(t *testing.T) {
 	})
 }
 
+const resolveArtistQ = `INSERT INTO artists (name) VALUES ($1) ON CONFLICT ((lower(btrim(name)))) DO NOTHING RETURNING id, name, created_at, updated_at`
+
+func expectResolveArtist(mock sqlmock.Sqlmock, name string, id uint64) {
+	now := time.Now()
+	mock.ExpectQuery(regexp.QuoteMeta(resolveArtistQ)).
+		WithArgs(name).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "name", "created_at", "updated_at"}).AddRow(id, name, now, now))
+}
+
 func TestRepository_CreateSong(t *testing.T) {
 	db, mock, err := sqlmock.New()
 	require.NoError(t, err)
 	defer db.Close()
 
 	repository := Repository{conn: db}
+	const insertQ = `INSERT INTO songs (artist_id, group_name, song_name, release_date, lyrics, link, enrichment_status) VALUES ($1, $2, $3, $4, $5, $6, $7) ON CONFLICT ((lower(btrim(group_name))), (lower(btrim(song_name)))) DO NOTHING RETURNING id`
+	const trackQ = `INSERT INTO album_tracks (album_id, song_id, disc, track_number) VALUES ($1, $2, $3, $4)`
 
 	t.Run("Success", func(t *testing.T) {
 		req := &model.Song{
+			ArtistID:    1,
 			Group:       "test-group",
 			Song:        "test-song",
 			ReleaseDate: time.Now(),
@@ -175,13 +504,12 @@ func TestRepository_CreateSong(t *testing.T) {
 			Link:        "https://example.com",
 		}
 
-		mock.ExpectQuery(regexp.QuoteMeta(`SELECT id FROM songs WHERE group_name=$1 AND song_name=$2`)).
-			WithArgs(req.Group, req.Song).
-			WillReturnError(sql.ErrNoRows)
-
-		mock.ExpectQuery(regexp.QuoteMeta(`INSERT INTO songs (group_name, song_name, release_date, lyrics, link) VALUES ($1, $2, $3, $4, $5) RETURNING id`)).
-			WithArgs(req.Group, req.Song, req.ReleaseDate, pq.Array(req.Lyrics), req.Link).
+		mock.ExpectBegin()
+		expectResolveArtist(mock, req.Group, 1)
+		mock.ExpectQuery(regexp.QuoteMeta(insertQ)).
+			WithArgs(uint64(1), req.Group, req.Song, req.ReleaseDate, pq.Array(req.Lyrics), req.Link, model.EnrichmentDone).
 			WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+		mock.ExpectCommit()
 
 		id, err := repository.CreateSong(context.Background(), req)
 		require.NoError(t, err)
@@ -199,9 +527,12 @@ func TestRepository_CreateSong(t *testing.T) {
 			Link:        "https://example.com",
 		}
 
-		mock.ExpectQuery(regexp.QuoteMeta(`SELECT id FROM songs WHERE group_name=$1 AND song_name=$2`)).
-			WithArgs(req.Group, req.Song).
-			WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+		mock.ExpectBegin()
+		expectResolveArtist(mock, req.Group, 1)
+		mock.ExpectQuery(regexp.QuoteMeta(insertQ)).
+			WithArgs(uint64(1), req.Group, req.Song, req.ReleaseDate, pq.Array(req.Lyrics), req.Link, model.EnrichmentDone).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}))
+		mock.ExpectRollback()
 
 		id, err := repository.CreateSong(context.Background(), req)
 		require.Error(t, err)
@@ -209,7 +540,7 @@ func TestRepository_CreateSong(t *testing.T) {
 		assert.Equal(t, uint64(0), id)
 	})
 
-	t.Run("DBErrorOnSelect", func(t *testing.T) {
+	t.Run("ErrUniqueViolation", func(t *testing.T) {
 		req := &model.Song{
 			Group:       "test-group",
 			Song:        "test-song",
@@ -218,13 +549,16 @@ func TestRepository_CreateSong(t *testing.T) {
 			Link:        "https://example.com",
 		}
 
-		mock.ExpectQuery(regexp.QuoteMeta(`SELECT id FROM songs WHERE group_name=$1 AND song_name=$2`)).
-			WithArgs(req.Group, req.Song).
-			WillReturnError(errors.New("some database error"))
+		mock.ExpectBegin()
+		expectResolveArtist(mock, req.Group, 1)
+		mock.ExpectQuery(regexp.QuoteMeta(insertQ)).
+			WithArgs(uint64(1), req.Group, req.Song, req.ReleaseDate, pq.Array(req.Lyrics), req.Link, model.EnrichmentDone).
+			WillReturnError(&pq.Error{Code: uniqueViolation})
+		mock.ExpectRollback()
 
 		id, err := repository.CreateSong(context.Background(), req)
 		require.Error(t, err)
-		assert.Equal(t, "some database error", err.Error())
+		assert.Equal(t, repo.ErrAlreadyExists, err)
 		assert.Equal(t, uint64(0), id)
 	})
 
@@ -237,13 +571,12 @@ func TestRepository_CreateSong(t *testing.T) {
 			Link:        "https://example.com",
 		}
 
-		mock.ExpectQuery(regexp.QuoteMeta(`SELECT id FROM songs WHERE group_name=$1 AND song_name=$2`)).
-			WithArgs(req.Group, req.Song).
-			WillReturnError(sql.ErrNoRows)
-
-		mock.ExpectQuery(regexp.QuoteMeta(`INSERT INTO songs (group_name, song_name, release_date, lyrics, link) VALUES ($1, $2, $3, $4, $5) RETURNING id`)).
-			WithArgs(req.Group, req.Song, req.ReleaseDate, pq.Array(req.Lyrics), req.Link).
+		mock.ExpectBegin()
+		expectResolveArtist(mock, req.Group, 1)
+		mock.ExpectQuery(regexp.QuoteMeta(insertQ)).
+			WithArgs(uint64(1), req.Group, req.Song, req.ReleaseDate, pq.Array(req.Lyrics), req.Link, model.EnrichmentDone).
 			WillReturnError(errors.New("some insert error"))
+		mock.ExpectRollback()
 
 		id, err := repository.CreateSong(context.Background(), req)
 		require.Error(t, err)
@@ -251,6 +584,93 @@ func TestRepository_CreateSong(t *testing.T) {
 		assert.Equal(t, uint64(0), id)
 	})
 
+	t.Run("DBErrorOnResolveArtist", func(t *testing.T) {
+		req := &model.Song{Group: "test-group", Song: "test-song"}
+
+		mock.ExpectBegin()
+		mock.ExpectQuery(regexp.QuoteMeta(resolveArtistQ)).
+			WithArgs(req.Group).
+			WillReturnError(errors.New("some resolve error"))
+		mock.ExpectRollback()
+
+		id, err := repository.CreateSong(context.Background(), req)
+		assert.Equal(t, "some resolve error", err.Error())
+		assert.Equal(t, uint64(0), id)
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("SuccessWithAlbum", func(t *testing.T) {
+		req := &model.Song{
+			ArtistID: 1,
+			Group:    "test-group",
+			Song:     "album-song",
+			Album:    &model.AlbumTrack{AlbumID: 2, Disc: 1, TrackNumber: 3},
+		}
+
+		mock.ExpectBegin()
+		expectResolveArtist(mock, req.Group, 1)
+		mock.ExpectQuery(regexp.QuoteMeta(insertQ)).
+			WithArgs(uint64(1), req.Group, req.Song, nil, pq.Array([]string{}), req.Link, model.EnrichmentDone).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(4))
+		mock.ExpectExec(regexp.QuoteMeta(trackQ)).
+			WithArgs(uint64(2), uint64(4), 1, 3).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectCommit()
+
+		id, err := repository.CreateSong(context.Background(), req)
+		require.NoError(t, err)
+		assert.Equal(t, uint64(4), id)
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("ErrAlbumNotFound", func(t *testing.T) {
+		req := &model.Song{
+			ArtistID: 1,
+			Group:    "test-group",
+			Song:     "album-song",
+			Album:    &model.AlbumTrack{AlbumID: 5, Disc: 1, TrackNumber: 1},
+		}
+
+		mock.ExpectBegin()
+		expectResolveArtist(mock, req.Group, 1)
+		mock.ExpectQuery(regexp.QuoteMeta(insertQ)).
+			WithArgs(uint64(1), req.Group, req.Song, nil, pq.Array([]string{}), req.Link, model.EnrichmentDone).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(4))
+		mock.ExpectExec(regexp.QuoteMeta(trackQ)).
+			WithArgs(uint64(5), uint64(4), 1, 1).
+			WillReturnError(&pq.Error{Code: foreignKeyViolation})
+		mock.ExpectRollback()
+
+		id, err := repository.CreateSong(context.Background(), req)
+		assert.Equal(t, repo.ErrNotFound, err)
+		assert.Equal(t, uint64(0), id)
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("ErrTrackTaken", func(t *testing.T) {
+		req := &model.Song{
+			ArtistID: 1,
+			Group:    "test-group",
+			Song:     "album-song",
+			Album:    &model.AlbumTrack{AlbumID: 2, Disc: 1, TrackNumber: 3},
+		}
+
+		mock.ExpectBegin()
+		expectResolveArtist(mock, req.Group, 1)
+		mock.ExpectQuery(regexp.QuoteMeta(insertQ)).
+			WithArgs(uint64(1), req.Group, req.Song, nil, pq.Array([]string{}), req.Link, model.EnrichmentDone).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(4))
+		mock.ExpectExec(regexp.QuoteMeta(trackQ)).
+			WithArgs(uint64(2), uint64(4), 1, 3).
+			WillReturnError(&pq.Error{Code: uniqueViolation})
+		mock.ExpectRollback()
+
+		id, err := repository.CreateSong(context.Background(), req)
+		assert.Equal(t, repo.ErrTrackTaken, err)
+		assert.Equal(t, uint64(0), id)
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+
 	require.NoError(t, mock.ExpectationsWereMet())
 }
 
@@ -260,10 +680,12 @@ func TestRepository_UpdateSong(t *testing.T) {
 	defer db.Close()
 
 	repository := Repository{conn: db}
+	const updateQ = `UPDATE songs SET artist_id = $1, group_name = $2, song_name = $3, release_date = $4, lyrics = $5, link = $6 WHERE id = $7`
 
 	t.Run("Success", func(t *testing.T) {
 		req := &model.Song{
 			ID:          1,
+			ArtistID:    1,
 			Group:       "test-group",
 			Song:        "updated-test-song",
 			ReleaseDate: time.Now(),
@@ -271,19 +693,44 @@ func TestRepository_UpdateSong(t *testing.T) {
 			Link:        "https://example.com/updated",
 		}
 
-		mock.ExpectQuery(regexp.QuoteMeta(`SELECT id FROM songs WHERE id = $1`)).
-			WithArgs(req.ID).
-			WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(req.ID))
-
-		mock.ExpectExec(regexp.QuoteMeta(`UPDATE songs SET group_name = $1, song_name = $2, release_date = $3, lyrics = $4, link = $5 WHERE id = $6`)).
-			WithArgs(req.Group, req.Song, req.ReleaseDate, pq.Array(req.Lyrics), req.Link, req.ID).
+		mock.ExpectBegin()
+		expectResolveArtist(mock, req.Group, 1)
+		mock.ExpectExec(regexp.QuoteMeta(updateQ)).
+			WithArgs(uint64(1), req.Group, req.Song, req.ReleaseDate, pq.Array(req.Lyrics), req.Link, req.ID).
 			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectQuery(regexp.QuoteMeta(alignedQ)).
+			WithArgs(req.ID, len(req.Lyrics)).
+			WillReturnRows(sqlmock.NewRows([]string{"lang"}))
+		mock.ExpectCommit()
 
 		err := repository.UpdateSong(context.Background(), req)
 		require.NoError(t, err)
 		require.NoError(t, mock.ExpectationsWereMet())
 	})
 
+	t.Run("ErrTranslationsMisaligned", func(t *testing.T) {
+		req := &model.Song{
+			ID:     5,
+			Group:  "test-group",
+			Song:   "test-song",
+			Lyrics: []string{"Lyric 1", "Lyric 2", "Lyric 3"},
+		}
+
+		mock.ExpectBegin()
+		expectResolveArtist(mock, req.Group, 1)
+		mock.ExpectExec(regexp.QuoteMeta(updateQ)).
+			WithArgs(uint64(1), req.Group, req.Song, req.ReleaseDate, pq.Array(req.Lyrics), req.Link, req.ID).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectQuery(regexp.QuoteMeta(alignedQ)).
+			WithArgs(req.ID, len(req.Lyrics)).
+			WillReturnRows(sqlmock.NewRows([]string{"lang"}).AddRow("de"))
+		mock.ExpectRollback()
+
+		err := repository.UpdateSong(context.Background(), req)
+		assert.ErrorIs(t, err, repo.ErrTranslationsMisaligned)
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+
 	t.Run("ErrNotFound", func(t *testing.T) {
 		req := &model.Song{
 			ID:          2,
@@ -294,9 +741,12 @@ func TestRepository_UpdateSong(t *testing.T) {
 			Link:        "https://example.com",
 		}
 
-		mock.ExpectQuery(regexp.QuoteMeta(`SELECT id FROM songs WHERE id = $1`)).
-			WithArgs(req.ID).
-			WillReturnError(sql.ErrNoRows)
+		mock.ExpectBegin()
+		expectResolveArtist(mock, req.Group, 1)
+		mock.ExpectExec(regexp.QuoteMeta(updateQ)).
+			WithArgs(uint64(1), req.Group, req.Song, req.ReleaseDate, pq.Array(req.Lyrics), req.Link, req.ID).
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectRollback()
 
 		err := repository.UpdateSong(context.Background(), req)
 		require.Error(t, err)
@@ -304,7 +754,7 @@ func TestRepository_UpdateSong(t *testing.T) {
 		require.NoError(t, mock.ExpectationsWereMet())
 	})
 
-	t.Run("DBErrorOnSelect", func(t *testing.T) {
+	t.Run("ErrAlreadyExists", func(t *testing.T) {
 		req := &model.Song{
 			ID:          3,
 			Group:       "test-group",
@@ -314,13 +764,16 @@ func TestRepository_UpdateSong(t *testing.T) {
 			Link:        "https://example.com",
 		}
 
-		mock.ExpectQuery(regexp.QuoteMeta(`SELECT id FROM songs WHERE id = $1`)).
-			WithArgs(req.ID).
-			WillReturnError(errors.New("some database error"))
+		mock.ExpectBegin()
+		expectResolveArtist(mock, req.Group, 1)
+		mock.ExpectExec(regexp.QuoteMeta(updateQ)).
+			WithArgs(uint64(1), req.Group, req.Song, req.ReleaseDate, pq.Array(req.Lyrics), req.Link, req.ID).
+			WillReturnError(&pq.Error{Code: uniqueViolation})
+		mock.ExpectRollback()
 
 		err := repository.UpdateSong(context.Background(), req)
 		require.Error(t, err)
-		assert.Equal(t, "some database error", err.Error())
+		assert.Equal(t, repo.ErrAlreadyExists, err)
 		require.NoError(t, mock.ExpectationsWereMet())
 	})
 
@@ -334,13 +787,12 @@ func TestRepository_UpdateSong(t *testing.T) {
 			Link:        "https://example.com",
 		}
 
-		mock.ExpectQuery(regexp.QuoteMeta(`SELECT id FROM songs WHERE id = $1`)).
-			WithArgs(req.ID).
-			WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(req.ID))
-
-		mock.ExpectExec(regexp.QuoteMeta(`UPDATE songs SET group_name = $1, song_name = $2, release_date = $3, lyrics = $4, link = $5 WHERE id = $6`)).
-			WithArgs(req.Group, req.Song, req.ReleaseDate, pq.Array(req.Lyrics), req.Link, req.ID).
+		mock.ExpectBegin()
+		expectResolveArtist(mock, req.Group, 1)
+		mock.ExpectExec(regexp.QuoteMeta(updateQ)).
+			WithArgs(uint64(1), req.Group, req.Song, req.ReleaseDate, pq.Array(req.Lyrics), req.Link, req.ID).
 			WillReturnError(errors.New("some update error"))
+		mock.ExpectRollback()
 
 		err := repository.UpdateSong(context.Background(), req)
 		require.Error(t, err)
@@ -350,67 +802,2126 @@ func TestRepository_UpdateSong(t *testing.T) {
 	})
 }
 
-func TestRepository_DeleteSong(t *testing.T) {
+func TestRepository_PatchSong(t *testing.T) {
 	db, mock, err := sqlmock.New()
 	require.NoError(t, err)
 	defer db.Close()
 
 	repository := Repository{conn: db}
 
+	link := "https://example.com/patched"
+	lyrics := []string{"Patched Lyric 1"}
+
 	t.Run("Success", func(t *testing.T) {
 		id := uint64(1)
-		mock.ExpectQuery(regexp.QuoteMeta(`SELECT id FROM songs WHERE id = $1`)).
-			WithArgs(id).
-			WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(id))
+		req := &model.SongPatch{Link: &link, Lyrics: &lyrics}
 
-		mock.ExpectExec(regexp.QuoteMeta(`DELETE FROM songs WHERE id = $1`)).
-			WithArgs(id).
+		mock.ExpectBegin()
+		mock.ExpectExec(regexp.QuoteMeta(`UPDATE songs SET lyrics = $1, link = $2 WHERE id = $3`)).
+			WithArgs(pq.Array(lyrics), link, id).
 			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectQuery(regexp.QuoteMeta(alignedQ)).
+			WithArgs(id, len(lyrics)).
+			WillReturnRows(sqlmock.NewRows([]string{"lang"}))
+		mock.ExpectCommit()
 
-		err := repository.DeleteSong(context.Background(), id)
+		err := repository.PatchSong(context.Background(), id, req)
 		require.NoError(t, err)
 		require.NoError(t, mock.ExpectationsWereMet())
 	})
 
+	t.Run("ErrTranslationsMisaligned", func(t *testing.T) {
+		id := uint64(5)
+		req := &model.SongPatch{Lyrics: &lyrics}
+
+		mock.ExpectBegin()
+		mock.ExpectExec(regexp.QuoteMeta(`UPDATE songs SET lyrics = $1 WHERE id = $2`)).
+			WithArgs(pq.Array(lyrics), id).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectQuery(regexp.QuoteMeta(alignedQ)).
+			WithArgs(id, len(lyrics)).
+			WillReturnRows(sqlmock.NewRows([]string{"lang"}).AddRow("de"))
+		mock.ExpectRollback()
+
+		err := repository.PatchSong(context.Background(), id, req)
+		assert.ErrorIs(t, err, repo.ErrTranslationsMisaligned)
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+
 	t.Run("ErrNotFound", func(t *testing.T) {
 		id := uint64(2)
-		mock.ExpectQuery(regexp.QuoteMeta(`SELECT id FROM songs WHERE id = $1`)).
-			WithArgs(id).
-			WillReturnError(sql.ErrNoRows)
+		req := &model.SongPatch{Link: &link}
 
-		err := repository.DeleteSong(context.Background(), id)
+		mock.ExpectBegin()
+		mock.ExpectExec(regexp.QuoteMeta(`UPDATE songs SET link = $1 WHERE id = $2`)).
+			WithArgs(link, id).
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectRollback()
+
+		err := repository.PatchSong(context.Background(), id, req)
 		require.Error(t, err)
 		assert.Equal(t, repo.ErrNotFound, err)
 		require.NoError(t, mock.ExpectationsWereMet())
 	})
 
-	t.Run("DBErrorOnSelect", func(t *testing.T) {
+	t.Run("ErrAlreadyExists", func(t *testing.T) {
+		id := uint64(4)
+		group := "test-group"
+		req := &model.SongPatch{Group: &group}
+
+		mock.ExpectBegin()
+		expectResolveArtist(mock, group, 1)
+		mock.ExpectExec(regexp.QuoteMeta(`UPDATE songs SET artist_id = $1, group_name = $2 WHERE id = $3`)).
+			WithArgs(uint64(1), group, id).
+			WillReturnError(&pq.Error{Code: uniqueViolation})
+		mock.ExpectRollback()
+
+		err := repository.PatchSong(context.Background(), id, req)
+		require.Error(t, err)
+		assert.Equal(t, repo.ErrAlreadyExists, err)
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("DBErrorOnUpdate", func(t *testing.T) {
 		id := uint64(3)
-		mock.ExpectQuery(regexp.QuoteMeta(`SELECT id FROM songs WHERE id = $1`)).
-			WithArgs(id).
-			WillReturnError(errors.New("some database error"))
+		req := &model.SongPatch{Link: &link}
 
-		err := repository.DeleteSong(context.Background(), id)
+		mock.ExpectBegin()
+		mock.ExpectExec(regexp.QuoteMeta(`UPDATE songs SET link = $1 WHERE id = $2`)).
+			WithArgs(link, id).
+			WillReturnError(errors.New("some update error"))
+		mock.ExpectRollback()
+
+		err := repository.PatchSong(context.Background(), id, req)
 		require.Error(t, err)
-		assert.Equal(t, "some database error", err.Error())
+		assert.Equal(t, "some update error", err.Error())
 		require.NoError(t, mock.ExpectationsWereMet())
 	})
+}
 
-	t.Run("DBErrorOnDelete", func(t *testing.T) {
-		id := uint64(4)
+func TestRepository_DeleteSong(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	repository := Repository{conn: db}
+	const touchQ = `UPDATE playlists SET updated_at = NOW() WHERE id IN (SELECT playlist_id FROM playlist_songs WHERE song_id = $1)`
+	const shiftQ = `UPDATE playlist_songs SET position = playlist_songs.position - 1 FROM playlist_songs removed WHERE removed.song_id = $1 AND playlist_songs.playlist_id = removed.playlist_id AND playlist_songs.position > removed.position`
 
-		mock.ExpectQuery(regexp.QuoteMeta(`SELECT id FROM songs WHERE id = $1`)).
+	expectDetach := func(id uint64) {
+		mock.ExpectBegin()
+		mock.ExpectExec(regexp.QuoteMeta(touchQ)).
+			WithArgs(id).
+			WillReturnResult(sqlmock.NewResult(0, 2))
+		mock.ExpectExec(regexp.QuoteMeta(shiftQ)).
 			WithArgs(id).
-			WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(id))
+			WillReturnResult(sqlmock.NewResult(0, 5))
+	}
 
+	t.Run("Success", func(t *testing.T) {
+		id := uint64(1)
+		expectDetach(id)
 		mock.ExpectExec(regexp.QuoteMeta(`DELETE FROM songs WHERE id = $1`)).
 			WithArgs(id).
-			WillReturnError(errors.New("some delete error"))
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectCommit()
 
 		err := repository.DeleteSong(context.Background(), id)
-
-		require.Error(t, err)
-		assert.Equal(t, "some delete error", err.Error())
+		require.NoError(t, err)
 		require.NoError(t, mock.ExpectationsWereMet())
 	})
+
+	t.Run("ErrNotFound", func(t *testing.T) {
+		id := uint64(2)
+		expectDetach(id)
+		mock.ExpectExec(regexp.QuoteMeta(`DELETE FROM songs WHERE id = $1`)).
+			WithArgs(id).
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectRollback()
+
+		err := repository.DeleteSong(context.Background(), id)
+		require.Error(t, err)
+		assert.Equal(t, repo.ErrNotFound, err)
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("DBErrorOnDelete", func(t *testing.T) {
+		id := uint64(3)
+		expectDetach(id)
+		mock.ExpectExec(regexp.QuoteMeta(`DELETE FROM songs WHERE id = $1`)).
+			WithArgs(id).
+			WillReturnError(errors.New("some delete error"))
+		mock.ExpectRollback()
+
+		err := repository.DeleteSong(context.Background(), id)
+
+		require.Error(t, err)
+		assert.Equal(t, "some delete error", err.Error())
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("DBErrorOnDetach", func(t *testing.T) {
+		id := uint64(4)
+		mock.ExpectBegin()
+		mock.ExpectExec(regexp.QuoteMeta(touchQ)).
+			WithArgs(id).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectExec(regexp.QuoteMeta(shiftQ)).
+			WithArgs(id).
+			WillReturnError(errors.New("some update error"))
+		mock.ExpectRollback()
+
+		err := repository.DeleteSong(context.Background(), id)
+		assert.EqualError(t, err, "some update error")
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestRepository_ListArtists(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	repository := Repository{conn: db}
+	columns := []string{"id", "name", "created_at", "updated_at"}
+
+	t.Run("SuccessWithName", func(t *testing.T) {
+		now := time.Now()
+
+		mock.ExpectQuery(regexp.QuoteMeta(`SELECT id, name, created_at, updated_at FROM artists WHERE name ILIKE $1 ORDER BY name ASC, id ASC LIMIT 2 OFFSET 0`)).
+			WithArgs("%queen%").
+			WillReturnRows(sqlmock.NewRows(columns).
+				AddRow(1, "Queen", now, now).
+				AddRow(2, "Queens of the Stone Age", now, now))
+
+		mock.ExpectQuery(regexp.QuoteMeta(`SELECT COUNT(*) FROM artists WHERE name ILIKE $1`)).
+			WithArgs("%queen%").
+			WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(3))
+
+		result, err := repository.ListArtists(context.Background(), 1, 2, "queen")
+		require.NoError(t, err)
+		require.Len(t, result.Data, 2)
+		assert.Equal(t, "Queen", result.Data[0].Name)
+		assert.Equal(t, int64(3), result.Count)
+		assert.Equal(t, 2, result.TotalPages)
+		assert.True(t, result.HasNextPage)
+
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("DBErrorOnQuery", func(t *testing.T) {
+		mock.ExpectQuery(regexp.QuoteMeta(`SELECT id, name, created_at, updated_at FROM artists ORDER BY name ASC, id ASC LIMIT 2 OFFSET 0`)).
+			WillReturnError(errors.New("some database error"))
+
+		result, err := repository.ListArtists(context.Background(), 1, 2, "")
+		require.Error(t, err)
+		assert.Nil(t, result)
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestRepository_GetArtist(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	repository := Repository{conn: db}
+	const getQ = `SELECT id, name, created_at, updated_at FROM artists WHERE id = $1`
+
+	t.Run("Success", func(t *testing.T) {
+		now := time.Now()
+		mock.ExpectQuery(regexp.QuoteMeta(getQ)).
+			WithArgs(uint64(1)).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "name", "created_at", "updated_at"}).AddRow(1, "Queen", now, now))
+
+		res, err := repository.GetArtist(context.Background(), 1)
+		require.NoError(t, err)
+		assert.Equal(t, &model.Artist{ID: 1, Name: "Queen", CreatedAt: now, UpdatedAt: now}, res)
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("ErrNotFound", func(t *testing.T) {
+		mock.ExpectQuery(regexp.QuoteMeta(getQ)).
+			WithArgs(uint64(2)).
+			WillReturnError(sql.ErrNoRows)
+
+		res, err := repository.GetArtist(context.Background(), 2)
+		assert.Equal(t, repo.ErrNotFound, err)
+		assert.Nil(t, res)
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestRepository_CreateArtist(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	repository := Repository{conn: db}
+	const insertQ = `INSERT INTO artists (name) VALUES ($1) ON CONFLICT ((lower(btrim(name)))) DO NOTHING RETURNING id, name, created_at, updated_at`
+
+	t.Run("Success", func(t *testing.T) {
+		now := time.Now()
+		mock.ExpectQuery(regexp.QuoteMeta(insertQ)).
+			WithArgs("Queen").
+			WillReturnRows(sqlmock.NewRows([]string{"id", "name", "created_at", "updated_at"}).AddRow(1, "Queen", now, now))
+
+		res, err := repository.CreateArtist(context.Background(), &model.Artist{Name: "Queen"})
+		require.NoError(t, err)
+		assert.Equal(t, uint64(1), res.ID)
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("ErrAlreadyExists", func(t *testing.T) {
+		mock.ExpectQuery(regexp.QuoteMeta(insertQ)).
+			WithArgs("queen").
+			WillReturnRows(sqlmock.NewRows([]string{"id", "name", "created_at", "updated_at"}))
+
+		res, err := repository.CreateArtist(context.Background(), &model.Artist{Name: "queen"})
+		assert.Equal(t, repo.ErrAlreadyExists, err)
+		assert.Nil(t, res)
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestRepository_ResolveArtist(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	const selectQ = `SELECT id, name, created_at, updated_at FROM artists WHERE lower(btrim(name)) = lower(btrim($1))`
+
+	begin := func(t *testing.T) *sql.Tx {
+		mock.ExpectBegin()
+		tx, err := db.Begin()
+		require.NoError(t, err)
+		return tx
+	}
+
+	t.Run("Created", func(t *testing.T) {
+		tx := begin(t)
+		expectResolveArtist(mock, "Queen", 1)
+
+		res, err := resolveArtist(context.Background(), tx, "Queen")
+		require.NoError(t, err)
+		assert.Equal(t, uint64(1), res.ID)
+		assert.Equal(t, "Queen", res.Name)
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("ExistingSpellingWins", func(t *testing.T) {
+		now := time.Now()
+		tx := begin(t)
+		mock.ExpectQuery(regexp.QuoteMeta(resolveArtistQ)).
+			WithArgs("queen").
+			WillReturnRows(sqlmock.NewRows([]string{"id", "name", "created_at", "updated_at"}))
+		mock.ExpectQuery(regexp.QuoteMeta(selectQ)).
+			WithArgs("queen").
+			WillReturnRows(sqlmock.NewRows([]string{"id", "name", "created_at", "updated_at"}).AddRow(1, "Queen", now, now))
+
+		res, err := resolveArtist(context.Background(), tx, "queen")
+		require.NoError(t, err)
+		assert.Equal(t, uint64(1), res.ID)
+		assert.Equal(t, "Queen", res.Name)
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("DBError", func(t *testing.T) {
+		tx := begin(t)
+		mock.ExpectQuery(regexp.QuoteMeta(resolveArtistQ)).
+			WithArgs("queen").
+			WillReturnError(errors.New("some insert error"))
+
+		res, err := resolveArtist(context.Background(), tx, "queen")
+		require.Error(t, err)
+		assert.Nil(t, res)
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestRepository_UpdateArtist(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	repository := Repository{conn: db}
+	const updateQ = `UPDATE artists SET name = $1 WHERE id = $2`
+	const syncQ = `UPDATE songs SET group_name = $1 WHERE artist_id = $2`
+
+	t.Run("Success", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectExec(regexp.QuoteMeta(updateQ)).
+			WithArgs("Queen", uint64(1)).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectExec(regexp.QuoteMeta(syncQ)).
+			WithArgs("Queen", uint64(1)).
+			WillReturnResult(sqlmock.NewResult(0, 3))
+		mock.ExpectCommit()
+
+		err := repository.UpdateArtist(context.Background(), &model.Artist{ID: 1, Name: "Queen"})
+		require.NoError(t, err)
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("ErrNotFound", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectExec(regexp.QuoteMeta(updateQ)).
+			WithArgs("Queen", uint64(2)).
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectRollback()
+
+		err := repository.UpdateArtist(context.Background(), &model.Artist{ID: 2, Name: "Queen"})
+		assert.Equal(t, repo.ErrNotFound, err)
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("ErrAlreadyExists", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectExec(regexp.QuoteMeta(updateQ)).
+			WithArgs("Queen", uint64(3)).
+			WillReturnError(&pq.Error{Code: uniqueViolation})
+		mock.ExpectRollback()
+
+		err := repository.UpdateArtist(context.Background(), &model.Artist{ID: 3, Name: "Queen"})
+		assert.Equal(t, repo.ErrAlreadyExists, err)
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestRepository_DeleteArtist(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	repository := Repository{conn: db}
+	const deleteQ = `DELETE FROM artists WHERE id = $1`
+
+	t.Run("Success", func(t *testing.T) {
+		mock.ExpectExec(regexp.QuoteMeta(deleteQ)).
+			WithArgs(uint64(1)).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+
+		err := repository.DeleteArtist(context.Background(), 1)
+		require.NoError(t, err)
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("ErrNotFound", func(t *testing.T) {
+		mock.ExpectExec(regexp.QuoteMeta(deleteQ)).
+			WithArgs(uint64(2)).
+			WillReturnResult(sqlmock.NewResult(0, 0))
+
+		err := repository.DeleteArtist(context.Background(), 2)
+		assert.Equal(t, repo.ErrNotFound, err)
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("ErrInUse", func(t *testing.T) {
+		mock.ExpectExec(regexp.QuoteMeta(deleteQ)).
+			WithArgs(uint64(3)).
+			WillReturnError(&pq.Error{Code: foreignKeyViolation})
+
+		err := repository.DeleteArtist(context.Background(), 3)
+		assert.Equal(t, repo.ErrInUse, err)
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestRepository_GetAlbum(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	repository := Repository{conn: db}
+	const getQ = `SELECT albums.id, albums.artist_id, artists.name, albums.title, albums.release_date, albums.cover_link, albums.created_at, albums.updated_at FROM albums JOIN artists ON artists.id = albums.artist_id WHERE albums.id = $1`
+	columns := []string{"id", "artist_id", "name", "title", "release_date", "cover_link", "created_at", "updated_at"}
+
+	t.Run("Success", func(t *testing.T) {
+		now := time.Now()
+		mock.ExpectQuery(regexp.QuoteMeta(getQ)).
+			WithArgs(uint64(1)).
+			WillReturnRows(sqlmock.NewRows(columns).AddRow(1, 7, "Queen", "Jazz", nil, "", now, now))
+
+		res, err := repository.GetAlbum(context.Background(), 1)
+		require.NoError(t, err)
+		assert.Equal(t, &model.Album{ID: 1, ArtistID: 7, Artist: "Queen", Title: "Jazz", CreatedAt: now, UpdatedAt: now}, res)
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("ErrNotFound", func(t *testing.T) {
+		mock.ExpectQuery(regexp.QuoteMeta(getQ)).
+			WithArgs(uint64(2)).
+			WillReturnError(sql.ErrNoRows)
+
+		res, err := repository.GetAlbum(context.Background(), 2)
+		assert.Equal(t, repo.ErrNotFound, err)
+		assert.Nil(t, res)
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestRepository_ListAlbums(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	repository := Repository{conn: db}
+
+	t.Run("SuccessWithTitle", func(t *testing.T) {
+		now := time.Now()
+		releaseDate := time.Date(1978, 11, 10, 0, 0, 0, 0, time.UTC)
+
+		mock.ExpectQuery(regexp.QuoteMeta(`SELECT albums.id, albums.artist_id, artists.name, albums.title, albums.release_date, albums.cover_link, albums.created_at, albums.updated_at FROM albums JOIN artists ON artists.id = albums.artist_id WHERE albums.title ILIKE $1 ORDER BY albums.release_date DESC NULLS LAST, albums.id ASC LIMIT 2 OFFSET 0`)).
+			WithArgs("%jazz%").
+			WillReturnRows(sqlmock.NewRows([]string{"id", "artist_id", "name", "title", "release_date", "cover_link", "created_at", "updated_at"}).
+				AddRow(1, 7, "Queen", "Jazz", releaseDate, "https://example.com/jazz.jpg", now, now))
+
+		mock.ExpectQuery(regexp.QuoteMeta(`SELECT COUNT(*) FROM albums WHERE albums.title ILIKE $1`)).
+			WithArgs("%jazz%").
+			WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+
+		result, err := repository.ListAlbums(context.Background(), 1, 2, "jazz")
+		require.NoError(t, err)
+		require.Len(t, result.Data, 1)
+		assert.Equal(t, releaseDate, result.Data[0].ReleaseDate)
+		assert.Equal(t, int64(1), result.Count)
+		assert.False(t, result.HasNextPage)
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestRepository_CreateAlbum(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	repository := Repository{conn: db}
+	const insertQ = `INSERT INTO albums (artist_id, title, release_date, cover_link) VALUES ($1, $2, $3, $4) ON CONFLICT (artist_id, (lower(btrim(title)))) DO NOTHING RETURNING id, created_at, updated_at`
+
+	t.Run("Success", func(t *testing.T) {
+		now := time.Now()
+		req := &model.Album{Artist: "Queen", Title: "Jazz"}
+		mock.ExpectBegin()
+		expectResolveArtist(mock, "Queen", 7)
+		mock.ExpectQuery(regexp.QuoteMeta(insertQ)).
+			WithArgs(uint64(7), "Jazz", nil, "").
+			WillReturnRows(sqlmock.NewRows([]string{"id", "created_at", "updated_at"}).AddRow(1, now, now))
+		mock.ExpectCommit()
+
+		res, err := repository.CreateAlbum(context.Background(), req)
+		require.NoError(t, err)
+		assert.Equal(t, &model.Album{ID: 1, ArtistID: 7, Artist: "Queen", Title: "Jazz", CreatedAt: now, UpdatedAt: now}, res)
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("ErrAlreadyExists", func(t *testing.T) {
+		req := &model.Album{Artist: "Queen", Title: "jazz"}
+		mock.ExpectBegin()
+		expectResolveArtist(mock, "Queen", 7)
+		mock.ExpectQuery(regexp.QuoteMeta(insertQ)).
+			WithArgs(uint64(7), "jazz", nil, "").
+			WillReturnRows(sqlmock.NewRows([]string{"id", "created_at", "updated_at"}))
+		mock.ExpectRollback()
+
+		res, err := repository.CreateAlbum(context.Background(), req)
+		assert.Equal(t, repo.ErrAlreadyExists, err)
+		assert.Nil(t, res)
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestRepository_UpdateAlbum(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	repository := Repository{conn: db}
+	const updateQ = `UPDATE albums SET artist_id = $1, title = $2, release_date = $3, cover_link = $4 WHERE id = $5`
+	releaseDate := time.Date(1978, 11, 10, 0, 0, 0, 0, time.UTC)
+	req := &model.Album{ID: 1, Artist: "Queen", Title: "Jazz", ReleaseDate: releaseDate}
+
+	t.Run("Success", func(t *testing.T) {
+		mock.ExpectBegin()
+		expectResolveArtist(mock, "Queen", 7)
+		mock.ExpectExec(regexp.QuoteMeta(updateQ)).
+			WithArgs(uint64(7), "Jazz", releaseDate, "", uint64(1)).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectCommit()
+
+		require.NoError(t, repository.UpdateAlbum(context.Background(), req))
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("ErrNotFound", func(t *testing.T) {
+		mock.ExpectBegin()
+		expectResolveArtist(mock, "Queen", 7)
+		mock.ExpectExec(regexp.QuoteMeta(updateQ)).
+			WithArgs(uint64(7), "Jazz", releaseDate, "", uint64(1)).
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectRollback()
+
+		assert.Equal(t, repo.ErrNotFound, repository.UpdateAlbum(context.Background(), req))
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("ErrAlreadyExists", func(t *testing.T) {
+		mock.ExpectBegin()
+		expectResolveArtist(mock, "Queen", 7)
+		mock.ExpectExec(regexp.QuoteMeta(updateQ)).
+			WithArgs(uint64(7), "Jazz", releaseDate, "", uint64(1)).
+			WillReturnError(&pq.Error{Code: uniqueViolation})
+		mock.ExpectRollback()
+
+		assert.Equal(t, repo.ErrAlreadyExists, repository.UpdateAlbum(context.Background(), req))
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestRepository_DeleteAlbum(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	repository := Repository{conn: db}
+	const deleteQ = `DELETE FROM albums WHERE id = $1`
+
+	t.Run("Success", func(t *testing.T) {
+		mock.ExpectExec(regexp.QuoteMeta(deleteQ)).
+			WithArgs(uint64(1)).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+
+		require.NoError(t, repository.DeleteAlbum(context.Background(), 1))
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("ErrNotFound", func(t *testing.T) {
+		mock.ExpectExec(regexp.QuoteMeta(deleteQ)).
+			WithArgs(uint64(2)).
+			WillReturnResult(sqlmock.NewResult(0, 0))
+
+		assert.Equal(t, repo.ErrNotFound, repository.DeleteAlbum(context.Background(), 2))
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestRepository_ListAlbumTracks(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	repository := Repository{conn: db}
+	const tracksQ = `SELECT disc, track_number, id, artist_id, group_name, song_name, release_date, link, lyrics, enrichment_status, enrichment_error, metadata_sources, created_at, updated_at FROM album_tracks JOIN songs ON songs.id = album_tracks.song_id WHERE album_id = $1 ORDER BY disc ASC, track_number ASC`
+
+	t.Run("Success", func(t *testing.T) {
+		now := time.Now()
+		mock.ExpectQuery(regexp.QuoteMeta(tracksQ)).
+			WithArgs(uint64(1)).
+			WillReturnRows(sqlmock.NewRows([]string{"disc", "track_number", "id", "artist_id", "group_name", "song_name", "release_date", "link", "lyrics", "enrichment_status", "enrichment_error", "metadata_sources", "created_at", "updated_at"}).
+				AddRow(1, 1, 10, 7, "Queen", "Mustapha", nil, "", []byte(`{}`), "done", "", []byte(`{}`), now, now).
+				AddRow(1, 2, 11, 7, "Queen", "Fat Bottomed Girls", nil, "", []byte(`{}`), "done", "", []byte(`{}`), now, now))
+
+		res, err := repository.ListAlbumTracks(context.Background(), 1)
+		require.NoError(t, err)
+		require.Len(t, res, 2)
+		assert.Equal(t, 2, res[1].TrackNumber)
+		assert.Equal(t, "Fat Bottomed Girls", res[1].Song.Song)
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Empty", func(t *testing.T) {
+		mock.ExpectQuery(regexp.QuoteMeta(tracksQ)).
+			WithArgs(uint64(2)).
+			WillReturnRows(sqlmock.NewRows([]string{"disc"}))
+
+		res, err := repository.ListAlbumTracks(context.Background(), 2)
+		require.NoError(t, err)
+		assert.Empty(t, res)
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestRepository_SetAlbumTrack(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	repository := Repository{conn: db}
+	const setQ = `INSERT INTO album_tracks (album_id, song_id, disc, track_number) SELECT id, $2, $3, $4 FROM albums WHERE id = $1 ON CONFLICT (album_id, song_id) DO UPDATE SET disc = EXCLUDED.disc, track_number = EXCLUDED.track_number`
+
+	t.Run("Success", func(t *testing.T) {
+		mock.ExpectExec(regexp.QuoteMeta(setQ)).
+			WithArgs(uint64(1), uint64(2), 1, 3).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+
+		err := repository.SetAlbumTrack(context.Background(), 1, 2, 1, 3)
+		require.NoError(t, err)
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("ErrNotFound", func(t *testing.T) {
+		mock.ExpectExec(regexp.QuoteMeta(setQ)).
+			WithArgs(uint64(9), uint64(2), 1, 3).
+			WillReturnResult(sqlmock.NewResult(0, 0))
+
+		err := repository.SetAlbumTrack(context.Background(), 9, 2, 1, 3)
+		assert.Equal(t, repo.ErrNotFound, err)
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("ErrSongNotFound", func(t *testing.T) {
+		mock.ExpectExec(regexp.QuoteMeta(setQ)).
+			WithArgs(uint64(1), uint64(9), 1, 3).
+			WillReturnError(&pq.Error{Code: foreignKeyViolation})
+
+		err := repository.SetAlbumTrack(context.Background(), 1, 9, 1, 3)
+		assert.Equal(t, repo.ErrSongNotFound, err)
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("ErrTrackTaken", func(t *testing.T) {
+		mock.ExpectExec(regexp.QuoteMeta(setQ)).
+			WithArgs(uint64(1), uint64(2), 1, 1).
+			WillReturnError(&pq.Error{Code: uniqueViolation})
+
+		err := repository.SetAlbumTrack(context.Background(), 1, 2, 1, 1)
+		assert.Equal(t, repo.ErrTrackTaken, err)
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestRepository_RemoveAlbumTrack(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	repository := Repository{conn: db}
+	const removeQ = `DELETE FROM album_tracks WHERE album_id = $1 AND song_id = $2`
+
+	t.Run("Success", func(t *testing.T) {
+		mock.ExpectExec(regexp.QuoteMeta(removeQ)).
+			WithArgs(uint64(1), uint64(2)).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+
+		err := repository.RemoveAlbumTrack(context.Background(), 1, 2)
+		require.NoError(t, err)
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("ErrNotFound", func(t *testing.T) {
+		mock.ExpectExec(regexp.QuoteMeta(removeQ)).
+			WithArgs(uint64(1), uint64(9)).
+			WillReturnResult(sqlmock.NewResult(0, 0))
+
+		err := repository.RemoveAlbumTrack(context.Background(), 1, 9)
+		assert.Equal(t, repo.ErrNotFound, err)
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestRepository_ListSongsByLabels(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	repository := Repository{conn: db}
+	const columns = `id, artist_id, group_name, song_name, release_date, link, lyrics, enrichment_status, enrichment_error, metadata_sources, created_at, updated_at`
+
+	t.Run("AnyGenre", func(t *testing.T) {
+		filterQ := ` WHERE id IN (SELECT song_genres.song_id FROM song_genres JOIN genres ON genres.id = song_genres.genre_id WHERE lower(btrim(genres.name)) IN (SELECT lower(btrim(n)) FROM unnest($1::text[]) n))`
+		genres := pq.Array([]string{"rock", "metal"})
+
+		mock.ExpectQuery(regexp.QuoteMeta(`SELECT ` + columns + ` FROM songs` + filterQ + ` ORDER BY id ASC LIMIT 2 OFFSET 0`)).
+			WithArgs(genres).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}))
+		mock.ExpectQuery(regexp.QuoteMeta(`SELECT COUNT(*) FROM songs` + filterQ)).
+			WithArgs(genres).
+			WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(0))
+
+		res, err := repository.ListSongs(context.Background(), 1, 2, map[string]any{"genre": "rock, metal,"})
+		require.NoError(t, err)
+		assert.Nil(t, res.Facets)
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("AllTagsWithFacets", func(t *testing.T) {
+		filterQ := ` WHERE id IN (SELECT song_tags.song_id FROM song_tags JOIN tags ON tags.id = song_tags.tag_id WHERE lower(btrim(tags.name)) IN (SELECT lower(btrim(n)) FROM unnest($1::text[]) n) GROUP BY song_tags.song_id HAVING COUNT(*) = (SELECT COUNT(DISTINCT lower(btrim(n))) FROM unnest($1::text[]) n))`
+		tags := pq.Array([]string{"90s", "workout"})
+		now := time.Now()
+
+		mock.ExpectQuery(regexp.QuoteMeta(`SELECT ` + columns + ` FROM songs` + filterQ + ` ORDER BY id ASC LIMIT 2 OFFSET 0`)).
+			WithArgs(tags).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "artist_id", "group_name", "song_name", "release_date", "link", "lyrics", "enrichment_status", "enrichment_error", "metadata_sources", "created_at", "updated_at"}).
+				AddRow(1, 1, "test-group", "test-song", now, "https://example.com", []byte(`{}`), "done", "", []byte(`{}`), now, now))
+		mock.ExpectQuery(regexp.QuoteMeta(`SELECT COUNT(*) FROM songs` + filterQ)).
+			WithArgs(tags).
+			WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+		mock.ExpectQuery(regexp.QuoteMeta(`SELECT genres.name, COUNT(*) FROM song_genres JOIN genres ON genres.id = song_genres.genre_id WHERE song_genres.song_id IN (SELECT id FROM songs` + filterQ + `) GROUP BY genres.name ORDER BY COUNT(*) DESC, genres.name ASC LIMIT 50`)).
+			WithArgs(tags).
+			WillReturnRows(sqlmock.NewRows([]string{"name", "count"}).AddRow("Rock", 1))
+		mock.ExpectQuery(regexp.QuoteMeta(`SELECT tags.name, COUNT(*) FROM song_tags JOIN tags ON tags.id = song_tags.tag_id WHERE song_tags.song_id IN (SELECT id FROM songs` + filterQ + `) GROUP BY tags.name ORDER BY COUNT(*) DESC, tags.name ASC LIMIT 50`)).
+			WithArgs(tags).
+			WillReturnRows(sqlmock.NewRows([]string{"name", "count"}).AddRow("90s", 1).AddRow("workout", 1))
+
+		res, err := repository.ListSongs(context.Background(), 1, 2, map[string]any{"tag": "90s,workout", "tag_match": model.MatchAll, "facets": true})
+		require.NoError(t, err)
+		assert.Equal(t, &model.Facets{
+			Genres: []model.Facet{{Name: "Rock", Count: 1}},
+			Tags:   []model.Facet{{Name: "90s", Count: 1}, {Name: "workout", Count: 1}},
+		}, res.Facets)
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("CursorWithFacets", func(t *testing.T) {
+		mock.ExpectQuery(regexp.QuoteMeta(`SELECT ` + columns + ` FROM songs ORDER BY id ASC LIMIT 3`)).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}))
+		mock.ExpectQuery(regexp.QuoteMeta(`SELECT genres.name, COUNT(*) FROM song_genres JOIN genres ON genres.id = song_genres.genre_id WHERE song_genres.song_id IN (SELECT id FROM songs) GROUP BY genres.name`)).
+			WillReturnRows(sqlmock.NewRows([]string{"name", "count"}))
+		mock.ExpectQuery(regexp.QuoteMeta(`SELECT tags.name, COUNT(*) FROM song_tags`)).
+			WillReturnError(errors.New("some facet error"))
+
+		res, err := repository.ListSongsCursor(context.Background(), "", 2, false, map[string]any{"facets": true})
+		assert.EqualError(t, err, "some facet error")
+		assert.Nil(t, res)
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestRepository_ListGenres(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	repository := Repository{conn: db}
+	now := time.Now()
+
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT id, name, created_at FROM genres ORDER BY name ASC, id ASC`)).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "name", "created_at"}).AddRow(2, "Metal", now).AddRow(1, "Rock", now))
+
+	res, err := repository.ListGenres(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, []*model.Genre{{ID: 2, Name: "Metal", CreatedAt: now}, {ID: 1, Name: "Rock", CreatedAt: now}}, res)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestRepository_CreateGenre(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	repository := Repository{conn: db}
+	const insertQ = `INSERT INTO genres (name) VALUES ($1) ON CONFLICT ((lower(btrim(name)))) DO NOTHING RETURNING id, name, created_at`
+
+	t.Run("Success", func(t *testing.T) {
+		now := time.Now()
+		mock.ExpectQuery(regexp.QuoteMeta(insertQ)).
+			WithArgs("Rock").
+			WillReturnRows(sqlmock.NewRows([]string{"id", "name", "created_at"}).AddRow(1, "Rock", now))
+
+		res, err := repository.CreateGenre(context.Background(), &model.Genre{Name: "Rock"})
+		require.NoError(t, err)
+		assert.Equal(t, uint64(1), res.ID)
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("ErrAlreadyExists", func(t *testing.T) {
+		mock.ExpectQuery(regexp.QuoteMeta(insertQ)).
+			WithArgs("rock").
+			WillReturnRows(sqlmock.NewRows([]string{"id", "name", "created_at"}))
+
+		res, err := repository.CreateGenre(context.Background(), &model.Genre{Name: "rock"})
+		assert.Equal(t, repo.ErrAlreadyExists, err)
+		assert.Nil(t, res)
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestRepository_DeleteGenre(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	repository := Repository{conn: db}
+	const deleteQ = `DELETE FROM genres WHERE id = $1`
+
+	t.Run("Success", func(t *testing.T) {
+		mock.ExpectExec(regexp.QuoteMeta(deleteQ)).
+			WithArgs(uint64(1)).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+
+		err := repository.DeleteGenre(context.Background(), 1)
+		require.NoError(t, err)
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("ErrNotFound", func(t *testing.T) {
+		mock.ExpectExec(regexp.QuoteMeta(deleteQ)).
+			WithArgs(uint64(2)).
+			WillReturnResult(sqlmock.NewResult(0, 0))
+
+		err := repository.DeleteGenre(context.Background(), 2)
+		assert.Equal(t, repo.ErrNotFound, err)
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestRepository_ListTags(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	repository := Repository{conn: db}
+	now := time.Now()
+
+	t.Run("ByName", func(t *testing.T) {
+		mock.ExpectQuery(regexp.QuoteMeta(`SELECT id, name, created_at FROM tags WHERE name ILIKE $1 ORDER BY name ASC, id ASC`)).
+			WithArgs("%work%").
+			WillReturnRows(sqlmock.NewRows([]string{"id", "name", "created_at"}).AddRow(3, "workout", now))
+
+		res, err := repository.ListTags(context.Background(), "work")
+		require.NoError(t, err)
+		assert.Equal(t, []*model.Tag{{ID: 3, Name: "workout", CreatedAt: now}}, res)
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("DBError", func(t *testing.T) {
+		mock.ExpectQuery(regexp.QuoteMeta(`SELECT id, name, created_at FROM tags ORDER BY name ASC, id ASC`)).
+			WillReturnError(errors.New("some database error"))
+
+		res, err := repository.ListTags(context.Background(), "")
+		require.Error(t, err)
+		assert.Nil(t, res)
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestRepository_DeleteTag(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	repository := Repository{conn: db}
+
+	mock.ExpectExec(regexp.QuoteMeta(`DELETE FROM tags WHERE id = $1`)).
+		WithArgs(uint64(2)).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+
+	err = repository.DeleteTag(context.Background(), 2)
+	assert.Equal(t, repo.ErrNotFound, err)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestRepository_SetSongGenres(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	repository := Repository{conn: db}
+	const lockQ = `SELECT id FROM songs WHERE id = $1 FOR UPDATE`
+	const unknownQ = `SELECT n FROM unnest($1::text[]) n WHERE NOT EXISTS (SELECT 1 FROM genres WHERE lower(btrim(genres.name)) = lower(btrim(n)))`
+	const deleteQ = `DELETE FROM song_genres WHERE song_id = $1`
+	const insertQ = `INSERT INTO song_genres (song_id, genre_id) SELECT $1, id FROM genres WHERE lower(btrim(name)) IN (SELECT lower(btrim(n)) FROM unnest($2::text[]) n)`
+	names := []string{"Rock", "metal"}
+
+	t.Run("Success", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectQuery(regexp.QuoteMeta(lockQ)).
+			WithArgs(uint64(1)).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+		mock.ExpectQuery(regexp.QuoteMeta(unknownQ)).
+			WithArgs(pq.Array(names)).
+			WillReturnRows(sqlmock.NewRows([]string{"n"}))
+		mock.ExpectExec(regexp.QuoteMeta(deleteQ)).
+			WithArgs(uint64(1)).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectExec(regexp.QuoteMeta(insertQ)).
+			WithArgs(uint64(1), pq.Array(names)).
+			WillReturnResult(sqlmock.NewResult(0, 2))
+		mock.ExpectCommit()
+
+		err := repository.SetSongGenres(context.Background(), 1, names)
+		require.NoError(t, err)
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("ErrNotFound", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectQuery(regexp.QuoteMeta(lockQ)).
+			WithArgs(uint64(2)).
+			WillReturnError(sql.ErrNoRows)
+		mock.ExpectRollback()
+
+		err := repository.SetSongGenres(context.Background(), 2, names)
+		assert.Equal(t, repo.ErrNotFound, err)
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("ErrUnknownGenre", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectQuery(regexp.QuoteMeta(lockQ)).
+			WithArgs(uint64(3)).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(3))
+		mock.ExpectQuery(regexp.QuoteMeta(unknownQ)).
+			WithArgs(pq.Array(names)).
+			WillReturnRows(sqlmock.NewRows([]string{"n"}).AddRow("metal"))
+		mock.ExpectRollback()
+
+		err := repository.SetSongGenres(context.Background(), 3, names)
+		assert.ErrorIs(t, err, repo.ErrUnknownGenre)
+		assert.EqualError(t, err, "unknown genre: metal")
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestRepository_SetSongTags(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	repository := Repository{conn: db}
+	names := []string{"90s", "Workout"}
+
+	t.Run("Success", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectQuery(regexp.QuoteMeta(`SELECT id FROM songs WHERE id = $1 FOR UPDATE`)).
+			WithArgs(uint64(1)).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+		mock.ExpectExec(regexp.QuoteMeta(`INSERT INTO tags (name) SELECT DISTINCT ON (lower(btrim(n))) n FROM unnest($1::text[]) n ON CONFLICT ((lower(btrim(name)))) DO NOTHING`)).
+			WithArgs(pq.Array(names)).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectExec(regexp.QuoteMeta(`DELETE FROM song_tags WHERE song_id = $1`)).
+			WithArgs(uint64(1)).
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectExec(regexp.QuoteMeta(`INSERT INTO song_tags (song_id, tag_id) SELECT $1, id FROM tags WHERE lower(btrim(name)) IN (SELECT lower(btrim(n)) FROM unnest($2::text[]) n)`)).
+			WithArgs(uint64(1), pq.Array(names)).
+			WillReturnResult(sqlmock.NewResult(0, 2))
+		mock.ExpectCommit()
+
+		err := repository.SetSongTags(context.Background(), 1, names)
+		require.NoError(t, err)
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("DBError", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectQuery(regexp.QuoteMeta(`SELECT id FROM songs WHERE id = $1 FOR UPDATE`)).
+			WithArgs(uint64(2)).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(2))
+		mock.ExpectExec(regexp.QuoteMeta(`INSERT INTO tags (name)`)).
+			WillReturnError(errors.New("some insert error"))
+		mock.ExpectRollback()
+
+		err := repository.SetSongTags(context.Background(), 2, names)
+		assert.EqualError(t, err, "some insert error")
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func expectLockPlaylist(mock sqlmock.Sqlmock, id uint64, count int) {
+	mock.ExpectExec(regexp.QuoteMeta(`UPDATE playlists SET updated_at = NOW() WHERE id = $1`)).
+		WithArgs(id).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT COUNT(*) FROM playlist_songs WHERE playlist_id = $1`)).
+		WithArgs(id).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(count))
+}
+
+func TestRepository_DuplicatePlaylist(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	repository := Repository{conn: db}
+	now := time.Now()
+
+	t.Run("Success", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectQuery(regexp.QuoteMeta(`INSERT INTO playlists (name, description) SELECT COALESCE(NULLIF($2, ''), name || ' (copy)'), description FROM playlists WHERE id = $1 RETURNING id`)).
+			WithArgs(uint64(1), "").
+			WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(2))
+		mock.ExpectExec(regexp.QuoteMeta(`INSERT INTO playlist_songs (playlist_id, song_id, position) SELECT $1, song_id, position FROM playlist_songs WHERE playlist_id = $2`)).
+			WithArgs(uint64(2), uint64(1)).
+			WillReturnResult(sqlmock.NewResult(0, 3))
+		mock.ExpectQuery(regexp.QuoteMeta(`SELECT ` + playlistColumns + ` FROM playlists WHERE id = $1`)).
+			WithArgs(uint64(2)).
+			WillReturnRows(
+				sqlmock.NewRows([]string{"id", "name", "description", "songs_count", "created_at", "updated_at"}).
+					AddRow(2, "Road trip (copy)", "", 3, now, now),
+			)
+		mock.ExpectCommit()
+
+		res, err := repository.DuplicatePlaylist(context.Background(), 1, "")
+		require.NoError(t, err)
+		assert.Equal(t, "Road trip (copy)", res.Name)
+		assert.Equal(t, int64(3), res.SongsCount)
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("ErrNotFound", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectQuery(regexp.QuoteMeta(`INSERT INTO playlists (name, description)`)).
+			WithArgs(uint64(3), "Copy").
+			WillReturnError(sql.ErrNoRows)
+		mock.ExpectRollback()
+
+		res, err := repository.DuplicatePlaylist(context.Background(), 3, "Copy")
+		assert.Equal(t, repo.ErrNotFound, err)
+		assert.Nil(t, res)
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestRepository_AddPlaylistSong(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	repository := Repository{conn: db}
+
+	t.Run("Insert", func(t *testing.T) {
+		mock.ExpectBegin()
+		expectLockPlaylist(mock, 1, 3)
+		mock.ExpectExec(regexp.QuoteMeta(`UPDATE playlist_songs SET position = position + 1 WHERE playlist_id = $1 AND position >= $2`)).
+			WithArgs(uint64(1), 2).
+			WillReturnResult(sqlmock.NewResult(0, 2))
+		mock.ExpectExec(regexp.QuoteMeta(`INSERT INTO playlist_songs (playlist_id, song_id, position) VALUES ($1, $2, $3)`)).
+			WithArgs(uint64(1), uint64(5), 2).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectCommit()
+
+		position, err := repository.AddPlaylistSong(context.Background(), 1, 5, 2)
+		require.NoError(t, err)
+		assert.Equal(t, 2, position)
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("AppendPastTheEnd", func(t *testing.T) {
+		mock.ExpectBegin()
+		expectLockPlaylist(mock, 1, 3)
+		mock.ExpectExec(regexp.QuoteMeta(`UPDATE playlist_songs SET position = position + 1`)).
+			WithArgs(uint64(1), 4).
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectExec(regexp.QuoteMeta(`INSERT INTO playlist_songs`)).
+			WithArgs(uint64(1), uint64(5), 4).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectCommit()
+
+		position, err := repository.AddPlaylistSong(context.Background(), 1, 5, 10)
+		require.NoError(t, err)
+		assert.Equal(t, 4, position)
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("ErrNotFound", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectExec(regexp.QuoteMeta(`UPDATE playlists SET updated_at = NOW()`)).
+			WithArgs(uint64(2)).
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectRollback()
+
+		_, err := repository.AddPlaylistSong(context.Background(), 2, 5, 0)
+		assert.Equal(t, repo.ErrNotFound, err)
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("ErrSongNotFound", func(t *testing.T) {
+		mock.ExpectBegin()
+		expectLockPlaylist(mock, 1, 0)
+		mock.ExpectExec(regexp.QuoteMeta(`UPDATE playlist_songs SET position = position + 1`)).
+			WithArgs(uint64(1), 1).
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectExec(regexp.QuoteMeta(`INSERT INTO playlist_songs`)).
+			WithArgs(uint64(1), uint64(9), 1).
+			WillReturnError(&pq.Error{Code: foreignKeyViolation})
+		mock.ExpectRollback()
+
+		_, err := repository.AddPlaylistSong(context.Background(), 1, 9, 0)
+		assert.Equal(t, repo.ErrSongNotFound, err)
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("ErrAlreadyExists", func(t *testing.T) {
+		mock.ExpectBegin()
+		expectLockPlaylist(mock, 1, 1)
+		mock.ExpectExec(regexp.QuoteMeta(`UPDATE playlist_songs SET position = position + 1`)).
+			WithArgs(uint64(1), 1).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectExec(regexp.QuoteMeta(`INSERT INTO playlist_songs`)).
+			WithArgs(uint64(1), uint64(5), 1).
+			WillReturnError(&pq.Error{Code: uniqueViolation})
+		mock.ExpectRollback()
+
+		_, err := repository.AddPlaylistSong(context.Background(), 1, 5, 1)
+		assert.Equal(t, repo.ErrAlreadyExists, err)
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("ErrPlaylistChanged", func(t *testing.T) {
+		mock.ExpectBegin()
+		expectLockPlaylist(mock, 1, 2)
+		mock.ExpectExec(regexp.QuoteMeta(`UPDATE playlist_songs SET position = position + 1`)).
+			WithArgs(uint64(1), 3).
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectExec(regexp.QuoteMeta(`INSERT INTO playlist_songs`)).
+			WithArgs(uint64(1), uint64(5), 3).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectCommit().WillReturnError(&pq.Error{Code: uniqueViolation})
+
+		_, err := repository.AddPlaylistSong(context.Background(), 1, 5, 0)
+		assert.Equal(t, repo.ErrPlaylistChanged, err)
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestRepository_MovePlaylistSong(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	repository := Repository{conn: db}
+
+	expectCurrent := func(songID uint64, position int) {
+		mock.ExpectQuery(regexp.QuoteMeta(`SELECT position FROM playlist_songs WHERE playlist_id = $1 AND song_id = $2`)).
+			WithArgs(uint64(1), songID).
+			WillReturnRows(sqlmock.NewRows([]string{"position"}).AddRow(position))
+	}
+
+	t.Run("MoveUp", func(t *testing.T) {
+		mock.ExpectBegin()
+		expectLockPlaylist(mock, 1, 5)
+		expectCurrent(4, 4)
+		mock.ExpectExec(regexp.QuoteMeta(`UPDATE playlist_songs SET position = position + 1 WHERE playlist_id = $1 AND position >= $2 AND position < $3`)).
+			WithArgs(uint64(1), 1, 4).
+			WillReturnResult(sqlmock.NewResult(0, 3))
+		mock.ExpectExec(regexp.QuoteMeta(`UPDATE playlist_songs SET position = $1 WHERE playlist_id = $2 AND song_id = $3`)).
+			WithArgs(1, uint64(1), uint64(4)).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectCommit()
+
+		position, err := repository.MovePlaylistSong(context.Background(), 1, 4, 1)
+		require.NoError(t, err)
+		assert.Equal(t, 1, position)
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("MoveDownPastTheEnd", func(t *testing.T) {
+		mock.ExpectBegin()
+		expectLockPlaylist(mock, 1, 5)
+		expectCurrent(2, 2)
+		mock.ExpectExec(regexp.QuoteMeta(`UPDATE playlist_songs SET position = position - 1 WHERE playlist_id = $1 AND position > $2 AND position <= $3`)).
+			WithArgs(uint64(1), 2, 5).
+			WillReturnResult(sqlmock.NewResult(0, 3))
+		mock.ExpectExec(regexp.QuoteMeta(`UPDATE playlist_songs SET position = $1 WHERE playlist_id = $2 AND song_id = $3`)).
+			WithArgs(5, uint64(1), uint64(2)).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectCommit()
+
+		position, err := repository.MovePlaylistSong(context.Background(), 1, 2, 99)
+		require.NoError(t, err)
+		assert.Equal(t, 5, position)
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("SamePosition", func(t *testing.T) {
+		mock.ExpectBegin()
+		expectLockPlaylist(mock, 1, 5)
+		expectCurrent(3, 3)
+		mock.ExpectCommit()
+
+		position, err := repository.MovePlaylistSong(context.Background(), 1, 3, 3)
+		require.NoError(t, err)
+		assert.Equal(t, 3, position)
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("ErrNotFound", func(t *testing.T) {
+		mock.ExpectBegin()
+		expectLockPlaylist(mock, 1, 5)
+		mock.ExpectQuery(regexp.QuoteMeta(`SELECT position FROM playlist_songs`)).
+			WithArgs(uint64(1), uint64(9)).
+			WillReturnError(sql.ErrNoRows)
+		mock.ExpectRollback()
+
+		_, err := repository.MovePlaylistSong(context.Background(), 1, 9, 1)
+		assert.Equal(t, repo.ErrNotFound, err)
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("ErrPlaylistChanged", func(t *testing.T) {
+		mock.ExpectBegin()
+		expectLockPlaylist(mock, 1, 5)
+		expectCurrent(4, 4)
+		mock.ExpectExec(regexp.QuoteMeta(`UPDATE playlist_songs SET position = position + 1`)).
+			WithArgs(uint64(1), 2, 4).
+			WillReturnResult(sqlmock.NewResult(0, 2))
+		mock.ExpectExec(regexp.QuoteMeta(`UPDATE playlist_songs SET position = $1`)).
+			WithArgs(2, uint64(1), uint64(4)).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectCommit().WillReturnError(&pq.Error{Code: uniqueViolation})
+
+		_, err := repository.MovePlaylistSong(context.Background(), 1, 4, 2)
+		assert.Equal(t, repo.ErrPlaylistChanged, err)
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestRepository_RemovePlaylistSong(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	repository := Repository{conn: db}
+
+	t.Run("Success", func(t *testing.T) {
+		mock.ExpectBegin()
+		expectLockPlaylist(mock, 1, 3)
+		mock.ExpectQuery(regexp.QuoteMeta(`DELETE FROM playlist_songs WHERE playlist_id = $1 AND song_id = $2 RETURNING position`)).
+			WithArgs(uint64(1), uint64(2)).
+			WillReturnRows(sqlmock.NewRows([]string{"position"}).AddRow(2))
+		mock.ExpectExec(regexp.QuoteMeta(`UPDATE playlist_songs SET position = position - 1 WHERE playlist_id = $1 AND position > $2`)).
+			WithArgs(uint64(1), 2).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectCommit()
+
+		err := repository.RemovePlaylistSong(context.Background(), 1, 2)
+		require.NoError(t, err)
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("ErrNotFound", func(t *testing.T) {
+		mock.ExpectBegin()
+		expectLockPlaylist(mock, 1, 3)
+		mock.ExpectQuery(regexp.QuoteMeta(`DELETE FROM playlist_songs`)).
+			WithArgs(uint64(1), uint64(9)).
+			WillReturnError(sql.ErrNoRows)
+		mock.ExpectRollback()
+
+		err := repository.RemovePlaylistSong(context.Background(), 1, 9)
+		assert.Equal(t, repo.ErrNotFound, err)
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestRepository_ListVerses(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	repository := Repository{conn: db}
+
+	t.Run("Success", func(t *testing.T) {
+		mock.ExpectQuery(regexp.QuoteMeta(`SELECT lyrics[$2:$3], cardinality(lyrics) FROM songs WHERE id = $1`)).
+			WithArgs(uint64(1), 3, 4).
+			WillReturnRows(sqlmock.NewRows([]string{"lyrics", "count"}).AddRow(`{"third","fourth"}`, 5))
+
+		res, err := repository.ListVerses(context.Background(), 1, 2, 2)
+		require.NoError(t, err)
+		assert.Equal(t, []*model.Verse{{Position: 3, Text: "third"}, {Position: 4, Text: "fourth"}}, res.Data)
+		assert.Equal(t, int64(5), res.Count)
+		assert.Equal(t, 3, res.TotalPages)
+		assert.True(t, res.HasNextPage)
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("ErrNotFound", func(t *testing.T) {
+		mock.ExpectQuery(regexp.QuoteMeta(`SELECT lyrics[$2:$3], cardinality(lyrics) FROM songs WHERE id = $1`)).
+			WithArgs(uint64(2), 1, 2).
+			WillReturnError(sql.ErrNoRows)
+
+		res, err := repository.ListVerses(context.Background(), 2, 1, 2)
+		assert.Equal(t, repo.ErrNotFound, err)
+		assert.Nil(t, res)
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func expectLyrics(mock sqlmock.Sqlmock, id uint64, lyrics string) {
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT lyrics FROM songs WHERE id = $1 FOR UPDATE`)).
+		WithArgs(id).
+		WillReturnRows(sqlmock.NewRows([]string{"lyrics"}).AddRow(lyrics))
+}
+
+func expectSaveLyrics(mock sqlmock.Sqlmock, id uint64, lyrics []string) {
+	mock.ExpectExec(regexp.QuoteMeta(`UPDATE songs SET lyrics = $1 WHERE id = $2`)).
+		WithArgs(pq.Array(lyrics), id).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+}
+
+// expectTranslations expects the translations to be read while aligning them, given as lang and lyrics pairs
+func expectTranslations(mock sqlmock.Sqlmock, id uint64, translations ...string) {
+	rows := sqlmock.NewRows([]string{"lang", "lyrics"})
+	for i := 0; i < len(translations); i += 2 {
+		rows.AddRow(translations[i], translations[i+1])
+	}
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT lang, lyrics FROM song_translations WHERE song_id = $1`)).
+		WithArgs(id).
+		WillReturnRows(rows)
+}
+
+func expectSaveTranslation(mock sqlmock.Sqlmock, id uint64, lang string, lyrics []string) {
+	mock.ExpectExec(regexp.QuoteMeta(`UPDATE song_translations SET lyrics = $1 WHERE song_id = $2 AND lang = $3`)).
+		WithArgs(pq.Array(lyrics), id, lang).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+}
+
+func TestRepository_InsertVerse(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	repository := Repository{conn: db}
+
+	t.Run("Insert", func(t *testing.T) {
+		mock.ExpectBegin()
+		expectLyrics(mock, 1, `{"a","c"}`)
+		expectSaveLyrics(mock, 1, []string{"a", "b", "c"})
+		expectTranslations(mock, 1, "de", `{"A","C"}`)
+		expectSaveTranslation(mock, 1, "de", []string{"A", "", "C"})
+		mock.ExpectCommit()
+
+		position, err := repository.InsertVerse(context.Background(), 1, 2, "b")
+		require.NoError(t, err)
+		assert.Equal(t, 2, position)
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Append", func(t *testing.T) {
+		mock.ExpectBegin()
+		expectLyrics(mock, 1, `{}`)
+		expectSaveLyrics(mock, 1, []string{"a"})
+		expectTranslations(mock, 1)
+		mock.ExpectCommit()
+
+		position, err := repository.InsertVerse(context.Background(), 1, 0, "a")
+		require.NoError(t, err)
+		assert.Equal(t, 1, position)
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("ErrNotFound", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectQuery(regexp.QuoteMeta(`SELECT lyrics FROM songs WHERE id = $1 FOR UPDATE`)).
+			WithArgs(uint64(2)).
+			WillReturnError(sql.ErrNoRows)
+		mock.ExpectRollback()
+
+		_, err := repository.InsertVerse(context.Background(), 2, 0, "a")
+		assert.Equal(t, repo.ErrNotFound, err)
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestRepository_UpdateVerse(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	repository := Repository{conn: db}
+
+	t.Run("Success", func(t *testing.T) {
+		mock.ExpectBegin()
+		expectLyrics(mock, 1, `{"a","b"}`)
+		expectSaveLyrics(mock, 1, []string{"a", "B"})
+		mock.ExpectCommit()
+
+		err := repository.UpdateVerse(context.Background(), 1, 2, "B")
+		require.NoError(t, err)
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("ErrVerseNotFound", func(t *testing.T) {
+		mock.ExpectBegin()
+		expectLyrics(mock, 1, `{"a","b"}`)
+		mock.ExpectRollback()
+
+		err := repository.UpdateVerse(context.Background(), 1, 3, "c")
+		assert.Equal(t, repo.ErrVerseNotFound, err)
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestRepository_DeleteVerse(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	repository := Repository{conn: db}
+
+	t.Run("Success", func(t *testing.T) {
+		mock.ExpectBegin()
+		expectLyrics(mock, 1, `{"a","b","c"}`)
+		expectSaveLyrics(mock, 1, []string{"a", "c"})
+		expectTranslations(mock, 1, "de", `{"A","B","C"}`, "fr", `{"a"}`)
+		expectSaveTranslation(mock, 1, "de", []string{"A", "C"})
+		expectSaveTranslation(mock, 1, "fr", []string{"a"})
+		mock.ExpectCommit()
+
+		err := repository.DeleteVerse(context.Background(), 1, 2)
+		require.NoError(t, err)
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("ErrVerseNotFound", func(t *testing.T) {
+		mock.ExpectBegin()
+		expectLyrics(mock, 1, `{"a"}`)
+		mock.ExpectRollback()
+
+		err := repository.DeleteVerse(context.Background(), 1, 0)
+		assert.Equal(t, repo.ErrVerseNotFound, err)
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestRepository_MoveVerse(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	repository := Repository{conn: db}
+
+	t.Run("MoveUp", func(t *testing.T) {
+		mock.ExpectBegin()
+		expectLyrics(mock, 1, `{"a","b","c","d"}`)
+		expectSaveLyrics(mock, 1, []string{"d", "a", "b", "c"})
+		expectTranslations(mock, 1, "de", `{"A","B","C","D"}`)
+		expectSaveTranslation(mock, 1, "de", []string{"D", "A", "B", "C"})
+		mock.ExpectCommit()
+
+		position, err := repository.MoveVerse(context.Background(), 1, 4, 1)
+		require.NoError(t, err)
+		assert.Equal(t, 1, position)
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("MoveDownPastTheEnd", func(t *testing.T) {
+		mock.ExpectBegin()
+		expectLyrics(mock, 1, `{"a","b","c","d"}`)
+		expectSaveLyrics(mock, 1, []string{"a", "c", "d", "b"})
+		expectTranslations(mock, 1)
+		mock.ExpectCommit()
+
+		position, err := repository.MoveVerse(context.Background(), 1, 2, 10)
+		require.NoError(t, err)
+		assert.Equal(t, 4, position)
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("ErrVerseNotFound", func(t *testing.T) {
+		mock.ExpectBegin()
+		expectLyrics(mock, 1, `{"a"}`)
+		mock.ExpectRollback()
+
+		_, err := repository.MoveVerse(context.Background(), 1, 2, 1)
+		assert.Equal(t, repo.ErrVerseNotFound, err)
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestRepository_GetSongLyrics(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	repository := Repository{conn: db}
+
+	t.Run("Success", func(t *testing.T) {
+		mock.ExpectQuery(regexp.QuoteMeta(`SELECT group_name, song_name, lyrics FROM songs WHERE id = $1`)).
+			WithArgs(uint64(1)).
+			WillReturnRows(sqlmock.NewRows([]string{"group_name", "song_name", "lyrics"}).AddRow("Group", "Song", `{"first verse","second verse"}`))
+		mock.ExpectQuery(regexp.QuoteMeta(`SELECT time_ms, text FROM synced_lyrics WHERE song_id = $1 ORDER BY position ASC`)).
+			WithArgs(uint64(1)).
+			WillReturnRows(sqlmock.NewRows([]string{"time_ms", "text"}).AddRow(1500, "first").AddRow(4000, "second"))
+
+		res, err := repository.GetSongLyrics(context.Background(), 1)
+		require.NoError(t, err)
+		assert.Equal(t, &model.SongLyrics{
+			Group:  "Group",
+			Song:   "Song",
+			Verses: []string{"first verse", "second verse"},
+			Synced: []*model.SyncedLine{{TimeMs: 1500, Text: "first"}, {TimeMs: 4000, Text: "second"}},
+		}, res)
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("ErrNotFound", func(t *testing.T) {
+		mock.ExpectQuery(regexp.QuoteMeta(`SELECT group_name, song_name, lyrics FROM songs WHERE id = $1`)).
+			WithArgs(uint64(2)).
+			WillReturnError(sql.ErrNoRows)
+
+		res, err := repository.GetSongLyrics(context.Background(), 2)
+		assert.Equal(t, repo.ErrNotFound, err)
+		assert.Nil(t, res)
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestRepository_SetSyncedLyrics(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	repository := Repository{conn: db}
+	lines := []*model.SyncedLine{{TimeMs: 0, Text: "first"}, {TimeMs: 2500, Text: ""}}
+
+	t.Run("Success", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectQuery(regexp.QuoteMeta(`SELECT id FROM songs WHERE id = $1 FOR UPDATE`)).
+			WithArgs(uint64(1)).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+		mock.ExpectExec(regexp.QuoteMeta(`DELETE FROM synced_lyrics WHERE song_id = $1`)).
+			WithArgs(uint64(1)).
+			WillReturnResult(sqlmock.NewResult(0, 3))
+		mock.ExpectExec(regexp.QuoteMeta(`INSERT INTO synced_lyrics (song_id, position, time_ms, text) SELECT $1, l.position, l.time_ms, l.text FROM unnest($2::bigint[], $3::text[]) WITH ORDINALITY AS l(time_ms, text, position)`)).
+			WithArgs(uint64(1), pq.Array([]int64{0, 2500}), pq.Array([]string{"first", ""})).
+			WillReturnResult(sqlmock.NewResult(0, 2))
+		mock.ExpectCommit()
+
+		err := repository.SetSyncedLyrics(context.Background(), 1, lines)
+		require.NoError(t, err)
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("ErrNotFound", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectQuery(regexp.QuoteMeta(`SELECT id FROM songs WHERE id = $1 FOR UPDATE`)).
+			WithArgs(uint64(2)).
+			WillReturnError(sql.ErrNoRows)
+		mock.ExpectRollback()
+
+		err := repository.SetSyncedLyrics(context.Background(), 2, lines)
+		assert.Equal(t, repo.ErrNotFound, err)
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestRepository_GetTranslation(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	repository := Repository{conn: db}
+
+	t.Run("Success", func(t *testing.T) {
+		mock.ExpectQuery(regexp.QuoteMeta(`SELECT lyrics[$3:$4] FROM song_translations WHERE song_id = $1 AND lang = $2`)).
+			WithArgs(uint64(1), "de", 3, 4).
+			WillReturnRows(sqlmock.NewRows([]string{"lyrics"}).AddRow(`{"C","D"}`))
+
+		res, err := repository.GetTranslation(context.Background(), 1, "de", 2, 2)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"C", "D"}, res)
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("ErrNotFound", func(t *testing.T) {
+		mock.ExpectQuery(regexp.QuoteMeta(`SELECT lyrics[$3:$4] FROM song_translations WHERE song_id = $1 AND lang = $2`)).
+			WithArgs(uint64(1), "fr", 1, 40).
+			WillReturnError(sql.ErrNoRows)
+
+		res, err := repository.GetTranslation(context.Background(), 1, "fr", 1, 40)
+		assert.Equal(t, repo.ErrNotFound, err)
+		assert.Nil(t, res)
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+const alignedQ = `SELECT lang FROM song_translations WHERE song_id = $1 AND cardinality(lyrics) <> $2 ORDER BY lang LIMIT 1`
+
+func expectVerseCount(mock sqlmock.Sqlmock, id uint64, count int) {
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT cardinality(lyrics) FROM songs WHERE id = $1 FOR UPDATE`)).
+		WithArgs(id).
+		WillReturnRows(sqlmock.NewRows([]string{"cardinality"}).AddRow(count))
+}
+
+func TestRepository_CreateTranslation(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	repository := Repository{conn: db}
+	req := &model.Translation{Lang: "de", Lyrics: []string{"eins", ""}}
+
+	t.Run("Success", func(t *testing.T) {
+		mock.ExpectBegin()
+		expectVerseCount(mock, 1, 2)
+		mock.ExpectExec(regexp.QuoteMeta(`INSERT INTO song_translations (song_id, lang, lyrics) VALUES ($1, $2, $3) ON CONFLICT DO NOTHING`)).
+			WithArgs(uint64(1), "de", pq.Array(req.Lyrics)).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectCommit()
+
+		err := repository.CreateTranslation(context.Background(), 1, req)
+		require.NoError(t, err)
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("ErrAlreadyExists", func(t *testing.T) {
+		mock.ExpectBegin()
+		expectVerseCount(mock, 1, 2)
+		mock.ExpectExec(regexp.QuoteMeta(`INSERT INTO song_translations (song_id, lang, lyrics) VALUES ($1, $2, $3) ON CONFLICT DO NOTHING`)).
+			WithArgs(uint64(1), "de", pq.Array(req.Lyrics)).
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectRollback()
+
+		err := repository.CreateTranslation(context.Background(), 1, req)
+		assert.Equal(t, repo.ErrAlreadyExists, err)
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("ErrVerseCountMismatch", func(t *testing.T) {
+		mock.ExpectBegin()
+		expectVerseCount(mock, 1, 3)
+		mock.ExpectRollback()
+
+		err := repository.CreateTranslation(context.Background(), 1, req)
+		assert.ErrorIs(t, err, repo.ErrVerseCountMismatch)
+		assert.ErrorContains(t, err, "2 instead of 3")
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("ErrNotFound", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectQuery(regexp.QuoteMeta(`SELECT cardinality(lyrics) FROM songs WHERE id = $1 FOR UPDATE`)).
+			WithArgs(uint64(2)).
+			WillReturnError(sql.ErrNoRows)
+		mock.ExpectRollback()
+
+		err := repository.CreateTranslation(context.Background(), 2, req)
+		assert.Equal(t, repo.ErrNotFound, err)
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestRepository_UpdateTranslation(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	repository := Repository{conn: db}
+	req := &model.Translation{Lang: "de", Lyrics: []string{"eins"}}
+
+	t.Run("Success", func(t *testing.T) {
+		mock.ExpectBegin()
+		expectVerseCount(mock, 1, 1)
+		expectSaveTranslation(mock, 1, "de", req.Lyrics)
+		mock.ExpectCommit()
+
+		err := repository.UpdateTranslation(context.Background(), 1, req)
+		require.NoError(t, err)
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("ErrNotFound", func(t *testing.T) {
+		mock.ExpectBegin()
+		expectVerseCount(mock, 1, 1)
+		mock.ExpectExec(regexp.QuoteMeta(`UPDATE song_translations SET lyrics = $1 WHERE song_id = $2 AND lang = $3`)).
+			WithArgs(pq.Array(req.Lyrics), uint64(1), "de").
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectRollback()
+
+		err := repository.UpdateTranslation(context.Background(), 1, req)
+		assert.Equal(t, repo.ErrNotFound, err)
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestRepository_DeleteTranslation(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	repository := Repository{conn: db}
+
+	t.Run("Success", func(t *testing.T) {
+		mock.ExpectExec(regexp.QuoteMeta(`DELETE FROM song_translations WHERE song_id = $1 AND lang = $2`)).
+			WithArgs(uint64(1), "de").
+			WillReturnResult(sqlmock.NewResult(0, 1))
+
+		err := repository.DeleteTranslation(context.Background(), 1, "de")
+		require.NoError(t, err)
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("ErrNotFound", func(t *testing.T) {
+		mock.ExpectExec(regexp.QuoteMeta(`DELETE FROM song_translations WHERE song_id = $1 AND lang = $2`)).
+			WithArgs(uint64(1), "fr").
+			WillReturnResult(sqlmock.NewResult(0, 0))
+
+		err := repository.DeleteTranslation(context.Background(), 1, "fr")
+		assert.Equal(t, repo.ErrNotFound, err)
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestRepository_ListPendingEnrichments(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	repository := Repository{conn: db}
+
+	t.Run("Success", func(t *testing.T) {
+		mock.ExpectQuery(regexp.QuoteMeta(`SELECT id FROM songs WHERE enrichment_status = $1 ORDER BY id LIMIT $2`)).
+			WithArgs(model.EnrichmentPending, 10).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1).AddRow(2))
+
+		ids, err := repository.ListPendingEnrichments(context.Background(), 10)
+		require.NoError(t, err)
+		assert.Equal(t, []uint64{1, 2}, ids)
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("DBErrorOnQuery", func(t *testing.T) {
+		mock.ExpectQuery(regexp.QuoteMeta(`SELECT id FROM songs WHERE enrichment_status = $1 ORDER BY id LIMIT $2`)).
+			WithArgs(model.EnrichmentPending, 10).
+			WillReturnError(errors.New("some database error"))
+
+		ids, err := repository.ListPendingEnrichments(context.Background(), 10)
+		require.Error(t, err)
+		assert.Nil(t, ids)
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestRepository_ClaimEnrichment(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	repository := Repository{conn: db}
+	const claimQ = `UPDATE songs SET enrichment_status = $1, enrichment_claimed_at = NOW() WHERE id = $2 AND enrichment_status = $3 RETURNING group_name, song_name, updated_at, enrichment_attempts, ( SELECT albums.release_date FROM album_tracks JOIN albums ON albums.id = album_tracks.album_id WHERE album_tracks.song_id = songs.id AND songs.release_date IS NULL ORDER BY albums.release_date ASC NULLS LAST LIMIT 1 )`
+	now := time.Now()
+
+	t.Run("Success", func(t *testing.T) {
+		mock.ExpectQuery(regexp.QuoteMeta(claimQ)).
+			WithArgs(model.EnrichmentProcessing, uint64(1), model.EnrichmentPending).
+			WillReturnRows(sqlmock.NewRows([]string{"group_name", "song_name", "updated_at", "enrichment_attempts", "release_date"}).AddRow("test-group", "test-song", now, 2, nil))
+
+		song, attempts, err := repository.ClaimEnrichment(context.Background(), 1)
+		require.NoError(t, err)
+		assert.Equal(t, &model.Song{ID: 1, Group: "test-group", Song: "test-song", EnrichmentStatus: model.EnrichmentProcessing, UpdatedAt: now}, song)
+		assert.Equal(t, 2, attempts)
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("SuccessWithAlbumReleaseDate", func(t *testing.T) {
+		releaseDate := time.Date(1975, 11, 21, 0, 0, 0, 0, time.UTC)
+		mock.ExpectQuery(regexp.QuoteMeta(claimQ)).
+			WithArgs(model.EnrichmentProcessing, uint64(3), model.EnrichmentPending).
+			WillReturnRows(sqlmock.NewRows([]string{"group_name", "song_name", "updated_at", "enrichment_attempts", "release_date"}).AddRow("test-group", "test-song", now, 0, releaseDate))
+
+		song, _, err := repository.ClaimEnrichment(context.Background(), 3)
+		require.NoError(t, err)
+		assert.Equal(t, releaseDate, song.ReleaseDate)
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("ErrNotFound", func(t *testing.T) {
+		mock.ExpectQuery(regexp.QuoteMeta(claimQ)).
+			WithArgs(model.EnrichmentProcessing, uint64(2), model.EnrichmentPending).
+			WillReturnError(sql.ErrNoRows)
+
+		song, _, err := repository.ClaimEnrichment(context.Background(), 2)
+		assert.Equal(t, repo.ErrNotFound, err)
+		assert.Nil(t, song)
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestRepository_CompleteEnrichment(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	repository := Repository{conn: db}
+	const completeQ = `UPDATE songs SET release_date = COALESCE($1, release_date), lyrics = COALESCE($2, lyrics), link = COALESCE(NULLIF($3, ''), link), metadata_sources = metadata_sources || $4::jsonb, enrichment_status = $5, enrichment_error = '', enrichment_attempts = $6 WHERE id = $7 AND enrichment_status = $8 AND updated_at = $9`
+
+	claimedAt := time.Now()
+	req := &model.Song{
+		ID:          1,
+		ReleaseDate: time.Date(2006, 7, 16, 0, 0, 0, 0, time.UTC),
+		Lyrics:      []string{"Lyric 1", "Lyric 2"},
+		Link:        "https://example.com",
+		UpdatedAt:   claimedAt,
+		MetadataSources: map[string]string{
+			model.FieldReleaseDate: "info",
+			model.FieldLyrics:      "info",
+			model.FieldLink:        "fixtures",
+		},
+	}
+
+	t.Run("Success", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectExec(regexp.QuoteMeta(completeQ)).
+			WithArgs(req.ReleaseDate, pq.Array(req.Lyrics), req.Link, []byte(`{"link":"fixtures","lyrics":"info","release_date":"info"}`), model.EnrichmentDone, 2, req.ID, model.EnrichmentProcessing, claimedAt).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectQuery(regexp.QuoteMeta(`SELECT lang FROM song_translations WHERE song_id = $1 AND cardinality(lyrics) <> $2 ORDER BY lang LIMIT 1`)).
+			WithArgs(req.ID, len(req.Lyrics)).
+			WillReturnRows(sqlmock.NewRows([]string{"lang"}))
+		mock.ExpectCommit()
+
+		err := repository.CompleteEnrichment(context.Background(), req, 2)
+		require.NoError(t, err)
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("ErrTranslationsMisaligned", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectExec(regexp.QuoteMeta(completeQ)).
+			WithArgs(req.ReleaseDate, pq.Array(req.Lyrics), req.Link, []byte(`{"link":"fixtures","lyrics":"info","release_date":"info"}`), model.EnrichmentDone, 2, req.ID, model.EnrichmentProcessing, claimedAt).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectQuery(regexp.QuoteMeta(`SELECT lang FROM song_translations`)).
+			WithArgs(req.ID, len(req.Lyrics)).
+			WillReturnRows(sqlmock.NewRows([]string{"lang"}).AddRow("de"))
+		mock.ExpectRollback()
+
+		err := repository.CompleteEnrichment(context.Background(), req, 2)
+		assert.ErrorIs(t, err, repo.ErrTranslationsMisaligned)
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("KeepsMissingFields", func(t *testing.T) {
+		partial := &model.Song{
+			ID:              1,
+			UpdatedAt:       claimedAt,
+			MetadataSources: map[string]string{model.FieldLink: "fixtures"},
+		}
+
+		mock.ExpectBegin()
+		mock.ExpectExec(regexp.QuoteMeta(completeQ)).
+			WithArgs(nil, nil, "", []byte(`{"link":"fixtures"}`), model.EnrichmentDone, 1, partial.ID, model.EnrichmentProcessing, claimedAt).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectCommit()
+
+		err := repository.CompleteEnrichment(context.Background(), partial, 1)
+		require.NoError(t, err)
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("ErrNotFound", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectExec(regexp.QuoteMeta(completeQ)).
+			WithArgs(req.ReleaseDate, pq.Array(req.Lyrics), req.Link, []byte(`{"link":"fixtures","lyrics":"info","release_date":"info"}`), model.EnrichmentDone, 2, req.ID, model.EnrichmentProcessing, claimedAt).
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectRollback()
+
+		err := repository.CompleteEnrichment(context.Background(), req, 2)
+		assert.Equal(t, repo.ErrNotFound, err)
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestRepository_RequeueEnrichment(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	repository := Repository{conn: db}
+	const requeueQ = `UPDATE songs SET enrichment_status = $1, enrichment_error = $2, enrichment_attempts = $3 WHERE id = $4 AND enrichment_status = $5`
+
+	t.Run("Success", func(t *testing.T) {
+		mock.ExpectExec(regexp.QuoteMeta(requeueQ)).
+			WithArgs(model.EnrichmentPending, "exhausted", 2, uint64(1), model.EnrichmentProcessing).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+
+		err := repository.RequeueEnrichment(context.Background(), 1, "exhausted", 2)
+		require.NoError(t, err)
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("ErrNotFound", func(t *testing.T) {
+		mock.ExpectExec(regexp.QuoteMeta(requeueQ)).
+			WithArgs(model.EnrichmentPending, "exhausted", 2, uint64(2), model.EnrichmentProcessing).
+			WillReturnResult(sqlmock.NewResult(0, 0))
+
+		err := repository.RequeueEnrichment(context.Background(), 2, "exhausted", 2)
+		assert.Equal(t, repo.ErrNotFound, err)
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestRepository_FailEnrichment(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	repository := Repository{conn: db}
+
+	t.Run("Success", func(t *testing.T) {
+		mock.ExpectExec(regexp.QuoteMeta(`UPDATE songs SET enrichment_status = $1, enrichment_error = $2, enrichment_attempts = $3 WHERE id = $4 AND enrichment_status = $5`)).
+			WithArgs(model.EnrichmentFailed, "unreachable", 5, uint64(1), model.EnrichmentProcessing).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+
+		err := repository.FailEnrichment(context.Background(), 1, "unreachable", 5)
+		require.NoError(t, err)
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestRepository_ResetEnrichment(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	repository := Repository{conn: db}
+	const resetQ = `UPDATE songs SET enrichment_status = $1, enrichment_error = '', enrichment_attempts = 0 WHERE id = $2 AND enrichment_status <> $3`
+	const existsQ = `SELECT EXISTS(SELECT 1 FROM songs WHERE id = $1)`
+
+	t.Run("Success", func(t *testing.T) {
+		mock.ExpectExec(regexp.QuoteMeta(resetQ)).
+			WithArgs(model.EnrichmentPending, uint64(1), model.EnrichmentProcessing).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+
+		err := repository.ResetEnrichment(context.Background(), 1)
+		require.NoError(t, err)
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("ErrNotFound", func(t *testing.T) {
+		mock.ExpectExec(regexp.QuoteMeta(resetQ)).
+			WithArgs(model.EnrichmentPending, uint64(2), model.EnrichmentProcessing).
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectQuery(regexp.QuoteMeta(existsQ)).
+			WithArgs(uint64(2)).
+			WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(false))
+
+		err := repository.ResetEnrichment(context.Background(), 2)
+		assert.Equal(t, repo.ErrNotFound, err)
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("ErrEnrichmentInProgress", func(t *testing.T) {
+		mock.ExpectExec(regexp.QuoteMeta(resetQ)).
+			WithArgs(model.EnrichmentPending, uint64(4), model.EnrichmentProcessing).
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectQuery(regexp.QuoteMeta(existsQ)).
+			WithArgs(uint64(4)).
+			WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(true))
+
+		err := repository.ResetEnrichment(context.Background(), 4)
+		assert.Equal(t, repo.ErrEnrichmentInProgress, err)
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("DBErrorOnUpdate", func(t *testing.T) {
+		mock.ExpectExec(regexp.QuoteMeta(resetQ)).
+			WithArgs(model.EnrichmentPending, uint64(3), model.EnrichmentProcessing).
+			WillReturnError(errors.New("some update error"))
+
+		err := repository.ResetEnrichment(context.Background(), 3)
+		assert.Equal(t, "some update error", err.Error())
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestRepository_RequeueStaleEnrichments(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	repository := Repository{conn: db}
+	const requeueQ = `UPDATE songs SET enrichment_status = $1 WHERE enrichment_status = $2 AND (enrichment_claimed_at IS NULL OR enrichment_claimed_at < NOW() - make_interval(secs => $3))`
+
+	t.Run("Success", func(t *testing.T) {
+		mock.ExpectExec(regexp.QuoteMeta(requeueQ)).
+			WithArgs(model.EnrichmentPending, model.EnrichmentProcessing, float64(600)).
+			WillReturnResult(sqlmock.NewResult(0, 2))
+
+		err := repository.RequeueStaleEnrichments(context.Background(), 10*time.Minute)
+		require.NoError(t, err)
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("DBErrorOnUpdate", func(t *testing.T) {
+		mock.ExpectExec(regexp.QuoteMeta(requeueQ)).
+			WithArgs(model.EnrichmentPending, model.EnrichmentProcessing, float64(600)).
+			WillReturnError(errors.New("some update error"))
+
+		err := repository.RequeueStaleEnrichments(context.Background(), 10*time.Minute)
+		assert.Equal(t, "some update error", err.Error())
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestRepository_GetCachedSongDetail(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	repository := Repository{conn: db}
+	const getQ = `SELECT release_date, text, link, sources FROM song_detail_cache WHERE group_key = $1 AND song_key = $2 AND expires_at > NOW()`
+
+	t.Run("Success", func(t *testing.T) {
+		mock.ExpectQuery(regexp.QuoteMeta(getQ)).
+			WithArgs("test-group", "test-song").
+			WillReturnRows(sqlmock.NewRows([]string{"release_date", "text", "link", "sources"}).
+				AddRow("16.07.2006", "Lyric 1\n\nLyric 2", "https://example.com", []byte(`{"link": "info"}`)))
+
+		res, err := repository.GetCachedSongDetail(context.Background(), "test-group", "test-song")
+		require.NoError(t, err)
+		assert.Equal(t, &model.SongDetail{
+			ReleaseDate: "16.07.2006",
+			Text:        "Lyric 1\n\nLyric 2",
+			Link:        "https://example.com",
+			Sources:     map[string]string{model.FieldLink: "info"},
+		}, res)
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("ErrNotFound", func(t *testing.T) {
+		mock.ExpectQuery(regexp.QuoteMeta(getQ)).
+			WithArgs("test-group", "test-song").
+			WillReturnError(sql.ErrNoRows)
+
+		res, err := repository.GetCachedSongDetail(context.Background(), "test-group", "test-song")
+		assert.Equal(t, repo.ErrNotFound, err)
+		assert.Nil(t, res)
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestRepository_SaveCachedSongDetail(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	repository := Repository{conn: db}
+	const saveQ = `INSERT INTO song_detail_cache (group_key, song_key, release_date, text, link, sources, expires_at) VALUES ($1, $2, $3, $4, $5, $6, $7) ON CONFLICT (group_key, song_key) DO UPDATE SET release_date = EXCLUDED.release_date, text = EXCLUDED.text, link = EXCLUDED.link, sources = EXCLUDED.sources, expires_at = EXCLUDED.expires_at`
+
+	req := &model.SongDetail{ReleaseDate: "16.07.2006", Text: "Lyric 1", Link: "https://example.com"}
+
+	t.Run("Success", func(t *testing.T) {
+		mock.ExpectExec(regexp.QuoteMeta(saveQ)).
+			WithArgs("test-group", "test-song", req.ReleaseDate, req.Text, req.Link, []byte(`{}`), sqlmock.AnyArg()).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+
+		err := repository.SaveCachedSongDetail(context.Background(), "test-group", "test-song", req, time.Hour)
+		require.NoError(t, err)
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("DBError", func(t *testing.T) {
+		dbErr := errors.New("db error")
+		mock.ExpectExec(regexp.QuoteMeta(saveQ)).
+			WithArgs("test-group", "test-song", req.ReleaseDate, req.Text, req.Link, []byte(`{}`), sqlmock.AnyArg()).
+			WillReturnError(dbErr)
+
+		err := repository.SaveCachedSongDetail(context.Background(), "test-group", "test-song", req, time.Hour)
+		assert.Equal(t, dbErr, err)
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestEndSpan(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	tracer := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)).Tracer("test")
+
+	for _, err := range []error{nil, repo.ErrNotFound, errors.New("connection reset")} {
+		_, span := tracer.Start(context.Background(), "songs.Test.repo")
+		endSpan(span, &err)
+	}
+
+	spans := recorder.Ended()
+	require.Len(t, spans, 3)
+	assert.Equal(t, codes.Unset, spans[0].Status().Code)
+	assert.Equal(t, codes.Unset, spans[1].Status().Code)
+	assert.Equal(t, codes.Error, spans[2].Status().Code)
+	assert.Equal(t, "connection reset", spans[2].Status().Description)
 }
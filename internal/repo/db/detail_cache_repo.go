@@ -0,0 +1,50 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"github.com/JMURv/effectiveMobile/internal/repo"
+	"github.com/JMURv/effectiveMobile/pkg/model"
+	"time"
+)
+
+func (r *Repository) GetCachedSongDetail(ctx context.Context, group, song string) (_ *model.SongDetail, err error) {
+	ctx, span := startSpan(ctx, "songs.GetCachedSongDetail.repo")
+	defer endSpan(span, &err)
+
+	res := &model.SongDetail{}
+	err = r.conn.QueryRowContext(
+		ctx,
+		`SELECT release_date, text, link, sources FROM song_detail_cache 
+		 WHERE group_key = $1 AND song_key = $2 AND expires_at > NOW()`,
+		group, song,
+	).Scan(&res.ReleaseDate, &res.Text, &res.Link, jsonMap{&res.Sources})
+
+	if err == sql.ErrNoRows {
+		return nil, repo.ErrNotFound
+	} else if err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+func (r *Repository) SaveCachedSongDetail(ctx context.Context, group, song string, req *model.SongDetail, ttl time.Duration) (err error) {
+	ctx, span := startSpan(ctx, "songs.SaveCachedSongDetail.repo")
+	defer endSpan(span, &err)
+
+	sources, err := toJSONMap(req.Sources)
+	if err != nil {
+		return err
+	}
+
+	_, err = r.conn.ExecContext(
+		ctx,
+		`INSERT INTO song_detail_cache (group_key, song_key, release_date, text, link, sources, expires_at)
+		 VALUES ($1, $2, $3, $4, $5, $6, $7)
+		 ON CONFLICT (group_key, song_key) DO UPDATE
+		 SET release_date = EXCLUDED.release_date, text = EXCLUDED.text, link = EXCLUDED.link, sources = EXCLUDED.sources, expires_at = EXCLUDED.expires_at`,
+		group, song, req.ReleaseDate, req.Text, req.Link, sources, time.Now().Add(ttl),
+	)
+	return err
+}
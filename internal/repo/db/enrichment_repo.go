@@ -0,0 +1,223 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"github.com/JMURv/effectiveMobile/internal/repo"
+	"github.com/JMURv/effectiveMobile/pkg/model"
+	"github.com/lib/pq"
+	"time"
+)
+
+func (r *Repository) ListPendingEnrichments(ctx context.Context, limit int) (_ []uint64, err error) {
+	ctx, span := startSpan(ctx, "songs.ListPendingEnrichments.repo")
+	defer endSpan(span, &err)
+
+	rows, err := r.conn.QueryContext(
+		ctx,
+		`SELECT id FROM songs WHERE enrichment_status = $1 ORDER BY id LIMIT $2`,
+		model.EnrichmentPending, limit,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	res := make([]uint64, 0, limit)
+	for rows.Next() {
+		var id uint64
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		res = append(res, id)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// RequeueStaleEnrichments returns to pending the songs claimed longer than the lease ago.
+// Their worker is gone, e.g. its instance stopped, while younger claims may still be in progress
+func (r *Repository) RequeueStaleEnrichments(ctx context.Context, lease time.Duration) (err error) {
+	ctx, span := startSpan(ctx, "songs.RequeueStaleEnrichments.repo")
+	defer endSpan(span, &err)
+
+	_, err = r.conn.ExecContext(
+		ctx,
+		`UPDATE songs SET enrichment_status = $1
+		 WHERE enrichment_status = $2
+		   AND (enrichment_claimed_at IS NULL OR enrichment_claimed_at < NOW() - make_interval(secs => $3))`,
+		model.EnrichmentPending, model.EnrichmentProcessing, lease.Seconds(),
+	)
+	return err
+}
+
+// ClaimEnrichment marks a pending song as processing and returns it along with the attempts
+// made so far. The returned updated_at is the version the enrichment is stored against.
+// A song without a release date gets the one of its earliest album as the default
+// for the one the providers return
+func (r *Repository) ClaimEnrichment(ctx context.Context, id uint64) (_ *model.Song, _ int, err error) {
+	ctx, span := startSpan(ctx, "songs.ClaimEnrichment.repo")
+	defer endSpan(span, &err)
+
+	res := &model.Song{ID: id, EnrichmentStatus: model.EnrichmentProcessing}
+	attempts := 0
+	err = r.conn.QueryRowContext(
+		ctx,
+		`UPDATE songs SET enrichment_status = $1, enrichment_claimed_at = NOW()
+		 WHERE id = $2 AND enrichment_status = $3
+		 RETURNING group_name, song_name, updated_at, enrichment_attempts, (
+		     SELECT albums.release_date FROM album_tracks
+		     JOIN albums ON albums.id = album_tracks.album_id
+		     WHERE album_tracks.song_id = songs.id AND songs.release_date IS NULL
+		     ORDER BY albums.release_date ASC NULLS LAST
+		     LIMIT 1
+		 )`,
+		model.EnrichmentProcessing, id, model.EnrichmentPending,
+	).Scan(&res.Group, &res.Song, &res.UpdatedAt, &attempts, nullTime{&res.ReleaseDate})
+
+	if err == sql.ErrNoRows {
+		return nil, 0, repo.ErrNotFound
+	} else if err != nil {
+		return nil, 0, err
+	}
+
+	return res, attempts, nil
+}
+
+// CompleteEnrichment stores the fetched details, leaving alone the fields the providers
+// did not return. The song must still be the version it was claimed at, so that edits
+// made in the meantime are not overwritten. Lyrics that no longer fit the translations
+// are not stored, the song keeps its current ones
+func (r *Repository) CompleteEnrichment(ctx context.Context, req *model.Song, attempts int) (err error) {
+	ctx, span := startSpan(ctx, "songs.CompleteEnrichment.repo")
+	defer endSpan(span, &err)
+
+	sources, err := toJSONMap(req.MetadataSources)
+	if err != nil {
+		return err
+	}
+
+	tx, err := r.conn.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	res, err := tx.ExecContext(
+		ctx,
+		`UPDATE songs
+		 SET release_date = COALESCE($1, release_date), lyrics = COALESCE($2, lyrics), link = COALESCE(NULLIF($3, ''), link),
+		     metadata_sources = metadata_sources || $4::jsonb, enrichment_status = $5, enrichment_error = '', enrichment_attempts = $6
+		 WHERE id = $7 AND enrichment_status = $8 AND updated_at = $9`,
+		toNullTime(req.ReleaseDate), pq.Array(req.Lyrics), req.Link, sources, model.EnrichmentDone, attempts,
+		req.ID, model.EnrichmentProcessing, req.UpdatedAt,
+	)
+	if err != nil {
+		return err
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if affected == 0 {
+		return repo.ErrNotFound
+	}
+
+	if req.Lyrics != nil {
+		if err := checkTranslationsAligned(ctx, tx, req.ID, len(req.Lyrics)); err != nil {
+			return err
+		}
+	}
+	return tx.Commit()
+}
+
+// RequeueEnrichment returns a claimed song to pending to be tried again by the next sweep
+func (r *Repository) RequeueEnrichment(ctx context.Context, id uint64, reason string, attempts int) (err error) {
+	ctx, span := startSpan(ctx, "songs.RequeueEnrichment.repo")
+	defer endSpan(span, &err)
+
+	res, err := r.conn.ExecContext(
+		ctx,
+		`UPDATE songs SET enrichment_status = $1, enrichment_error = $2, enrichment_attempts = $3
+		 WHERE id = $4 AND enrichment_status = $5`,
+		model.EnrichmentPending, reason, attempts, id, model.EnrichmentProcessing,
+	)
+	if err != nil {
+		return err
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if affected == 0 {
+		return repo.ErrNotFound
+	}
+	return nil
+}
+
+func (r *Repository) FailEnrichment(ctx context.Context, id uint64, reason string, attempts int) (err error) {
+	ctx, span := startSpan(ctx, "songs.FailEnrichment.repo")
+	defer endSpan(span, &err)
+
+	res, err := r.conn.ExecContext(
+		ctx,
+		`UPDATE songs SET enrichment_status = $1, enrichment_error = $2, enrichment_attempts = $3
+		 WHERE id = $4 AND enrichment_status = $5`,
+		model.EnrichmentFailed, reason, attempts, id, model.EnrichmentProcessing,
+	)
+	if err != nil {
+		return err
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if affected == 0 {
+		return repo.ErrNotFound
+	}
+	return nil
+}
+
+// ResetEnrichment queues the song for another enrichment, starting over with the attempts.
+// A song that is being enriched right now is left to its worker
+func (r *Repository) ResetEnrichment(ctx context.Context, id uint64) (err error) {
+	ctx, span := startSpan(ctx, "songs.ResetEnrichment.repo")
+	defer endSpan(span, &err)
+
+	res, err := r.conn.ExecContext(
+		ctx,
+		`UPDATE songs SET enrichment_status = $1, enrichment_error = '', enrichment_attempts = 0
+		 WHERE id = $2 AND enrichment_status <> $3`,
+		model.EnrichmentPending, id, model.EnrichmentProcessing,
+	)
+	if err != nil {
+		return err
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if affected > 0 {
+		return nil
+	}
+
+	var exists bool
+	if err := r.conn.QueryRowContext(ctx, `SELECT EXISTS(SELECT 1 FROM songs WHERE id = $1)`, id).Scan(&exists); err != nil {
+		return err
+	}
+	if !exists {
+		return repo.ErrNotFound
+	}
+	return repo.ErrEnrichmentInProgress
+}
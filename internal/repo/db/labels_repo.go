@@ -0,0 +1,282 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"github.com/JMURv/effectiveMobile/internal/repo"
+	"github.com/JMURv/effectiveMobile/pkg/model"
+	"github.com/lib/pq"
+	"strings"
+)
+
+// maxFacets limits the number of genres and tags counted for a song list
+const maxFacets = 50
+
+func (r *Repository) ListGenres(ctx context.Context) (_ []*model.Genre, err error) {
+	ctx, span := startSpan(ctx, "songs.ListGenres.repo")
+	defer endSpan(span, &err)
+
+	rows, err := r.conn.QueryContext(ctx, `SELECT id, name, created_at FROM genres ORDER BY name ASC, id ASC`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	res := make([]*model.Genre, 0)
+	for rows.Next() {
+		genre := &model.Genre{}
+		if err := rows.Scan(&genre.ID, &genre.Name, &genre.CreatedAt); err != nil {
+			return nil, err
+		}
+		res = append(res, genre)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+func (r *Repository) CreateGenre(ctx context.Context, req *model.Genre) (_ *model.Genre, err error) {
+	ctx, span := startSpan(ctx, "songs.CreateGenre.repo")
+	defer endSpan(span, &err)
+
+	res := &model.Genre{}
+	err = r.conn.QueryRowContext(
+		ctx,
+		`INSERT INTO genres (name) VALUES ($1)
+		 ON CONFLICT ((lower(btrim(name)))) DO NOTHING
+		 RETURNING id, name, created_at`,
+		req.Name,
+	).Scan(&res.ID, &res.Name, &res.CreatedAt)
+	if err == sql.ErrNoRows || isUniqueViolation(err) {
+		return nil, repo.ErrAlreadyExists
+	} else if err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+// DeleteGenre removes the genre from all its songs
+func (r *Repository) DeleteGenre(ctx context.Context, id uint64) (err error) {
+	ctx, span := startSpan(ctx, "songs.DeleteGenre.repo")
+	defer endSpan(span, &err)
+
+	return r.deleteLabel(ctx, `DELETE FROM genres WHERE id = $1`, id)
+}
+
+// ListTags lists the tags containing name, all of them when it is empty
+func (r *Repository) ListTags(ctx context.Context, name string) (_ []*model.Tag, err error) {
+	ctx, span := startSpan(ctx, "songs.ListTags.repo")
+	defer endSpan(span, &err)
+
+	filterQ, args := "", []any{}
+	if name != "" {
+		filterQ = " WHERE name ILIKE $1"
+		args = append(args, "%"+name+"%")
+	}
+
+	rows, err := r.conn.QueryContext(ctx, `SELECT id, name, created_at FROM tags`+filterQ+` ORDER BY name ASC, id ASC`, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	res := make([]*model.Tag, 0)
+	for rows.Next() {
+		tag := &model.Tag{}
+		if err := rows.Scan(&tag.ID, &tag.Name, &tag.CreatedAt); err != nil {
+			return nil, err
+		}
+		res = append(res, tag)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// DeleteTag removes the tag from all its songs
+func (r *Repository) DeleteTag(ctx context.Context, id uint64) (err error) {
+	ctx, span := startSpan(ctx, "songs.DeleteTag.repo")
+	defer endSpan(span, &err)
+
+	return r.deleteLabel(ctx, `DELETE FROM tags WHERE id = $1`, id)
+}
+
+// SetSongGenres replaces the genres of the song. All of them have to exist,
+// otherwise repo.ErrUnknownGenre lists the missing ones
+func (r *Repository) SetSongGenres(ctx context.Context, id uint64, names []string) (err error) {
+	ctx, span := startSpan(ctx, "songs.SetSongGenres.repo")
+	defer endSpan(span, &err)
+
+	tx, err := r.conn.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if err := lockSong(ctx, tx, id); err != nil {
+		return err
+	}
+
+	rows, err := tx.QueryContext(
+		ctx,
+		`SELECT n FROM unnest($1::text[]) n
+		 WHERE NOT EXISTS (SELECT 1 FROM genres WHERE lower(btrim(genres.name)) = lower(btrim(n)))`,
+		pq.Array(names),
+	)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	unknown := make([]string, 0)
+	for rows.Next() {
+		var name string
+		if err := rows.Scan(&name); err != nil {
+			return err
+		}
+		unknown = append(unknown, name)
+	}
+
+	if err := rows.Err(); err != nil {
+		return err
+	}
+
+	if len(unknown) > 0 {
+		return fmt.Errorf("%w: %s", repo.ErrUnknownGenre, strings.Join(unknown, ", "))
+	}
+
+	if err := replaceSongLabels(ctx, tx, "genre", id, names); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// SetSongTags replaces the tags of the song, creating the ones used for the first time
+func (r *Repository) SetSongTags(ctx context.Context, id uint64, names []string) (err error) {
+	ctx, span := startSpan(ctx, "songs.SetSongTags.repo")
+	defer endSpan(span, &err)
+
+	tx, err := r.conn.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if err := lockSong(ctx, tx, id); err != nil {
+		return err
+	}
+
+	_, err = tx.ExecContext(
+		ctx,
+		`INSERT INTO tags (name) SELECT DISTINCT ON (lower(btrim(n))) n FROM unnest($1::text[]) n
+		 ON CONFLICT ((lower(btrim(name)))) DO NOTHING`,
+		pq.Array(names),
+	)
+	if err != nil {
+		return err
+	}
+
+	if err := replaceSongLabels(ctx, tx, "tag", id, names); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// listFacets counts the genres and tags of the songs matching the filter,
+// the most used first
+func (r *Repository) listFacets(ctx context.Context, filterQ string, args []any) (*model.Facets, error) {
+	genres, err := r.countLabels(ctx, "genre", filterQ, args)
+	if err != nil {
+		return nil, err
+	}
+
+	tags, err := r.countLabels(ctx, "tag", filterQ, args)
+	if err != nil {
+		return nil, err
+	}
+
+	return &model.Facets{Genres: genres, Tags: tags}, nil
+}
+
+func (r *Repository) countLabels(ctx context.Context, label, filterQ string, args []any) ([]model.Facet, error) {
+	table, link := label+"s", "song_"+label+"s"
+	rows, err := r.conn.QueryContext(
+		ctx,
+		fmt.Sprintf(
+			"SELECT %[1]s.name, COUNT(*) FROM %[2]s JOIN %[1]s ON %[1]s.id = %[2]s.%[3]s_id"+
+				" WHERE %[2]s.song_id IN (SELECT id FROM songs%[4]s)"+
+				" GROUP BY %[1]s.name ORDER BY COUNT(*) DESC, %[1]s.name ASC LIMIT %[5]d",
+			table, link, label, filterQ, maxFacets,
+		),
+		args...,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	res := make([]model.Facet, 0)
+	for rows.Next() {
+		var facet model.Facet
+		if err := rows.Scan(&facet.Name, &facet.Count); err != nil {
+			return nil, err
+		}
+		res = append(res, facet)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+func (r *Repository) deleteLabel(ctx context.Context, query string, id uint64) error {
+	res, err := r.conn.ExecContext(ctx, query, id)
+	if err != nil {
+		return err
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if affected == 0 {
+		return repo.ErrNotFound
+	}
+	return nil
+}
+
+// lockSong keeps concurrent replacements of the song genres or tags from interleaving
+func lockSong(ctx context.Context, tx *sql.Tx, id uint64) error {
+	var locked uint64
+	err := tx.QueryRowContext(ctx, `SELECT id FROM songs WHERE id = $1 FOR UPDATE`, id).Scan(&locked)
+	if err == sql.ErrNoRows {
+		return repo.ErrNotFound
+	}
+	return err
+}
+
+func replaceSongLabels(ctx context.Context, tx *sql.Tx, label string, id uint64, names []string) error {
+	table, link := label+"s", "song_"+label+"s"
+	if _, err := tx.ExecContext(ctx, fmt.Sprintf("DELETE FROM %s WHERE song_id = $1", link), id); err != nil {
+		return err
+	}
+
+	_, err := tx.ExecContext(
+		ctx,
+		fmt.Sprintf(
+			"INSERT INTO %[2]s (song_id, %[3]s_id) SELECT $1, id FROM %[1]s"+
+				" WHERE lower(btrim(name)) IN (SELECT lower(btrim(n)) FROM unnest($2::text[]) n)",
+			table, link, label,
+		),
+		id, pq.Array(names),
+	)
+	return err
+}
@@ -0,0 +1,86 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"github.com/JMURv/effectiveMobile/internal/repo"
+	"github.com/JMURv/effectiveMobile/pkg/model"
+	"github.com/lib/pq"
+)
+
+// GetSongLyrics returns all the verses of the song along with its time-synced lines
+func (r *Repository) GetSongLyrics(ctx context.Context, id uint64) (_ *model.SongLyrics, err error) {
+	ctx, span := startSpan(ctx, "songs.GetSongLyrics.repo")
+	defer endSpan(span, &err)
+
+	res := &model.SongLyrics{}
+	err = r.conn.QueryRowContext(ctx, `SELECT group_name, song_name, lyrics FROM songs WHERE id = $1`, id).
+		Scan(&res.Group, &res.Song, pq.Array(&res.Verses))
+	if err == sql.ErrNoRows {
+		return nil, repo.ErrNotFound
+	} else if err != nil {
+		return nil, err
+	}
+
+	rows, err := r.conn.QueryContext(
+		ctx,
+		`SELECT time_ms, text FROM synced_lyrics WHERE song_id = $1 ORDER BY position ASC`,
+		id,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	res.Synced = make([]*model.SyncedLine, 0)
+	for rows.Next() {
+		line := &model.SyncedLine{}
+		if err := rows.Scan(&line.TimeMs, &line.Text); err != nil {
+			return nil, err
+		}
+		res.Synced = append(res.Synced, line)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+// SetSyncedLyrics replaces the time-synced lines of the song, keeping their order
+func (r *Repository) SetSyncedLyrics(ctx context.Context, id uint64, lines []*model.SyncedLine) (err error) {
+	ctx, span := startSpan(ctx, "songs.SetSyncedLyrics.repo")
+	defer endSpan(span, &err)
+
+	tx, err := r.conn.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if err := lockSong(ctx, tx, id); err != nil {
+		return err
+	}
+
+	if _, err := tx.ExecContext(ctx, `DELETE FROM synced_lyrics WHERE song_id = $1`, id); err != nil {
+		return err
+	}
+
+	times, texts := make([]int64, 0, len(lines)), make([]string, 0, len(lines))
+	for _, line := range lines {
+		times, texts = append(times, line.TimeMs), append(texts, line.Text)
+	}
+
+	_, err = tx.ExecContext(
+		ctx,
+		`INSERT INTO synced_lyrics (song_id, position, time_ms, text)
+		 SELECT $1, l.position, l.time_ms, l.text
+		 FROM unnest($2::bigint[], $3::text[]) WITH ORDINALITY AS l(time_ms, text, position)`,
+		id, pq.Array(times), pq.Array(texts),
+	)
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
@@ -0,0 +1,423 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"github.com/JMURv/effectiveMobile/internal/repo"
+	"github.com/JMURv/effectiveMobile/pkg/model"
+)
+
+const playlistColumns = "id, name, description, (SELECT COUNT(*) FROM playlist_songs WHERE playlist_id = playlists.id), created_at, updated_at"
+
+func playlistDest(playlist *model.Playlist) []any {
+	return []any{
+		&playlist.ID,
+		&playlist.Name,
+		&playlist.Description,
+		&playlist.SongsCount,
+		&playlist.CreatedAt,
+		&playlist.UpdatedAt,
+	}
+}
+
+func (r *Repository) ListPlaylists(ctx context.Context, page, size int, name string) (_ *model.PaginatedPlaylists, err error) {
+	ctx, span := startSpan(ctx, "songs.ListPlaylists.repo")
+	defer endSpan(span, &err)
+
+	filterQ, args := "", []any{}
+	if name != "" {
+		filterQ = " WHERE name ILIKE $1"
+		args = append(args, "%"+name+"%")
+	}
+
+	rows, err := r.conn.QueryContext(
+		ctx,
+		fmt.Sprintf("SELECT %s FROM playlists%s ORDER BY updated_at DESC, id ASC LIMIT %d OFFSET %d", playlistColumns, filterQ, size, (page-1)*size),
+		args...,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	res := make([]*model.Playlist, 0, size)
+	for rows.Next() {
+		playlist := &model.Playlist{}
+		if err := rows.Scan(playlistDest(playlist)...); err != nil {
+			return nil, err
+		}
+		res = append(res, playlist)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	var count int64
+	if err := r.conn.QueryRowContext(ctx, "SELECT COUNT(*) FROM playlists"+filterQ, args...).Scan(&count); err != nil {
+		return nil, err
+	}
+
+	totalPages := int((count + int64(size) - 1) / int64(size))
+	return &model.PaginatedPlaylists{
+		Data:        res,
+		Count:       count,
+		TotalPages:  totalPages,
+		CurrentPage: page,
+		HasNextPage: page < totalPages,
+	}, nil
+}
+
+func (r *Repository) GetPlaylist(ctx context.Context, id uint64) (_ *model.Playlist, err error) {
+	ctx, span := startSpan(ctx, "songs.GetPlaylist.repo")
+	defer endSpan(span, &err)
+
+	res := &model.Playlist{}
+	err = r.conn.QueryRowContext(ctx, `SELECT `+playlistColumns+` FROM playlists WHERE id = $1`, id).
+		Scan(playlistDest(res)...)
+	if err == sql.ErrNoRows {
+		return nil, repo.ErrNotFound
+	} else if err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+func (r *Repository) CreatePlaylist(ctx context.Context, req *model.Playlist) (_ *model.Playlist, err error) {
+	ctx, span := startSpan(ctx, "songs.CreatePlaylist.repo")
+	defer endSpan(span, &err)
+
+	res := &model.Playlist{}
+	err = r.conn.QueryRowContext(
+		ctx,
+		`INSERT INTO playlists (name, description) VALUES ($1, $2) RETURNING `+playlistColumns,
+		req.Name, req.Description,
+	).Scan(playlistDest(res)...)
+	if err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+func (r *Repository) UpdatePlaylist(ctx context.Context, req *model.Playlist) (err error) {
+	ctx, span := startSpan(ctx, "songs.UpdatePlaylist.repo")
+	defer endSpan(span, &err)
+
+	res, err := r.conn.ExecContext(
+		ctx,
+		`UPDATE playlists SET name = $1, description = $2 WHERE id = $3`,
+		req.Name, req.Description, req.ID,
+	)
+	if err != nil {
+		return err
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if affected == 0 {
+		return repo.ErrNotFound
+	}
+	return nil
+}
+
+func (r *Repository) DeletePlaylist(ctx context.Context, id uint64) (err error) {
+	ctx, span := startSpan(ctx, "songs.DeletePlaylist.repo")
+	defer endSpan(span, &err)
+
+	res, err := r.conn.ExecContext(ctx, `DELETE FROM playlists WHERE id = $1`, id)
+	if err != nil {
+		return err
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if affected == 0 {
+		return repo.ErrNotFound
+	}
+	return nil
+}
+
+// DuplicatePlaylist copies the playlist with all its entries. An empty name
+// keeps the original one with a "(copy)" suffix
+func (r *Repository) DuplicatePlaylist(ctx context.Context, id uint64, name string) (_ *model.Playlist, err error) {
+	ctx, span := startSpan(ctx, "songs.DuplicatePlaylist.repo")
+	defer endSpan(span, &err)
+
+	tx, err := r.conn.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	res := &model.Playlist{}
+	err = tx.QueryRowContext(
+		ctx,
+		`INSERT INTO playlists (name, description)
+		 SELECT COALESCE(NULLIF($2, ''), name || ' (copy)'), description FROM playlists WHERE id = $1
+		 RETURNING id`,
+		id, name,
+	).Scan(&res.ID)
+	if err == sql.ErrNoRows {
+		return nil, repo.ErrNotFound
+	} else if err != nil {
+		return nil, err
+	}
+
+	_, err = tx.ExecContext(
+		ctx,
+		`INSERT INTO playlist_songs (playlist_id, song_id, position)
+		 SELECT $1, song_id, position FROM playlist_songs WHERE playlist_id = $2`,
+		res.ID, id,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	err = tx.QueryRowContext(ctx, `SELECT `+playlistColumns+` FROM playlists WHERE id = $1`, res.ID).
+		Scan(playlistDest(res)...)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+func (r *Repository) ListPlaylistSongs(ctx context.Context, id uint64, page, size int) (_ *model.PaginatedSongs, err error) {
+	ctx, span := startSpan(ctx, "songs.ListPlaylistSongs.repo")
+	defer endSpan(span, &err)
+
+	rows, err := r.conn.QueryContext(
+		ctx,
+		fmt.Sprintf(
+			`SELECT position, added_at, %s FROM playlist_songs
+			 JOIN songs ON songs.id = playlist_songs.song_id
+			 WHERE playlist_id = $1
+			 ORDER BY position ASC LIMIT %d OFFSET %d`,
+			songColumns, size, (page-1)*size,
+		),
+		id,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	res := make([]*model.PlaylistEntry, 0, size)
+	for rows.Next() {
+		entry := &model.PlaylistEntry{Song: &model.Song{}}
+		if err := rows.Scan(append([]any{&entry.Position, &entry.AddedAt}, songDest(entry.Song)...)...); err != nil {
+			return nil, err
+		}
+		res = append(res, entry)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	var count int64
+	if err := r.conn.QueryRowContext(ctx, `SELECT COUNT(*) FROM playlist_songs WHERE playlist_id = $1`, id).Scan(&count); err != nil {
+		return nil, err
+	}
+
+	totalPages := int((count + int64(size) - 1) / int64(size))
+	return &model.PaginatedSongs{
+		Data:        res,
+		Count:       count,
+		TotalPages:  totalPages,
+		CurrentPage: page,
+		HasNextPage: page < totalPages,
+	}, nil
+}
+
+// AddPlaylistSong inserts the song at the position, shifting the following entries down.
+// Position 0 or past the end appends the song. Returns the position it ended up at
+func (r *Repository) AddPlaylistSong(ctx context.Context, id, songID uint64, position int) (_ int, err error) {
+	ctx, span := startSpan(ctx, "songs.AddPlaylistSong.repo")
+	defer endSpan(span, &err)
+
+	tx, err := r.conn.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback()
+
+	count, err := lockPlaylist(ctx, tx, id)
+	if err != nil {
+		return 0, err
+	}
+
+	if position < 1 || position > count+1 {
+		position = count + 1
+	}
+
+	_, err = tx.ExecContext(
+		ctx,
+		`UPDATE playlist_songs SET position = position + 1 WHERE playlist_id = $1 AND position >= $2`,
+		id, position,
+	)
+	if err != nil {
+		return 0, err
+	}
+
+	_, err = tx.ExecContext(
+		ctx,
+		`INSERT INTO playlist_songs (playlist_id, song_id, position) VALUES ($1, $2, $3)`,
+		id, songID, position,
+	)
+	if isForeignKeyViolation(err) {
+		return 0, repo.ErrSongNotFound
+	} else if isUniqueViolation(err) {
+		return 0, repo.ErrAlreadyExists
+	} else if err != nil {
+		return 0, err
+	}
+
+	if err := tx.Commit(); isUniqueViolation(err) {
+		return 0, repo.ErrPlaylistChanged
+	} else if err != nil {
+		return 0, err
+	}
+	return position, nil
+}
+
+// MovePlaylistSong puts the song at the position, shifting the entries in between.
+// Positions past the end move the song to the last one. Returns the position it ended up at
+func (r *Repository) MovePlaylistSong(ctx context.Context, id, songID uint64, position int) (_ int, err error) {
+	ctx, span := startSpan(ctx, "songs.MovePlaylistSong.repo")
+	defer endSpan(span, &err)
+
+	tx, err := r.conn.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback()
+
+	count, err := lockPlaylist(ctx, tx, id)
+	if err != nil {
+		return 0, err
+	}
+
+	var current int
+	err = tx.QueryRowContext(
+		ctx,
+		`SELECT position FROM playlist_songs WHERE playlist_id = $1 AND song_id = $2`,
+		id, songID,
+	).Scan(&current)
+	if err == sql.ErrNoRows {
+		return 0, repo.ErrNotFound
+	} else if err != nil {
+		return 0, err
+	}
+
+	position = min(max(position, 1), count)
+	if position == current {
+		return position, tx.Commit()
+	}
+
+	if position < current {
+		_, err = tx.ExecContext(
+			ctx,
+			`UPDATE playlist_songs SET position = position + 1 WHERE playlist_id = $1 AND position >= $2 AND position < $3`,
+			id, position, current,
+		)
+	} else {
+		_, err = tx.ExecContext(
+			ctx,
+			`UPDATE playlist_songs SET position = position - 1 WHERE playlist_id = $1 AND position > $2 AND position <= $3`,
+			id, current, position,
+		)
+	}
+	if err != nil {
+		return 0, err
+	}
+
+	_, err = tx.ExecContext(
+		ctx,
+		`UPDATE playlist_songs SET position = $1 WHERE playlist_id = $2 AND song_id = $3`,
+		position, id, songID,
+	)
+	if err != nil {
+		return 0, err
+	}
+
+	if err := tx.Commit(); isUniqueViolation(err) {
+		return 0, repo.ErrPlaylistChanged
+	} else if err != nil {
+		return 0, err
+	}
+	return position, nil
+}
+
+// RemovePlaylistSong takes the song out of the playlist, closing the gap it leaves
+func (r *Repository) RemovePlaylistSong(ctx context.Context, id, songID uint64) (err error) {
+	ctx, span := startSpan(ctx, "songs.RemovePlaylistSong.repo")
+	defer endSpan(span, &err)
+
+	tx, err := r.conn.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if _, err := lockPlaylist(ctx, tx, id); err != nil {
+		return err
+	}
+
+	var position int
+	err = tx.QueryRowContext(
+		ctx,
+		`DELETE FROM playlist_songs WHERE playlist_id = $1 AND song_id = $2 RETURNING position`,
+		id, songID,
+	).Scan(&position)
+	if err == sql.ErrNoRows {
+		return repo.ErrNotFound
+	} else if err != nil {
+		return err
+	}
+
+	_, err = tx.ExecContext(
+		ctx,
+		`UPDATE playlist_songs SET position = position - 1 WHERE playlist_id = $1 AND position > $2`,
+		id, position,
+	)
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// lockPlaylist touches the playlist, so that concurrent changes of its entries
+// line up, and returns the number of entries it has. The entries are counted
+// in a separate statement, after the lock is held, so the count includes
+// the changes committed by whoever held the lock before
+func lockPlaylist(ctx context.Context, tx *sql.Tx, id uint64) (int, error) {
+	res, err := tx.ExecContext(ctx, `UPDATE playlists SET updated_at = NOW() WHERE id = $1`, id)
+	if err != nil {
+		return 0, err
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return 0, err
+	}
+
+	if affected == 0 {
+		return 0, repo.ErrNotFound
+	}
+
+	var count int
+	err = tx.QueryRowContext(ctx, `SELECT COUNT(*) FROM playlist_songs WHERE playlist_id = $1`, id).Scan(&count)
+	return count, err
+}
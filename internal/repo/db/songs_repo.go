@@ -3,24 +3,73 @@ package db
 import (
 	"context"
 	"database/sql"
-	"errors"
 	"fmt"
 	"github.com/JMURv/effectiveMobile/internal/repo"
 	"github.com/JMURv/effectiveMobile/pkg/model"
 	utils "github.com/JMURv/effectiveMobile/pkg/utils/db"
+	"github.com/JMURv/effectiveMobile/pkg/utils/keyset"
 	"github.com/lib/pq"
+	"slices"
+	"strconv"
 	"strings"
+	"time"
 )
 
-func (r *Repository) ListSongs(ctx context.Context, page, size int, filters map[string]any) (*model.PaginatedSongs, error) {
+const songColumns = "id, artist_id, group_name, song_name, release_date, link, lyrics, enrichment_status, enrichment_error, metadata_sources, created_at, updated_at"
+
+func songDest(song *model.Song) []any {
+	return []any{
+		&song.ID,
+		&song.ArtistID,
+		&song.Group,
+		&song.Song,
+		nullTime{&song.ReleaseDate},
+		&song.Link,
+		pq.Array(&song.Lyrics),
+		&song.EnrichmentStatus,
+		&song.EnrichmentError,
+		jsonMap{&song.MetadataSources},
+		&song.CreatedAt,
+		&song.UpdatedAt,
+	}
+}
+
+func (r *Repository) ListSongs(ctx context.Context, page, size int, filters map[string]any) (_ *model.PaginatedSongs, err error) {
+	ctx, span := startSpan(ctx, "songs.ListSongs.repo")
+	defer endSpan(span, &err)
+
 	filterQ, args := utils.BuildFilterQuery(filters)
+	countArgs := args
+
+	sort, _ := filters["sort"].(string)
+	sortFields, err := utils.ParseSort(sort)
+	if err != nil {
+		return nil, err
+	}
 
 	var selectQ strings.Builder
-	selectQ.WriteString(`
-		SELECT group_name, song_name, release_date, link, lyrics 
-		FROM songs
-	`)
+	selectQ.WriteString("SELECT " + songColumns)
+
+	q, search := filters["q"].(string)
+	search = search && q != ""
+	if search {
+		args = append(args, q)
+		selectQ.WriteString(fmt.Sprintf(
+			", ts_headline('simple', array_to_string(lyrics, E'\\n'), websearch_to_tsquery('simple', $%d)) AS headline",
+			len(args),
+		))
+	}
+
+	selectQ.WriteString(" FROM songs")
 	selectQ.WriteString(filterQ)
+	if search && len(sortFields) == 0 {
+		selectQ.WriteString(fmt.Sprintf(
+			" ORDER BY ts_rank(lyrics_tsv, websearch_to_tsquery('simple', $%d)) DESC, id ASC",
+			len(args),
+		))
+	} else {
+		selectQ.WriteString(utils.BuildSortQuery(sortFields))
+	}
 	selectQ.WriteString(fmt.Sprintf(" LIMIT %v OFFSET %v", size, (page-1)*size))
 
 	rows, err := r.conn.QueryContext(ctx, selectQ.String(), args...)
@@ -32,7 +81,12 @@ func (r *Repository) ListSongs(ctx context.Context, page, size int, filters map[
 	res := make([]*model.Song, 0, size)
 	for rows.Next() {
 		song := &model.Song{}
-		if err := rows.Scan(&song.Group, &song.Song, &song.ReleaseDate, &song.Link, pq.Array(&song.Lyrics)); err != nil {
+		dest := songDest(song)
+		if search {
+			dest = append(dest, &song.Headline)
+		}
+
+		if err := rows.Scan(dest...); err != nil {
 			return nil, err
 		}
 		res = append(res, song)
@@ -43,31 +97,53 @@ func (r *Repository) ListSongs(ctx context.Context, page, size int, filters map[
 	countQ.WriteString(filterQ)
 
 	var count int64
-	if err := r.conn.QueryRowContext(ctx, countQ.String(), args...).Scan(&count); err != nil {
+	if err := r.conn.QueryRowContext(ctx, countQ.String(), countArgs...).Scan(&count); err != nil {
 		return nil, err
 	}
 
 	totalPages := int((count + int64(size) - 1) / int64(size))
-	return &model.PaginatedSongs{
+	paginated := &model.PaginatedSongs{
 		Data:        res,
 		Count:       count,
 		TotalPages:  totalPages,
 		CurrentPage: page,
 		HasNextPage: page < totalPages,
-	}, nil
+	}
+
+	if withFacets, _ := filters["facets"].(bool); withFacets {
+		if paginated.Facets, err = r.listFacets(ctx, filterQ, countArgs); err != nil {
+			return nil, err
+		}
+	}
+	return paginated, nil
 }
 
-func (r *Repository) GetSong(ctx context.Context, id uint64, page, size int) (*model.PaginatedSongs, error) {
+func (r *Repository) GetSong(ctx context.Context, id uint64, page, size int) (_ *model.PaginatedSongs, err error) {
+	ctx, span := startSpan(ctx, "songs.GetSong.repo")
+	defer endSpan(span, &err)
+
 	var count int64
 	res := &model.Song{}
 
 	offset := (page - 1) * size
-	err := r.conn.QueryRowContext(ctx, `
-		SELECT group_name, song_name, release_date, link, lyrics[$2:$3], array_length(lyrics, 1) as count
+	err = r.conn.QueryRowContext(ctx, `
+		SELECT id, artist_id, group_name, song_name, release_date, link, lyrics[$2:$3], enrichment_status, enrichment_error, metadata_sources, created_at, updated_at,
+		       ARRAY(
+		           SELECT genres.name FROM song_genres
+		           JOIN genres ON genres.id = song_genres.genre_id
+		           WHERE song_genres.song_id = songs.id ORDER BY genres.name
+		       ) AS genres,
+		       ARRAY(
+		           SELECT tags.name FROM song_tags
+		           JOIN tags ON tags.id = song_tags.tag_id
+		           WHERE song_tags.song_id = songs.id ORDER BY tags.name
+		       ) AS tags,
+		       ARRAY(SELECT lang FROM song_translations WHERE song_id = songs.id ORDER BY lang) AS translations,
+		       cardinality(lyrics) as count
 		FROM songs
 		WHERE id = $1
 		`, id, offset+1, offset+size).
-		Scan(&res.Group, &res.Song, &res.ReleaseDate, &res.Link, pq.Array(&res.Lyrics), &count)
+		Scan(append(songDest(res), pq.Array(&res.Genres), pq.Array(&res.Tags), pq.Array(&res.Translations), &count)...)
 
 	if err == sql.ErrNoRows {
 		return nil, repo.ErrNotFound
@@ -85,66 +161,388 @@ func (r *Repository) GetSong(ctx context.Context, id uint64, page, size int) (*m
 	}, nil
 }
 
-func (r *Repository) CreateSong(ctx context.Context, req *model.Song) (uint64, error) {
-	var idx uint64
-	err := r.conn.QueryRow(`SELECT id FROM songs WHERE group_name=$1 AND song_name=$2`, req.Group, req.Song).Scan(&idx)
-	if err == nil {
-		return 0, repo.ErrAlreadyExists
-	} else if err != nil && err != sql.ErrNoRows {
+func (r *Repository) CreateSong(ctx context.Context, req *model.Song) (_ uint64, err error) {
+	ctx, span := startSpan(ctx, "songs.CreateSong.repo")
+	defer endSpan(span, &err)
+
+	status := req.EnrichmentStatus
+	if status == "" {
+		status = model.EnrichmentDone
+	}
+
+	lyrics := req.Lyrics
+	if lyrics == nil {
+		lyrics = []string{}
+	}
+
+	tx, err := r.conn.BeginTx(ctx, nil)
+	if err != nil {
 		return 0, err
 	}
+	defer tx.Rollback()
+
+	artist, err := resolveArtist(ctx, tx, req.Group)
+	if err != nil {
+		return 0, err
+	}
+	req.ArtistID, req.Group = artist.ID, artist.Name
 
 	var id uint64
-	err = r.conn.QueryRowContext(
+	err = tx.QueryRowContext(
 		ctx,
-		`INSERT INTO songs (group_name, song_name, release_date, lyrics, link) 
-		 VALUES ($1, $2, $3, $4, $5) RETURNING id`,
-		req.Group, req.Song, req.ReleaseDate, pq.Array(req.Lyrics), req.Link,
+		`INSERT INTO songs (artist_id, group_name, song_name, release_date, lyrics, link, enrichment_status) 
+		 VALUES ($1, $2, $3, $4, $5, $6, $7)
+		 ON CONFLICT ((lower(btrim(group_name))), (lower(btrim(song_name)))) DO NOTHING
+		 RETURNING id`,
+		req.ArtistID, req.Group, req.Song, toNullTime(req.ReleaseDate), pq.Array(lyrics), req.Link, status,
 	).Scan(&id)
 
-	if err != nil {
+	if err == sql.ErrNoRows || isUniqueViolation(err) {
+		return 0, repo.ErrAlreadyExists
+	} else if err != nil {
 		return 0, err
 	}
 
+	if req.Album != nil {
+		_, err = tx.ExecContext(
+			ctx,
+			`INSERT INTO album_tracks (album_id, song_id, disc, track_number) VALUES ($1, $2, $3, $4)`,
+			req.Album.AlbumID, id, req.Album.Disc, req.Album.TrackNumber,
+		)
+		if isForeignKeyViolation(err) {
+			return 0, repo.ErrNotFound
+		} else if isUniqueViolation(err) {
+			return 0, repo.ErrTrackTaken
+		} else if err != nil {
+			return 0, err
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return 0, err
+	}
 	return id, nil
 }
 
-func (r *Repository) UpdateSong(ctx context.Context, req *model.Song) error {
-	var idx uint64
-	err := r.conn.
-		QueryRow(`SELECT id FROM songs WHERE id = $1`, req.ID).
-		Scan(&idx)
+func (r *Repository) UpdateSong(ctx context.Context, req *model.Song) (err error) {
+	ctx, span := startSpan(ctx, "songs.UpdateSong.repo")
+	defer endSpan(span, &err)
 
-	if err == sql.ErrNoRows {
-		return repo.ErrNotFound
+	tx, err := r.conn.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	artist, err := resolveArtist(ctx, tx, req.Group)
+	if err != nil {
+		return err
+	}
+	req.ArtistID, req.Group = artist.ID, artist.Name
+
+	res, err := tx.ExecContext(ctx,
+		`UPDATE songs SET artist_id = $1, group_name = $2, song_name = $3, release_date = $4, lyrics = $5, link = $6 WHERE id = $7`,
+		req.ArtistID, req.Group, req.Song, req.ReleaseDate, pq.Array(req.Lyrics), req.Link, req.ID,
+	)
+	if isUniqueViolation(err) {
+		return repo.ErrAlreadyExists
 	} else if err != nil {
 		return err
 	}
 
-	if _, err = r.conn.ExecContext(ctx,
-		`UPDATE songs SET group_name = $1, song_name = $2, release_date = $3, lyrics = $4, link = $5 WHERE id = $6`,
-		req.Group, req.Song, req.ReleaseDate, pq.Array(req.Lyrics), req.Link, req.ID,
-	); err != nil {
+	affected, err := res.RowsAffected()
+	if err != nil {
 		return err
 	}
 
-	return nil
+	if affected == 0 {
+		return repo.ErrNotFound
+	}
+
+	if err := checkTranslationsAligned(ctx, tx, req.ID, len(req.Lyrics)); err != nil {
+		return err
+	}
+	return tx.Commit()
 }
 
-func (r *Repository) DeleteSong(ctx context.Context, id uint64) error {
-	var idx uint64
-	err := r.conn.
-		QueryRow(`SELECT id FROM songs WHERE id = $1`, id).
-		Scan(&idx)
+// DeleteSong removes the song along with its playlist entries,
+// moving the songs after it up in every playlist
+func (r *Repository) DeleteSong(ctx context.Context, id uint64) (err error) {
+	ctx, span := startSpan(ctx, "songs.DeleteSong.repo")
+	defer endSpan(span, &err)
+
+	tx, err := r.conn.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	// Playlists are locked before their entries, as every playlist change does
+	_, err = tx.ExecContext(
+		ctx,
+		`UPDATE playlists SET updated_at = NOW() 
+		 WHERE id IN (SELECT playlist_id FROM playlist_songs WHERE song_id = $1)`,
+		id,
+	)
+	if err != nil {
+		return err
+	}
 
-	if err != nil && errors.Is(err, sql.ErrNoRows) {
+	_, err = tx.ExecContext(
+		ctx,
+		`UPDATE playlist_songs SET position = playlist_songs.position - 1
+		 FROM playlist_songs removed
+		 WHERE removed.song_id = $1 AND playlist_songs.playlist_id = removed.playlist_id AND playlist_songs.position > removed.position`,
+		id,
+	)
+	if err != nil {
+		return err
+	}
+
+	res, err := tx.ExecContext(ctx, `DELETE FROM songs WHERE id = $1`, id)
+	if err != nil {
+		return err
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if affected == 0 {
 		return repo.ErrNotFound
+	}
+	return tx.Commit()
+}
+
+func (r *Repository) PatchSong(ctx context.Context, id uint64, req *model.SongPatch) (err error) {
+	ctx, span := startSpan(ctx, "songs.PatchSong.repo")
+	defer endSpan(span, &err)
+
+	tx, err := r.conn.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if req.Group != nil {
+		artist, err := resolveArtist(ctx, tx, *req.Group)
+		if err != nil {
+			return err
+		}
+		req.ArtistID, req.Group = &artist.ID, &artist.Name
+	}
+
+	setQ, args := utils.BuildPatchQuery(req)
+	args = append(args, id)
+
+	res, err := tx.ExecContext(
+		ctx,
+		fmt.Sprintf("UPDATE songs SET %s WHERE id = $%d", setQ, len(args)),
+		args...,
+	)
+	if isUniqueViolation(err) {
+		return repo.ErrAlreadyExists
 	} else if err != nil {
 		return err
 	}
 
-	if _, err = r.conn.ExecContext(ctx, `DELETE FROM songs WHERE id = $1`, id); err != nil {
+	affected, err := res.RowsAffected()
+	if err != nil {
 		return err
 	}
-	return nil
+
+	if affected == 0 {
+		return repo.ErrNotFound
+	}
+
+	if req.Lyrics != nil {
+		if err := checkTranslationsAligned(ctx, tx, id, len(*req.Lyrics)); err != nil {
+			return err
+		}
+	}
+	return tx.Commit()
+}
+
+func (r *Repository) ListSongsCursor(ctx context.Context, cursor string, limit int, withCount bool, filters map[string]any) (_ *model.CursorPaginatedSongs, err error) {
+	ctx, span := startSpan(ctx, "songs.ListSongsCursor.repo")
+	defer endSpan(span, &err)
+
+	filterQ, args := utils.BuildFilterQuery(filters)
+	countArgs := args
+
+	sort, _ := filters["sort"].(string)
+	var c *keyset.Cursor
+	if cursor != "" {
+		var err error
+		if c, err = keyset.Decode(cursor); err != nil {
+			return nil, err
+		}
+
+		if sort != "" && sort != c.Sort {
+			return nil, keyset.ErrInvalidCursor
+		}
+		sort = c.Sort
+	}
+
+	sortFields, err := utils.ParseSort(sort)
+	if err != nil && c != nil {
+		return nil, keyset.ErrInvalidCursor
+	} else if err != nil {
+		return nil, err
+	}
+
+	keysetFields := utils.KeysetFields(sortFields)
+	order := keysetFields
+	if c != nil && c.Backward {
+		order = utils.ReverseSort(keysetFields)
+	}
+
+	var selectQ strings.Builder
+	selectQ.WriteString("SELECT " + songColumns + " FROM songs")
+	selectQ.WriteString(filterQ)
+
+	if c != nil {
+		values, err := decodeKeyset(keysetFields, c.Values)
+		if err != nil {
+			return nil, err
+		}
+
+		if filterQ == "" {
+			selectQ.WriteString(" WHERE ")
+		} else {
+			selectQ.WriteString(" AND ")
+		}
+		keysetQ, keysetArgs := utils.BuildKeysetQuery(order, values, len(args)+1)
+		selectQ.WriteString(keysetQ)
+		args = append(args, keysetArgs...)
+	}
+
+	selectQ.WriteString(utils.BuildSortQuery(order))
+	selectQ.WriteString(fmt.Sprintf(" LIMIT %v", limit+1))
+
+	rows, err := r.conn.QueryContext(ctx, selectQ.String(), args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	res := make([]*model.Song, 0, limit+1)
+	for rows.Next() {
+		song := &model.Song{}
+		if err := rows.Scan(songDest(song)...); err != nil {
+			return nil, err
+		}
+		res = append(res, song)
+	}
+
+	hasMore := len(res) > limit
+	if hasMore {
+		res = res[:limit]
+	}
+
+	backward := c != nil && c.Backward
+	if backward {
+		slices.Reverse(res)
+	}
+
+	paginated := &model.CursorPaginatedSongs{Data: res}
+	if len(res) > 0 {
+		first, last := res[0], res[len(res)-1]
+		if hasMore || backward {
+			paginated.NextCursor = keyset.Encode(&keyset.Cursor{
+				Sort:   sort,
+				Values: encodeKeyset(keysetFields, last),
+			})
+		}
+		if (backward && hasMore) || (!backward && c != nil) {
+			paginated.PrevCursor = keyset.Encode(&keyset.Cursor{
+				Sort:     sort,
+				Values:   encodeKeyset(keysetFields, first),
+				Backward: true,
+			})
+		}
+	}
+
+	if withCount {
+		var countQ strings.Builder
+		countQ.WriteString("SELECT COUNT(*) FROM songs")
+		countQ.WriteString(filterQ)
+
+		var count int64
+		if err := r.conn.QueryRowContext(ctx, countQ.String(), countArgs...).Scan(&count); err != nil {
+			return nil, err
+		}
+		paginated.Count = &count
+	}
+
+	if withFacets, _ := filters["facets"].(bool); withFacets {
+		if paginated.Facets, err = r.listFacets(ctx, filterQ, countArgs); err != nil {
+			return nil, err
+		}
+	}
+	return paginated, nil
+}
+
+func encodeKeyset(fields []utils.SortField, song *model.Song) []*string {
+	res := make([]*string, 0, len(fields))
+	for _, f := range fields {
+		var value string
+		switch f.Column {
+		case "id":
+			value = strconv.FormatUint(song.ID, 10)
+		case "group_name":
+			value = song.Group
+		case "song_name":
+			value = song.Song
+		case "release_date":
+			if song.ReleaseDate.IsZero() {
+				// The zero time stands for a NULL release date, see nullTime
+				res = append(res, nil)
+				continue
+			}
+			value = song.ReleaseDate.Format(time.RFC3339Nano)
+		case "created_at":
+			value = song.CreatedAt.Format(time.RFC3339Nano)
+		case "updated_at":
+			value = song.UpdatedAt.Format(time.RFC3339Nano)
+		}
+		res = append(res, &value)
+	}
+	return res
+}
+
+func decodeKeyset(fields []utils.SortField, values []*string) ([]any, error) {
+	if len(values) != len(fields) {
+		return nil, keyset.ErrInvalidCursor
+	}
+
+	res := make([]any, 0, len(fields))
+	for i, f := range fields {
+		if values[i] == nil {
+			if !f.Nullable {
+				return nil, keyset.ErrInvalidCursor
+			}
+			res = append(res, nil)
+			continue
+		}
+
+		switch f.Column {
+		case "id":
+			id, err := strconv.ParseUint(*values[i], 10, 64)
+			if err != nil {
+				return nil, keyset.ErrInvalidCursor
+			}
+			res = append(res, id)
+		case "release_date", "created_at", "updated_at":
+			t, err := time.Parse(time.RFC3339Nano, *values[i])
+			if err != nil {
+				return nil, keyset.ErrInvalidCursor
+			}
+			res = append(res, t)
+		default:
+			res = append(res, *values[i])
+		}
+	}
+	return res, nil
 }
@@ -0,0 +1,156 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"github.com/JMURv/effectiveMobile/internal/repo"
+	"github.com/JMURv/effectiveMobile/pkg/model"
+	"github.com/lib/pq"
+)
+
+// GetTranslation returns the translated verses of the page, like GetSong does for the original ones
+func (r *Repository) GetTranslation(ctx context.Context, id uint64, lang string, page, size int) (_ []string, err error) {
+	ctx, span := startSpan(ctx, "songs.GetTranslation.repo")
+	defer endSpan(span, &err)
+
+	var res []string
+
+	offset := (page - 1) * size
+	err = r.conn.QueryRowContext(
+		ctx,
+		`SELECT lyrics[$3:$4] FROM song_translations WHERE song_id = $1 AND lang = $2`,
+		id, lang, offset+1, offset+size,
+	).Scan(pq.Array(&res))
+	if err == sql.ErrNoRows {
+		return nil, repo.ErrNotFound
+	} else if err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+func (r *Repository) CreateTranslation(ctx context.Context, id uint64, req *model.Translation) (err error) {
+	ctx, span := startSpan(ctx, "songs.CreateTranslation.repo")
+	defer endSpan(span, &err)
+
+	tx, err := r.conn.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if err := checkVerseCount(ctx, tx, id, len(req.Lyrics)); err != nil {
+		return err
+	}
+
+	res, err := tx.ExecContext(
+		ctx,
+		`INSERT INTO song_translations (song_id, lang, lyrics) VALUES ($1, $2, $3) ON CONFLICT DO NOTHING`,
+		id, req.Lang, pq.Array(req.Lyrics),
+	)
+	if err != nil {
+		return err
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if affected == 0 {
+		return repo.ErrAlreadyExists
+	}
+	return tx.Commit()
+}
+
+func (r *Repository) UpdateTranslation(ctx context.Context, id uint64, req *model.Translation) (err error) {
+	ctx, span := startSpan(ctx, "songs.UpdateTranslation.repo")
+	defer endSpan(span, &err)
+
+	tx, err := r.conn.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if err := checkVerseCount(ctx, tx, id, len(req.Lyrics)); err != nil {
+		return err
+	}
+
+	res, err := tx.ExecContext(
+		ctx,
+		`UPDATE song_translations SET lyrics = $1 WHERE song_id = $2 AND lang = $3`,
+		pq.Array(req.Lyrics), id, req.Lang,
+	)
+	if err != nil {
+		return err
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if affected == 0 {
+		return repo.ErrNotFound
+	}
+	return tx.Commit()
+}
+
+func (r *Repository) DeleteTranslation(ctx context.Context, id uint64, lang string) (err error) {
+	ctx, span := startSpan(ctx, "songs.DeleteTranslation.repo")
+	defer endSpan(span, &err)
+
+	res, err := r.conn.ExecContext(ctx, `DELETE FROM song_translations WHERE song_id = $1 AND lang = $2`, id, lang)
+	if err != nil {
+		return err
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if affected == 0 {
+		return repo.ErrNotFound
+	}
+	return nil
+}
+
+// checkVerseCount locks the song, so that its verses can't change until the
+// translation is stored, and makes sure the translation has as many verses
+func checkVerseCount(ctx context.Context, tx *sql.Tx, id uint64, count int) error {
+	var verses int
+	err := tx.QueryRowContext(ctx, `SELECT cardinality(lyrics) FROM songs WHERE id = $1 FOR UPDATE`, id).Scan(&verses)
+	if err == sql.ErrNoRows {
+		return repo.ErrNotFound
+	} else if err != nil {
+		return err
+	}
+
+	if count != verses {
+		return fmt.Errorf("%w: %d instead of %d", repo.ErrVerseCountMismatch, count, verses)
+	}
+	return nil
+}
+
+// checkTranslationsAligned makes sure the lyrics that replace the song's ones in the transaction
+// still have as many verses as every translation. It runs after the song is updated, whose row
+// lock keeps new translations out until the transaction ends
+func checkTranslationsAligned(ctx context.Context, tx *sql.Tx, id uint64, count int) error {
+	var lang string
+	err := tx.QueryRowContext(
+		ctx,
+		`SELECT lang FROM song_translations WHERE song_id = $1 AND cardinality(lyrics) <> $2 ORDER BY lang LIMIT 1`,
+		id, count,
+	).Scan(&lang)
+	if err == sql.ErrNoRows {
+		return nil
+	} else if err != nil {
+		return err
+	}
+
+	return fmt.Errorf("%w: %s", repo.ErrTranslationsMisaligned, lang)
+}
@@ -0,0 +1,206 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"github.com/JMURv/effectiveMobile/internal/repo"
+	"github.com/JMURv/effectiveMobile/pkg/model"
+	"github.com/lib/pq"
+	"slices"
+)
+
+func (r *Repository) ListVerses(ctx context.Context, id uint64, page, size int) (_ *model.PaginatedVerses, err error) {
+	ctx, span := startSpan(ctx, "songs.ListVerses.repo")
+	defer endSpan(span, &err)
+
+	var count int64
+	var lyrics []string
+
+	offset := (page - 1) * size
+	err = r.conn.QueryRowContext(
+		ctx,
+		`SELECT lyrics[$2:$3], cardinality(lyrics) FROM songs WHERE id = $1`,
+		id, offset+1, offset+size,
+	).Scan(pq.Array(&lyrics), &count)
+	if err == sql.ErrNoRows {
+		return nil, repo.ErrNotFound
+	} else if err != nil {
+		return nil, err
+	}
+
+	res := make([]*model.Verse, 0, len(lyrics))
+	for i, text := range lyrics {
+		res = append(res, &model.Verse{Position: offset + i + 1, Text: text})
+	}
+
+	totalPages := int((count + int64(size) - 1) / int64(size))
+	return &model.PaginatedVerses{
+		Data:        res,
+		Count:       count,
+		TotalPages:  totalPages,
+		CurrentPage: page,
+		HasNextPage: page < totalPages,
+	}, nil
+}
+
+// InsertVerse inserts the verse at the position, shifting the following verses down.
+// Position 0 or past the end appends the verse. Returns the position it ended up at
+func (r *Repository) InsertVerse(ctx context.Context, id uint64, position int, text string) (_ int, err error) {
+	ctx, span := startSpan(ctx, "songs.InsertVerse.repo")
+	defer endSpan(span, &err)
+
+	err = r.editLyrics(ctx, id, func(lyrics []string) ([]string, error) {
+		if position < 1 || position > len(lyrics)+1 {
+			position = len(lyrics) + 1
+		}
+		return slices.Insert(lyrics, position-1, text), nil
+	}, func(translation []string) []string {
+		// The verse is left untranslated
+		return slices.Insert(translation, min(position-1, len(translation)), "")
+	})
+	if err != nil {
+		return 0, err
+	}
+	return position, nil
+}
+
+func (r *Repository) UpdateVerse(ctx context.Context, id uint64, position int, text string) (err error) {
+	ctx, span := startSpan(ctx, "songs.UpdateVerse.repo")
+	defer endSpan(span, &err)
+
+	return r.editLyrics(ctx, id, func(lyrics []string) ([]string, error) {
+		if position < 1 || position > len(lyrics) {
+			return nil, repo.ErrVerseNotFound
+		}
+		lyrics[position-1] = text
+		return lyrics, nil
+	}, nil)
+}
+
+// DeleteVerse removes the verse, moving the following verses up
+func (r *Repository) DeleteVerse(ctx context.Context, id uint64, position int) (err error) {
+	ctx, span := startSpan(ctx, "songs.DeleteVerse.repo")
+	defer endSpan(span, &err)
+
+	remove := func(lyrics []string) []string {
+		if position > len(lyrics) {
+			return lyrics
+		}
+		return slices.Delete(lyrics, position-1, position)
+	}
+
+	return r.editLyrics(ctx, id, func(lyrics []string) ([]string, error) {
+		if position < 1 || position > len(lyrics) {
+			return nil, repo.ErrVerseNotFound
+		}
+		return remove(lyrics), nil
+	}, remove)
+}
+
+// MoveVerse puts the verse at the new position, shifting the verses in between.
+// Positions past the end move the verse to the last one. Returns the position it ended up at
+func (r *Repository) MoveVerse(ctx context.Context, id uint64, position, to int) (_ int, err error) {
+	ctx, span := startSpan(ctx, "songs.MoveVerse.repo")
+	defer endSpan(span, &err)
+
+	move := func(lyrics []string) []string {
+		if position > len(lyrics) {
+			return lyrics
+		}
+		verse := lyrics[position-1]
+		lyrics = slices.Delete(lyrics, position-1, position)
+		return slices.Insert(lyrics, min(to-1, len(lyrics)), verse)
+	}
+
+	err = r.editLyrics(ctx, id, func(lyrics []string) ([]string, error) {
+		if position < 1 || position > len(lyrics) {
+			return nil, repo.ErrVerseNotFound
+		}
+
+		to = min(max(to, 1), len(lyrics))
+		return move(lyrics), nil
+	}, move)
+	if err != nil {
+		return 0, err
+	}
+	return to, nil
+}
+
+// editLyrics locks the song row, so that concurrent verse edits apply one after
+// another instead of overwriting each other, and stores the lyrics returned by edit.
+// When the edit adds, removes or moves verses, align does the same to every translation,
+// so that they stay aligned with the original
+func (r *Repository) editLyrics(ctx context.Context, id uint64, edit func(lyrics []string) ([]string, error), align func(translation []string) []string) error {
+	tx, err := r.conn.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	var lyrics []string
+	err = tx.QueryRowContext(ctx, `SELECT lyrics FROM songs WHERE id = $1 FOR UPDATE`, id).
+		Scan(pq.Array(&lyrics))
+	if err == sql.ErrNoRows {
+		return repo.ErrNotFound
+	} else if err != nil {
+		return err
+	}
+
+	lyrics, err = edit(lyrics)
+	if err != nil {
+		return err
+	}
+	if lyrics == nil {
+		lyrics = []string{}
+	}
+
+	_, err = tx.ExecContext(ctx, `UPDATE songs SET lyrics = $1 WHERE id = $2`, pq.Array(lyrics), id)
+	if err != nil {
+		return err
+	}
+
+	if align != nil {
+		if err := alignTranslations(ctx, tx, id, align); err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
+
+func alignTranslations(ctx context.Context, tx *sql.Tx, id uint64, align func(translation []string) []string) error {
+	rows, err := tx.QueryContext(ctx, `SELECT lang, lyrics FROM song_translations WHERE song_id = $1`, id)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	translations := make([]*model.Translation, 0)
+	for rows.Next() {
+		translation := &model.Translation{}
+		if err := rows.Scan(&translation.Lang, pq.Array(&translation.Lyrics)); err != nil {
+			return err
+		}
+		translations = append(translations, translation)
+	}
+	if err := rows.Err(); err != nil {
+		return err
+	}
+
+	for _, translation := range translations {
+		lyrics := align(translation.Lyrics)
+		if lyrics == nil {
+			lyrics = []string{}
+		}
+
+		_, err := tx.ExecContext(
+			ctx,
+			`UPDATE song_translations SET lyrics = $1 WHERE song_id = $2 AND lang = $3`,
+			pq.Array(lyrics), id, translation.Lang,
+		)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
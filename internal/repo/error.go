@@ -4,3 +4,12 @@ import "errors"
 
 var ErrNotFound = errors.New("not found")
 var ErrAlreadyExists = errors.New("already exists")
+var ErrInUse = errors.New("still referenced")
+var ErrTrackTaken = errors.New("track position already taken")
+var ErrUnknownGenre = errors.New("unknown genre")
+var ErrSongNotFound = errors.New("song not found")
+var ErrPlaylistChanged = errors.New("playlist was changed concurrently")
+var ErrVerseNotFound = errors.New("verse not found")
+var ErrVerseCountMismatch = errors.New("verse count differs from the original lyrics")
+var ErrTranslationsMisaligned = errors.New("translations have a different number of verses than the lyrics")
+var ErrEnrichmentInProgress = errors.New("song is being enriched")
@@ -0,0 +1,65 @@
+package tracing
+
+import (
+	"context"
+	"fmt"
+	cfg "github.com/JMURv/effectiveMobile/pkg/config"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const (
+	ExporterNone   = "none"
+	ExporterStdout = "stdout"
+	ExporterOTLP   = "otlp"
+)
+
+// Setup installs the global tracer provider and the W3C trace context propagator.
+// The returned function flushes pending spans and must be called on shutdown
+func Setup(ctx context.Context, conf *cfg.TracingConfig) (func(context.Context) error, error) {
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(
+		propagation.TraceContext{},
+		propagation.Baggage{},
+	))
+
+	var exporter sdktrace.SpanExporter
+	var err error
+	switch conf.Exporter {
+	case ExporterNone, "":
+		return func(context.Context) error { return nil }, nil
+	case ExporterStdout:
+		exporter, err = stdouttrace.New(stdouttrace.WithPrettyPrint())
+	case ExporterOTLP:
+		opts := []otlptracehttp.Option{otlptracehttp.WithEndpoint(conf.Endpoint)}
+		if conf.Insecure {
+			opts = append(opts, otlptracehttp.WithInsecure())
+		}
+		exporter, err = otlptracehttp.New(ctx, opts...)
+	default:
+		return nil, fmt.Errorf("unknown tracing exporter %q", conf.Exporter)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	tp := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithResource(resource.NewSchemaless(semconv.ServiceName(conf.ServiceName))),
+		sdktrace.WithSampler(sdktrace.ParentBased(sdktrace.TraceIDRatioBased(conf.SampleRatio))),
+	)
+	otel.SetTracerProvider(tp)
+	return tp.Shutdown, nil
+}
+
+// RecordError marks the span as failed
+func RecordError(span trace.Span, err error) {
+	span.RecordError(err)
+	span.SetStatus(codes.Error, err.Error())
+}
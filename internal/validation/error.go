@@ -1,6 +1,87 @@
 package validation
 
-import "errors"
+import (
+	"errors"
+	utils "github.com/JMURv/effectiveMobile/pkg/utils/http"
+	"strings"
+)
 
 var ErrMissingGroup = errors.New("missing group")
 var ErrMissingSong = errors.New("missing song")
+var ErrMissingName = errors.New("missing name")
+var ErrEmptyPatch = errors.New("no fields to update")
+var ErrTooLong = errors.New("value is too long")
+var ErrTooManyVerses = errors.New("too many verses")
+var ErrTooManyLines = errors.New("too many lines")
+var ErrInvalidLink = errors.New("link must be an absolute http(s) URL")
+var ErrFutureReleaseDate = errors.New("release date is in the future")
+var ErrReleaseDateTooEarly = errors.New("release date is too early")
+var ErrMissingReleaseDate = errors.New("missing release date")
+var ErrMissingLyrics = errors.New("missing lyrics")
+var ErrEmptyVerse = errors.New("verse is empty")
+var ErrMissingLink = errors.New("missing link")
+var ErrMissingArtist = errors.New("missing artist")
+var ErrMissingTitle = errors.New("missing title")
+var ErrMissingAlbum = errors.New("missing album")
+var ErrInvalidDisc = errors.New("invalid disc number")
+var ErrInvalidTrackNumber = errors.New("invalid track number")
+var ErrTooManyLabels = errors.New("too many genres or tags")
+var ErrInvalidPage = errors.New("page must be positive")
+var ErrInvalidPageSize = errors.New("invalid page size")
+var ErrInvalidPosition = errors.New("position must be positive")
+var ErrInvalidMatch = errors.New(`match must be "any" or "all"`)
+var ErrInvalidSort = errors.New("invalid sort field")
+var ErrInvalidLanguage = errors.New("invalid BCP 47 language tag")
+
+// FieldError ties a validation error to the JSON pointer of the invalid field
+type FieldError struct {
+	Pointer string
+	Err     error
+}
+
+func (e *FieldError) Error() string {
+	return e.Pointer + ": " + e.Err.Error()
+}
+
+func (e *FieldError) Unwrap() error {
+	return e.Err
+}
+
+// Errors collects every invalid field of a request
+type Errors []*FieldError
+
+func (e Errors) Error() string {
+	msgs := make([]string, 0, len(e))
+	for _, err := range e {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+func (e Errors) Unwrap() []error {
+	res := make([]error, 0, len(e))
+	for _, err := range e {
+		res = append(res, err)
+	}
+	return res
+}
+
+func (e Errors) FieldErrors() []utils.FieldError {
+	res := make([]utils.FieldError, 0, len(e))
+	for _, err := range e {
+		res = append(res, utils.FieldError{Pointer: err.Pointer, Detail: err.Err.Error()})
+	}
+	return res
+}
+
+func (e *Errors) add(pointer string, err error) {
+	*e = append(*e, &FieldError{Pointer: pointer, Err: err})
+}
+
+// err returns nil when nothing was collected, so that callers can compare it with nil
+func (e Errors) err() error {
+	if len(e) == 0 {
+		return nil
+	}
+	return e
+}
@@ -0,0 +1,97 @@
+package validation
+
+import (
+	"github.com/JMURv/effectiveMobile/pkg/model"
+	"golang.org/x/text/language"
+	"golang.org/x/text/unicode/norm"
+	"strings"
+)
+
+// NormalizeSong brings the text fields to a canonical form before they are
+// validated and stored, so that stray spaces or a different Unicode composition
+// of the same name do not produce a duplicate song
+func NormalizeSong(req *model.Song) {
+	req.Group = NormalizeName(req.Group)
+	req.Song = NormalizeName(req.Song)
+	req.Link = strings.TrimSpace(req.Link)
+	for i := range req.Lyrics {
+		req.Lyrics[i] = NormalizeVerse(req.Lyrics[i])
+	}
+	if req.Album != nil && req.Album.Disc == 0 {
+		// Most albums have a single disc
+		req.Album.Disc = 1
+	}
+}
+
+func NormalizeSongPatch(req *model.SongPatch) {
+	if req.Group != nil {
+		*req.Group = NormalizeName(*req.Group)
+	}
+	if req.Song != nil {
+		*req.Song = NormalizeName(*req.Song)
+	}
+	if req.Link != nil {
+		*req.Link = strings.TrimSpace(*req.Link)
+	}
+	if req.Lyrics != nil {
+		for i := range *req.Lyrics {
+			(*req.Lyrics)[i] = NormalizeVerse((*req.Lyrics)[i])
+		}
+	}
+}
+
+func NormalizeAlbum(req *model.Album) {
+	req.Artist = NormalizeName(req.Artist)
+	req.Title = NormalizeName(req.Title)
+	req.CoverLink = strings.TrimSpace(req.CoverLink)
+}
+
+func NormalizePlaylist(req *model.Playlist) {
+	req.Name = NormalizeName(req.Name)
+	req.Description = strings.TrimSpace(req.Description)
+}
+
+func NormalizeSyncedLyrics(lines []*model.SyncedLine) {
+	for _, line := range lines {
+		line.Text = NormalizeName(line.Text)
+	}
+}
+
+// NormalizeTranslation brings the language tag to its canonical form, e.g. "EN-us" to "en-US",
+// so that the same language is not stored twice. Invalid tags are left for ValidateTranslation
+func NormalizeTranslation(req *model.Translation) {
+	req.Lang = NormalizeLanguage(req.Lang)
+	for i := range req.Lyrics {
+		req.Lyrics[i] = NormalizeVerse(req.Lyrics[i])
+	}
+}
+
+// NormalizeLanguage returns the canonical form of the language tag or the tag as is when it is invalid
+func NormalizeLanguage(lang string) string {
+	lang = strings.TrimSpace(lang)
+	if tag, err := language.Parse(lang); err == nil {
+		return tag.String()
+	}
+	return lang
+}
+
+func NormalizeLabels(names []string) {
+	for i := range names {
+		names[i] = NormalizeName(names[i])
+	}
+}
+
+// NormalizeName trims the name, collapses whitespace runs into a single space
+// and converts it to Unicode NFC
+func NormalizeName(s string) string {
+	return strings.Join(strings.Fields(norm.NFC.String(s)), " ")
+}
+
+// NormalizeVerse normalizes every line of the verse like a name, keeping the line breaks
+func NormalizeVerse(s string) string {
+	lines := strings.Split(strings.TrimSpace(s), "\n")
+	for i, line := range lines {
+		lines[i] = NormalizeName(line)
+	}
+	return strings.Join(lines, "\n")
+}
@@ -1,17 +1,379 @@
 package validation
 
 import (
+	"fmt"
 	"github.com/JMURv/effectiveMobile/pkg/model"
+	"github.com/JMURv/effectiveMobile/pkg/utils/keyset"
+	"golang.org/x/text/language"
+	"net/url"
+	"strings"
+	"time"
+	"unicode/utf8"
 )
 
+const (
+	MaxGroupLength = 255
+	MaxSongLength  = 255
+	MaxLinkLength  = 2048
+	MaxVerses      = 500
+	MaxVerseLength = 5000
+	MaxTitleLength = 255
+	MaxDisc        = 99
+	MaxTrackNumber = 999
+	MaxLabelLength = 64
+	MaxLabels      = 50
+	MaxPageSize    = 100
+
+	MaxDescriptionLength = 2000
+	MaxSyncedLines       = 2000
+	MaxSyncedLineLength  = 500
+)
+
+// MinReleaseDate is around the earliest sound recordings
+var MinReleaseDate = time.Date(1860, time.January, 1, 0, 0, 0, 0, time.UTC)
+
+// ValidateSong checks a new song and returns Errors listing all the invalid fields.
+// Release date, lyrics and link may be left out, the enrichment fills them in
 func ValidateSong(req *model.Song) error {
+	var errs Errors
+	checkNames(&errs, req)
+	if !req.ReleaseDate.IsZero() {
+		checkReleaseDate(&errs, "/release_date", req.ReleaseDate)
+	}
+	checkLyrics(&errs, req.Lyrics)
+	if req.Link != "" {
+		checkLink(&errs, "/link", req.Link)
+	}
+	if req.Album != nil {
+		checkTrack(&errs, req.Album)
+	}
+	return errs.err()
+}
+
+// ValidateSongUpdate checks a full replacement of a song, where every field is required
+func ValidateSongUpdate(req *model.Song) error {
+	var errs Errors
+	checkNames(&errs, req)
+
+	if req.ReleaseDate.IsZero() {
+		errs.add("/release_date", ErrMissingReleaseDate)
+	} else {
+		checkReleaseDate(&errs, "/release_date", req.ReleaseDate)
+	}
+
+	if len(req.Lyrics) == 0 {
+		errs.add("/lyrics", ErrMissingLyrics)
+	} else {
+		checkLyrics(&errs, req.Lyrics)
+	}
+
+	if req.Link == "" {
+		errs.add("/link", ErrMissingLink)
+	} else {
+		checkLink(&errs, "/link", req.Link)
+	}
+	return errs.err()
+}
+
+func ValidateSongPatch(req *model.SongPatch) error {
+	if req.Group == nil && req.Song == nil && req.ReleaseDate == nil && req.Lyrics == nil && req.Link == nil {
+		return ErrEmptyPatch
+	}
+
+	var errs Errors
+	if req.Group != nil {
+		if *req.Group == "" {
+			errs.add("/group", ErrMissingGroup)
+		}
+		checkLength(&errs, "/group", *req.Group, MaxGroupLength)
+	}
+
+	if req.Song != nil {
+		if *req.Song == "" {
+			errs.add("/song", ErrMissingSong)
+		}
+		checkLength(&errs, "/song", *req.Song, MaxSongLength)
+	}
+
+	if req.ReleaseDate != nil {
+		checkReleaseDate(&errs, "/release_date", *req.ReleaseDate)
+	}
+	if req.Lyrics != nil {
+		if len(*req.Lyrics) == 0 {
+			errs.add("/lyrics", ErrMissingLyrics)
+		}
+		checkLyrics(&errs, *req.Lyrics)
+	}
+	if req.Link != nil {
+		if *req.Link == "" {
+			errs.add("/link", ErrMissingLink)
+		} else {
+			checkLink(&errs, "/link", *req.Link)
+		}
+	}
+	return errs.err()
+}
+
+func ValidateArtist(req *model.Artist) error {
+	var errs Errors
+	if req.Name == "" {
+		errs.add("/name", ErrMissingName)
+	}
+	checkLength(&errs, "/name", req.Name, MaxGroupLength)
+	return errs.err()
+}
+
+// ValidateAlbum checks a new or replaced album, release date and cover link are optional
+func ValidateAlbum(req *model.Album) error {
+	var errs Errors
+	if req.Artist == "" {
+		errs.add("/artist", ErrMissingArtist)
+	}
+	checkLength(&errs, "/artist", req.Artist, MaxGroupLength)
+
+	if req.Title == "" {
+		errs.add("/title", ErrMissingTitle)
+	}
+	checkLength(&errs, "/title", req.Title, MaxTitleLength)
+
+	if !req.ReleaseDate.IsZero() {
+		checkReleaseDate(&errs, "/release_date", req.ReleaseDate)
+	}
+	if req.CoverLink != "" {
+		checkLink(&errs, "/cover_link", req.CoverLink)
+	}
+	return errs.err()
+}
+
+func ValidatePlaylist(req *model.Playlist) error {
+	var errs Errors
+	if req.Name == "" {
+		errs.add("/name", ErrMissingName)
+	}
+	checkLength(&errs, "/name", req.Name, MaxTitleLength)
+	checkLength(&errs, "/description", req.Description, MaxDescriptionLength)
+	return errs.err()
+}
+
+// ValidatePlaylistCopy checks the name of a playlist copy, empty means the original name
+func ValidatePlaylistCopy(name string) error {
+	var errs Errors
+	checkLength(&errs, "/name", name, MaxTitleLength)
+	return errs.err()
+}
+
+// ValidatePlaylistSong checks a song added to a playlist, position 0 appends it
+func ValidatePlaylistSong(songID uint64, position int) error {
+	var errs Errors
+	if songID == 0 {
+		errs.add("/song_id", ErrMissingSong)
+	}
+	if position < 0 {
+		errs.add("/position", ErrInvalidPosition)
+	}
+	return errs.err()
+}
+
+// ValidateTrack checks the disc and track number a song is put on an album at
+func ValidateTrack(disc, trackNumber int) error {
+	var errs Errors
+	checkTrackPosition(&errs, "", disc, trackNumber)
+	return errs.err()
+}
+
+func ValidatePosition(position int) error {
+	var errs Errors
+	if position < 1 {
+		errs.add("/position", ErrInvalidPosition)
+	}
+	return errs.err()
+}
+
+// ValidateVerse checks a verse of the lyrics, position 0 appends it
+func ValidateVerse(req *model.Verse) error {
+	var errs Errors
+	if req.Text == "" {
+		errs.add("/text", ErrEmptyVerse)
+	}
+	checkLength(&errs, "/text", req.Text, MaxVerseLength)
+	if req.Position < 0 {
+		errs.add("/position", ErrInvalidPosition)
+	}
+	return errs.err()
+}
+
+// ValidateSyncedLyrics checks the lines read from an LRC file, pointers are the line indexes
+func ValidateSyncedLyrics(lines []*model.SyncedLine) error {
+	var errs Errors
+	if len(lines) > MaxSyncedLines {
+		errs.add("/lines", fmt.Errorf("%w: at most %d", ErrTooManyLines, MaxSyncedLines))
+		return errs.err()
+	}
+	for i, line := range lines {
+		checkLength(&errs, fmt.Sprintf("/lines/%d", i), line.Text, MaxSyncedLineLength)
+	}
+	return errs.err()
+}
+
+// ValidateTranslation checks a translation of the lyrics. Verses may be empty,
+// as the ones added to the original after the translation are yet to be translated
+func ValidateTranslation(req *model.Translation) error {
+	var errs Errors
+	if _, err := language.Parse(req.Lang); err != nil {
+		errs.add("/lang", ErrInvalidLanguage)
+	}
+
+	if len(req.Lyrics) == 0 {
+		errs.add("/lyrics", ErrMissingLyrics)
+	}
+	if len(req.Lyrics) > MaxVerses {
+		errs.add("/lyrics", fmt.Errorf("%w: at most %d", ErrTooManyVerses, MaxVerses))
+	}
+	for i, verse := range req.Lyrics {
+		checkLength(&errs, fmt.Sprintf("/lyrics/%d", i), verse, MaxVerseLength)
+	}
+	return errs.err()
+}
+
+func ValidateGenre(req *model.Genre) error {
+	var errs Errors
+	if req.Name == "" {
+		errs.add("/name", ErrMissingName)
+	}
+	checkLength(&errs, "/name", req.Name, MaxLabelLength)
+	return errs.err()
+}
+
+// ValidateLabels checks the genres or tags given to a song, pointer names the list field
+func ValidateLabels(pointer string, names []string) error {
+	var errs Errors
+	if len(names) > MaxLabels {
+		errs.add(pointer, fmt.Errorf("%w: at most %d", ErrTooManyLabels, MaxLabels))
+	}
+
+	for i, name := range names {
+		item := fmt.Sprintf("%s/%d", pointer, i)
+		if name == "" {
+			errs.add(item, ErrMissingName)
+		}
+		checkLength(&errs, item, name, MaxLabelLength)
+	}
+	return errs.err()
+}
+
+// ValidatePage checks the page number and size of a paginated list
+func ValidatePage(page, size int) error {
+	if page < 1 {
+		return ErrInvalidPage
+	}
+	if size < 1 || size > MaxPageSize {
+		return fmt.Errorf("%w: from 1 to %d", ErrInvalidPageSize, MaxPageSize)
+	}
+	return nil
+}
+
+// ValidateMatch checks the match mode of the genre and tag filters, empty means any
+func ValidateMatch(match string) error {
+	switch match {
+	case "", model.MatchAny, model.MatchAll:
+		return nil
+	default:
+		return ErrInvalidMatch
+	}
+}
+
+// ValidateSort checks a comma separated list of sort fields like "-release_date,group"
+func ValidateSort(sort string) error {
+	if sort == "" {
+		return nil
+	}
+
+	for _, field := range strings.Split(sort, ",") {
+		field = strings.TrimPrefix(strings.TrimPrefix(strings.TrimSpace(field), "-"), "+")
+		if _, ok := model.SortColumns[field]; !ok {
+			return fmt.Errorf("%w: %q", ErrInvalidSort, field)
+		}
+	}
+	return nil
+}
+
+// ValidateCursor checks that the cursor is a well-formed token, the boundary values are checked by the repository
+func ValidateCursor(cursor string) error {
+	if cursor == "" {
+		return nil
+	}
+
+	c, err := keyset.Decode(cursor)
+	if err != nil {
+		return err
+	}
+	if ValidateSort(c.Sort) != nil {
+		return keyset.ErrInvalidCursor
+	}
+	return nil
+}
+
+func checkNames(errs *Errors, req *model.Song) {
 	if req.Group == "" {
-		return ErrMissingGroup
+		errs.add("/group", ErrMissingGroup)
 	}
+	checkLength(errs, "/group", req.Group, MaxGroupLength)
 
 	if req.Song == "" {
-		return ErrMissingSong
+		errs.add("/song", ErrMissingSong)
 	}
+	checkLength(errs, "/song", req.Song, MaxSongLength)
+}
 
-	return nil
+func checkLength(errs *Errors, pointer, val string, limit int) {
+	if utf8.RuneCountInString(val) > limit {
+		errs.add(pointer, fmt.Errorf("%w: at most %d characters", ErrTooLong, limit))
+	}
+}
+
+func checkReleaseDate(errs *Errors, pointer string, date time.Time) {
+	switch {
+	case date.Before(MinReleaseDate):
+		errs.add(pointer, fmt.Errorf("%w: not before %s", ErrReleaseDateTooEarly, MinReleaseDate.Format(time.DateOnly)))
+	case date.After(time.Now()):
+		errs.add(pointer, ErrFutureReleaseDate)
+	}
+}
+
+func checkLyrics(errs *Errors, lyrics []string) {
+	if len(lyrics) > MaxVerses {
+		errs.add("/lyrics", fmt.Errorf("%w: at most %d", ErrTooManyVerses, MaxVerses))
+	}
+
+	for i, verse := range lyrics {
+		pointer := fmt.Sprintf("/lyrics/%d", i)
+		if verse == "" {
+			errs.add(pointer, ErrEmptyVerse)
+		}
+		checkLength(errs, pointer, verse, MaxVerseLength)
+	}
+}
+
+func checkLink(errs *Errors, pointer, link string) {
+	checkLength(errs, pointer, link, MaxLinkLength)
+	u, err := url.ParseRequestURI(link)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		errs.add(pointer, ErrInvalidLink)
+	}
+}
+
+func checkTrack(errs *Errors, track *model.AlbumTrack) {
+	if track.AlbumID == 0 {
+		errs.add("/album/album_id", ErrMissingAlbum)
+	}
+	checkTrackPosition(errs, "/album", track.Disc, track.TrackNumber)
+}
+
+func checkTrackPosition(errs *Errors, pointer string, disc, trackNumber int) {
+	if disc < 1 || disc > MaxDisc {
+		errs.add(pointer+"/disc", fmt.Errorf("%w: from 1 to %d", ErrInvalidDisc, MaxDisc))
+	}
+	if trackNumber < 1 || trackNumber > MaxTrackNumber {
+		errs.add(pointer+"/track_number", fmt.Errorf("%w: from 1 to %d", ErrInvalidTrackNumber, MaxTrackNumber))
+	}
 }
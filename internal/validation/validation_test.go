@@ -0,0 +1,279 @@
+package validation
+
+import (
+	"errors"
+	"github.com/JMURv/effectiveMobile/pkg/model"
+	"github.com/JMURv/effectiveMobile/pkg/utils/keyset"
+	"github.com/stretchr/testify/assert"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestValidateSong(t *testing.T) {
+	t.Run("Valid", func(t *testing.T) {
+		assert.Nil(t, ValidateSong(&model.Song{
+			Group:       "group",
+			Song:        "song",
+			ReleaseDate: time.Date(2006, 7, 16, 0, 0, 0, 0, time.UTC),
+			Lyrics:      []string{"verse"},
+			Link:        "https://example.com/song",
+		}))
+	})
+
+	t.Run("AllFieldsCollected", func(t *testing.T) {
+		err := ValidateSong(&model.Song{
+			Group:       strings.Repeat("я", MaxGroupLength+1),
+			ReleaseDate: time.Now().Add(time.Hour),
+			Lyrics:      []string{"ok", strings.Repeat("a", MaxVerseLength+1)},
+			Link:        "not a url",
+		})
+
+		var errs Errors
+		assert.True(t, errors.As(err, &errs))
+		assert.Equal(t, []string{"/group", "/song", "/release_date", "/lyrics/1", "/link"}, pointers(errs))
+		assert.ErrorIs(t, err, ErrMissingSong)
+		assert.ErrorIs(t, err, ErrTooLong)
+		assert.ErrorIs(t, err, ErrFutureReleaseDate)
+		assert.ErrorIs(t, err, ErrInvalidLink)
+	})
+
+	t.Run("LengthCountsCharacters", func(t *testing.T) {
+		assert.Nil(t, ValidateSong(&model.Song{Group: strings.Repeat("я", MaxGroupLength), Song: "song"}))
+	})
+}
+
+func TestValidateSongPatch(t *testing.T) {
+	assert.ErrorIs(t, ValidateSongPatch(&model.SongPatch{}), ErrEmptyPatch)
+
+	empty, link := "", "mailto:someone@example.com"
+	err := ValidateSongPatch(&model.SongPatch{Song: &empty, Link: &link})
+
+	var errs Errors
+	assert.True(t, errors.As(err, &errs))
+	assert.Equal(t, []string{"/song", "/link"}, pointers(errs))
+	assert.Len(t, errs.FieldErrors(), 2)
+}
+
+func pointers(errs Errors) []string {
+	res := make([]string, 0, len(errs))
+	for _, err := range errs {
+		res = append(res, err.Pointer)
+	}
+	return res
+}
+
+func TestValidateSongUpdate(t *testing.T) {
+	valid := func() *model.Song {
+		return &model.Song{
+			Group:       "group",
+			Song:        "song",
+			ReleaseDate: time.Date(2006, 7, 16, 0, 0, 0, 0, time.UTC),
+			Lyrics:      []string{"verse"},
+			Link:        "https://example.com/song",
+		}
+	}
+	assert.Nil(t, ValidateSongUpdate(valid()))
+
+	t.Run("MissingFields", func(t *testing.T) {
+		err := ValidateSongUpdate(&model.Song{Group: "group", Song: "song"})
+
+		var errs Errors
+		assert.True(t, errors.As(err, &errs))
+		assert.Equal(t, []string{"/release_date", "/lyrics", "/link"}, pointers(errs))
+	})
+
+	t.Run("InvalidFields", func(t *testing.T) {
+		req := valid()
+		req.ReleaseDate = time.Date(1700, 1, 1, 0, 0, 0, 0, time.UTC)
+		req.Lyrics = []string{"verse", ""}
+		req.Link = "https://"
+
+		err := ValidateSongUpdate(req)
+		assert.ErrorIs(t, err, ErrReleaseDateTooEarly)
+		assert.ErrorIs(t, err, ErrEmptyVerse)
+		assert.ErrorIs(t, err, ErrInvalidLink)
+	})
+
+	t.Run("TooManyVerses", func(t *testing.T) {
+		req := valid()
+		req.Lyrics = make([]string, MaxVerses+1)
+		for i := range req.Lyrics {
+			req.Lyrics[i] = "verse"
+		}
+		assert.ErrorIs(t, ValidateSongUpdate(req), ErrTooManyVerses)
+	})
+}
+
+func TestValidateArtist(t *testing.T) {
+	assert.Nil(t, ValidateArtist(&model.Artist{Name: "Queen"}))
+	assert.ErrorIs(t, ValidateArtist(&model.Artist{}), ErrMissingName)
+	assert.ErrorIs(t, ValidateArtist(&model.Artist{Name: strings.Repeat("a", MaxGroupLength+1)}), ErrTooLong)
+}
+
+func TestValidateAlbum(t *testing.T) {
+	assert.Nil(t, ValidateAlbum(&model.Album{Artist: "Queen", Title: "Jazz"}))
+
+	err := ValidateAlbum(&model.Album{
+		ReleaseDate: time.Now().Add(48 * time.Hour),
+		CoverLink:   "ftp://example.com/jazz.jpg",
+	})
+
+	var errs Errors
+	assert.True(t, errors.As(err, &errs))
+	assert.Equal(t, []string{"/artist", "/title", "/release_date", "/cover_link"}, pointers(errs))
+}
+
+func TestValidateSong_Album(t *testing.T) {
+	valid := &model.Song{Group: "group", Song: "song", Album: &model.AlbumTrack{AlbumID: 1, Disc: 1, TrackNumber: 1}}
+	assert.Nil(t, ValidateSong(valid))
+
+	err := ValidateSong(&model.Song{Group: "group", Song: "song", Album: &model.AlbumTrack{Disc: MaxDisc + 1}})
+
+	var errs Errors
+	assert.True(t, errors.As(err, &errs))
+	assert.Equal(t, []string{"/album/album_id", "/album/disc", "/album/track_number"}, pointers(errs))
+}
+
+func TestValidateTrack(t *testing.T) {
+	assert.Nil(t, ValidateTrack(1, 1))
+
+	err := ValidateTrack(0, MaxTrackNumber+1)
+	var errs Errors
+	assert.True(t, errors.As(err, &errs))
+	assert.Equal(t, []string{"/disc", "/track_number"}, pointers(errs))
+}
+
+func TestValidateLabels(t *testing.T) {
+	assert.Nil(t, ValidateLabels("/tags", []string{"90s", "workout"}))
+	assert.Nil(t, ValidateLabels("/tags", nil))
+
+	err := ValidateLabels("/genres", []string{"rock", "", strings.Repeat("a", MaxLabelLength+1)})
+	var errs Errors
+	assert.True(t, errors.As(err, &errs))
+	assert.Equal(t, []string{"/genres/1", "/genres/2"}, pointers(errs))
+
+	assert.ErrorIs(t, ValidateLabels("/tags", make([]string, MaxLabels+1)), ErrTooManyLabels)
+}
+
+func TestValidatePage(t *testing.T) {
+	assert.Nil(t, ValidatePage(1, 40))
+	assert.Nil(t, ValidatePage(3, MaxPageSize))
+	assert.ErrorIs(t, ValidatePage(0, 40), ErrInvalidPage)
+	assert.ErrorIs(t, ValidatePage(1, 0), ErrInvalidPageSize)
+	assert.ErrorIs(t, ValidatePage(1, -5), ErrInvalidPageSize)
+	assert.ErrorIs(t, ValidatePage(1, MaxPageSize+1), ErrInvalidPageSize)
+}
+
+func TestValidateMatch(t *testing.T) {
+	assert.Nil(t, ValidateMatch(""))
+	assert.Nil(t, ValidateMatch("any"))
+	assert.Nil(t, ValidateMatch("all"))
+	assert.Equal(t, ErrInvalidMatch, ValidateMatch("none"))
+}
+
+func TestValidateSort(t *testing.T) {
+	assert.Nil(t, ValidateSort(""))
+	assert.Nil(t, ValidateSort("-release_date, group,+id"))
+	assert.ErrorIs(t, ValidateSort("group,lyrics"), ErrInvalidSort)
+}
+
+func TestValidateCursor(t *testing.T) {
+	assert.Nil(t, ValidateCursor(""))
+	id := "1"
+	assert.Nil(t, ValidateCursor(keyset.Encode(&keyset.Cursor{Sort: "-release_date", Values: []*string{nil, &id}})))
+	assert.ErrorIs(t, ValidateCursor("invalid!"), keyset.ErrInvalidCursor)
+	assert.ErrorIs(t, ValidateCursor(keyset.Encode(&keyset.Cursor{Sort: "lyrics"})), keyset.ErrInvalidCursor)
+}
+
+func TestValidatePlaylist(t *testing.T) {
+	assert.Nil(t, ValidatePlaylist(&model.Playlist{Name: "Road trip"}))
+
+	err := ValidatePlaylist(&model.Playlist{Description: strings.Repeat("a", MaxDescriptionLength+1)})
+	var errs Errors
+	assert.True(t, errors.As(err, &errs))
+	assert.Equal(t, []string{"/name", "/description"}, pointers(errs))
+
+	assert.Nil(t, ValidatePlaylistCopy(""))
+	assert.NotNil(t, ValidatePlaylistCopy(strings.Repeat("a", MaxTitleLength+1)))
+}
+
+func TestValidatePlaylistSong(t *testing.T) {
+	assert.Nil(t, ValidatePlaylistSong(1, 0))
+	assert.Nil(t, ValidatePlaylistSong(1, 3))
+
+	err := ValidatePlaylistSong(0, -1)
+	var errs Errors
+	assert.True(t, errors.As(err, &errs))
+	assert.Equal(t, []string{"/song_id", "/position"}, pointers(errs))
+
+	assert.Nil(t, ValidatePosition(1))
+	assert.ErrorIs(t, ValidatePosition(0), ErrInvalidPosition)
+}
+
+func TestValidateVerse(t *testing.T) {
+	assert.Nil(t, ValidateVerse(&model.Verse{Text: "chorus"}))
+
+	err := ValidateVerse(&model.Verse{Position: -1})
+	var errs Errors
+	assert.True(t, errors.As(err, &errs))
+	assert.Equal(t, []string{"/text", "/position"}, pointers(errs))
+
+	assert.ErrorIs(t, ValidateVerse(&model.Verse{Text: strings.Repeat("a", MaxVerseLength+1)}), ErrTooLong)
+}
+
+func TestValidateSyncedLyrics(t *testing.T) {
+	assert.Nil(t, ValidateSyncedLyrics([]*model.SyncedLine{{TimeMs: 0, Text: ""}, {TimeMs: 100, Text: "line"}}))
+
+	err := ValidateSyncedLyrics([]*model.SyncedLine{{Text: "ok"}, {Text: strings.Repeat("a", MaxSyncedLineLength+1)}})
+	var errs Errors
+	assert.True(t, errors.As(err, &errs))
+	assert.Equal(t, []string{"/lines/1"}, pointers(errs))
+
+	assert.ErrorIs(t, ValidateSyncedLyrics(make([]*model.SyncedLine, MaxSyncedLines+1)), ErrTooManyLines)
+}
+
+func TestValidateTranslation(t *testing.T) {
+	assert.Nil(t, ValidateTranslation(&model.Translation{Lang: "pt-BR", Lyrics: []string{"verso", ""}}))
+
+	err := ValidateTranslation(&model.Translation{Lang: "english", Lyrics: []string{"ok", strings.Repeat("a", MaxVerseLength+1)}})
+	var errs Errors
+	assert.True(t, errors.As(err, &errs))
+	assert.Equal(t, []string{"/lang", "/lyrics/1"}, pointers(errs))
+	assert.ErrorIs(t, err, ErrInvalidLanguage)
+
+	assert.ErrorIs(t, ValidateTranslation(&model.Translation{Lang: "de"}), ErrMissingLyrics)
+	assert.ErrorIs(t, ValidateTranslation(&model.Translation{Lang: "de", Lyrics: make([]string, MaxVerses+1)}), ErrTooManyVerses)
+}
+
+func TestNormalizeTranslation(t *testing.T) {
+	req := &model.Translation{Lang: " EN-gb ", Lyrics: []string{"  one \n  two  ", ""}}
+	NormalizeTranslation(req)
+	assert.Equal(t, &model.Translation{Lang: "en-GB", Lyrics: []string{"one\ntwo", ""}}, req)
+
+	assert.Equal(t, "english", NormalizeLanguage(" english "))
+}
+
+func TestNormalizeSong(t *testing.T) {
+	req := &model.Song{
+		Group:  "  The\tBeatles  ",
+		Song:   "Cafe\u0301  del   Mar",
+		Lyrics: []string{"  first  line \r\n  second\t line  "},
+		Link:   " https://example.com ",
+	}
+	NormalizeSong(req)
+
+	assert.Equal(t, "The Beatles", req.Group)
+	assert.Equal(t, "Caf\u00e9 del Mar", req.Song)
+	assert.Equal(t, []string{"first line\nsecond line"}, req.Lyrics)
+	assert.Equal(t, "https://example.com", req.Link)
+
+	group := " a  b "
+	patch := &model.SongPatch{Group: &group}
+	NormalizeSongPatch(patch)
+	assert.Equal(t, "a b", *patch.Group)
+
+	withAlbum := &model.Song{Album: &model.AlbumTrack{AlbumID: 1, TrackNumber: 2}}
+	NormalizeSong(withAlbum)
+	assert.Equal(t, 1, withAlbum.Album.Disc)
+}
@@ -0,0 +1,71 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./internal/ctrl/external/cache.go
+//
+// Generated by this command:
+//
+//	mockgen -source=./internal/ctrl/external/cache.go -destination=mocks/mock_cache.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	model "github.com/JMURv/effectiveMobile/pkg/model"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockDetailCacheRepo is a mock of DetailCacheRepo interface.
+type MockDetailCacheRepo struct {
+	ctrl     *gomock.Controller
+	recorder *MockDetailCacheRepoMockRecorder
+}
+
+// MockDetailCacheRepoMockRecorder is the mock recorder for MockDetailCacheRepo.
+type MockDetailCacheRepoMockRecorder struct {
+	mock *MockDetailCacheRepo
+}
+
+// NewMockDetailCacheRepo creates a new mock instance.
+func NewMockDetailCacheRepo(ctrl *gomock.Controller) *MockDetailCacheRepo {
+	mock := &MockDetailCacheRepo{ctrl: ctrl}
+	mock.recorder = &MockDetailCacheRepoMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockDetailCacheRepo) EXPECT() *MockDetailCacheRepoMockRecorder {
+	return m.recorder
+}
+
+// GetCachedSongDetail mocks base method.
+func (m *MockDetailCacheRepo) GetCachedSongDetail(ctx context.Context, group, song string) (*model.SongDetail, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCachedSongDetail", ctx, group, song)
+	ret0, _ := ret[0].(*model.SongDetail)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCachedSongDetail indicates an expected call of GetCachedSongDetail.
+func (mr *MockDetailCacheRepoMockRecorder) GetCachedSongDetail(ctx, group, song any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCachedSongDetail", reflect.TypeOf((*MockDetailCacheRepo)(nil).GetCachedSongDetail), ctx, group, song)
+}
+
+// SaveCachedSongDetail mocks base method.
+func (m *MockDetailCacheRepo) SaveCachedSongDetail(ctx context.Context, group, song string, req *model.SongDetail, ttl time.Duration) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveCachedSongDetail", ctx, group, song, req, ttl)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SaveCachedSongDetail indicates an expected call of SaveCachedSongDetail.
+func (mr *MockDetailCacheRepoMockRecorder) SaveCachedSongDetail(ctx, group, song, req, ttl any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveCachedSongDetail", reflect.TypeOf((*MockDetailCacheRepo)(nil).SaveCachedSongDetail), ctx, group, song, req, ttl)
+}
@@ -15,6 +15,7 @@ import (
 
 	model "github.com/JMURv/effectiveMobile/pkg/model"
 	gomock "go.uber.org/mock/gomock"
+	language "golang.org/x/text/language"
 )
 
 // MockCtrl is a mock of Ctrl interface.
@@ -40,6 +41,81 @@ func (m *MockCtrl) EXPECT() *MockCtrlMockRecorder {
 	return m.recorder
 }
 
+// AddPlaylistSong mocks base method.
+func (m *MockCtrl) AddPlaylistSong(ctx context.Context, id, songID uint64, position int) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddPlaylistSong", ctx, id, songID, position)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AddPlaylistSong indicates an expected call of AddPlaylistSong.
+func (mr *MockCtrlMockRecorder) AddPlaylistSong(ctx, id, songID, position any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddPlaylistSong", reflect.TypeOf((*MockCtrl)(nil).AddPlaylistSong), ctx, id, songID, position)
+}
+
+// CreateAlbum mocks base method.
+func (m *MockCtrl) CreateAlbum(ctx context.Context, req *model.Album) (*model.Album, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateAlbum", ctx, req)
+	ret0, _ := ret[0].(*model.Album)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateAlbum indicates an expected call of CreateAlbum.
+func (mr *MockCtrlMockRecorder) CreateAlbum(ctx, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateAlbum", reflect.TypeOf((*MockCtrl)(nil).CreateAlbum), ctx, req)
+}
+
+// CreateArtist mocks base method.
+func (m *MockCtrl) CreateArtist(ctx context.Context, req *model.Artist) (*model.Artist, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateArtist", ctx, req)
+	ret0, _ := ret[0].(*model.Artist)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateArtist indicates an expected call of CreateArtist.
+func (mr *MockCtrlMockRecorder) CreateArtist(ctx, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateArtist", reflect.TypeOf((*MockCtrl)(nil).CreateArtist), ctx, req)
+}
+
+// CreateGenre mocks base method.
+func (m *MockCtrl) CreateGenre(ctx context.Context, req *model.Genre) (*model.Genre, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateGenre", ctx, req)
+	ret0, _ := ret[0].(*model.Genre)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateGenre indicates an expected call of CreateGenre.
+func (mr *MockCtrlMockRecorder) CreateGenre(ctx, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateGenre", reflect.TypeOf((*MockCtrl)(nil).CreateGenre), ctx, req)
+}
+
+// CreatePlaylist mocks base method.
+func (m *MockCtrl) CreatePlaylist(ctx context.Context, req *model.Playlist) (*model.Playlist, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreatePlaylist", ctx, req)
+	ret0, _ := ret[0].(*model.Playlist)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreatePlaylist indicates an expected call of CreatePlaylist.
+func (mr *MockCtrlMockRecorder) CreatePlaylist(ctx, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreatePlaylist", reflect.TypeOf((*MockCtrl)(nil).CreatePlaylist), ctx, req)
+}
+
 // CreateSong mocks base method.
 func (m *MockCtrl) CreateSong(ctx context.Context, req *model.Song) (uint64, error) {
 	m.ctrl.T.Helper()
@@ -55,6 +131,76 @@ func (mr *MockCtrlMockRecorder) CreateSong(ctx, req any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateSong", reflect.TypeOf((*MockCtrl)(nil).CreateSong), ctx, req)
 }
 
+// CreateTranslation mocks base method.
+func (m *MockCtrl) CreateTranslation(ctx context.Context, id uint64, req *model.Translation) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateTranslation", ctx, id, req)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateTranslation indicates an expected call of CreateTranslation.
+func (mr *MockCtrlMockRecorder) CreateTranslation(ctx, id, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateTranslation", reflect.TypeOf((*MockCtrl)(nil).CreateTranslation), ctx, id, req)
+}
+
+// DeleteAlbum mocks base method.
+func (m *MockCtrl) DeleteAlbum(ctx context.Context, id uint64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteAlbum", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteAlbum indicates an expected call of DeleteAlbum.
+func (mr *MockCtrlMockRecorder) DeleteAlbum(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteAlbum", reflect.TypeOf((*MockCtrl)(nil).DeleteAlbum), ctx, id)
+}
+
+// DeleteArtist mocks base method.
+func (m *MockCtrl) DeleteArtist(ctx context.Context, id uint64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteArtist", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteArtist indicates an expected call of DeleteArtist.
+func (mr *MockCtrlMockRecorder) DeleteArtist(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteArtist", reflect.TypeOf((*MockCtrl)(nil).DeleteArtist), ctx, id)
+}
+
+// DeleteGenre mocks base method.
+func (m *MockCtrl) DeleteGenre(ctx context.Context, id uint64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteGenre", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteGenre indicates an expected call of DeleteGenre.
+func (mr *MockCtrlMockRecorder) DeleteGenre(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteGenre", reflect.TypeOf((*MockCtrl)(nil).DeleteGenre), ctx, id)
+}
+
+// DeletePlaylist mocks base method.
+func (m *MockCtrl) DeletePlaylist(ctx context.Context, id uint64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeletePlaylist", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeletePlaylist indicates an expected call of DeletePlaylist.
+func (mr *MockCtrlMockRecorder) DeletePlaylist(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeletePlaylist", reflect.TypeOf((*MockCtrl)(nil).DeletePlaylist), ctx, id)
+}
+
 // DeleteSong mocks base method.
 func (m *MockCtrl) DeleteSong(ctx context.Context, id uint64) error {
 	m.ctrl.T.Helper()
@@ -69,19 +215,256 @@ func (mr *MockCtrlMockRecorder) DeleteSong(ctx, id any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteSong", reflect.TypeOf((*MockCtrl)(nil).DeleteSong), ctx, id)
 }
 
+// DeleteTag mocks base method.
+func (m *MockCtrl) DeleteTag(ctx context.Context, id uint64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteTag", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteTag indicates an expected call of DeleteTag.
+func (mr *MockCtrlMockRecorder) DeleteTag(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteTag", reflect.TypeOf((*MockCtrl)(nil).DeleteTag), ctx, id)
+}
+
+// DeleteTranslation mocks base method.
+func (m *MockCtrl) DeleteTranslation(ctx context.Context, id uint64, lang string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteTranslation", ctx, id, lang)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteTranslation indicates an expected call of DeleteTranslation.
+func (mr *MockCtrlMockRecorder) DeleteTranslation(ctx, id, lang any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteTranslation", reflect.TypeOf((*MockCtrl)(nil).DeleteTranslation), ctx, id, lang)
+}
+
+// DeleteVerse mocks base method.
+func (m *MockCtrl) DeleteVerse(ctx context.Context, id uint64, position int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteVerse", ctx, id, position)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteVerse indicates an expected call of DeleteVerse.
+func (mr *MockCtrlMockRecorder) DeleteVerse(ctx, id, position any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteVerse", reflect.TypeOf((*MockCtrl)(nil).DeleteVerse), ctx, id, position)
+}
+
+// DuplicatePlaylist mocks base method.
+func (m *MockCtrl) DuplicatePlaylist(ctx context.Context, id uint64, name string) (*model.Playlist, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DuplicatePlaylist", ctx, id, name)
+	ret0, _ := ret[0].(*model.Playlist)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DuplicatePlaylist indicates an expected call of DuplicatePlaylist.
+func (mr *MockCtrlMockRecorder) DuplicatePlaylist(ctx, id, name any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DuplicatePlaylist", reflect.TypeOf((*MockCtrl)(nil).DuplicatePlaylist), ctx, id, name)
+}
+
+// GetAlbum mocks base method.
+func (m *MockCtrl) GetAlbum(ctx context.Context, id uint64) (*model.Album, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAlbum", ctx, id)
+	ret0, _ := ret[0].(*model.Album)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAlbum indicates an expected call of GetAlbum.
+func (mr *MockCtrlMockRecorder) GetAlbum(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAlbum", reflect.TypeOf((*MockCtrl)(nil).GetAlbum), ctx, id)
+}
+
+// GetArtist mocks base method.
+func (m *MockCtrl) GetArtist(ctx context.Context, id uint64) (*model.Artist, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetArtist", ctx, id)
+	ret0, _ := ret[0].(*model.Artist)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetArtist indicates an expected call of GetArtist.
+func (mr *MockCtrlMockRecorder) GetArtist(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetArtist", reflect.TypeOf((*MockCtrl)(nil).GetArtist), ctx, id)
+}
+
+// GetPlaylist mocks base method.
+func (m *MockCtrl) GetPlaylist(ctx context.Context, id uint64) (*model.Playlist, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPlaylist", ctx, id)
+	ret0, _ := ret[0].(*model.Playlist)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPlaylist indicates an expected call of GetPlaylist.
+func (mr *MockCtrlMockRecorder) GetPlaylist(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPlaylist", reflect.TypeOf((*MockCtrl)(nil).GetPlaylist), ctx, id)
+}
+
 // GetSong mocks base method.
-func (m *MockCtrl) GetSong(ctx context.Context, id uint64, page, size int) (*model.PaginatedSongs, error) {
+func (m *MockCtrl) GetSong(ctx context.Context, id uint64, page, size int, langs []language.Tag) (*model.PaginatedSongs, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetSong", ctx, id, page, size)
+	ret := m.ctrl.Call(m, "GetSong", ctx, id, page, size, langs)
 	ret0, _ := ret[0].(*model.PaginatedSongs)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetSong indicates an expected call of GetSong.
-func (mr *MockCtrlMockRecorder) GetSong(ctx, id, page, size any) *gomock.Call {
+func (mr *MockCtrlMockRecorder) GetSong(ctx, id, page, size, langs any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSong", reflect.TypeOf((*MockCtrl)(nil).GetSong), ctx, id, page, size, langs)
+}
+
+// GetSongLyrics mocks base method.
+func (m *MockCtrl) GetSongLyrics(ctx context.Context, id uint64) (*model.SongLyrics, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSongLyrics", ctx, id)
+	ret0, _ := ret[0].(*model.SongLyrics)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSongLyrics indicates an expected call of GetSongLyrics.
+func (mr *MockCtrlMockRecorder) GetSongLyrics(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSongLyrics", reflect.TypeOf((*MockCtrl)(nil).GetSongLyrics), ctx, id)
+}
+
+// InsertVerse mocks base method.
+func (m *MockCtrl) InsertVerse(ctx context.Context, id uint64, position int, text string) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InsertVerse", ctx, id, position, text)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// InsertVerse indicates an expected call of InsertVerse.
+func (mr *MockCtrlMockRecorder) InsertVerse(ctx, id, position, text any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InsertVerse", reflect.TypeOf((*MockCtrl)(nil).InsertVerse), ctx, id, position, text)
+}
+
+// ListAlbumTracks mocks base method.
+func (m *MockCtrl) ListAlbumTracks(ctx context.Context, id uint64) ([]*model.Track, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListAlbumTracks", ctx, id)
+	ret0, _ := ret[0].([]*model.Track)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListAlbumTracks indicates an expected call of ListAlbumTracks.
+func (mr *MockCtrlMockRecorder) ListAlbumTracks(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListAlbumTracks", reflect.TypeOf((*MockCtrl)(nil).ListAlbumTracks), ctx, id)
+}
+
+// ListAlbums mocks base method.
+func (m *MockCtrl) ListAlbums(ctx context.Context, page, size int, title string) (*model.PaginatedAlbums, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListAlbums", ctx, page, size, title)
+	ret0, _ := ret[0].(*model.PaginatedAlbums)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListAlbums indicates an expected call of ListAlbums.
+func (mr *MockCtrlMockRecorder) ListAlbums(ctx, page, size, title any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListAlbums", reflect.TypeOf((*MockCtrl)(nil).ListAlbums), ctx, page, size, title)
+}
+
+// ListArtistSongs mocks base method.
+func (m *MockCtrl) ListArtistSongs(ctx context.Context, id uint64, page, size int, filters map[string]any) (*model.PaginatedSongs, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListArtistSongs", ctx, id, page, size, filters)
+	ret0, _ := ret[0].(*model.PaginatedSongs)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListArtistSongs indicates an expected call of ListArtistSongs.
+func (mr *MockCtrlMockRecorder) ListArtistSongs(ctx, id, page, size, filters any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSong", reflect.TypeOf((*MockCtrl)(nil).GetSong), ctx, id, page, size)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListArtistSongs", reflect.TypeOf((*MockCtrl)(nil).ListArtistSongs), ctx, id, page, size, filters)
+}
+
+// ListArtists mocks base method.
+func (m *MockCtrl) ListArtists(ctx context.Context, page, size int, name string) (*model.PaginatedArtists, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListArtists", ctx, page, size, name)
+	ret0, _ := ret[0].(*model.PaginatedArtists)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListArtists indicates an expected call of ListArtists.
+func (mr *MockCtrlMockRecorder) ListArtists(ctx, page, size, name any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListArtists", reflect.TypeOf((*MockCtrl)(nil).ListArtists), ctx, page, size, name)
+}
+
+// ListGenres mocks base method.
+func (m *MockCtrl) ListGenres(ctx context.Context) ([]*model.Genre, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListGenres", ctx)
+	ret0, _ := ret[0].([]*model.Genre)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListGenres indicates an expected call of ListGenres.
+func (mr *MockCtrlMockRecorder) ListGenres(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListGenres", reflect.TypeOf((*MockCtrl)(nil).ListGenres), ctx)
+}
+
+// ListPlaylistSongs mocks base method.
+func (m *MockCtrl) ListPlaylistSongs(ctx context.Context, id uint64, page, size int) (*model.PaginatedSongs, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListPlaylistSongs", ctx, id, page, size)
+	ret0, _ := ret[0].(*model.PaginatedSongs)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListPlaylistSongs indicates an expected call of ListPlaylistSongs.
+func (mr *MockCtrlMockRecorder) ListPlaylistSongs(ctx, id, page, size any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListPlaylistSongs", reflect.TypeOf((*MockCtrl)(nil).ListPlaylistSongs), ctx, id, page, size)
+}
+
+// ListPlaylists mocks base method.
+func (m *MockCtrl) ListPlaylists(ctx context.Context, page, size int, name string) (*model.PaginatedPlaylists, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListPlaylists", ctx, page, size, name)
+	ret0, _ := ret[0].(*model.PaginatedPlaylists)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListPlaylists indicates an expected call of ListPlaylists.
+func (mr *MockCtrlMockRecorder) ListPlaylists(ctx, page, size, name any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListPlaylists", reflect.TypeOf((*MockCtrl)(nil).ListPlaylists), ctx, page, size, name)
 }
 
 // ListSongs mocks base method.
@@ -99,6 +482,235 @@ func (mr *MockCtrlMockRecorder) ListSongs(ctx, page, size, filters any) *gomock.
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListSongs", reflect.TypeOf((*MockCtrl)(nil).ListSongs), ctx, page, size, filters)
 }
 
+// ListSongsCursor mocks base method.
+func (m *MockCtrl) ListSongsCursor(ctx context.Context, cursor string, limit int, withCount bool, filters map[string]any) (*model.CursorPaginatedSongs, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListSongsCursor", ctx, cursor, limit, withCount, filters)
+	ret0, _ := ret[0].(*model.CursorPaginatedSongs)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListSongsCursor indicates an expected call of ListSongsCursor.
+func (mr *MockCtrlMockRecorder) ListSongsCursor(ctx, cursor, limit, withCount, filters any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListSongsCursor", reflect.TypeOf((*MockCtrl)(nil).ListSongsCursor), ctx, cursor, limit, withCount, filters)
+}
+
+// ListTags mocks base method.
+func (m *MockCtrl) ListTags(ctx context.Context, name string) ([]*model.Tag, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListTags", ctx, name)
+	ret0, _ := ret[0].([]*model.Tag)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListTags indicates an expected call of ListTags.
+func (mr *MockCtrlMockRecorder) ListTags(ctx, name any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListTags", reflect.TypeOf((*MockCtrl)(nil).ListTags), ctx, name)
+}
+
+// ListVerses mocks base method.
+func (m *MockCtrl) ListVerses(ctx context.Context, id uint64, page, size int) (*model.PaginatedVerses, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListVerses", ctx, id, page, size)
+	ret0, _ := ret[0].(*model.PaginatedVerses)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListVerses indicates an expected call of ListVerses.
+func (mr *MockCtrlMockRecorder) ListVerses(ctx, id, page, size any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListVerses", reflect.TypeOf((*MockCtrl)(nil).ListVerses), ctx, id, page, size)
+}
+
+// MovePlaylistSong mocks base method.
+func (m *MockCtrl) MovePlaylistSong(ctx context.Context, id, songID uint64, position int) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MovePlaylistSong", ctx, id, songID, position)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// MovePlaylistSong indicates an expected call of MovePlaylistSong.
+func (mr *MockCtrlMockRecorder) MovePlaylistSong(ctx, id, songID, position any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MovePlaylistSong", reflect.TypeOf((*MockCtrl)(nil).MovePlaylistSong), ctx, id, songID, position)
+}
+
+// MoveVerse mocks base method.
+func (m *MockCtrl) MoveVerse(ctx context.Context, id uint64, position, to int) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MoveVerse", ctx, id, position, to)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// MoveVerse indicates an expected call of MoveVerse.
+func (mr *MockCtrlMockRecorder) MoveVerse(ctx, id, position, to any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MoveVerse", reflect.TypeOf((*MockCtrl)(nil).MoveVerse), ctx, id, position, to)
+}
+
+// PatchSong mocks base method.
+func (m *MockCtrl) PatchSong(ctx context.Context, id uint64, req *model.SongPatch) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PatchSong", ctx, id, req)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// PatchSong indicates an expected call of PatchSong.
+func (mr *MockCtrlMockRecorder) PatchSong(ctx, id, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PatchSong", reflect.TypeOf((*MockCtrl)(nil).PatchSong), ctx, id, req)
+}
+
+// RefreshSong mocks base method.
+func (m *MockCtrl) RefreshSong(ctx context.Context, id uint64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RefreshSong", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RefreshSong indicates an expected call of RefreshSong.
+func (mr *MockCtrlMockRecorder) RefreshSong(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RefreshSong", reflect.TypeOf((*MockCtrl)(nil).RefreshSong), ctx, id)
+}
+
+// RemoveAlbumTrack mocks base method.
+func (m *MockCtrl) RemoveAlbumTrack(ctx context.Context, id, songID uint64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemoveAlbumTrack", ctx, id, songID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RemoveAlbumTrack indicates an expected call of RemoveAlbumTrack.
+func (mr *MockCtrlMockRecorder) RemoveAlbumTrack(ctx, id, songID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveAlbumTrack", reflect.TypeOf((*MockCtrl)(nil).RemoveAlbumTrack), ctx, id, songID)
+}
+
+// RemovePlaylistSong mocks base method.
+func (m *MockCtrl) RemovePlaylistSong(ctx context.Context, id, songID uint64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemovePlaylistSong", ctx, id, songID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RemovePlaylistSong indicates an expected call of RemovePlaylistSong.
+func (mr *MockCtrlMockRecorder) RemovePlaylistSong(ctx, id, songID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemovePlaylistSong", reflect.TypeOf((*MockCtrl)(nil).RemovePlaylistSong), ctx, id, songID)
+}
+
+// SetAlbumTrack mocks base method.
+func (m *MockCtrl) SetAlbumTrack(ctx context.Context, id, songID uint64, disc, trackNumber int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetAlbumTrack", ctx, id, songID, disc, trackNumber)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetAlbumTrack indicates an expected call of SetAlbumTrack.
+func (mr *MockCtrlMockRecorder) SetAlbumTrack(ctx, id, songID, disc, trackNumber any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetAlbumTrack", reflect.TypeOf((*MockCtrl)(nil).SetAlbumTrack), ctx, id, songID, disc, trackNumber)
+}
+
+// SetSongGenres mocks base method.
+func (m *MockCtrl) SetSongGenres(ctx context.Context, id uint64, names []string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetSongGenres", ctx, id, names)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetSongGenres indicates an expected call of SetSongGenres.
+func (mr *MockCtrlMockRecorder) SetSongGenres(ctx, id, names any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetSongGenres", reflect.TypeOf((*MockCtrl)(nil).SetSongGenres), ctx, id, names)
+}
+
+// SetSongTags mocks base method.
+func (m *MockCtrl) SetSongTags(ctx context.Context, id uint64, names []string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetSongTags", ctx, id, names)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetSongTags indicates an expected call of SetSongTags.
+func (mr *MockCtrlMockRecorder) SetSongTags(ctx, id, names any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetSongTags", reflect.TypeOf((*MockCtrl)(nil).SetSongTags), ctx, id, names)
+}
+
+// SetSyncedLyrics mocks base method.
+func (m *MockCtrl) SetSyncedLyrics(ctx context.Context, id uint64, lines []*model.SyncedLine) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetSyncedLyrics", ctx, id, lines)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetSyncedLyrics indicates an expected call of SetSyncedLyrics.
+func (mr *MockCtrlMockRecorder) SetSyncedLyrics(ctx, id, lines any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetSyncedLyrics", reflect.TypeOf((*MockCtrl)(nil).SetSyncedLyrics), ctx, id, lines)
+}
+
+// UpdateAlbum mocks base method.
+func (m *MockCtrl) UpdateAlbum(ctx context.Context, req *model.Album) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateAlbum", ctx, req)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateAlbum indicates an expected call of UpdateAlbum.
+func (mr *MockCtrlMockRecorder) UpdateAlbum(ctx, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateAlbum", reflect.TypeOf((*MockCtrl)(nil).UpdateAlbum), ctx, req)
+}
+
+// UpdateArtist mocks base method.
+func (m *MockCtrl) UpdateArtist(ctx context.Context, req *model.Artist) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateArtist", ctx, req)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateArtist indicates an expected call of UpdateArtist.
+func (mr *MockCtrlMockRecorder) UpdateArtist(ctx, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateArtist", reflect.TypeOf((*MockCtrl)(nil).UpdateArtist), ctx, req)
+}
+
+// UpdatePlaylist mocks base method.
+func (m *MockCtrl) UpdatePlaylist(ctx context.Context, req *model.Playlist) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdatePlaylist", ctx, req)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdatePlaylist indicates an expected call of UpdatePlaylist.
+func (mr *MockCtrlMockRecorder) UpdatePlaylist(ctx, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdatePlaylist", reflect.TypeOf((*MockCtrl)(nil).UpdatePlaylist), ctx, req)
+}
+
 // UpdateSong mocks base method.
 func (m *MockCtrl) UpdateSong(ctx context.Context, req *model.Song) error {
 	m.ctrl.T.Helper()
@@ -112,3 +724,31 @@ func (mr *MockCtrlMockRecorder) UpdateSong(ctx, req any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateSong", reflect.TypeOf((*MockCtrl)(nil).UpdateSong), ctx, req)
 }
+
+// UpdateTranslation mocks base method.
+func (m *MockCtrl) UpdateTranslation(ctx context.Context, id uint64, req *model.Translation) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateTranslation", ctx, id, req)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateTranslation indicates an expected call of UpdateTranslation.
+func (mr *MockCtrlMockRecorder) UpdateTranslation(ctx, id, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateTranslation", reflect.TypeOf((*MockCtrl)(nil).UpdateTranslation), ctx, id, req)
+}
+
+// UpdateVerse mocks base method.
+func (m *MockCtrl) UpdateVerse(ctx context.Context, id uint64, position int, text string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateVerse", ctx, id, position, text)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateVerse indicates an expected call of UpdateVerse.
+func (mr *MockCtrlMockRecorder) UpdateVerse(ctx, id, position, text any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateVerse", reflect.TypeOf((*MockCtrl)(nil).UpdateVerse), ctx, id, position, text)
+}
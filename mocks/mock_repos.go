@@ -12,6 +12,7 @@ package mocks
 import (
 	context "context"
 	reflect "reflect"
+	time "time"
 
 	model "github.com/JMURv/effectiveMobile/pkg/model"
 	gomock "go.uber.org/mock/gomock"
@@ -40,6 +41,36 @@ func (m *MockSongsRepo) EXPECT() *MockSongsRepoMockRecorder {
 	return m.recorder
 }
 
+// ClaimEnrichment mocks base method.
+func (m *MockSongsRepo) ClaimEnrichment(ctx context.Context, id uint64) (*model.Song, int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ClaimEnrichment", ctx, id)
+	ret0, _ := ret[0].(*model.Song)
+	ret1, _ := ret[1].(int)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// ClaimEnrichment indicates an expected call of ClaimEnrichment.
+func (mr *MockSongsRepoMockRecorder) ClaimEnrichment(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ClaimEnrichment", reflect.TypeOf((*MockSongsRepo)(nil).ClaimEnrichment), ctx, id)
+}
+
+// CompleteEnrichment mocks base method.
+func (m *MockSongsRepo) CompleteEnrichment(ctx context.Context, req *model.Song, attempts int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CompleteEnrichment", ctx, req, attempts)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CompleteEnrichment indicates an expected call of CompleteEnrichment.
+func (mr *MockSongsRepoMockRecorder) CompleteEnrichment(ctx, req, attempts any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CompleteEnrichment", reflect.TypeOf((*MockSongsRepo)(nil).CompleteEnrichment), ctx, req, attempts)
+}
+
 // CreateSong mocks base method.
 func (m *MockSongsRepo) CreateSong(ctx context.Context, req *model.Song) (uint64, error) {
 	m.ctrl.T.Helper()
@@ -69,6 +100,20 @@ func (mr *MockSongsRepoMockRecorder) DeleteSong(ctx, id any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteSong", reflect.TypeOf((*MockSongsRepo)(nil).DeleteSong), ctx, id)
 }
 
+// FailEnrichment mocks base method.
+func (m *MockSongsRepo) FailEnrichment(ctx context.Context, id uint64, reason string, attempts int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FailEnrichment", ctx, id, reason, attempts)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// FailEnrichment indicates an expected call of FailEnrichment.
+func (mr *MockSongsRepoMockRecorder) FailEnrichment(ctx, id, reason, attempts any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FailEnrichment", reflect.TypeOf((*MockSongsRepo)(nil).FailEnrichment), ctx, id, reason, attempts)
+}
+
 // GetSong mocks base method.
 func (m *MockSongsRepo) GetSong(ctx context.Context, id uint64, page, size int) (*model.PaginatedSongs, error) {
 	m.ctrl.T.Helper()
@@ -84,6 +129,21 @@ func (mr *MockSongsRepoMockRecorder) GetSong(ctx, id, page, size any) *gomock.Ca
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSong", reflect.TypeOf((*MockSongsRepo)(nil).GetSong), ctx, id, page, size)
 }
 
+// ListPendingEnrichments mocks base method.
+func (m *MockSongsRepo) ListPendingEnrichments(ctx context.Context, limit int) ([]uint64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListPendingEnrichments", ctx, limit)
+	ret0, _ := ret[0].([]uint64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListPendingEnrichments indicates an expected call of ListPendingEnrichments.
+func (mr *MockSongsRepoMockRecorder) ListPendingEnrichments(ctx, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListPendingEnrichments", reflect.TypeOf((*MockSongsRepo)(nil).ListPendingEnrichments), ctx, limit)
+}
+
 // ListSongs mocks base method.
 func (m *MockSongsRepo) ListSongs(ctx context.Context, page, size int, filters map[string]any) (*model.PaginatedSongs, error) {
 	m.ctrl.T.Helper()
@@ -99,6 +159,77 @@ func (mr *MockSongsRepoMockRecorder) ListSongs(ctx, page, size, filters any) *go
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListSongs", reflect.TypeOf((*MockSongsRepo)(nil).ListSongs), ctx, page, size, filters)
 }
 
+// ListSongsCursor mocks base method.
+func (m *MockSongsRepo) ListSongsCursor(ctx context.Context, cursor string, limit int, withCount bool, filters map[string]any) (*model.CursorPaginatedSongs, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListSongsCursor", ctx, cursor, limit, withCount, filters)
+	ret0, _ := ret[0].(*model.CursorPaginatedSongs)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListSongsCursor indicates an expected call of ListSongsCursor.
+func (mr *MockSongsRepoMockRecorder) ListSongsCursor(ctx, cursor, limit, withCount, filters any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListSongsCursor", reflect.TypeOf((*MockSongsRepo)(nil).ListSongsCursor), ctx, cursor, limit, withCount, filters)
+}
+
+// PatchSong mocks base method.
+func (m *MockSongsRepo) PatchSong(ctx context.Context, id uint64, req *model.SongPatch) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PatchSong", ctx, id, req)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// PatchSong indicates an expected call of PatchSong.
+func (mr *MockSongsRepoMockRecorder) PatchSong(ctx, id, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PatchSong", reflect.TypeOf((*MockSongsRepo)(nil).PatchSong), ctx, id, req)
+}
+
+// RequeueEnrichment mocks base method.
+func (m *MockSongsRepo) RequeueEnrichment(ctx context.Context, id uint64, reason string, attempts int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RequeueEnrichment", ctx, id, reason, attempts)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RequeueEnrichment indicates an expected call of RequeueEnrichment.
+func (mr *MockSongsRepoMockRecorder) RequeueEnrichment(ctx, id, reason, attempts any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RequeueEnrichment", reflect.TypeOf((*MockSongsRepo)(nil).RequeueEnrichment), ctx, id, reason, attempts)
+}
+
+// RequeueStaleEnrichments mocks base method.
+func (m *MockSongsRepo) RequeueStaleEnrichments(ctx context.Context, lease time.Duration) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RequeueStaleEnrichments", ctx, lease)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RequeueStaleEnrichments indicates an expected call of RequeueStaleEnrichments.
+func (mr *MockSongsRepoMockRecorder) RequeueStaleEnrichments(ctx, lease any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RequeueStaleEnrichments", reflect.TypeOf((*MockSongsRepo)(nil).RequeueStaleEnrichments), ctx, lease)
+}
+
+// ResetEnrichment mocks base method.
+func (m *MockSongsRepo) ResetEnrichment(ctx context.Context, id uint64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ResetEnrichment", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ResetEnrichment indicates an expected call of ResetEnrichment.
+func (mr *MockSongsRepoMockRecorder) ResetEnrichment(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResetEnrichment", reflect.TypeOf((*MockSongsRepo)(nil).ResetEnrichment), ctx, id)
+}
+
 // UpdateSong mocks base method.
 func (m *MockSongsRepo) UpdateSong(ctx context.Context, req *model.Song) error {
 	m.ctrl.T.Helper()
@@ -113,6 +244,1585 @@ func (mr *MockSongsRepoMockRecorder) UpdateSong(ctx, req any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateSong", reflect.TypeOf((*MockSongsRepo)(nil).UpdateSong), ctx, req)
 }
 
+// MockArtistsRepo is a mock of ArtistsRepo interface.
+type MockArtistsRepo struct {
+	ctrl     *gomock.Controller
+	recorder *MockArtistsRepoMockRecorder
+}
+
+// MockArtistsRepoMockRecorder is the mock recorder for MockArtistsRepo.
+type MockArtistsRepoMockRecorder struct {
+	mock *MockArtistsRepo
+}
+
+// NewMockArtistsRepo creates a new mock instance.
+func NewMockArtistsRepo(ctrl *gomock.Controller) *MockArtistsRepo {
+	mock := &MockArtistsRepo{ctrl: ctrl}
+	mock.recorder = &MockArtistsRepoMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockArtistsRepo) EXPECT() *MockArtistsRepoMockRecorder {
+	return m.recorder
+}
+
+// CreateArtist mocks base method.
+func (m *MockArtistsRepo) CreateArtist(ctx context.Context, req *model.Artist) (*model.Artist, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateArtist", ctx, req)
+	ret0, _ := ret[0].(*model.Artist)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateArtist indicates an expected call of CreateArtist.
+func (mr *MockArtistsRepoMockRecorder) CreateArtist(ctx, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateArtist", reflect.TypeOf((*MockArtistsRepo)(nil).CreateArtist), ctx, req)
+}
+
+// DeleteArtist mocks base method.
+func (m *MockArtistsRepo) DeleteArtist(ctx context.Context, id uint64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteArtist", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteArtist indicates an expected call of DeleteArtist.
+func (mr *MockArtistsRepoMockRecorder) DeleteArtist(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteArtist", reflect.TypeOf((*MockArtistsRepo)(nil).DeleteArtist), ctx, id)
+}
+
+// GetArtist mocks base method.
+func (m *MockArtistsRepo) GetArtist(ctx context.Context, id uint64) (*model.Artist, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetArtist", ctx, id)
+	ret0, _ := ret[0].(*model.Artist)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetArtist indicates an expected call of GetArtist.
+func (mr *MockArtistsRepoMockRecorder) GetArtist(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetArtist", reflect.TypeOf((*MockArtistsRepo)(nil).GetArtist), ctx, id)
+}
+
+// ListArtists mocks base method.
+func (m *MockArtistsRepo) ListArtists(ctx context.Context, page, size int, name string) (*model.PaginatedArtists, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListArtists", ctx, page, size, name)
+	ret0, _ := ret[0].(*model.PaginatedArtists)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListArtists indicates an expected call of ListArtists.
+func (mr *MockArtistsRepoMockRecorder) ListArtists(ctx, page, size, name any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListArtists", reflect.TypeOf((*MockArtistsRepo)(nil).ListArtists), ctx, page, size, name)
+}
+
+// UpdateArtist mocks base method.
+func (m *MockArtistsRepo) UpdateArtist(ctx context.Context, req *model.Artist) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateArtist", ctx, req)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateArtist indicates an expected call of UpdateArtist.
+func (mr *MockArtistsRepoMockRecorder) UpdateArtist(ctx, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateArtist", reflect.TypeOf((*MockArtistsRepo)(nil).UpdateArtist), ctx, req)
+}
+
+// MockAlbumsRepo is a mock of AlbumsRepo interface.
+type MockAlbumsRepo struct {
+	ctrl     *gomock.Controller
+	recorder *MockAlbumsRepoMockRecorder
+}
+
+// MockAlbumsRepoMockRecorder is the mock recorder for MockAlbumsRepo.
+type MockAlbumsRepoMockRecorder struct {
+	mock *MockAlbumsRepo
+}
+
+// NewMockAlbumsRepo creates a new mock instance.
+func NewMockAlbumsRepo(ctrl *gomock.Controller) *MockAlbumsRepo {
+	mock := &MockAlbumsRepo{ctrl: ctrl}
+	mock.recorder = &MockAlbumsRepoMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAlbumsRepo) EXPECT() *MockAlbumsRepoMockRecorder {
+	return m.recorder
+}
+
+// CreateAlbum mocks base method.
+func (m *MockAlbumsRepo) CreateAlbum(ctx context.Context, req *model.Album) (*model.Album, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateAlbum", ctx, req)
+	ret0, _ := ret[0].(*model.Album)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateAlbum indicates an expected call of CreateAlbum.
+func (mr *MockAlbumsRepoMockRecorder) CreateAlbum(ctx, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateAlbum", reflect.TypeOf((*MockAlbumsRepo)(nil).CreateAlbum), ctx, req)
+}
+
+// DeleteAlbum mocks base method.
+func (m *MockAlbumsRepo) DeleteAlbum(ctx context.Context, id uint64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteAlbum", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteAlbum indicates an expected call of DeleteAlbum.
+func (mr *MockAlbumsRepoMockRecorder) DeleteAlbum(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteAlbum", reflect.TypeOf((*MockAlbumsRepo)(nil).DeleteAlbum), ctx, id)
+}
+
+// GetAlbum mocks base method.
+func (m *MockAlbumsRepo) GetAlbum(ctx context.Context, id uint64) (*model.Album, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAlbum", ctx, id)
+	ret0, _ := ret[0].(*model.Album)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAlbum indicates an expected call of GetAlbum.
+func (mr *MockAlbumsRepoMockRecorder) GetAlbum(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAlbum", reflect.TypeOf((*MockAlbumsRepo)(nil).GetAlbum), ctx, id)
+}
+
+// ListAlbumTracks mocks base method.
+func (m *MockAlbumsRepo) ListAlbumTracks(ctx context.Context, id uint64) ([]*model.Track, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListAlbumTracks", ctx, id)
+	ret0, _ := ret[0].([]*model.Track)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListAlbumTracks indicates an expected call of ListAlbumTracks.
+func (mr *MockAlbumsRepoMockRecorder) ListAlbumTracks(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListAlbumTracks", reflect.TypeOf((*MockAlbumsRepo)(nil).ListAlbumTracks), ctx, id)
+}
+
+// ListAlbums mocks base method.
+func (m *MockAlbumsRepo) ListAlbums(ctx context.Context, page, size int, title string) (*model.PaginatedAlbums, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListAlbums", ctx, page, size, title)
+	ret0, _ := ret[0].(*model.PaginatedAlbums)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListAlbums indicates an expected call of ListAlbums.
+func (mr *MockAlbumsRepoMockRecorder) ListAlbums(ctx, page, size, title any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListAlbums", reflect.TypeOf((*MockAlbumsRepo)(nil).ListAlbums), ctx, page, size, title)
+}
+
+// RemoveAlbumTrack mocks base method.
+func (m *MockAlbumsRepo) RemoveAlbumTrack(ctx context.Context, id, songID uint64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemoveAlbumTrack", ctx, id, songID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RemoveAlbumTrack indicates an expected call of RemoveAlbumTrack.
+func (mr *MockAlbumsRepoMockRecorder) RemoveAlbumTrack(ctx, id, songID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveAlbumTrack", reflect.TypeOf((*MockAlbumsRepo)(nil).RemoveAlbumTrack), ctx, id, songID)
+}
+
+// SetAlbumTrack mocks base method.
+func (m *MockAlbumsRepo) SetAlbumTrack(ctx context.Context, id, songID uint64, disc, trackNumber int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetAlbumTrack", ctx, id, songID, disc, trackNumber)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetAlbumTrack indicates an expected call of SetAlbumTrack.
+func (mr *MockAlbumsRepoMockRecorder) SetAlbumTrack(ctx, id, songID, disc, trackNumber any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetAlbumTrack", reflect.TypeOf((*MockAlbumsRepo)(nil).SetAlbumTrack), ctx, id, songID, disc, trackNumber)
+}
+
+// UpdateAlbum mocks base method.
+func (m *MockAlbumsRepo) UpdateAlbum(ctx context.Context, req *model.Album) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateAlbum", ctx, req)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateAlbum indicates an expected call of UpdateAlbum.
+func (mr *MockAlbumsRepoMockRecorder) UpdateAlbum(ctx, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateAlbum", reflect.TypeOf((*MockAlbumsRepo)(nil).UpdateAlbum), ctx, req)
+}
+
+// MockLabelsRepo is a mock of LabelsRepo interface.
+type MockLabelsRepo struct {
+	ctrl     *gomock.Controller
+	recorder *MockLabelsRepoMockRecorder
+}
+
+// MockLabelsRepoMockRecorder is the mock recorder for MockLabelsRepo.
+type MockLabelsRepoMockRecorder struct {
+	mock *MockLabelsRepo
+}
+
+// NewMockLabelsRepo creates a new mock instance.
+func NewMockLabelsRepo(ctrl *gomock.Controller) *MockLabelsRepo {
+	mock := &MockLabelsRepo{ctrl: ctrl}
+	mock.recorder = &MockLabelsRepoMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockLabelsRepo) EXPECT() *MockLabelsRepoMockRecorder {
+	return m.recorder
+}
+
+// CreateGenre mocks base method.
+func (m *MockLabelsRepo) CreateGenre(ctx context.Context, req *model.Genre) (*model.Genre, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateGenre", ctx, req)
+	ret0, _ := ret[0].(*model.Genre)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateGenre indicates an expected call of CreateGenre.
+func (mr *MockLabelsRepoMockRecorder) CreateGenre(ctx, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateGenre", reflect.TypeOf((*MockLabelsRepo)(nil).CreateGenre), ctx, req)
+}
+
+// DeleteGenre mocks base method.
+func (m *MockLabelsRepo) DeleteGenre(ctx context.Context, id uint64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteGenre", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteGenre indicates an expected call of DeleteGenre.
+func (mr *MockLabelsRepoMockRecorder) DeleteGenre(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteGenre", reflect.TypeOf((*MockLabelsRepo)(nil).DeleteGenre), ctx, id)
+}
+
+// DeleteTag mocks base method.
+func (m *MockLabelsRepo) DeleteTag(ctx context.Context, id uint64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteTag", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteTag indicates an expected call of DeleteTag.
+func (mr *MockLabelsRepoMockRecorder) DeleteTag(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteTag", reflect.TypeOf((*MockLabelsRepo)(nil).DeleteTag), ctx, id)
+}
+
+// ListGenres mocks base method.
+func (m *MockLabelsRepo) ListGenres(ctx context.Context) ([]*model.Genre, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListGenres", ctx)
+	ret0, _ := ret[0].([]*model.Genre)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListGenres indicates an expected call of ListGenres.
+func (mr *MockLabelsRepoMockRecorder) ListGenres(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListGenres", reflect.TypeOf((*MockLabelsRepo)(nil).ListGenres), ctx)
+}
+
+// ListTags mocks base method.
+func (m *MockLabelsRepo) ListTags(ctx context.Context, name string) ([]*model.Tag, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListTags", ctx, name)
+	ret0, _ := ret[0].([]*model.Tag)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListTags indicates an expected call of ListTags.
+func (mr *MockLabelsRepoMockRecorder) ListTags(ctx, name any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListTags", reflect.TypeOf((*MockLabelsRepo)(nil).ListTags), ctx, name)
+}
+
+// SetSongGenres mocks base method.
+func (m *MockLabelsRepo) SetSongGenres(ctx context.Context, id uint64, names []string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetSongGenres", ctx, id, names)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetSongGenres indicates an expected call of SetSongGenres.
+func (mr *MockLabelsRepoMockRecorder) SetSongGenres(ctx, id, names any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetSongGenres", reflect.TypeOf((*MockLabelsRepo)(nil).SetSongGenres), ctx, id, names)
+}
+
+// SetSongTags mocks base method.
+func (m *MockLabelsRepo) SetSongTags(ctx context.Context, id uint64, names []string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetSongTags", ctx, id, names)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetSongTags indicates an expected call of SetSongTags.
+func (mr *MockLabelsRepoMockRecorder) SetSongTags(ctx, id, names any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetSongTags", reflect.TypeOf((*MockLabelsRepo)(nil).SetSongTags), ctx, id, names)
+}
+
+// MockPlaylistsRepo is a mock of PlaylistsRepo interface.
+type MockPlaylistsRepo struct {
+	ctrl     *gomock.Controller
+	recorder *MockPlaylistsRepoMockRecorder
+}
+
+// MockPlaylistsRepoMockRecorder is the mock recorder for MockPlaylistsRepo.
+type MockPlaylistsRepoMockRecorder struct {
+	mock *MockPlaylistsRepo
+}
+
+// NewMockPlaylistsRepo creates a new mock instance.
+func NewMockPlaylistsRepo(ctrl *gomock.Controller) *MockPlaylistsRepo {
+	mock := &MockPlaylistsRepo{ctrl: ctrl}
+	mock.recorder = &MockPlaylistsRepoMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPlaylistsRepo) EXPECT() *MockPlaylistsRepoMockRecorder {
+	return m.recorder
+}
+
+// AddPlaylistSong mocks base method.
+func (m *MockPlaylistsRepo) AddPlaylistSong(ctx context.Context, id, songID uint64, position int) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddPlaylistSong", ctx, id, songID, position)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AddPlaylistSong indicates an expected call of AddPlaylistSong.
+func (mr *MockPlaylistsRepoMockRecorder) AddPlaylistSong(ctx, id, songID, position any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddPlaylistSong", reflect.TypeOf((*MockPlaylistsRepo)(nil).AddPlaylistSong), ctx, id, songID, position)
+}
+
+// CreatePlaylist mocks base method.
+func (m *MockPlaylistsRepo) CreatePlaylist(ctx context.Context, req *model.Playlist) (*model.Playlist, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreatePlaylist", ctx, req)
+	ret0, _ := ret[0].(*model.Playlist)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreatePlaylist indicates an expected call of CreatePlaylist.
+func (mr *MockPlaylistsRepoMockRecorder) CreatePlaylist(ctx, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreatePlaylist", reflect.TypeOf((*MockPlaylistsRepo)(nil).CreatePlaylist), ctx, req)
+}
+
+// DeletePlaylist mocks base method.
+func (m *MockPlaylistsRepo) DeletePlaylist(ctx context.Context, id uint64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeletePlaylist", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeletePlaylist indicates an expected call of DeletePlaylist.
+func (mr *MockPlaylistsRepoMockRecorder) DeletePlaylist(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeletePlaylist", reflect.TypeOf((*MockPlaylistsRepo)(nil).DeletePlaylist), ctx, id)
+}
+
+// DuplicatePlaylist mocks base method.
+func (m *MockPlaylistsRepo) DuplicatePlaylist(ctx context.Context, id uint64, name string) (*model.Playlist, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DuplicatePlaylist", ctx, id, name)
+	ret0, _ := ret[0].(*model.Playlist)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DuplicatePlaylist indicates an expected call of DuplicatePlaylist.
+func (mr *MockPlaylistsRepoMockRecorder) DuplicatePlaylist(ctx, id, name any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DuplicatePlaylist", reflect.TypeOf((*MockPlaylistsRepo)(nil).DuplicatePlaylist), ctx, id, name)
+}
+
+// GetPlaylist mocks base method.
+func (m *MockPlaylistsRepo) GetPlaylist(ctx context.Context, id uint64) (*model.Playlist, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPlaylist", ctx, id)
+	ret0, _ := ret[0].(*model.Playlist)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPlaylist indicates an expected call of GetPlaylist.
+func (mr *MockPlaylistsRepoMockRecorder) GetPlaylist(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPlaylist", reflect.TypeOf((*MockPlaylistsRepo)(nil).GetPlaylist), ctx, id)
+}
+
+// ListPlaylistSongs mocks base method.
+func (m *MockPlaylistsRepo) ListPlaylistSongs(ctx context.Context, id uint64, page, size int) (*model.PaginatedSongs, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListPlaylistSongs", ctx, id, page, size)
+	ret0, _ := ret[0].(*model.PaginatedSongs)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListPlaylistSongs indicates an expected call of ListPlaylistSongs.
+func (mr *MockPlaylistsRepoMockRecorder) ListPlaylistSongs(ctx, id, page, size any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListPlaylistSongs", reflect.TypeOf((*MockPlaylistsRepo)(nil).ListPlaylistSongs), ctx, id, page, size)
+}
+
+// ListPlaylists mocks base method.
+func (m *MockPlaylistsRepo) ListPlaylists(ctx context.Context, page, size int, name string) (*model.PaginatedPlaylists, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListPlaylists", ctx, page, size, name)
+	ret0, _ := ret[0].(*model.PaginatedPlaylists)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListPlaylists indicates an expected call of ListPlaylists.
+func (mr *MockPlaylistsRepoMockRecorder) ListPlaylists(ctx, page, size, name any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListPlaylists", reflect.TypeOf((*MockPlaylistsRepo)(nil).ListPlaylists), ctx, page, size, name)
+}
+
+// MovePlaylistSong mocks base method.
+func (m *MockPlaylistsRepo) MovePlaylistSong(ctx context.Context, id, songID uint64, position int) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MovePlaylistSong", ctx, id, songID, position)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// MovePlaylistSong indicates an expected call of MovePlaylistSong.
+func (mr *MockPlaylistsRepoMockRecorder) MovePlaylistSong(ctx, id, songID, position any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MovePlaylistSong", reflect.TypeOf((*MockPlaylistsRepo)(nil).MovePlaylistSong), ctx, id, songID, position)
+}
+
+// RemovePlaylistSong mocks base method.
+func (m *MockPlaylistsRepo) RemovePlaylistSong(ctx context.Context, id, songID uint64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemovePlaylistSong", ctx, id, songID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RemovePlaylistSong indicates an expected call of RemovePlaylistSong.
+func (mr *MockPlaylistsRepoMockRecorder) RemovePlaylistSong(ctx, id, songID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemovePlaylistSong", reflect.TypeOf((*MockPlaylistsRepo)(nil).RemovePlaylistSong), ctx, id, songID)
+}
+
+// UpdatePlaylist mocks base method.
+func (m *MockPlaylistsRepo) UpdatePlaylist(ctx context.Context, req *model.Playlist) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdatePlaylist", ctx, req)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdatePlaylist indicates an expected call of UpdatePlaylist.
+func (mr *MockPlaylistsRepoMockRecorder) UpdatePlaylist(ctx, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdatePlaylist", reflect.TypeOf((*MockPlaylistsRepo)(nil).UpdatePlaylist), ctx, req)
+}
+
+// MockVersesRepo is a mock of VersesRepo interface.
+type MockVersesRepo struct {
+	ctrl     *gomock.Controller
+	recorder *MockVersesRepoMockRecorder
+}
+
+// MockVersesRepoMockRecorder is the mock recorder for MockVersesRepo.
+type MockVersesRepoMockRecorder struct {
+	mock *MockVersesRepo
+}
+
+// NewMockVersesRepo creates a new mock instance.
+func NewMockVersesRepo(ctrl *gomock.Controller) *MockVersesRepo {
+	mock := &MockVersesRepo{ctrl: ctrl}
+	mock.recorder = &MockVersesRepoMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockVersesRepo) EXPECT() *MockVersesRepoMockRecorder {
+	return m.recorder
+}
+
+// DeleteVerse mocks base method.
+func (m *MockVersesRepo) DeleteVerse(ctx context.Context, id uint64, position int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteVerse", ctx, id, position)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteVerse indicates an expected call of DeleteVerse.
+func (mr *MockVersesRepoMockRecorder) DeleteVerse(ctx, id, position any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteVerse", reflect.TypeOf((*MockVersesRepo)(nil).DeleteVerse), ctx, id, position)
+}
+
+// InsertVerse mocks base method.
+func (m *MockVersesRepo) InsertVerse(ctx context.Context, id uint64, position int, text string) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InsertVerse", ctx, id, position, text)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// InsertVerse indicates an expected call of InsertVerse.
+func (mr *MockVersesRepoMockRecorder) InsertVerse(ctx, id, position, text any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InsertVerse", reflect.TypeOf((*MockVersesRepo)(nil).InsertVerse), ctx, id, position, text)
+}
+
+// ListVerses mocks base method.
+func (m *MockVersesRepo) ListVerses(ctx context.Context, id uint64, page, size int) (*model.PaginatedVerses, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListVerses", ctx, id, page, size)
+	ret0, _ := ret[0].(*model.PaginatedVerses)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListVerses indicates an expected call of ListVerses.
+func (mr *MockVersesRepoMockRecorder) ListVerses(ctx, id, page, size any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListVerses", reflect.TypeOf((*MockVersesRepo)(nil).ListVerses), ctx, id, page, size)
+}
+
+// MoveVerse mocks base method.
+func (m *MockVersesRepo) MoveVerse(ctx context.Context, id uint64, position, to int) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MoveVerse", ctx, id, position, to)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// MoveVerse indicates an expected call of MoveVerse.
+func (mr *MockVersesRepoMockRecorder) MoveVerse(ctx, id, position, to any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MoveVerse", reflect.TypeOf((*MockVersesRepo)(nil).MoveVerse), ctx, id, position, to)
+}
+
+// UpdateVerse mocks base method.
+func (m *MockVersesRepo) UpdateVerse(ctx context.Context, id uint64, position int, text string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateVerse", ctx, id, position, text)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateVerse indicates an expected call of UpdateVerse.
+func (mr *MockVersesRepoMockRecorder) UpdateVerse(ctx, id, position, text any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateVerse", reflect.TypeOf((*MockVersesRepo)(nil).UpdateVerse), ctx, id, position, text)
+}
+
+// MockLyricsRepo is a mock of LyricsRepo interface.
+type MockLyricsRepo struct {
+	ctrl     *gomock.Controller
+	recorder *MockLyricsRepoMockRecorder
+}
+
+// MockLyricsRepoMockRecorder is the mock recorder for MockLyricsRepo.
+type MockLyricsRepoMockRecorder struct {
+	mock *MockLyricsRepo
+}
+
+// NewMockLyricsRepo creates a new mock instance.
+func NewMockLyricsRepo(ctrl *gomock.Controller) *MockLyricsRepo {
+	mock := &MockLyricsRepo{ctrl: ctrl}
+	mock.recorder = &MockLyricsRepoMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockLyricsRepo) EXPECT() *MockLyricsRepoMockRecorder {
+	return m.recorder
+}
+
+// GetSongLyrics mocks base method.
+func (m *MockLyricsRepo) GetSongLyrics(ctx context.Context, id uint64) (*model.SongLyrics, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSongLyrics", ctx, id)
+	ret0, _ := ret[0].(*model.SongLyrics)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSongLyrics indicates an expected call of GetSongLyrics.
+func (mr *MockLyricsRepoMockRecorder) GetSongLyrics(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSongLyrics", reflect.TypeOf((*MockLyricsRepo)(nil).GetSongLyrics), ctx, id)
+}
+
+// SetSyncedLyrics mocks base method.
+func (m *MockLyricsRepo) SetSyncedLyrics(ctx context.Context, id uint64, lines []*model.SyncedLine) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetSyncedLyrics", ctx, id, lines)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetSyncedLyrics indicates an expected call of SetSyncedLyrics.
+func (mr *MockLyricsRepoMockRecorder) SetSyncedLyrics(ctx, id, lines any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetSyncedLyrics", reflect.TypeOf((*MockLyricsRepo)(nil).SetSyncedLyrics), ctx, id, lines)
+}
+
+// MockTranslationsRepo is a mock of TranslationsRepo interface.
+type MockTranslationsRepo struct {
+	ctrl     *gomock.Controller
+	recorder *MockTranslationsRepoMockRecorder
+}
+
+// MockTranslationsRepoMockRecorder is the mock recorder for MockTranslationsRepo.
+type MockTranslationsRepoMockRecorder struct {
+	mock *MockTranslationsRepo
+}
+
+// NewMockTranslationsRepo creates a new mock instance.
+func NewMockTranslationsRepo(ctrl *gomock.Controller) *MockTranslationsRepo {
+	mock := &MockTranslationsRepo{ctrl: ctrl}
+	mock.recorder = &MockTranslationsRepoMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockTranslationsRepo) EXPECT() *MockTranslationsRepoMockRecorder {
+	return m.recorder
+}
+
+// CreateTranslation mocks base method.
+func (m *MockTranslationsRepo) CreateTranslation(ctx context.Context, id uint64, req *model.Translation) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateTranslation", ctx, id, req)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateTranslation indicates an expected call of CreateTranslation.
+func (mr *MockTranslationsRepoMockRecorder) CreateTranslation(ctx, id, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateTranslation", reflect.TypeOf((*MockTranslationsRepo)(nil).CreateTranslation), ctx, id, req)
+}
+
+// DeleteTranslation mocks base method.
+func (m *MockTranslationsRepo) DeleteTranslation(ctx context.Context, id uint64, lang string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteTranslation", ctx, id, lang)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteTranslation indicates an expected call of DeleteTranslation.
+func (mr *MockTranslationsRepoMockRecorder) DeleteTranslation(ctx, id, lang any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteTranslation", reflect.TypeOf((*MockTranslationsRepo)(nil).DeleteTranslation), ctx, id, lang)
+}
+
+// GetTranslation mocks base method.
+func (m *MockTranslationsRepo) GetTranslation(ctx context.Context, id uint64, lang string, page, size int) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTranslation", ctx, id, lang, page, size)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTranslation indicates an expected call of GetTranslation.
+func (mr *MockTranslationsRepoMockRecorder) GetTranslation(ctx, id, lang, page, size any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTranslation", reflect.TypeOf((*MockTranslationsRepo)(nil).GetTranslation), ctx, id, lang, page, size)
+}
+
+// UpdateTranslation mocks base method.
+func (m *MockTranslationsRepo) UpdateTranslation(ctx context.Context, id uint64, req *model.Translation) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateTranslation", ctx, id, req)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateTranslation indicates an expected call of UpdateTranslation.
+func (mr *MockTranslationsRepoMockRecorder) UpdateTranslation(ctx, id, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateTranslation", reflect.TypeOf((*MockTranslationsRepo)(nil).UpdateTranslation), ctx, id, req)
+}
+
+// MockRepo is a mock of Repo interface.
+type MockRepo struct {
+	ctrl     *gomock.Controller
+	recorder *MockRepoMockRecorder
+}
+
+// MockRepoMockRecorder is the mock recorder for MockRepo.
+type MockRepoMockRecorder struct {
+	mock *MockRepo
+}
+
+// NewMockRepo creates a new mock instance.
+func NewMockRepo(ctrl *gomock.Controller) *MockRepo {
+	mock := &MockRepo{ctrl: ctrl}
+	mock.recorder = &MockRepoMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRepo) EXPECT() *MockRepoMockRecorder {
+	return m.recorder
+}
+
+// AddPlaylistSong mocks base method.
+func (m *MockRepo) AddPlaylistSong(ctx context.Context, id, songID uint64, position int) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddPlaylistSong", ctx, id, songID, position)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AddPlaylistSong indicates an expected call of AddPlaylistSong.
+func (mr *MockRepoMockRecorder) AddPlaylistSong(ctx, id, songID, position any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddPlaylistSong", reflect.TypeOf((*MockRepo)(nil).AddPlaylistSong), ctx, id, songID, position)
+}
+
+// ClaimEnrichment mocks base method.
+func (m *MockRepo) ClaimEnrichment(ctx context.Context, id uint64) (*model.Song, int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ClaimEnrichment", ctx, id)
+	ret0, _ := ret[0].(*model.Song)
+	ret1, _ := ret[1].(int)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// ClaimEnrichment indicates an expected call of ClaimEnrichment.
+func (mr *MockRepoMockRecorder) ClaimEnrichment(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ClaimEnrichment", reflect.TypeOf((*MockRepo)(nil).ClaimEnrichment), ctx, id)
+}
+
+// CompleteEnrichment mocks base method.
+func (m *MockRepo) CompleteEnrichment(ctx context.Context, req *model.Song, attempts int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CompleteEnrichment", ctx, req, attempts)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CompleteEnrichment indicates an expected call of CompleteEnrichment.
+func (mr *MockRepoMockRecorder) CompleteEnrichment(ctx, req, attempts any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CompleteEnrichment", reflect.TypeOf((*MockRepo)(nil).CompleteEnrichment), ctx, req, attempts)
+}
+
+// CreateAlbum mocks base method.
+func (m *MockRepo) CreateAlbum(ctx context.Context, req *model.Album) (*model.Album, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateAlbum", ctx, req)
+	ret0, _ := ret[0].(*model.Album)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateAlbum indicates an expected call of CreateAlbum.
+func (mr *MockRepoMockRecorder) CreateAlbum(ctx, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateAlbum", reflect.TypeOf((*MockRepo)(nil).CreateAlbum), ctx, req)
+}
+
+// CreateArtist mocks base method.
+func (m *MockRepo) CreateArtist(ctx context.Context, req *model.Artist) (*model.Artist, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateArtist", ctx, req)
+	ret0, _ := ret[0].(*model.Artist)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateArtist indicates an expected call of CreateArtist.
+func (mr *MockRepoMockRecorder) CreateArtist(ctx, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateArtist", reflect.TypeOf((*MockRepo)(nil).CreateArtist), ctx, req)
+}
+
+// CreateGenre mocks base method.
+func (m *MockRepo) CreateGenre(ctx context.Context, req *model.Genre) (*model.Genre, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateGenre", ctx, req)
+	ret0, _ := ret[0].(*model.Genre)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateGenre indicates an expected call of CreateGenre.
+func (mr *MockRepoMockRecorder) CreateGenre(ctx, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateGenre", reflect.TypeOf((*MockRepo)(nil).CreateGenre), ctx, req)
+}
+
+// CreatePlaylist mocks base method.
+func (m *MockRepo) CreatePlaylist(ctx context.Context, req *model.Playlist) (*model.Playlist, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreatePlaylist", ctx, req)
+	ret0, _ := ret[0].(*model.Playlist)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreatePlaylist indicates an expected call of CreatePlaylist.
+func (mr *MockRepoMockRecorder) CreatePlaylist(ctx, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreatePlaylist", reflect.TypeOf((*MockRepo)(nil).CreatePlaylist), ctx, req)
+}
+
+// CreateSong mocks base method.
+func (m *MockRepo) CreateSong(ctx context.Context, req *model.Song) (uint64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateSong", ctx, req)
+	ret0, _ := ret[0].(uint64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateSong indicates an expected call of CreateSong.
+func (mr *MockRepoMockRecorder) CreateSong(ctx, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateSong", reflect.TypeOf((*MockRepo)(nil).CreateSong), ctx, req)
+}
+
+// CreateTranslation mocks base method.
+func (m *MockRepo) CreateTranslation(ctx context.Context, id uint64, req *model.Translation) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateTranslation", ctx, id, req)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateTranslation indicates an expected call of CreateTranslation.
+func (mr *MockRepoMockRecorder) CreateTranslation(ctx, id, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateTranslation", reflect.TypeOf((*MockRepo)(nil).CreateTranslation), ctx, id, req)
+}
+
+// DeleteAlbum mocks base method.
+func (m *MockRepo) DeleteAlbum(ctx context.Context, id uint64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteAlbum", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteAlbum indicates an expected call of DeleteAlbum.
+func (mr *MockRepoMockRecorder) DeleteAlbum(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteAlbum", reflect.TypeOf((*MockRepo)(nil).DeleteAlbum), ctx, id)
+}
+
+// DeleteArtist mocks base method.
+func (m *MockRepo) DeleteArtist(ctx context.Context, id uint64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteArtist", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteArtist indicates an expected call of DeleteArtist.
+func (mr *MockRepoMockRecorder) DeleteArtist(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteArtist", reflect.TypeOf((*MockRepo)(nil).DeleteArtist), ctx, id)
+}
+
+// DeleteGenre mocks base method.
+func (m *MockRepo) DeleteGenre(ctx context.Context, id uint64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteGenre", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteGenre indicates an expected call of DeleteGenre.
+func (mr *MockRepoMockRecorder) DeleteGenre(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteGenre", reflect.TypeOf((*MockRepo)(nil).DeleteGenre), ctx, id)
+}
+
+// DeletePlaylist mocks base method.
+func (m *MockRepo) DeletePlaylist(ctx context.Context, id uint64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeletePlaylist", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeletePlaylist indicates an expected call of DeletePlaylist.
+func (mr *MockRepoMockRecorder) DeletePlaylist(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeletePlaylist", reflect.TypeOf((*MockRepo)(nil).DeletePlaylist), ctx, id)
+}
+
+// DeleteSong mocks base method.
+func (m *MockRepo) DeleteSong(ctx context.Context, id uint64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteSong", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteSong indicates an expected call of DeleteSong.
+func (mr *MockRepoMockRecorder) DeleteSong(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteSong", reflect.TypeOf((*MockRepo)(nil).DeleteSong), ctx, id)
+}
+
+// DeleteTag mocks base method.
+func (m *MockRepo) DeleteTag(ctx context.Context, id uint64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteTag", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteTag indicates an expected call of DeleteTag.
+func (mr *MockRepoMockRecorder) DeleteTag(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteTag", reflect.TypeOf((*MockRepo)(nil).DeleteTag), ctx, id)
+}
+
+// DeleteTranslation mocks base method.
+func (m *MockRepo) DeleteTranslation(ctx context.Context, id uint64, lang string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteTranslation", ctx, id, lang)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteTranslation indicates an expected call of DeleteTranslation.
+func (mr *MockRepoMockRecorder) DeleteTranslation(ctx, id, lang any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteTranslation", reflect.TypeOf((*MockRepo)(nil).DeleteTranslation), ctx, id, lang)
+}
+
+// DeleteVerse mocks base method.
+func (m *MockRepo) DeleteVerse(ctx context.Context, id uint64, position int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteVerse", ctx, id, position)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteVerse indicates an expected call of DeleteVerse.
+func (mr *MockRepoMockRecorder) DeleteVerse(ctx, id, position any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteVerse", reflect.TypeOf((*MockRepo)(nil).DeleteVerse), ctx, id, position)
+}
+
+// DuplicatePlaylist mocks base method.
+func (m *MockRepo) DuplicatePlaylist(ctx context.Context, id uint64, name string) (*model.Playlist, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DuplicatePlaylist", ctx, id, name)
+	ret0, _ := ret[0].(*model.Playlist)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DuplicatePlaylist indicates an expected call of DuplicatePlaylist.
+func (mr *MockRepoMockRecorder) DuplicatePlaylist(ctx, id, name any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DuplicatePlaylist", reflect.TypeOf((*MockRepo)(nil).DuplicatePlaylist), ctx, id, name)
+}
+
+// FailEnrichment mocks base method.
+func (m *MockRepo) FailEnrichment(ctx context.Context, id uint64, reason string, attempts int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FailEnrichment", ctx, id, reason, attempts)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// FailEnrichment indicates an expected call of FailEnrichment.
+func (mr *MockRepoMockRecorder) FailEnrichment(ctx, id, reason, attempts any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FailEnrichment", reflect.TypeOf((*MockRepo)(nil).FailEnrichment), ctx, id, reason, attempts)
+}
+
+// GetAlbum mocks base method.
+func (m *MockRepo) GetAlbum(ctx context.Context, id uint64) (*model.Album, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAlbum", ctx, id)
+	ret0, _ := ret[0].(*model.Album)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAlbum indicates an expected call of GetAlbum.
+func (mr *MockRepoMockRecorder) GetAlbum(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAlbum", reflect.TypeOf((*MockRepo)(nil).GetAlbum), ctx, id)
+}
+
+// GetArtist mocks base method.
+func (m *MockRepo) GetArtist(ctx context.Context, id uint64) (*model.Artist, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetArtist", ctx, id)
+	ret0, _ := ret[0].(*model.Artist)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetArtist indicates an expected call of GetArtist.
+func (mr *MockRepoMockRecorder) GetArtist(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetArtist", reflect.TypeOf((*MockRepo)(nil).GetArtist), ctx, id)
+}
+
+// GetPlaylist mocks base method.
+func (m *MockRepo) GetPlaylist(ctx context.Context, id uint64) (*model.Playlist, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPlaylist", ctx, id)
+	ret0, _ := ret[0].(*model.Playlist)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPlaylist indicates an expected call of GetPlaylist.
+func (mr *MockRepoMockRecorder) GetPlaylist(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPlaylist", reflect.TypeOf((*MockRepo)(nil).GetPlaylist), ctx, id)
+}
+
+// GetSong mocks base method.
+func (m *MockRepo) GetSong(ctx context.Context, id uint64, page, size int) (*model.PaginatedSongs, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSong", ctx, id, page, size)
+	ret0, _ := ret[0].(*model.PaginatedSongs)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSong indicates an expected call of GetSong.
+func (mr *MockRepoMockRecorder) GetSong(ctx, id, page, size any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSong", reflect.TypeOf((*MockRepo)(nil).GetSong), ctx, id, page, size)
+}
+
+// GetSongLyrics mocks base method.
+func (m *MockRepo) GetSongLyrics(ctx context.Context, id uint64) (*model.SongLyrics, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSongLyrics", ctx, id)
+	ret0, _ := ret[0].(*model.SongLyrics)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSongLyrics indicates an expected call of GetSongLyrics.
+func (mr *MockRepoMockRecorder) GetSongLyrics(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSongLyrics", reflect.TypeOf((*MockRepo)(nil).GetSongLyrics), ctx, id)
+}
+
+// GetTranslation mocks base method.
+func (m *MockRepo) GetTranslation(ctx context.Context, id uint64, lang string, page, size int) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTranslation", ctx, id, lang, page, size)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTranslation indicates an expected call of GetTranslation.
+func (mr *MockRepoMockRecorder) GetTranslation(ctx, id, lang, page, size any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTranslation", reflect.TypeOf((*MockRepo)(nil).GetTranslation), ctx, id, lang, page, size)
+}
+
+// InsertVerse mocks base method.
+func (m *MockRepo) InsertVerse(ctx context.Context, id uint64, position int, text string) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InsertVerse", ctx, id, position, text)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// InsertVerse indicates an expected call of InsertVerse.
+func (mr *MockRepoMockRecorder) InsertVerse(ctx, id, position, text any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InsertVerse", reflect.TypeOf((*MockRepo)(nil).InsertVerse), ctx, id, position, text)
+}
+
+// ListAlbumTracks mocks base method.
+func (m *MockRepo) ListAlbumTracks(ctx context.Context, id uint64) ([]*model.Track, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListAlbumTracks", ctx, id)
+	ret0, _ := ret[0].([]*model.Track)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListAlbumTracks indicates an expected call of ListAlbumTracks.
+func (mr *MockRepoMockRecorder) ListAlbumTracks(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListAlbumTracks", reflect.TypeOf((*MockRepo)(nil).ListAlbumTracks), ctx, id)
+}
+
+// ListAlbums mocks base method.
+func (m *MockRepo) ListAlbums(ctx context.Context, page, size int, title string) (*model.PaginatedAlbums, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListAlbums", ctx, page, size, title)
+	ret0, _ := ret[0].(*model.PaginatedAlbums)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListAlbums indicates an expected call of ListAlbums.
+func (mr *MockRepoMockRecorder) ListAlbums(ctx, page, size, title any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListAlbums", reflect.TypeOf((*MockRepo)(nil).ListAlbums), ctx, page, size, title)
+}
+
+// ListArtists mocks base method.
+func (m *MockRepo) ListArtists(ctx context.Context, page, size int, name string) (*model.PaginatedArtists, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListArtists", ctx, page, size, name)
+	ret0, _ := ret[0].(*model.PaginatedArtists)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListArtists indicates an expected call of ListArtists.
+func (mr *MockRepoMockRecorder) ListArtists(ctx, page, size, name any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListArtists", reflect.TypeOf((*MockRepo)(nil).ListArtists), ctx, page, size, name)
+}
+
+// ListGenres mocks base method.
+func (m *MockRepo) ListGenres(ctx context.Context) ([]*model.Genre, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListGenres", ctx)
+	ret0, _ := ret[0].([]*model.Genre)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListGenres indicates an expected call of ListGenres.
+func (mr *MockRepoMockRecorder) ListGenres(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListGenres", reflect.TypeOf((*MockRepo)(nil).ListGenres), ctx)
+}
+
+// ListPendingEnrichments mocks base method.
+func (m *MockRepo) ListPendingEnrichments(ctx context.Context, limit int) ([]uint64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListPendingEnrichments", ctx, limit)
+	ret0, _ := ret[0].([]uint64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListPendingEnrichments indicates an expected call of ListPendingEnrichments.
+func (mr *MockRepoMockRecorder) ListPendingEnrichments(ctx, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListPendingEnrichments", reflect.TypeOf((*MockRepo)(nil).ListPendingEnrichments), ctx, limit)
+}
+
+// ListPlaylistSongs mocks base method.
+func (m *MockRepo) ListPlaylistSongs(ctx context.Context, id uint64, page, size int) (*model.PaginatedSongs, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListPlaylistSongs", ctx, id, page, size)
+	ret0, _ := ret[0].(*model.PaginatedSongs)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListPlaylistSongs indicates an expected call of ListPlaylistSongs.
+func (mr *MockRepoMockRecorder) ListPlaylistSongs(ctx, id, page, size any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListPlaylistSongs", reflect.TypeOf((*MockRepo)(nil).ListPlaylistSongs), ctx, id, page, size)
+}
+
+// ListPlaylists mocks base method.
+func (m *MockRepo) ListPlaylists(ctx context.Context, page, size int, name string) (*model.PaginatedPlaylists, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListPlaylists", ctx, page, size, name)
+	ret0, _ := ret[0].(*model.PaginatedPlaylists)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListPlaylists indicates an expected call of ListPlaylists.
+func (mr *MockRepoMockRecorder) ListPlaylists(ctx, page, size, name any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListPlaylists", reflect.TypeOf((*MockRepo)(nil).ListPlaylists), ctx, page, size, name)
+}
+
+// ListSongs mocks base method.
+func (m *MockRepo) ListSongs(ctx context.Context, page, size int, filters map[string]any) (*model.PaginatedSongs, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListSongs", ctx, page, size, filters)
+	ret0, _ := ret[0].(*model.PaginatedSongs)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListSongs indicates an expected call of ListSongs.
+func (mr *MockRepoMockRecorder) ListSongs(ctx, page, size, filters any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListSongs", reflect.TypeOf((*MockRepo)(nil).ListSongs), ctx, page, size, filters)
+}
+
+// ListSongsCursor mocks base method.
+func (m *MockRepo) ListSongsCursor(ctx context.Context, cursor string, limit int, withCount bool, filters map[string]any) (*model.CursorPaginatedSongs, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListSongsCursor", ctx, cursor, limit, withCount, filters)
+	ret0, _ := ret[0].(*model.CursorPaginatedSongs)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListSongsCursor indicates an expected call of ListSongsCursor.
+func (mr *MockRepoMockRecorder) ListSongsCursor(ctx, cursor, limit, withCount, filters any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListSongsCursor", reflect.TypeOf((*MockRepo)(nil).ListSongsCursor), ctx, cursor, limit, withCount, filters)
+}
+
+// ListTags mocks base method.
+func (m *MockRepo) ListTags(ctx context.Context, name string) ([]*model.Tag, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListTags", ctx, name)
+	ret0, _ := ret[0].([]*model.Tag)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListTags indicates an expected call of ListTags.
+func (mr *MockRepoMockRecorder) ListTags(ctx, name any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListTags", reflect.TypeOf((*MockRepo)(nil).ListTags), ctx, name)
+}
+
+// ListVerses mocks base method.
+func (m *MockRepo) ListVerses(ctx context.Context, id uint64, page, size int) (*model.PaginatedVerses, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListVerses", ctx, id, page, size)
+	ret0, _ := ret[0].(*model.PaginatedVerses)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListVerses indicates an expected call of ListVerses.
+func (mr *MockRepoMockRecorder) ListVerses(ctx, id, page, size any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListVerses", reflect.TypeOf((*MockRepo)(nil).ListVerses), ctx, id, page, size)
+}
+
+// MovePlaylistSong mocks base method.
+func (m *MockRepo) MovePlaylistSong(ctx context.Context, id, songID uint64, position int) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MovePlaylistSong", ctx, id, songID, position)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// MovePlaylistSong indicates an expected call of MovePlaylistSong.
+func (mr *MockRepoMockRecorder) MovePlaylistSong(ctx, id, songID, position any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MovePlaylistSong", reflect.TypeOf((*MockRepo)(nil).MovePlaylistSong), ctx, id, songID, position)
+}
+
+// MoveVerse mocks base method.
+func (m *MockRepo) MoveVerse(ctx context.Context, id uint64, position, to int) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MoveVerse", ctx, id, position, to)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// MoveVerse indicates an expected call of MoveVerse.
+func (mr *MockRepoMockRecorder) MoveVerse(ctx, id, position, to any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MoveVerse", reflect.TypeOf((*MockRepo)(nil).MoveVerse), ctx, id, position, to)
+}
+
+// PatchSong mocks base method.
+func (m *MockRepo) PatchSong(ctx context.Context, id uint64, req *model.SongPatch) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PatchSong", ctx, id, req)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// PatchSong indicates an expected call of PatchSong.
+func (mr *MockRepoMockRecorder) PatchSong(ctx, id, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PatchSong", reflect.TypeOf((*MockRepo)(nil).PatchSong), ctx, id, req)
+}
+
+// RemoveAlbumTrack mocks base method.
+func (m *MockRepo) RemoveAlbumTrack(ctx context.Context, id, songID uint64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemoveAlbumTrack", ctx, id, songID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RemoveAlbumTrack indicates an expected call of RemoveAlbumTrack.
+func (mr *MockRepoMockRecorder) RemoveAlbumTrack(ctx, id, songID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveAlbumTrack", reflect.TypeOf((*MockRepo)(nil).RemoveAlbumTrack), ctx, id, songID)
+}
+
+// RemovePlaylistSong mocks base method.
+func (m *MockRepo) RemovePlaylistSong(ctx context.Context, id, songID uint64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemovePlaylistSong", ctx, id, songID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RemovePlaylistSong indicates an expected call of RemovePlaylistSong.
+func (mr *MockRepoMockRecorder) RemovePlaylistSong(ctx, id, songID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemovePlaylistSong", reflect.TypeOf((*MockRepo)(nil).RemovePlaylistSong), ctx, id, songID)
+}
+
+// RequeueEnrichment mocks base method.
+func (m *MockRepo) RequeueEnrichment(ctx context.Context, id uint64, reason string, attempts int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RequeueEnrichment", ctx, id, reason, attempts)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RequeueEnrichment indicates an expected call of RequeueEnrichment.
+func (mr *MockRepoMockRecorder) RequeueEnrichment(ctx, id, reason, attempts any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RequeueEnrichment", reflect.TypeOf((*MockRepo)(nil).RequeueEnrichment), ctx, id, reason, attempts)
+}
+
+// RequeueStaleEnrichments mocks base method.
+func (m *MockRepo) RequeueStaleEnrichments(ctx context.Context, lease time.Duration) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RequeueStaleEnrichments", ctx, lease)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RequeueStaleEnrichments indicates an expected call of RequeueStaleEnrichments.
+func (mr *MockRepoMockRecorder) RequeueStaleEnrichments(ctx, lease any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RequeueStaleEnrichments", reflect.TypeOf((*MockRepo)(nil).RequeueStaleEnrichments), ctx, lease)
+}
+
+// ResetEnrichment mocks base method.
+func (m *MockRepo) ResetEnrichment(ctx context.Context, id uint64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ResetEnrichment", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ResetEnrichment indicates an expected call of ResetEnrichment.
+func (mr *MockRepoMockRecorder) ResetEnrichment(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResetEnrichment", reflect.TypeOf((*MockRepo)(nil).ResetEnrichment), ctx, id)
+}
+
+// SetAlbumTrack mocks base method.
+func (m *MockRepo) SetAlbumTrack(ctx context.Context, id, songID uint64, disc, trackNumber int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetAlbumTrack", ctx, id, songID, disc, trackNumber)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetAlbumTrack indicates an expected call of SetAlbumTrack.
+func (mr *MockRepoMockRecorder) SetAlbumTrack(ctx, id, songID, disc, trackNumber any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetAlbumTrack", reflect.TypeOf((*MockRepo)(nil).SetAlbumTrack), ctx, id, songID, disc, trackNumber)
+}
+
+// SetSongGenres mocks base method.
+func (m *MockRepo) SetSongGenres(ctx context.Context, id uint64, names []string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetSongGenres", ctx, id, names)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetSongGenres indicates an expected call of SetSongGenres.
+func (mr *MockRepoMockRecorder) SetSongGenres(ctx, id, names any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetSongGenres", reflect.TypeOf((*MockRepo)(nil).SetSongGenres), ctx, id, names)
+}
+
+// SetSongTags mocks base method.
+func (m *MockRepo) SetSongTags(ctx context.Context, id uint64, names []string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetSongTags", ctx, id, names)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetSongTags indicates an expected call of SetSongTags.
+func (mr *MockRepoMockRecorder) SetSongTags(ctx, id, names any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetSongTags", reflect.TypeOf((*MockRepo)(nil).SetSongTags), ctx, id, names)
+}
+
+// SetSyncedLyrics mocks base method.
+func (m *MockRepo) SetSyncedLyrics(ctx context.Context, id uint64, lines []*model.SyncedLine) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetSyncedLyrics", ctx, id, lines)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetSyncedLyrics indicates an expected call of SetSyncedLyrics.
+func (mr *MockRepoMockRecorder) SetSyncedLyrics(ctx, id, lines any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetSyncedLyrics", reflect.TypeOf((*MockRepo)(nil).SetSyncedLyrics), ctx, id, lines)
+}
+
+// UpdateAlbum mocks base method.
+func (m *MockRepo) UpdateAlbum(ctx context.Context, req *model.Album) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateAlbum", ctx, req)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateAlbum indicates an expected call of UpdateAlbum.
+func (mr *MockRepoMockRecorder) UpdateAlbum(ctx, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateAlbum", reflect.TypeOf((*MockRepo)(nil).UpdateAlbum), ctx, req)
+}
+
+// UpdateArtist mocks base method.
+func (m *MockRepo) UpdateArtist(ctx context.Context, req *model.Artist) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateArtist", ctx, req)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateArtist indicates an expected call of UpdateArtist.
+func (mr *MockRepoMockRecorder) UpdateArtist(ctx, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateArtist", reflect.TypeOf((*MockRepo)(nil).UpdateArtist), ctx, req)
+}
+
+// UpdatePlaylist mocks base method.
+func (m *MockRepo) UpdatePlaylist(ctx context.Context, req *model.Playlist) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdatePlaylist", ctx, req)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdatePlaylist indicates an expected call of UpdatePlaylist.
+func (mr *MockRepoMockRecorder) UpdatePlaylist(ctx, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdatePlaylist", reflect.TypeOf((*MockRepo)(nil).UpdatePlaylist), ctx, req)
+}
+
+// UpdateSong mocks base method.
+func (m *MockRepo) UpdateSong(ctx context.Context, req *model.Song) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateSong", ctx, req)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateSong indicates an expected call of UpdateSong.
+func (mr *MockRepoMockRecorder) UpdateSong(ctx, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateSong", reflect.TypeOf((*MockRepo)(nil).UpdateSong), ctx, req)
+}
+
+// UpdateTranslation mocks base method.
+func (m *MockRepo) UpdateTranslation(ctx context.Context, id uint64, req *model.Translation) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateTranslation", ctx, id, req)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateTranslation indicates an expected call of UpdateTranslation.
+func (mr *MockRepoMockRecorder) UpdateTranslation(ctx, id, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateTranslation", reflect.TypeOf((*MockRepo)(nil).UpdateTranslation), ctx, id, req)
+}
+
+// UpdateVerse mocks base method.
+func (m *MockRepo) UpdateVerse(ctx context.Context, id uint64, position int, text string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateVerse", ctx, id, position, text)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateVerse indicates an expected call of UpdateVerse.
+func (mr *MockRepoMockRecorder) UpdateVerse(ctx, id, position, text any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateVerse", reflect.TypeOf((*MockRepo)(nil).UpdateVerse), ctx, id, position, text)
+}
+
 // MockAPIRepo is a mock of APIRepo interface.
 type MockAPIRepo struct {
 	ctrl     *gomock.Controller
@@ -137,16 +1847,16 @@ func (m *MockAPIRepo) EXPECT() *MockAPIRepoMockRecorder {
 }
 
 // FetchSongDetail mocks base method.
-func (m *MockAPIRepo) FetchSongDetail(group, song string) (*model.SongDetail, error) {
+func (m *MockAPIRepo) FetchSongDetail(ctx context.Context, group, song string) (*model.SongDetail, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "FetchSongDetail", group, song)
+	ret := m.ctrl.Call(m, "FetchSongDetail", ctx, group, song)
 	ret0, _ := ret[0].(*model.SongDetail)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // FetchSongDetail indicates an expected call of FetchSongDetail.
-func (mr *MockAPIRepoMockRecorder) FetchSongDetail(group, song any) *gomock.Call {
+func (mr *MockAPIRepoMockRecorder) FetchSongDetail(ctx, group, song any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FetchSongDetail", reflect.TypeOf((*MockAPIRepo)(nil).FetchSongDetail), group, song)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FetchSongDetail", reflect.TypeOf((*MockAPIRepo)(nil).FetchSongDetail), ctx, group, song)
 }
@@ -4,12 +4,19 @@ import (
 	"github.com/joho/godotenv"
 	"os"
 	"strconv"
+	"strings"
+	"time"
 )
 
 type Config struct {
-	Server          *ServerConfig
-	DB              *DBConfig
-	ExternalAPIPort int
+	Server      *ServerConfig
+	DB          *DBConfig
+	Enrichment  *EnrichmentConfig
+	ExternalAPI *ExternalAPIConfig
+	Providers   *ProvidersConfig
+	Metrics     *MetricsConfig
+	Tracing     *TracingConfig
+	Auth        *AuthConfig
 }
 
 type ServerConfig struct {
@@ -27,6 +34,91 @@ type DBConfig struct {
 	Database string
 }
 
+type ExternalAPIConfig struct {
+	Scheme     string
+	Host       string
+	Port       int
+	Timeout    time.Duration
+	Resilience *ResilienceConfig
+	Cache      *CacheConfig
+}
+
+type CacheConfig struct {
+	Size        int
+	TTL         time.Duration
+	NegativeTTL time.Duration
+	Persistent  bool
+}
+
+type ResilienceConfig struct {
+	MaxRetries       int
+	BaseBackoff      time.Duration
+	MaxBackoff       time.Duration
+	FailureThreshold int
+	OpenTimeout      time.Duration
+	// RateLimit is the number of requests per second, 0 disables the limiter.
+	// Burst below 1 is treated as 1
+	RateLimit float64
+	Burst     int
+}
+
+// ProvidersConfig lists the song metadata providers in priority order
+type ProvidersConfig struct {
+	Order       []string
+	FixturesDir string
+	HTTP        *HTTPProviderConfig
+}
+
+// HTTPProviderConfig describes a generic JSON API. URL may contain {group} and {song}
+// placeholders, fields are dot-separated paths into the response body
+type HTTPProviderConfig struct {
+	URL               string
+	Timeout           time.Duration
+	ReleaseDateField  string
+	ReleaseDateLayout string
+	TextField         string
+	LinkField         string
+}
+
+// MetricsConfig controls the Prometheus endpoint. When Port is 0, metrics are served
+// on the API server and require the admin role, a separate port is not authenticated
+// and must only be reachable from the private network
+type MetricsConfig struct {
+	Enabled bool
+	Port    int
+	Path    string
+}
+
+// TracingConfig selects the span exporter: otlp, stdout or none
+type TracingConfig struct {
+	Exporter    string
+	Endpoint    string
+	Insecure    bool
+	ServiceName string
+	SampleRatio float64
+}
+
+// AuthConfig lists the accepted credentials. APIKeys maps a key to its role,
+// bearer tokens are verified with the HS256 secret and/or the RS256 public key
+type AuthConfig struct {
+	Enabled          bool
+	APIKeys          map[string]string
+	JWTSecret        string
+	JWTPublicKeyFile string
+	Issuer           string
+	Audience         string
+	RoleClaim        string
+}
+
+type EnrichmentConfig struct {
+	Workers       int
+	MaxAttempts   int
+	SweepInterval time.Duration
+	// Lease is how long a claimed song may stay in processing before the sweep requeues it,
+	// it must outlast the longest enrichment with all of its retries
+	Lease time.Duration
+}
+
 func MustLoad() *Config {
 	err := godotenv.Load()
 	if err != nil {
@@ -47,7 +139,66 @@ func MustLoad() *Config {
 			Password: getEnv("DB_PASSWORD", "postgres"),
 			Database: getEnv("DB_NAME", "db"),
 		},
-		ExternalAPIPort: getEnvAsInt("EXTERNAL_API_PORT", 8081),
+		Enrichment: &EnrichmentConfig{
+			Workers:       getEnvAsInt("ENRICHMENT_WORKERS", 4),
+			MaxAttempts:   getEnvAsInt("ENRICHMENT_MAX_ATTEMPTS", 5),
+			SweepInterval: getEnvAsDuration("ENRICHMENT_SWEEP_INTERVAL", 30*time.Second),
+			Lease:         getEnvAsDuration("ENRICHMENT_LEASE", 15*time.Minute),
+		},
+		ExternalAPI: &ExternalAPIConfig{
+			Scheme:  getEnv("EXTERNAL_API_SCHEME", "http"),
+			Host:    getEnv("EXTERNAL_API_HOST", "localhost"),
+			Port:    getEnvAsInt("EXTERNAL_API_PORT", 8081),
+			Timeout: getEnvAsDuration("EXTERNAL_API_TIMEOUT", 5*time.Second),
+			Resilience: &ResilienceConfig{
+				MaxRetries:       getEnvAsInt("EXTERNAL_API_MAX_RETRIES", 3),
+				BaseBackoff:      getEnvAsDuration("EXTERNAL_API_BASE_BACKOFF", 100*time.Millisecond),
+				MaxBackoff:       getEnvAsDuration("EXTERNAL_API_MAX_BACKOFF", 5*time.Second),
+				FailureThreshold: getEnvAsInt("EXTERNAL_API_FAILURE_THRESHOLD", 5),
+				OpenTimeout:      getEnvAsDuration("EXTERNAL_API_OPEN_TIMEOUT", 30*time.Second),
+				RateLimit:        getEnvAsFloat("EXTERNAL_API_RATE_LIMIT", 10),
+				Burst:            getEnvAsInt("EXTERNAL_API_BURST", 20),
+			},
+			Cache: &CacheConfig{
+				Size:        getEnvAsInt("EXTERNAL_API_CACHE_SIZE", 1000),
+				TTL:         getEnvAsDuration("EXTERNAL_API_CACHE_TTL", 24*time.Hour),
+				NegativeTTL: getEnvAsDuration("EXTERNAL_API_CACHE_NEGATIVE_TTL", 5*time.Minute),
+				Persistent:  getEnvAsBool("EXTERNAL_API_CACHE_PERSISTENT", false),
+			},
+		},
+		Providers: &ProvidersConfig{
+			Order:       getEnvAsSlice("PROVIDERS", []string{"info"}),
+			FixturesDir: getEnv("PROVIDER_FIXTURES_DIR", "./fixtures"),
+			HTTP: &HTTPProviderConfig{
+				URL:               getEnv("PROVIDER_HTTP_URL", ""),
+				Timeout:           getEnvAsDuration("PROVIDER_HTTP_TIMEOUT", 5*time.Second),
+				ReleaseDateField:  getEnv("PROVIDER_HTTP_RELEASE_DATE_FIELD", "releaseDate"),
+				ReleaseDateLayout: getEnv("PROVIDER_HTTP_RELEASE_DATE_LAYOUT", "02.01.2006"),
+				TextField:         getEnv("PROVIDER_HTTP_TEXT_FIELD", "text"),
+				LinkField:         getEnv("PROVIDER_HTTP_LINK_FIELD", "link"),
+			},
+		},
+		Metrics: &MetricsConfig{
+			Enabled: getEnvAsBool("METRICS_ENABLED", true),
+			Port:    getEnvAsInt("METRICS_PORT", 0),
+			Path:    getEnv("METRICS_PATH", "/metrics"),
+		},
+		Tracing: &TracingConfig{
+			Exporter:    getEnv("TRACING_EXPORTER", "none"),
+			Endpoint:    getEnv("TRACING_ENDPOINT", "localhost:4318"),
+			Insecure:    getEnvAsBool("TRACING_INSECURE", true),
+			ServiceName: getEnv("TRACING_SERVICE_NAME", "songs"),
+			SampleRatio: getEnvAsFloat("TRACING_SAMPLE_RATIO", 1),
+		},
+		Auth: &AuthConfig{
+			Enabled:          getEnvAsBool("AUTH_ENABLED", true),
+			APIKeys:          getEnvAsMap("AUTH_API_KEYS", map[string]string{}),
+			JWTSecret:        getEnv("AUTH_JWT_SECRET", ""),
+			JWTPublicKeyFile: getEnv("AUTH_JWT_PUBLIC_KEY_FILE", ""),
+			Issuer:           getEnv("AUTH_JWT_ISSUER", ""),
+			Audience:         getEnv("AUTH_JWT_AUDIENCE", ""),
+			RoleClaim:        getEnv("AUTH_JWT_ROLE_CLAIM", "role"),
+		},
 	}
 }
 
@@ -64,3 +215,56 @@ func getEnvAsInt(key string, defaultVal int) int {
 	}
 	return defaultVal
 }
+
+func getEnvAsDuration(key string, defaultVal time.Duration) time.Duration {
+	if val, err := time.ParseDuration(getEnv(key, "")); err == nil {
+		return val
+	}
+	return defaultVal
+}
+
+func getEnvAsFloat(key string, defaultVal float64) float64 {
+	if val, err := strconv.ParseFloat(getEnv(key, ""), 64); err == nil {
+		return val
+	}
+	return defaultVal
+}
+
+func getEnvAsBool(key string, defaultVal bool) bool {
+	if val, err := strconv.ParseBool(getEnv(key, "")); err == nil {
+		return val
+	}
+	return defaultVal
+}
+
+func getEnvAsSlice(key string, defaultVal []string) []string {
+	val := getEnv(key, "")
+	if val == "" {
+		return defaultVal
+	}
+
+	res := make([]string, 0, strings.Count(val, ",")+1)
+	for _, item := range strings.Split(val, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			res = append(res, item)
+		}
+	}
+	return res
+}
+
+// getEnvAsMap parses comma-separated key:value pairs
+func getEnvAsMap(key string, defaultVal map[string]string) map[string]string {
+	val := getEnv(key, "")
+	if val == "" {
+		return defaultVal
+	}
+
+	res := make(map[string]string)
+	for _, item := range strings.Split(val, ",") {
+		k, v, ok := strings.Cut(strings.TrimSpace(item), ":")
+		if ok && k != "" {
+			res[k] = strings.TrimSpace(v)
+		}
+	}
+	return res
+}
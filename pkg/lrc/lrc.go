@@ -0,0 +1,193 @@
+// Package lrc reads and writes LRC files, the lyrics format where every line
+// is prefixed with the time it starts at, e.g. "[01:02.50]Hello"
+package lrc
+
+import (
+	"bufio"
+	"cmp"
+	"errors"
+	"fmt"
+	"io"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var ErrInvalidTimestamp = errors.New("invalid timestamp")
+var ErrInvalidOffset = errors.New("invalid offset")
+var ErrUnclosedBracket = errors.New("unclosed bracket")
+var ErrNoLines = errors.New("no timed lines")
+
+// OffsetTag shifts every line, a positive offset makes the lines appear sooner
+const OffsetTag = "offset"
+
+var (
+	timestampRe = regexp.MustCompile(`^(\d+):(\d{1,2})(?:[.:](\d{1,3}))?$`)
+	tagRe       = regexp.MustCompile(`^([A-Za-z]+):(.*)$`)
+)
+
+type Line struct {
+	Time time.Duration
+	Text string
+}
+
+type Lyrics struct {
+	// Tags holds the ID tags, like ar or ti, with lowercase keys. The offset
+	// tag is applied to the line times while parsing and is never kept
+	Tags  map[string]string
+	Lines []Line
+}
+
+// Parse reads an LRC file. A line with several timestamps is repeated at every
+// one of them, and the lines are returned ordered by time. Lines without
+// a timestamp or a tag are skipped
+func Parse(r io.Reader) (*Lyrics, error) {
+	res := &Lyrics{Tags: make(map[string]string)}
+	offset := time.Duration(0)
+
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for n := 1; scanner.Scan(); n++ {
+		line := strings.TrimSpace(scanner.Text())
+		if n == 1 {
+			line = strings.TrimPrefix(line, "\ufeff")
+		}
+
+		times, text, tag, err := parseLine(line)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", n, err)
+		}
+
+		if tag != nil {
+			if tag[0] != OffsetTag {
+				res.Tags[tag[0]] = tag[1]
+				continue
+			}
+
+			ms, err := strconv.Atoi(tag[1])
+			if err != nil {
+				return nil, fmt.Errorf("line %d: %w: %q", n, ErrInvalidOffset, tag[1])
+			}
+			offset = time.Duration(ms) * time.Millisecond
+			continue
+		}
+
+		for _, t := range times {
+			res.Lines = append(res.Lines, Line{Time: t, Text: text})
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	if len(res.Lines) == 0 {
+		return nil, ErrNoLines
+	}
+
+	for i := range res.Lines {
+		res.Lines[i].Time = max(res.Lines[i].Time-offset, 0)
+	}
+	slices.SortStableFunc(res.Lines, func(a, b Line) int {
+		return cmp.Compare(a.Time, b.Time)
+	})
+	return res, nil
+}
+
+// parseLine returns either the timestamps and the text of a lyrics line
+// or the key and the value of a tag
+func parseLine(line string) ([]time.Duration, string, []string, error) {
+	var times []time.Duration
+	for strings.HasPrefix(line, "[") {
+		end := strings.IndexByte(line, ']')
+		if end < 0 {
+			return nil, "", nil, ErrUnclosedBracket
+		}
+		content := line[1:end]
+
+		if t, ok := parseTime(content); ok {
+			times = append(times, t)
+			line = line[end+1:]
+			continue
+		}
+
+		// Brackets after the timestamps are a part of the text, like "[01:00.00][Chorus]"
+		if len(times) > 0 {
+			break
+		}
+
+		if m := tagRe.FindStringSubmatch(content); m != nil {
+			return nil, "", []string{strings.ToLower(m[1]), strings.TrimSpace(m[2])}, nil
+		}
+
+		if content != "" && content[0] >= '0' && content[0] <= '9' {
+			return nil, "", nil, fmt.Errorf("%w: %q", ErrInvalidTimestamp, content)
+		}
+		return nil, "", nil, nil
+	}
+
+	return times, strings.TrimSpace(line), nil, nil
+}
+
+// parseTime parses mm:ss, mm:ss.xx or mm:ss.xxx. Minutes may run past 99
+func parseTime(s string) (time.Duration, bool) {
+	m := timestampRe.FindStringSubmatch(s)
+	if m == nil {
+		return 0, false
+	}
+
+	minutes, _ := strconv.Atoi(m[1])
+	seconds, _ := strconv.Atoi(m[2])
+	if seconds >= 60 {
+		return 0, false
+	}
+
+	ms := 0
+	if m[3] != "" {
+		// The fraction is decimal, so ".5" means 500 ms and ".05" means 50 ms
+		ms, _ = strconv.Atoi(m[3] + strings.Repeat("0", 3-len(m[3])))
+	}
+
+	return time.Duration(minutes)*time.Minute +
+		time.Duration(seconds)*time.Second +
+		time.Duration(ms)*time.Millisecond, true
+}
+
+// Write writes the tags ordered by key followed by the lines, one timestamp per line.
+// Times are written with millisecond precision, so that Parse reads back the same lyrics
+func Write(w io.Writer, lyrics *Lyrics) error {
+	bw := bufio.NewWriter(w)
+
+	keys := make([]string, 0, len(lyrics.Tags))
+	for key := range lyrics.Tags {
+		if key != OffsetTag {
+			keys = append(keys, key)
+		}
+	}
+	slices.Sort(keys)
+
+	for _, key := range keys {
+		if _, err := fmt.Fprintf(bw, "[%s:%s]\n", key, lyrics.Tags[key]); err != nil {
+			return err
+		}
+	}
+
+	for _, line := range lyrics.Lines {
+		if _, err := fmt.Fprintf(bw, "[%s]%s\n", FormatTime(line.Time), line.Text); err != nil {
+			return err
+		}
+	}
+
+	return bw.Flush()
+}
+
+// FormatTime formats the time as mm:ss.xx, or as mm:ss.xxx when it isn't a whole number of centiseconds
+func FormatTime(t time.Duration) string {
+	ms := t.Milliseconds()
+	minutes, seconds, ms := ms/60000, ms/1000%60, ms%1000
+	if ms%10 == 0 {
+		return fmt.Sprintf("%02d:%02d.%02d", minutes, seconds, ms/10)
+	}
+	return fmt.Sprintf("%02d:%02d.%03d", minutes, seconds, ms)
+}
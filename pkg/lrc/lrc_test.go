@@ -0,0 +1,132 @@
+package lrc
+
+import (
+	"bytes"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestParse(t *testing.T) {
+	t.Run("TagsAndLines", func(t *testing.T) {
+		src := "\ufeff[ar: Queen ]\r\n[TI:Bohemian Rhapsody]\r\n\r\n[00:01.5]Is this the real life?\r\n[00:04.25]  Is this just fantasy?  \r\n"
+
+		res, err := Parse(strings.NewReader(src))
+		require.NoError(t, err)
+		assert.Equal(t, map[string]string{"ar": "Queen", "ti": "Bohemian Rhapsody"}, res.Tags)
+		assert.Equal(t, []Line{
+			{Time: 1500 * time.Millisecond, Text: "Is this the real life?"},
+			{Time: 4250 * time.Millisecond, Text: "Is this just fantasy?"},
+		}, res.Lines)
+	})
+
+	t.Run("MultipleTimestamps", func(t *testing.T) {
+		src := "[00:10.00]Verse\n[00:05.00][00:20.00]Chorus\n[00:15.000]\n"
+
+		res, err := Parse(strings.NewReader(src))
+		require.NoError(t, err)
+		assert.Equal(t, []Line{
+			{Time: 5 * time.Second, Text: "Chorus"},
+			{Time: 10 * time.Second, Text: "Verse"},
+			{Time: 15 * time.Second, Text: ""},
+			{Time: 20 * time.Second, Text: "Chorus"},
+		}, res.Lines)
+	})
+
+	t.Run("Offset", func(t *testing.T) {
+		res, err := Parse(strings.NewReader("[offset:+500]\n[00:00.20]First\n[00:02.00]Second\n"))
+		require.NoError(t, err)
+		assert.Empty(t, res.Tags)
+		assert.Equal(t, []Line{{Time: 0, Text: "First"}, {Time: 1500 * time.Millisecond, Text: "Second"}}, res.Lines)
+
+		res, err = Parse(strings.NewReader("[offset:-250]\n[01:02.03]Later\n"))
+		require.NoError(t, err)
+		assert.Equal(t, time.Minute+2280*time.Millisecond, res.Lines[0].Time)
+	})
+
+	t.Run("TextInBrackets", func(t *testing.T) {
+		res, err := Parse(strings.NewReader("[Intro]\n[120:00:50][Chorus] La la\nuntimed line\n"))
+		require.NoError(t, err)
+		assert.Equal(t, []Line{{Time: 2*time.Hour + 500*time.Millisecond, Text: "[Chorus] La la"}}, res.Lines)
+	})
+
+	t.Run("Errors", func(t *testing.T) {
+		_, err := Parse(strings.NewReader("[00:01.00]ok\n[00:75.00]bad\n"))
+		assert.ErrorIs(t, err, ErrInvalidTimestamp)
+		assert.ErrorContains(t, err, "line 2")
+
+		_, err = Parse(strings.NewReader("[offset:soon]\n[00:01.00]ok\n"))
+		assert.ErrorIs(t, err, ErrInvalidOffset)
+
+		_, err = Parse(strings.NewReader("[00:01.00\n"))
+		assert.ErrorIs(t, err, ErrUnclosedBracket)
+
+		_, err = Parse(strings.NewReader("[ar:Nobody]\njust text\n"))
+		assert.ErrorIs(t, err, ErrNoLines)
+	})
+}
+
+func TestWrite(t *testing.T) {
+	buf := &bytes.Buffer{}
+	err := Write(buf, &Lyrics{
+		Tags: map[string]string{"ti": "Song", "ar": "Group", OffsetTag: "100"},
+		Lines: []Line{
+			{Time: 1500 * time.Millisecond, Text: "First"},
+			{Time: 61*time.Second + 5*time.Millisecond, Text: "Second"},
+			{Time: 100 * time.Minute, Text: ""},
+		},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "[ar:Group]\n[ti:Song]\n[00:01.50]First\n[01:01.005]Second\n[100:00.00]\n", buf.String())
+}
+
+func TestRoundTrip(t *testing.T) {
+	cases := map[string]*Lyrics{
+		"Simple": {
+			Tags: map[string]string{"ar": "Group", "ti": "Song", "by": "editor"},
+			Lines: []Line{
+				{Time: 0, Text: "Start"},
+				{Time: 12340 * time.Millisecond, Text: "Centiseconds"},
+				{Time: 12345 * time.Millisecond, Text: "Milliseconds"},
+				{Time: 12345 * time.Millisecond, Text: "Same time"},
+				{Time: 75 * time.Minute, Text: ""},
+			},
+		},
+		"NoTags": {
+			Tags:  map[string]string{},
+			Lines: []Line{{Time: time.Second, Text: "[Chorus] only line"}},
+		},
+	}
+
+	for name, lyrics := range cases {
+		t.Run(name, func(t *testing.T) {
+			buf := &bytes.Buffer{}
+			require.NoError(t, Write(buf, lyrics))
+
+			res, err := Parse(bytes.NewReader(buf.Bytes()))
+			require.NoError(t, err)
+			assert.Equal(t, lyrics, res)
+
+			again := &bytes.Buffer{}
+			require.NoError(t, Write(again, res))
+			assert.Equal(t, buf.String(), again.String())
+		})
+	}
+
+	t.Run("FromFile", func(t *testing.T) {
+		src := "[offset:1000]\n[ar:Group]\n[00:05.00][00:09.00]Chorus\n[00:07.5]Verse\n"
+
+		parsed, err := Parse(strings.NewReader(src))
+		require.NoError(t, err)
+
+		buf := &bytes.Buffer{}
+		require.NoError(t, Write(buf, parsed))
+		assert.Equal(t, "[ar:Group]\n[00:04.00]Chorus\n[00:06.50]Verse\n[00:08.00]Chorus\n", buf.String())
+
+		res, err := Parse(bytes.NewReader(buf.Bytes()))
+		require.NoError(t, err)
+		assert.Equal(t, parsed, res)
+	})
+}
@@ -0,0 +1,36 @@
+package model
+
+import "time"
+
+type Album struct {
+	ID       uint64 `json:"id"`
+	ArtistID uint64 `json:"artist_id"`
+	// Artist is the artist name, resolved to ArtistID by the repository
+	Artist      string    `json:"artist"`
+	Title       string    `json:"title"`
+	ReleaseDate time.Time `json:"release_date"`
+	CoverLink   string    `json:"cover_link"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
+
+type PaginatedAlbums struct {
+	Data        []*Album `json:"data"`
+	Count       int64    `json:"count"`
+	TotalPages  int      `json:"total_pages"`
+	CurrentPage int      `json:"current_page"`
+	HasNextPage bool     `json:"has_next_page"`
+}
+
+// AlbumTrack places a song on an album
+type AlbumTrack struct {
+	AlbumID     uint64 `json:"album_id"`
+	Disc        int    `json:"disc"`
+	TrackNumber int    `json:"track_number"`
+}
+
+type Track struct {
+	Disc        int   `json:"disc"`
+	TrackNumber int   `json:"track_number"`
+	Song        *Song `json:"song"`
+}
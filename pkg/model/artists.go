@@ -0,0 +1,18 @@
+package model
+
+import "time"
+
+type Artist struct {
+	ID        uint64    `json:"id"`
+	Name      string    `json:"name"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+type PaginatedArtists struct {
+	Data        []*Artist `json:"data"`
+	Count       int64     `json:"count"`
+	TotalPages  int       `json:"total_pages"`
+	CurrentPage int       `json:"current_page"`
+	HasNextPage bool      `json:"has_next_page"`
+}
@@ -0,0 +1,28 @@
+package model
+
+import "time"
+
+// Genre is a curated song category. Unlike tags, genres have to exist before songs get them
+type Genre struct {
+	ID        uint64    `json:"id"`
+	Name      string    `json:"name"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// Tag is a free-form song label, created the first time a song gets it
+type Tag struct {
+	ID        uint64    `json:"id"`
+	Name      string    `json:"name"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// Facet is the number of songs matching the current filter that have the genre or tag
+type Facet struct {
+	Name  string `json:"name"`
+	Count int64  `json:"count"`
+}
+
+type Facets struct {
+	Genres []Facet `json:"genres"`
+	Tags   []Facet `json:"tags"`
+}
@@ -0,0 +1,15 @@
+package model
+
+// SyncedLine is a line of the time-synced lyrics, it starts at TimeMs from the beginning of the song
+type SyncedLine struct {
+	TimeMs int64  `json:"time_ms"`
+	Text   string `json:"text"`
+}
+
+// SongLyrics holds both the verses and the time-synced lines of a song
+type SongLyrics struct {
+	Group  string        `json:"group"`
+	Song   string        `json:"song"`
+	Verses []string      `json:"verses"`
+	Synced []*SyncedLine `json:"synced"`
+}
@@ -0,0 +1,27 @@
+package model
+
+import "time"
+
+type Playlist struct {
+	ID          uint64    `json:"id"`
+	Name        string    `json:"name"`
+	Description string    `json:"description"`
+	SongsCount  int64     `json:"songs_count"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
+
+type PaginatedPlaylists struct {
+	Data        []*Playlist `json:"data"`
+	Count       int64       `json:"count"`
+	TotalPages  int         `json:"total_pages"`
+	CurrentPage int         `json:"current_page"`
+	HasNextPage bool        `json:"has_next_page"`
+}
+
+// PlaylistEntry is a song at its position in a playlist, positions start from 1
+type PlaylistEntry struct {
+	Position int       `json:"position"`
+	AddedAt  time.Time `json:"added_at"`
+	Song     *Song     `json:"song"`
+}
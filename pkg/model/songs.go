@@ -4,19 +4,77 @@ import (
 	"time"
 )
 
+const (
+	EnrichmentPending    = "pending"
+	EnrichmentProcessing = "processing"
+	EnrichmentDone       = "done"
+	EnrichmentFailed     = "failed"
+)
+
+// Song fields filled in from the metadata providers
+const (
+	FieldReleaseDate = "release_date"
+	FieldLyrics      = "lyrics"
+	FieldLink        = "link"
+)
+
+// SourceAlbum marks a release date taken from the album of the song instead of a provider
+const SourceAlbum = "album"
+
+// SortColumns maps the song fields the song lists can be sorted by to their columns in the songs table
+var SortColumns = map[string]string{
+	"id":           "id",
+	"group":        "group_name",
+	"song":         "song_name",
+	"release_date": "release_date",
+	"created_at":   "created_at",
+	"updated_at":   "updated_at",
+}
+
+// Match modes of the genre and tag filters
+const (
+	MatchAny = "any"
+	MatchAll = "all"
+)
+
+// SongDetailDateLayout is the release date format used by SongDetail
+const SongDetailDateLayout = "02.01.2006"
+
 type SongDetail struct {
 	ReleaseDate string `json:"releaseDate"`
 	Text        string `json:"text"`
 	Link        string `json:"link"`
+
+	// Sources maps a song field to the name of the provider that supplied it
+	Sources map[string]string `json:"-"`
 }
 
 type Song struct {
 	ID          uint64    `json:"id"`
+	ArtistID    uint64    `json:"artist_id"`
 	Group       string    `json:"group"`
 	Song        string    `json:"song"`
 	ReleaseDate time.Time `json:"release_date"`
 	Lyrics      []string  `json:"lyrics"`
 	Link        string    `json:"link"`
+	Headline    string    `json:"headline,omitempty"`
+
+	// Album optionally places a new song on an album
+	Album *AlbumTrack `json:"album,omitempty"`
+
+	// Genres and Tags are loaded for a single song only
+	Genres []string `json:"genres,omitempty"`
+	Tags   []string `json:"tags,omitempty"`
+
+	// Translations lists the languages the lyrics are translated to, loaded for a single song only.
+	// TranslatedLyrics holds the translation to Lang of the same verses as Lyrics
+	Translations     []string `json:"translations,omitempty"`
+	Lang             string   `json:"lang,omitempty"`
+	TranslatedLyrics []string `json:"translated_lyrics,omitempty"`
+
+	EnrichmentStatus string            `json:"enrichment_status"`
+	EnrichmentError  string            `json:"enrichment_error,omitempty"`
+	MetadataSources  map[string]string `json:"metadata_sources,omitempty"`
 
 	CreatedAt time.Time `json:"created_at"`
 	UpdatedAt time.Time `json:"updated_at"`
@@ -28,4 +86,25 @@ type PaginatedSongs struct {
 	TotalPages  int   `json:"total_pages"`
 	CurrentPage int   `json:"current_page"`
 	HasNextPage bool  `json:"has_next_page"`
+
+	Facets *Facets `json:"facets,omitempty"`
+}
+
+type CursorPaginatedSongs struct {
+	Data       any    `json:"data"`
+	Count      *int64 `json:"count,omitempty"`
+	NextCursor string `json:"next_cursor,omitempty"`
+	PrevCursor string `json:"prev_cursor,omitempty"`
+
+	Facets *Facets `json:"facets,omitempty"`
+}
+
+type SongPatch struct {
+	// ArtistID is resolved from Group by the repository
+	ArtistID    *uint64    `json:"-"`
+	Group       *string    `json:"group,omitempty"`
+	Song        *string    `json:"song,omitempty"`
+	ReleaseDate *time.Time `json:"release_date,omitempty"`
+	Lyrics      *[]string  `json:"lyrics,omitempty"`
+	Link        *string    `json:"link,omitempty"`
 }
@@ -0,0 +1,8 @@
+package model
+
+// Translation is the song lyrics in another language, aligned verse by verse
+// with the original. Lang is a BCP 47 language tag
+type Translation struct {
+	Lang   string   `json:"lang"`
+	Lyrics []string `json:"lyrics"`
+}
@@ -0,0 +1,15 @@
+package model
+
+// Verse is a verse of the song lyrics at its position, positions start from 1
+type Verse struct {
+	Position int    `json:"position"`
+	Text     string `json:"text"`
+}
+
+type PaginatedVerses struct {
+	Data        []*Verse `json:"data"`
+	Count       int64    `json:"count"`
+	TotalPages  int      `json:"total_pages"`
+	CurrentPage int      `json:"current_page"`
+	HasNextPage bool     `json:"has_next_page"`
+}
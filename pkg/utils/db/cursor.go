@@ -0,0 +1,80 @@
+package utils
+
+import (
+	"strconv"
+	"strings"
+)
+
+// KeysetFields returns the sort fields with the id tiebreaker appended,
+// which is the exact key a cursor is built from
+func KeysetFields(fields []SortField) []SortField {
+	for _, f := range fields {
+		if f.Column == "id" {
+			return fields
+		}
+	}
+	return append(fields[:len(fields):len(fields)], SortField{Column: "id"})
+}
+
+// ReverseSort flips every field direction along with the NULLs placement, used to walk pages backwards
+func ReverseSort(fields []SortField) []SortField {
+	res := make([]SortField, len(fields))
+	for i, f := range fields {
+		res[i] = SortField{Column: f.Column, Desc: !f.Desc, Nullable: f.Nullable, NullsFirst: !f.NullsFirst}
+	}
+	return res
+}
+
+// BuildKeysetQuery renders the condition selecting rows strictly after the cursor
+// in the given order, a nil value stands for NULL. Placeholders start at argPos,
+// the returned args hold the non-NULL values in placeholder order
+func BuildKeysetQuery(fields []SortField, values []any, argPos int) (string, []any) {
+	args := make([]any, 0, len(values))
+	placeholders := make([]string, len(values))
+	for i, v := range values {
+		if v != nil {
+			placeholders[i] = "$" + strconv.Itoa(argPos+len(args))
+			args = append(args, v)
+		}
+	}
+
+	ors := make([]string, 0, len(fields))
+	for i, f := range fields {
+		after, ok := keysetAfter(f, values[i], placeholders[i])
+		if !ok {
+			continue
+		}
+
+		ands := make([]string, 0, i+1)
+		for j := 0; j < i; j++ {
+			if values[j] == nil {
+				ands = append(ands, fields[j].Column+" IS NULL")
+			} else {
+				ands = append(ands, fields[j].Column+" = "+placeholders[j])
+			}
+		}
+		ands = append(ands, after)
+		ors = append(ors, "("+strings.Join(ands, " AND ")+")")
+	}
+
+	return "(" + strings.Join(ors, " OR ") + ")", args
+}
+
+// keysetAfter renders the condition on a single column selecting values after the boundary one,
+// false means no value comes after it, like after a NULL when NULLs go last
+func keysetAfter(f SortField, value any, placeholder string) (string, bool) {
+	if value == nil {
+		return f.Column + " IS NOT NULL", f.NullsFirst
+	}
+
+	op := " > "
+	if f.Desc {
+		op = " < "
+	}
+
+	cond := f.Column + op + placeholder
+	if f.Nullable && !f.NullsFirst {
+		cond = "(" + cond + " OR " + f.Column + " IS NULL)"
+	}
+	return cond, true
+}
@@ -2,16 +2,33 @@ package utils
 
 import (
 	"database/sql"
+	"errors"
 	"fmt"
 	conf "github.com/JMURv/effectiveMobile/pkg/config"
+	"github.com/JMURv/effectiveMobile/pkg/model"
 	"github.com/golang-migrate/migrate/v4"
 	"github.com/golang-migrate/migrate/v4/database/postgres"
+	"github.com/lib/pq"
 	"go.uber.org/zap"
 	"path/filepath"
 	"strconv"
 	"strings"
 )
 
+var ErrInvalidSort = errors.New("invalid sort field")
+
+// nullableColumns are the sort columns that may hold NULL, pending and failed songs have no release date yet
+var nullableColumns = map[string]bool{"release_date": true}
+
+// SortField is a column of the sort order. NULLs of a nullable column go last in the requested
+// order, NullsFirst is only set when the order is reversed to walk pages backwards
+type SortField struct {
+	Column     string
+	Desc       bool
+	Nullable   bool
+	NullsFirst bool
+}
+
 func ApplyMigrations(db *sql.DB, conf *conf.DBConfig) error {
 	driver, err := postgres.WithInstance(db, &postgres.Config{})
 	if err != nil {
@@ -41,8 +58,11 @@ func BuildFilterQuery(filters map[string]any) (string, []any) {
 		newArg := strconv.Itoa(len(args) + 1)
 		switch key {
 		case "group":
-			conds = append(conds, "group_name ILIKE $"+newArg)
+			conds = append(conds, "artist_id IN (SELECT id FROM artists WHERE name ILIKE $"+newArg+")")
 			args = append(args, "%"+value.(string)+"%")
+		case "artist_id":
+			conds = append(conds, "artist_id = $"+newArg)
+			args = append(args, value)
 		case "song":
 			conds = append(conds, "song_name ILIKE $"+newArg)
 			args = append(args, "%"+value.(string)+"%")
@@ -58,6 +78,20 @@ func BuildFilterQuery(filters map[string]any) (string, []any) {
 		case "link":
 			conds = append(conds, "link ILIKE $"+newArg)
 			args = append(args, "%"+value.(string)+"%")
+		case "q":
+			if value.(string) == "" {
+				continue
+			}
+			conds = append(conds, "lyrics_tsv @@ websearch_to_tsquery('simple', $"+newArg+")")
+			args = append(args, value)
+		case "genre", "tag":
+			names := splitList(value.(string))
+			if len(names) == 0 {
+				continue
+			}
+			match, _ := filters[key+"_match"].(string)
+			conds = append(conds, buildLabelQuery(key, match == model.MatchAll, newArg))
+			args = append(args, pq.Array(names))
 		}
 	}
 
@@ -69,3 +103,119 @@ func BuildFilterQuery(filters map[string]any) (string, []any) {
 
 	return q.String(), args
 }
+
+// buildLabelQuery matches the songs having any, or all, of the genres or tags
+// passed as a text array in the argument
+func buildLabelQuery(label string, all bool, arg string) string {
+	table, link := label+"s", "song_"+label+"s"
+	names := "SELECT lower(btrim(n)) FROM unnest($" + arg + "::text[]) n"
+
+	var q strings.Builder
+	q.WriteString("id IN (SELECT " + link + ".song_id FROM " + link)
+	q.WriteString(" JOIN " + table + " ON " + table + ".id = " + link + "." + label + "_id")
+	q.WriteString(" WHERE lower(btrim(" + table + ".name)) IN (" + names + ")")
+	if all {
+		q.WriteString(" GROUP BY " + link + ".song_id")
+		q.WriteString(" HAVING COUNT(*) = (SELECT COUNT(DISTINCT lower(btrim(n))) FROM unnest($" + arg + "::text[]) n)")
+	}
+	q.WriteString(")")
+	return q.String()
+}
+
+// splitList splits a comma separated filter value, dropping the empty items
+func splitList(s string) []string {
+	parts := strings.Split(s, ",")
+	res := make([]string, 0, len(parts))
+	for _, part := range parts {
+		if part = strings.TrimSpace(part); part != "" {
+			res = append(res, part)
+		}
+	}
+	return res
+}
+
+func BuildPatchQuery(req *model.SongPatch) (string, []any) {
+	sets := make([]string, 0, 6)
+	args := make([]any, 0, 6)
+
+	add := func(column string, value any) {
+		args = append(args, value)
+		sets = append(sets, column+" = $"+strconv.Itoa(len(args)))
+	}
+
+	if req.ArtistID != nil {
+		add("artist_id", *req.ArtistID)
+	}
+	if req.Group != nil {
+		add("group_name", *req.Group)
+	}
+	if req.Song != nil {
+		add("song_name", *req.Song)
+	}
+	if req.ReleaseDate != nil {
+		add("release_date", *req.ReleaseDate)
+	}
+	if req.Lyrics != nil {
+		add("lyrics", pq.Array(*req.Lyrics))
+	}
+	if req.Link != nil {
+		add("link", *req.Link)
+	}
+
+	return strings.Join(sets, ", "), args
+}
+
+// ParseSort parses a comma separated list of fields like "-release_date,group",
+// where a leading "-" means descending order
+func ParseSort(sort string) ([]SortField, error) {
+	if sort == "" {
+		return nil, nil
+	}
+
+	parts := strings.Split(sort, ",")
+	res := make([]SortField, 0, len(parts))
+	seen := make(map[string]struct{}, len(parts))
+	for _, part := range parts {
+		part = strings.TrimSpace(part)
+		desc := strings.HasPrefix(part, "-")
+		part = strings.TrimPrefix(strings.TrimPrefix(part, "-"), "+")
+
+		column, ok := model.SortColumns[part]
+		if !ok {
+			return nil, fmt.Errorf("%w: %q", ErrInvalidSort, part)
+		}
+		if _, ok := seen[column]; ok {
+			continue
+		}
+
+		seen[column] = struct{}{}
+		res = append(res, SortField{Column: column, Desc: desc, Nullable: nullableColumns[column]})
+	}
+
+	return res, nil
+}
+
+// BuildSortQuery renders an ORDER BY clause, always ending with id
+// so that rows with equal sort keys keep a stable order between pages
+func BuildSortQuery(fields []SortField) string {
+	fields = KeysetFields(fields)
+
+	terms := make([]string, 0, len(fields))
+	for _, f := range fields {
+		term := f.Column + " ASC"
+		if f.Desc {
+			term = f.Column + " DESC"
+		}
+
+		// Postgres puts NULLs first in descending order by default, the placement is fixed
+		// explicitly so that it matches the keyset condition
+		if f.Nullable && f.NullsFirst {
+			term += " NULLS FIRST"
+		} else if f.Nullable {
+			term += " NULLS LAST"
+		}
+		terms = append(terms, term)
+	}
+
+	return " ORDER BY " + strings.Join(terms, ", ")
+}
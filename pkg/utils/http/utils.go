@@ -2,6 +2,8 @@ package utils
 
 import (
 	"encoding/json"
+	"errors"
+	"github.com/JMURv/effectiveMobile/pkg/utils/logger"
 	"net/http"
 )
 
@@ -9,8 +11,32 @@ type Response struct {
 	Data any `json:"data"`
 }
 
-type ErrorResponse struct {
-	Error string `json:"error"`
+// ProblemContentType is the media type of RFC 7807 error responses
+const ProblemContentType = "application/problem+json"
+
+// ValidationProblemType identifies problems listing invalid request fields
+const ValidationProblemType = "urn:songs:problem:validation"
+
+// Problem is an RFC 7807 error response
+type Problem struct {
+	Type      string       `json:"type"`
+	Title     string       `json:"title"`
+	Status    int          `json:"status"`
+	Detail    string       `json:"detail,omitempty"`
+	Instance  string       `json:"instance,omitempty"`
+	RequestID string       `json:"request_id,omitempty"`
+	Errors    []FieldError `json:"errors,omitempty"`
+}
+
+// FieldError points at an invalid member of the request body
+type FieldError struct {
+	Pointer string `json:"pointer"`
+	Detail  string `json:"detail"`
+}
+
+// FieldErrorer is implemented by errors that carry per-field details
+type FieldErrorer interface {
+	FieldErrors() []FieldError
 }
 
 func SuccessPaginatedResponse(w http.ResponseWriter, statusCode int, data any) {
@@ -27,12 +53,35 @@ func SuccessResponse(w http.ResponseWriter, statusCode int, data any) {
 	})
 }
 
-func ErrResponse(w http.ResponseWriter, statusCode int, err error) {
-	w.Header().Set("Content-Type", "application/json")
+// TextResponse writes data as plain UTF-8 text
+func TextResponse(w http.ResponseWriter, statusCode int, data []byte) {
+	w.Header().Set("Content-Type", "text/plain; charset=utf-8")
 	w.WriteHeader(statusCode)
-	json.NewEncoder(w).Encode(&ErrorResponse{
-		Error: err.Error(),
-	})
+	w.Write(data)
+}
+
+// ErrResponse writes err as a problem. Errors implementing FieldErrorer
+// are reported as a validation problem with the list of invalid fields
+func ErrResponse(w http.ResponseWriter, r *http.Request, statusCode int, err error) {
+	res := &Problem{
+		Type:      "about:blank",
+		Title:     http.StatusText(statusCode),
+		Status:    statusCode,
+		Detail:    err.Error(),
+		Instance:  r.URL.Path,
+		RequestID: logger.RequestID(r.Context()),
+	}
+
+	var fe FieldErrorer
+	if errors.As(err, &fe) {
+		res.Type = ValidationProblemType
+		res.Title = "Validation failed"
+		res.Errors = fe.FieldErrors()
+	}
+
+	w.Header().Set("Content-Type", ProblemContentType)
+	w.WriteHeader(statusCode)
+	json.NewEncoder(w).Encode(res)
 }
 
 func ParseFiltersByURL(r *http.Request) map[string]any {
@@ -43,6 +92,11 @@ func ParseFiltersByURL(r *http.Request) map[string]any {
 			continue
 		case key == "size":
 			continue
+		case key == "cursor", key == "limit", key == "with_count", key == "facets":
+			continue
+		case key == "artist_id":
+			// Set from the path of /api/artists/{id}/songs only
+			continue
 		case len(values) > 0:
 			filters[key] = values[0]
 		}
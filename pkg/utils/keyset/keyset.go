@@ -0,0 +1,39 @@
+// Package keyset holds the opaque cursor tokens of keyset pagination
+package keyset
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+)
+
+var ErrInvalidCursor = errors.New("invalid cursor")
+
+// Cursor is the decoded form of the opaque token used by keyset pagination.
+// Values holds the sort key of the boundary row, with its id as the last element.
+// A nil value is a NULL, like the release date of a song that is not enriched yet
+type Cursor struct {
+	Sort     string    `json:"s,omitempty"`
+	Values   []*string `json:"v"`
+	Backward bool      `json:"b,omitempty"`
+}
+
+func Encode(c *Cursor) string {
+	data, _ := json.Marshal(c)
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+// Decode reads the token back. The sort fields are checked by the caller,
+// which knows the fields it can sort by
+func Decode(token string) (*Cursor, error) {
+	data, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return nil, ErrInvalidCursor
+	}
+
+	c := &Cursor{}
+	if err := json.Unmarshal(data, c); err != nil {
+		return nil, ErrInvalidCursor
+	}
+	return c, nil
+}
@@ -0,0 +1,31 @@
+package logger
+
+import (
+	"context"
+	"go.uber.org/zap"
+)
+
+type loggerKey struct{}
+type requestIDKey struct{}
+
+// WithLogger stores a request-scoped logger in the context
+func WithLogger(ctx context.Context, l *zap.Logger) context.Context {
+	return context.WithValue(ctx, loggerKey{}, l)
+}
+
+// FromContext returns the request-scoped logger, or the global one when there is none
+func FromContext(ctx context.Context) *zap.Logger {
+	if l, ok := ctx.Value(loggerKey{}).(*zap.Logger); ok {
+		return l
+	}
+	return zap.L()
+}
+
+func WithRequestID(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, requestIDKey{}, id)
+}
+
+func RequestID(ctx context.Context) string {
+	id, _ := ctx.Value(requestIDKey{}).(string)
+	return id
+}